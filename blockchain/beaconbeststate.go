@@ -33,6 +33,7 @@ type BeaconBestState struct {
 	Epoch                                  uint64               `json:"Epoch"`
 	BeaconHeight                           uint64               `json:"BeaconHeight"`
 	BeaconProposerIndex                    int                  `json:"BeaconProposerIndex"`
+	BeaconFailedProposers                  []string             `json:"BeaconFailedProposers"` // beacon committee members which failed their last proposal, skipped by next proposer rotation
 	BeaconCommittee                        []string             `json:"BeaconCommittee"`
 	BeaconPendingValidator                 []string             `json:"BeaconPendingValidator"`
 	CandidateShardWaitingForCurrentRandom  []string             `json:"CandidateShardWaitingForCurrentRandom"` // snapshot shard candidate list, waiting to be shuffled in this current epoch
@@ -303,6 +304,9 @@ func (beaconBestState *BeaconBestState) GetBytes() []byte {
 	binary.LittleEndian.PutUint64(heightBytes, beaconBestState.BeaconHeight)
 	res = append(res, heightBytes...)
 	res = append(res, []byte(strconv.Itoa(beaconBestState.BeaconProposerIndex))...)
+	for _, value := range beaconBestState.BeaconFailedProposers {
+		res = append(res, []byte(value)...)
+	}
 	for _, value := range beaconBestState.BeaconCommittee {
 		res = append(res, []byte(value)...)
 	}
@@ -428,7 +432,7 @@ func (beaconBestState *BeaconBestState) GetPubkeyRole(pubkey string, round int)
 
 	found := common.IndexOfStr(pubkey, beaconBestState.BeaconCommittee)
 	if found > -1 {
-		tmpID := (beaconBestState.BeaconProposerIndex + round) % len(beaconBestState.BeaconCommittee)
+		tmpID := GetProposerPosition(beaconBestState.BeaconCommittee, beaconBestState.BeaconProposerIndex, beaconBestState.BeaconFailedProposers, round)
 		if found == tmpID {
 			return common.PROPOSER_ROLE, 0
 		}
@@ -127,17 +127,26 @@ func (blockchain *BlockChain) InsertBeaconBlock(beaconBlock *BeaconBlock, isVali
 		return err
 	}
 	go blockchain.removeOldDataAfterProcessingBeaconBlock()
+	if err := blockchain.processExportCheckpoint(beaconBlock); err != nil {
+		Logger.log.Error(err)
+	}
+	blockchain.startPruneBeaconBlocks()
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 		metrics.Measurement:      metrics.NumOfBlockInsertToChain,
 		metrics.MeasurementValue: float64(1),
 		metrics.Tag:              metrics.ShardIDTag,
 		metrics.TagValue:         metrics.Beacon,
 	})
-	Logger.log.Infof("Finish Insert new Beacon Block %+v, with hash %+v \n", beaconBlock.Header.Height, *beaconBlock.Hash())
+	Logger.log.WithFields(common.LogFields{
+		Shard:     common.BEACON_ROLE,
+		Height:    beaconBlock.Header.Height,
+		BlockHash: blockHash.String(),
+	}).Infof("Finish Insert new Beacon Block %+v, with hash %+v \n", beaconBlock.Header.Height, *beaconBlock.Hash())
 	if beaconBlock.Header.Height%50 == 0 {
 		BLogger.log.Debugf("Inserted beacon height: %d", beaconBlock.Header.Height)
 	}
-	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, beaconBlock))
+	// published synchronously like reverted blocks, so ordered subscribers get chain changes in order
+	blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBeaconBlockTopic, beaconBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconBeststateTopic, blockchain.BestState.Beacon))
 	return nil
 }
@@ -358,7 +367,7 @@ func (beaconBestState *BeaconBestState) verifyBestStateWithBeaconBlock(beaconBlo
 	hash := beaconBlock.Header.Hash()
 	//verify producer via index
 	producerPublicKey := base58.Base58Check{}.Encode(beaconBlock.Header.ProducerAddress.Pk, common.ZeroByte)
-	producerPosition := (beaconBestState.BeaconProposerIndex + beaconBlock.Header.Round) % len(beaconBestState.BeaconCommittee)
+	producerPosition := GetProposerPosition(beaconBestState.BeaconCommittee, beaconBestState.BeaconProposerIndex, beaconBestState.BeaconFailedProposers, beaconBlock.Header.Round)
 	tempProducer := beaconBestState.BeaconCommittee[producerPosition]
 	if strings.Compare(tempProducer, producerPublicKey) != 0 {
 		return NewBlockChainError(BeaconBlockProducerError, fmt.Errorf("Expect Producer Public Key to be equal but get %+v From Index, %+v From Header", tempProducer, producerPublicKey))
@@ -504,6 +513,7 @@ func (beaconBestState *BeaconBestState) updateBeaconBestState(beaconBlock *Beaco
 	beaconBestState.BestBlock = *beaconBlock
 	beaconBestState.Epoch = beaconBlock.Header.Epoch
 	beaconBestState.BeaconHeight = beaconBlock.Header.Height
+	beaconBestState.BeaconFailedProposers = UpdateFailedProposers(beaconBestState.BeaconCommittee, beaconBestState.BeaconProposerIndex, beaconBestState.BeaconFailedProposers, beaconBlock.Header.Round)
 	beaconBestState.BeaconProposerIndex = common.IndexOfStr(base58.Base58Check{}.Encode(beaconBlock.Header.ProducerAddress.Pk, common.ZeroByte), beaconBestState.BeaconCommittee)
 	// Update new best new block hash
 	for shardID, shardStates := range beaconBlock.Body.ShardState {
@@ -582,6 +592,7 @@ func (beaconBestState *BeaconBestState) initBeaconBestState(genesisBeaconBlock *
 	beaconBestState.Epoch = genesisBeaconBlock.Header.Epoch
 	beaconBestState.BeaconHeight = genesisBeaconBlock.Header.Height
 	beaconBestState.BeaconProposerIndex = 0
+	beaconBestState.BeaconFailedProposers = []string{}
 	beaconBestState.BestShardHash = make(map[byte]common.Hash)
 	beaconBestState.BestShardHeight = make(map[byte]uint64)
 	// Update new best new block hash
@@ -793,5 +804,9 @@ func (blockchain *BlockChain) processStoreBeaconBlock(beaconBlock *BeaconBlock)
 	if err != nil {
 		return NewBlockChainError(ProcessBridgeInstructionError, err)
 	}
+	err = blockchain.processPrivacyTokenInstructions(beaconBlock)
+	if err != nil {
+		return NewBlockChainError(ProcessPrivacyTokenInstructionError, err)
+	}
 	return nil
 }
@@ -483,6 +483,7 @@ func (blockChain *BlockChain) GetShardStateFromBlock(
 		bridgeInstructionForBlock = append(bridgeInstructionForBlock, confirmInsts...)
 		BLogger.log.Infof("Found bridge swap confirm inst in shard block %d: %s", shardBlock.Header.Height, confirmInsts)
 	}
+	bridgeInstructionForBlock = append(bridgeInstructionForBlock, buildPrivacyTokenInstructions(shardID, shardBlock.Instructions)...)
 	bridgeInstructions = append(bridgeInstructions, bridgeInstructionForBlock...)
 	Logger.log.Infof("Becon Produce: Got Shard Block %+v Shard %+v \n", shardBlock.Header.Height, shardID)
 	return shardStates, stakeInstructions, swapInstructions, bridgeInstructions, acceptedRewardInstructions
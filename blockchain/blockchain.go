@@ -42,6 +42,13 @@ type BlockChain struct {
 	BestState *BestState
 	config    Config
 	chainLock sync.Mutex
+	// snapshotLock serializes state snapshot exports running in background
+	snapshotLock sync.Mutex
+	// pruneLock serializes pruning of block bodies running in background
+	pruneLock sync.Mutex
+	// oneTimeCoinScans keeps scan progress of output coins with one-time public key per readonly key
+	oneTimeCoinScans    map[common.Hash]*oneTimeCoinScan
+	oneTimeCoinScanLock sync.Mutex
 	//channel
 	cQuitSync        chan struct{}
 	Synker           synker
@@ -94,6 +101,10 @@ type Config struct {
 		UpdateConsensusState(role string, userPbk string, currentShard *byte, beaconCommittee []string, shardCommittee map[byte][]string)
 	}
 	UserKeySet *incognitokey.KeySet
+	// SnapshotDir is where state snapshots are exported at every epoch boundary, empty means disabled
+	SnapshotDir string
+	// PruneBlocks is the number of latest blocks whose bodies are kept, 0 means node keeps every block (archival)
+	PruneBlocks uint64
 }
 
 func NewBlockChain(config *Config, isTest bool) *BlockChain {
@@ -131,6 +142,12 @@ func (blockchain *BlockChain) Init(config *Config) error {
 	if config.ChainParams == nil {
 		return NewBlockChainError(UnExpectedError, errors.New("Chain parameters is not config"))
 	}
+	// ring size of one out of many proofs is a protocol parameter of the network
+	if config.ChainParams.MinCommitmentRingSize > 0 {
+		if _, ok := privacy.GetCommitmentRingSizeExp(config.ChainParams.MinCommitmentRingSize); !ok {
+			return NewBlockChainError(UnExpectedError, fmt.Errorf("ring size %d is not supported", config.ChainParams.MinCommitmentRingSize))
+		}
+	}
 	blockchain.config = *config
 	blockchain.config.IsBlockGenStarted = false
 	blockchain.IsTest = false
@@ -470,6 +487,32 @@ func (blockchain *BlockChain) StoreTransactionIndex(txHash *common.Hash, blockHa
 	return blockchain.config.DataBase.StoreTransactionIndex(*txHash, blockHash, index)
 }
 
+// newTxQueryIndex returns position and attributes of a tx in a shard block, by which txs are listed page by page
+func newTxQueryIndex(tx metadata.Transaction, block *ShardBlock, index int) database.TxQueryIndex {
+	receivers, _ := tx.GetReceivers()
+	tokenReceivers, _ := tx.GetTokenReceivers()
+	return database.TxQueryIndex{
+		TxHash:       *tx.Hash(),
+		ShardID:      block.Header.ShardID,
+		BlockHeight:  block.Header.Height,
+		IndexInBlock: index,
+		Type:         tx.GetType(),
+		MetadataType: tx.GetMetadataType(),
+		TokenID:      *tx.GetTokenID(),
+		Receivers:    append(receivers, tokenReceivers...),
+	}
+}
+
+// StoreTxQueryIndex - store tx of a shard block in ordered indexes of database for paginated queries
+func (blockchain *BlockChain) StoreTxQueryIndex(tx metadata.Transaction, block *ShardBlock, index int) error {
+	return blockchain.config.DataBase.StoreTxQueryIndex(newTxQueryIndex(tx, block, index))
+}
+
+// DeleteTxQueryIndex - remove tx of a shard block from ordered indexes of database, when block is reverted or pruned
+func (blockchain *BlockChain) DeleteTxQueryIndex(tx metadata.Transaction, block *ShardBlock, index int) error {
+	return blockchain.config.DataBase.DeleteTxQueryIndex(newTxQueryIndex(tx, block, index))
+}
+
 /*
 Uses an existing database to update the set of used tx by saving list serialNumber of privacy,
 this is a list tx-out which are used by a new tx
@@ -599,6 +642,17 @@ func (blockchain *BlockChain) StoreCommitmentsFromTxViewPoint(view TxViewPoint,
 				outputCoinBytesArray = append(outputCoinBytesArray, outputCoin.Bytes())
 			}
 			err = blockchain.config.DataBase.StoreOutputCoins(*view.tokenID, publicKeyBytes, outputCoinBytesArray, publicKeyShardID)
+			if err != nil {
+				return err
+			}
+			// output coins with one-time public key are indexed in order, so readonly keys scan only new ones
+			oneTimeOutputCoinBytesArray := make([][]byte, 0)
+			for index, outputCoin := range outputCoinArray {
+				if outputCoin.CoinDetails != nil && outputCoin.CoinDetails.IsOneTimePublicKey() {
+					oneTimeOutputCoinBytesArray = append(oneTimeOutputCoinBytesArray, outputCoinBytesArray[index])
+				}
+			}
+			err = blockchain.config.DataBase.StoreOneTimeOutputCoins(*view.tokenID, oneTimeOutputCoinBytesArray, publicKeyShardID)
 			// clear cached data
 			if blockchain.config.MemCache != nil {
 				cachedKey := memcache.GetListOutputcoinCachedKey(publicKeyBytes, view.tokenID, publicKeyShardID)
@@ -694,9 +748,12 @@ func (blockchain *BlockChain) CreateAndSaveTxViewPointFromBlock(block *ShardBloc
 		case transaction.CustomTokenInit:
 			{
 				Logger.log.Info("Store custom token when it is issued", privacyCustomTokenTx.TxTokenPrivacyData.PropertyID, privacyCustomTokenTx.TxTokenPrivacyData.PropertySymbol, privacyCustomTokenTx.TxTokenPrivacyData.PropertyName)
-				err = blockchain.config.DataBase.StorePrivacyCustomToken(privacyCustomTokenTx.TxTokenPrivacyData.PropertyID, privacyCustomTokenTx.Hash()[:])
-				if err != nil {
-					return err
+				// token minted by issuer keeps its tx init token
+				if privacyCustomTokenTx.GetMetadataType() != metadata.TokenMintRequestMeta {
+					err = blockchain.config.DataBase.StorePrivacyCustomToken(privacyCustomTokenTx.TxTokenPrivacyData.PropertyID, privacyCustomTokenTx.Hash()[:])
+					if err != nil {
+						return err
+					}
 				}
 			}
 		case transaction.CustomTokenTransfer:
@@ -923,6 +980,9 @@ func (blockchain *BlockChain) DecryptOutputCoinByKey(outCoinTemp *privacy.Output
 		in case readonly-key: return all outputcoin tx with amount value
 		in case payment-address: return all outputcoin tx with no amount value
 	*/
+	if outCoinTemp.CoinDetails.IsOneTimePublicKey() {
+		return blockchain.decryptOneTimeKeyOutputCoin(outCoinTemp, keySet, shardID, tokenID)
+	}
 	pubkeyCompress := outCoinTemp.CoinDetails.GetPublicKey().Compress()
 	if bytes.Equal(pubkeyCompress, keySet.PaymentAddress.Pk[:]) {
 		result := &privacy.OutputCoin{
@@ -952,6 +1012,101 @@ func (blockchain *BlockChain) DecryptOutputCoinByKey(outCoinTemp *privacy.Output
 	return nil
 }
 
+/*
+decryptOneTimeKeyOutputCoin - detect output coin sent to a one-time public key with readonly key,
+decrypted coin is converted to a coin of keyset's public key so it can be spent as normal coins.
+Output coins with one-time public key can not be detected with payment address only
+*/
+func (blockchain *BlockChain) decryptOneTimeKeyOutputCoin(outCoinTemp *privacy.OutputCoin, keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash) *privacy.OutputCoin {
+	if _, ok := outCoinTemp.CoinDetails.GetOneTimeKeyOffset(keySet.ReadonlyKey); !ok {
+		return nil
+	}
+	// work on a copy, output coin may be a part of a block
+	result := &privacy.OutputCoin{CoinDetails: new(privacy.Coin)}
+	if err := result.SetBytes(outCoinTemp.Bytes()); err != nil {
+		return nil
+	}
+	if result.CoinDetailsEncrypted != nil {
+		if err := result.Decrypt(keySet.ReadonlyKey); err != nil {
+			return nil
+		}
+	}
+	if err := result.CoinDetails.ConvertToStaticPublicKey(keySet.ReadonlyKey); err != nil {
+		return nil
+	}
+	if len(keySet.PrivateKey) > 0 {
+		// check spent with private-key
+		result.CoinDetails.SetSerialNumber(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex].Derive(new(big.Int).SetBytes(keySet.PrivateKey),
+			result.CoinDetails.GetSNDerivator()))
+		ok, err := blockchain.config.DataBase.HasSerialNumber(*tokenID, result.CoinDetails.GetSerialNumber().Compress(), shardID)
+		if ok || err != nil {
+			return nil
+		}
+	}
+	return result
+}
+
+// oneTimeCoinScan is the scan progress of a readonly key: output coins found so far and index of the next coin to scan,
+// it has its own lock so scans of different keys run in parallel
+type oneTimeCoinScan struct {
+	lock      sync.Mutex
+	nextIndex uint64
+	coins     [][]byte
+}
+
+// getOneTimeCoinScan returns scan progress of scanKey, progress is only a cache: an evicted key is scanned again from the first coin
+func (blockchain *BlockChain) getOneTimeCoinScan(scanKey common.Hash) *oneTimeCoinScan {
+	blockchain.oneTimeCoinScanLock.Lock()
+	defer blockchain.oneTimeCoinScanLock.Unlock()
+	if blockchain.oneTimeCoinScans == nil {
+		blockchain.oneTimeCoinScans = make(map[common.Hash]*oneTimeCoinScan)
+	}
+	scan, ok := blockchain.oneTimeCoinScans[scanKey]
+	if !ok {
+		if len(blockchain.oneTimeCoinScans) >= MaxOneTimeCoinScans {
+			for key := range blockchain.oneTimeCoinScans {
+				delete(blockchain.oneTimeCoinScans, key)
+				break
+			}
+		}
+		scan = &oneTimeCoinScan{}
+		blockchain.oneTimeCoinScans[scanKey] = scan
+	}
+	return scan
+}
+
+/*
+scanOneTimeOutputCoins - return all output coins with one-time public key belonging to readonly key of keyset.
+Recognizing a coin costs a scalar multiplication, so only coins added since the last scan of the key are scanned,
+MaxOneTimeCoinsScan coins are read from database at a time until the end of index
+*/
+func (blockchain *BlockChain) scanOneTimeOutputCoins(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([][]byte, error) {
+	scanKey := common.HashH(append(append(append(append([]byte{}, keyset.ReadonlyKey.Rk...), keyset.ReadonlyKey.Pk...), tokenID[:]...), shardID))
+	scan := blockchain.getOneTimeCoinScan(scanKey)
+	scan.lock.Lock()
+	defer scan.lock.Unlock()
+	for {
+		newCoins, err := blockchain.config.DataBase.GetOneTimeOutputCoins(*tokenID, shardID, scan.nextIndex, MaxOneTimeCoinsScan)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range newCoins {
+			outcoin := &privacy.OutputCoin{}
+			if err := outcoin.SetBytes(item); err != nil || outcoin.CoinDetails == nil {
+				continue
+			}
+			if _, ok := outcoin.CoinDetails.GetOneTimeKeyOffset(keyset.ReadonlyKey); ok {
+				scan.coins = append(scan.coins, item)
+			}
+		}
+		scan.nextIndex += uint64(len(newCoins))
+		if len(newCoins) < MaxOneTimeCoinsScan {
+			break
+		}
+	}
+	return append([][]byte{}, scan.coins...), nil
+}
+
 /*
 GetListOutputCoinsByKeyset - Read all blocks to get txs(not action tx) which can be decrypt by readonly secret key.
 With private-key, we can check unspent tx by check serialNumber from database
@@ -994,6 +1149,15 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 			return nil, err
 		}
 	}
+	// output coins with one-time public key are stored by their one-time public keys,
+	// they are found by scanning the one-time output coin index with readonly key
+	if len(keyset.ReadonlyKey.Rk) > 0 {
+		oneTimeOutCoinsInBytes, err := blockchain.scanOneTimeOutputCoins(keyset, shardID, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		outCointsInBytes = append(outCointsInBytes, oneTimeOutCoinsInBytes...)
+	}
 
 	// convert from []byte to object
 	outCoints := make([]*privacy.OutputCoin, 0)
@@ -1021,6 +1185,32 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 	return results, nil
 }
 
+/*
+GetListUnspentMultiSigOutputCoins - return unspent output coins of a multisig account,
+keyset has payment address and readonly key of the account but no private key,
+serial numbers of coins of multisig account are hashed from their public key and snd
+*/
+func (blockchain *BlockChain) GetListUnspentMultiSigOutputCoins(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
+	outCoins, err := blockchain.GetListOutputCoinsByKeyset(keyset, shardID, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*privacy.OutputCoin, 0)
+	for _, out := range outCoins {
+		// coin can not be spent if its details can not be decrypted with readonly key
+		if out.CoinDetails.GetRandomness() == nil || out.CoinDetails.GetSNDerivator() == nil {
+			continue
+		}
+		out.CoinDetails.SetSerialNumber(privacy.MultiSigSerialNumber(out.CoinDetails.GetPublicKey(), out.CoinDetails.GetSNDerivator()))
+		ok, err := blockchain.config.DataBase.HasSerialNumber(*tokenID, out.CoinDetails.GetSerialNumber().Compress(), shardID)
+		if ok || err != nil {
+			continue
+		}
+		results = append(results, out)
+	}
+	return results, nil
+}
+
 // GetUnspentTxCustomTokenVout - return all unspent tx custom token out of sender
 func (blockchain *BlockChain) GetUnspentTxCustomTokenVout(receiverKeyset incognitokey.KeySet, tokenID *common.Hash) ([]transaction.TxTokenVout, error) {
 	data, err := blockchain.config.DataBase.GetCustomTokenPaymentAddressUTXO(*tokenID, receiverKeyset.PaymentAddress.Bytes())
@@ -1091,6 +1281,20 @@ func (blockchain *BlockChain) GetTransactionHashByReceiver(keySet *incognitokey.
 	return result, nil
 }
 
+// ListTransactions - return a page of txs matching filter in chain order, starting after cursor (nil for the first page),
+// with cursor of the next page (nil for the last page)
+func (blockchain *BlockChain) ListTransactions(filter database.TxQueryFilter, cursor []byte, limit int) ([]database.TxQueryIndex, []byte, error) {
+	result, nextCursor, err := blockchain.config.DataBase.ListTxQueryIndex(filter, cursor, limit)
+	if err != nil {
+		dbErr, ok := err.(*database.DatabaseError)
+		if ok && dbErr.GetErrorCode() == database.ErrCodeMessage[database.InvalidCursor].Code {
+			return nil, nil, NewBlockChainError(InvalidTxQueryCursorError, err)
+		}
+		return nil, nil, NewBlockChainError(UnExpectedError, err)
+	}
+	return result, nextCursor, nil
+}
+
 // Check Custom token ID is existed
 func (blockchain *BlockChain) CustomTokenIDExisted(tokenID *common.Hash) bool {
 	return blockchain.config.DataBase.CustomTokenIDExisted(*tokenID)
@@ -1201,7 +1405,7 @@ func (self *BlockChain) GetCurrentBeaconBlockHeight(shardID byte) uint64 {
 	return self.BestState.Beacon.BestBlock.Header.Height
 }
 
-func (blockchain BlockChain) RandomCommitmentsProcess(usableInputCoins []*privacy.InputCoin, randNum int, shardID byte, tokenID *common.Hash) (commitmentIndexs []uint64, myCommitmentIndexs []uint64, commitments [][]byte) {
+func (blockchain BlockChain) RandomCommitmentsProcess(usableInputCoins []*privacy.InputCoin, randNum int, shardID byte, tokenID *common.Hash) (commitmentIndexs []uint64, myCommitmentIndexs []uint64, commitments [][]byte, err error) {
 	param := transaction.NewRandomCommitmentsProcessParam(usableInputCoins, randNum, blockchain.config.DataBase, shardID, tokenID)
 	return transaction.RandomCommitmentsProcess(param)
 }
@@ -1399,6 +1603,15 @@ func (blockchain *BlockChain) ValidateResponseTransactionFromTxsWithMetadata(blk
 	return nil
 }
 
+// GetMinCommitmentRingSize returns the minimum ring size of one out of many proofs of the network,
+// it is enforced on txs from the tx version which introduced it and new txs are created with it
+func (blockchain *BlockChain) GetMinCommitmentRingSize() int {
+	if blockchain.config.ChainParams == nil || blockchain.config.ChainParams.MinCommitmentRingSize == 0 {
+		return privacy.CommitmentRingSize
+	}
+	return blockchain.config.ChainParams.MinCommitmentRingSize
+}
+
 func (blockchain *BlockChain) GetRPCClient() *rpccaller.RPCClient {
 	return blockchain.RPCClient
 }
@@ -1609,7 +1822,7 @@ func (blockchain *BlockChain) ValidateBlockWithPrevShardBestState(block *ShardBl
 		return NewBlockChainError(ProducerError, errors.New("Producer's sig not match"))
 	}
 	//verify producer
-	producerPosition := (shardBestState.ShardProposerIdx + block.Header.Round) % len(shardBestState.ShardCommittee)
+	producerPosition := GetProposerPosition(shardBestState.ShardCommittee, shardBestState.ShardProposerIdx, shardBestState.ShardFailedProposers, block.Header.Round)
 	tempProducer := shardBestState.ShardCommittee[producerPosition]
 	if strings.Compare(tempProducer, producerPk) != 0 {
 		return NewBlockChainError(ProducerError, errors.New("Producer should be should be :"+tempProducer))
@@ -1660,10 +1873,13 @@ func (blockchain *BlockChain) RevertShardState(shardID byte) error {
 		return NewBlockChainError(UnExpectedError, err)
 	}
 
-	for _, tx := range currentBestState.BestBlock.Body.Transactions {
+	for index, tx := range currentBestState.BestBlock.Body.Transactions {
 		if err := blockchain.config.DataBase.DeleteTransactionIndex(*tx.Hash()); err != nil {
 			return err
 		}
+		if err := blockchain.DeleteTxQueryIndex(tx, currentBestStateBlk, index); err != nil {
+			return err
+		}
 	}
 
 	if err := blockchain.restoreFromTxViewPoint(currentBestStateBlk); err != nil {
@@ -1690,6 +1906,13 @@ func (blockchain *BlockChain) RevertShardState(shardID byte) error {
 	if err := blockchain.StoreShardBestState(shardID); err != nil {
 		return err
 	}
+	// commitments of reverted block are removed
+	if blockchain.config.TempTxPool != nil {
+		blockchain.config.TempTxPool.ResetVerificationCache()
+	}
+	// published synchronously like new blocks, subscribers registered by RegisterNewOrderedSubscriber
+	// get it before the block replacing it, other subscribers may get them in any order
+	blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBlockDisconnectedTopic, currentBestStateBlk))
 	return nil
 }
 
@@ -2091,9 +2314,11 @@ func (blockchain *BlockChain) restoreFromTxViewPoint(block *ShardBlock) error {
 		switch privacyCustomTokenTx.TxTokenPrivacyData.Type {
 		case transaction.CustomTokenInit:
 			{
-				err = blockchain.config.DataBase.DeletePrivacyCustomToken(privacyCustomTokenTx.TxTokenPrivacyData.PropertyID)
-				if err != nil {
-					return err
+				if privacyCustomTokenTx.GetMetadataType() != metadata.TokenMintRequestMeta {
+					err = blockchain.config.DataBase.DeletePrivacyCustomToken(privacyCustomTokenTx.TxTokenPrivacyData.PropertyID)
+					if err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -2183,6 +2408,7 @@ func (blockchain *BlockChain) restoreCommitmentsFromTxViewPoint(view TxViewPoint
 	}
 
 	// outputs
+	oneTimeOutputCoinCount := uint64(0)
 	for _, k := range keys {
 		publicKey := k
 		publicKeyBytes, _, err := base58.Base58Check{}.Decode(publicKey)
@@ -2201,7 +2427,21 @@ func (blockchain *BlockChain) restoreCommitmentsFromTxViewPoint(view TxViewPoint
 			if err != nil {
 				return err
 			}
+			for _, outputCoin := range outputCoinArray {
+				if outputCoin.CoinDetails != nil && outputCoin.CoinDetails.IsOneTimePublicKey() {
+					oneTimeOutputCoinCount++
+				}
+			}
+		}
+	}
+	// one-time output coins of the reverted block are the last ones in index
+	if oneTimeOutputCoinCount > 0 {
+		if err := blockchain.config.DataBase.DeleteLastOneTimeOutputCoins(*view.tokenID, shardID, oneTimeOutputCoinCount); err != nil {
+			return err
 		}
+		blockchain.oneTimeCoinScanLock.Lock()
+		blockchain.oneTimeCoinScans = nil
+		blockchain.oneTimeCoinScanLock.Unlock()
 	}
 	return nil
 }
@@ -2222,7 +2462,7 @@ func (blockchain *BlockChain) ValidateBlockWithPrevBeaconBestState(block *Beacon
 		return NewBlockChainError(ProducerError, errors.New("Producer's sig not match"))
 	}
 	//verify producer
-	producerPosition := (beaconBestState.BeaconProposerIndex + block.Header.Round) % len(beaconBestState.BeaconCommittee)
+	producerPosition := GetProposerPosition(beaconBestState.BeaconCommittee, beaconBestState.BeaconProposerIndex, beaconBestState.BeaconFailedProposers, block.Header.Round)
 	tempProducer := beaconBestState.BeaconCommittee[producerPosition]
 	if strings.Compare(tempProducer, producerPk) != 0 {
 		return NewBlockChainError(ProducerError, errors.New("Producer should be should be :"+tempProducer))
@@ -2325,6 +2565,10 @@ func (blockchain *BlockChain) RevertBeaconState() error {
 			}
 		}
 	}
+	err = blockchain.revertPrivacyTokenInstructions(&currentBestStateBlk)
+	if err != nil {
+		return err
+	}
 	err = blockchain.config.DataBase.DeleteBeaconBlock(currentBestStateBlk.Header.Hash(), currentBestStateBlk.Header.Height)
 	if err != nil {
 		return err
@@ -2333,6 +2577,13 @@ func (blockchain *BlockChain) RevertBeaconState() error {
 	if err := blockchain.StoreBeaconBestState(); err != nil {
 		return err
 	}
+	// txs verified against reverted beacon state (token supply, bridge...) are verified again
+	if blockchain.config.TempTxPool != nil {
+		blockchain.config.TempTxPool.ResetVerificationCache()
+	}
+	// published synchronously like new blocks, subscribers registered by RegisterNewOrderedSubscriber
+	// get it before the block replacing it, other subscribers may get them in any order
+	blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconBlockDisconnectedTopic, currentBestStateBlk))
 	return nil
 }
 
@@ -0,0 +1,139 @@
+package bridgeproof
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+)
+
+// Block is a beacon or bridge block whose instructions are relayed to Ethereum
+type Block interface {
+	SignerPubkeys(database.DatabaseInterface) ([][]byte, []int, error)
+	InstructionMerkleRoot() []byte
+	MetaHash() []byte
+	Hash() []byte
+	R() string
+	Sig() string
+	ValidatorsIdx(int) []int
+}
+
+type BeaconBlock struct {
+	*blockchain.BeaconBlock
+}
+
+func (bb *BeaconBlock) InstructionMerkleRoot() []byte {
+	return bb.Header.InstructionMerkleRoot[:]
+}
+
+func (bb *BeaconBlock) MetaHash() []byte {
+	h := bb.Header.MetaHash()
+	return h[:]
+}
+
+func (bb *BeaconBlock) Hash() []byte {
+	h := bb.Header.Hash()
+	return h[:]
+}
+
+func (bb *BeaconBlock) R() string {
+	return bb.BeaconBlock.R
+}
+
+func (bb *BeaconBlock) Sig() string {
+	return bb.BeaconBlock.AggregatedSig
+}
+
+// SignerPubkeys finds the pubkeys of all signers of a beacon block
+func (bb *BeaconBlock) SignerPubkeys(db database.DatabaseInterface) ([][]byte, []int, error) {
+	// Fetch with height-1 because BestStateBeacon is updated before saving committee to database => new committee is saved instead of the one signing this block
+	commsRaw, err := db.FetchBeaconCommitteeByHeight(bb.Header.Height - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comm := []string{}
+	err = json.Unmarshal(commsRaw, &comm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signerIdxs := bb.ValidatorsIdx(1) // List of signers
+	pubkeys, err := DecodeCommittee(comm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pubkeys, signerIdxs, nil
+}
+
+func (bb *BeaconBlock) ValidatorsIdx(idx int) []int {
+	return bb.BeaconBlock.ValidatorsIndex[idx]
+}
+
+type ShardBlock struct {
+	*blockchain.ShardBlock
+}
+
+func (sb *ShardBlock) InstructionMerkleRoot() []byte {
+	return sb.Header.InstructionMerkleRoot[:]
+}
+
+func (sb *ShardBlock) MetaHash() []byte {
+	h := sb.Header.MetaHash()
+	return h[:]
+}
+
+func (sb *ShardBlock) Hash() []byte {
+	h := sb.Header.Hash()
+	return h[:]
+}
+
+func (sb *ShardBlock) R() string {
+	return sb.ShardBlock.R
+}
+
+func (sb *ShardBlock) Sig() string {
+	return sb.ShardBlock.AggregatedSig
+}
+
+func (sb *ShardBlock) ValidatorsIdx(idx int) []int {
+	return sb.ShardBlock.ValidatorsIndex[idx]
+}
+
+// SignerPubkeys finds the pubkeys of all signers of a shard block
+func (sb *ShardBlock) SignerPubkeys(db database.DatabaseInterface) ([][]byte, []int, error) {
+	bridgeID := byte(common.BRIDGE_SHARD_ID)
+	commsRaw, err := db.FetchCommitteeFromShardBestState(bridgeID, sb.Header.Height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comm := []string{}
+	err = json.Unmarshal(commsRaw, &comm)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	signerIdxs := sb.ValidatorsIdx(1) // List of signers
+	pubkeys, err := DecodeCommittee(comm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pubkeys, signerIdxs, nil
+}
+
+// DecodeCommittee decodes base58 encoded pubkeys of a committee (as stored in database and returned by RPCs)
+func DecodeCommittee(comm []string) ([][]byte, error) {
+	pubkeys := make([][]byte, len(comm))
+	for i, pkRaw := range comm {
+		pubkey, _, err := base58.Base58Check{}.Decode(pkRaw)
+		if err != nil {
+			return nil, err
+		}
+		pubkeys[i] = pubkey
+	}
+	return pubkeys, nil
+}
@@ -0,0 +1,50 @@
+package bridgeproof
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnexpectedError = iota
+	DecodeProofError
+	InstNotInMerkleTreeError
+	BlockHashMismatchError
+	CommitteeMismatchError
+	InvalidSignerIndexError
+	NotEnoughSignersError
+	InvalidSignatureError
+)
+
+var ErrCodeMessage = map[int]struct {
+	code    int
+	message string
+}{
+	UnexpectedError:          {-1, "Unexpected error"},
+	DecodeProofError:         {-2, "Can not decode proof"},
+	InstNotInMerkleTreeError: {-3, "Instruction is not in merkle tree of block"},
+	BlockHashMismatchError:   {-4, "Block hash does not match block data and instruction root"},
+	CommitteeMismatchError:   {-5, "Pubkeys of proof are not the known committee"},
+	InvalidSignerIndexError:  {-6, "Invalid index of signer"},
+	NotEnoughSignersError:    {-7, "Block is signed by less than 2/3 of committee"},
+	InvalidSignatureError:    {-8, "Invalid aggregated signature of committee"},
+}
+
+type BridgeProofError struct {
+	Code    int
+	Message string
+	err     error
+}
+
+func (e BridgeProofError) Error() string {
+	return fmt.Sprintf("%d: %s %+v", e.Code, e.Message, e.err)
+}
+
+func NewBridgeProofError(key int, err error) *BridgeProofError {
+	return &BridgeProofError{
+		Code:    ErrCodeMessage[key].code,
+		Message: ErrCodeMessage[key].message,
+		err:     errors.Wrap(err, ErrCodeMessage[key].message),
+	}
+}
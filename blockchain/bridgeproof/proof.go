@@ -0,0 +1,139 @@
+package bridgeproof
+
+import (
+	"encoding/hex"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+// InstProof is the proof of an instruction in a beacon or bridge block, it is submitted to Ethereum
+// so all of its fields (except the instruction itself) are hex encoded
+type InstProof struct {
+	Inst []string
+
+	InstPath       []string
+	InstPathIsLeft []bool
+	InstRoot       string
+	BlkData        string
+	BlkHash        string
+	SignerSig      string
+	Pubkeys        []string
+	SigIdxs        []int
+	RIdxs          []int
+	R              string
+}
+
+// Proof is the proof of an instruction on both beacon and bridge,
+// returned by getburnproof, getbeaconswapproof and getbridgeswapproof RPCs
+type Proof struct {
+	// Instruction is the hex encoded decoded instruction, without block height for BurningConfirm instruction
+	Instruction  string
+	BeaconHeight string
+	BridgeHeight string
+
+	Beacon *InstProof
+	Bridge *InstProof
+}
+
+// BuildProofForBlock builds an InstProof for an instruction in a block (beacon or shard)
+func BuildProofForBlock(
+	blk Block,
+	insts [][]string,
+	id int,
+	db database.DatabaseInterface,
+) (*InstProof, error) {
+	// Get committee pubkey and signature
+	pubkeys, signerIdxs, err := blk.SignerPubkeys(db)
+	if err != nil {
+		return nil, err
+	}
+	signerPubkeys := make([]string, len(pubkeys))
+	for i, pk := range pubkeys {
+		signerPubkeys[i] = hex.EncodeToString(pk)
+	}
+
+	// Build merkle proof for instruction in block
+	instProof, err := BuildInstProof(insts, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get meta hash and block hash
+	instRoot := hex.EncodeToString(blk.InstructionMerkleRoot())
+	metaHash := blk.MetaHash()
+	blkHash := blk.Hash()
+
+	// Get sig data
+	r, _, err := base58.Base58Check{}.Decode(blk.R())
+	if err != nil {
+		return nil, err
+	}
+	sig, _, err := base58.Base58Check{}.Decode(blk.Sig())
+	if err != nil {
+		return nil, err
+	}
+	rIdxs := blk.ValidatorsIdx(0)
+
+	return &InstProof{
+		Inst:           insts[id],
+		InstPath:       instProof.GetPath(),
+		InstPathIsLeft: instProof.Left,
+		InstRoot:       instRoot,
+		BlkData:        hex.EncodeToString(metaHash[:]),
+		BlkHash:        hex.EncodeToString(blkHash[:]),
+		SignerSig:      hex.EncodeToString(sig),
+		Pubkeys:        signerPubkeys,
+		RIdxs:          rIdxs,
+		SigIdxs:        signerIdxs,
+		R:              hex.EncodeToString(r),
+	}, nil
+}
+
+// Keccak256MerkleProof is the path from a leaf to the root of a merkle tree built by blockchain.BuildKeccak256MerkleTree,
+// Left[i] tells if Path[i] is the left sibling
+type Keccak256MerkleProof struct {
+	Path [][]byte
+	Left []bool
+}
+
+// GetPath encodes the path of merkle proof as string and returns
+func (p *Keccak256MerkleProof) GetPath() []string {
+	path := make([]string, len(p.Path))
+	for i, h := range p.Path {
+		path[i] = hex.EncodeToString(h)
+	}
+	return path
+}
+
+// BuildProofFromTree builds a merkle proof for one element in a merkle tree
+func BuildProofFromTree(merkles [][]byte, id int) *Keccak256MerkleProof {
+	path, left := blockchain.GetKeccak256MerkleProofFromTree(merkles, id)
+	return &Keccak256MerkleProof{Path: path, Left: left}
+}
+
+// BuildProof receives a list of data (as bytes) and returns a merkle proof for one element in the list
+func BuildProof(data [][]byte, id int) *Keccak256MerkleProof {
+	merkles := blockchain.BuildKeccak256MerkleTree(data)
+	return BuildProofFromTree(merkles, id)
+}
+
+// BuildInstProof receives a list of instructions (as string) and returns a merkle proof for one instruction in the list
+func BuildInstProof(insts [][]string, id int) (*Keccak256MerkleProof, error) {
+	flattenInsts, err := blockchain.FlattenAndConvertStringInst(insts)
+	if err != nil {
+		return nil, err
+	}
+	return BuildProof(flattenInsts, id), nil
+}
+
+// BuildSignersProof builds the merkle proofs for some elements in a list of pubkeys
+func BuildSignersProof(pubkeys [][]byte, idxs []int) []*Keccak256MerkleProof {
+	merkles := blockchain.BuildKeccak256MerkleTree(pubkeys)
+	proofs := make([]*Keccak256MerkleProof, len(idxs))
+	for i, pid := range idxs {
+		proofs[i] = BuildProofFromTree(merkles, pid)
+	}
+	return proofs
+}
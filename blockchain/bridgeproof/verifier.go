@@ -0,0 +1,178 @@
+package bridgeproof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/pkg/errors"
+)
+
+/*
+Verifier mirrors the checks of the Ethereum contract on a relayed instruction, so a relayer can
+validate a proof before paying gas to submit it:
+1. keccak256 of the instruction is in the keccak256 merkle tree of the block (InstPath, InstPathIsLeft => InstRoot)
+2. block hash is keccak256(BlkData || InstRoot)
+3. more than 2/3 of the known committee signed the block hash with the aggregated Schnorr signature
+The instruction must be in both beacon and bridge blocks
+*/
+
+// Verify checks the instruction of proof is in a beacon block signed by beaconCommittee
+// and in a bridge block signed by bridgeCommittee
+func (proof *Proof) Verify(beaconCommittee [][]byte, bridgeCommittee [][]byte) error {
+	if proof.Beacon == nil || proof.Bridge == nil {
+		return NewBridgeProofError(DecodeProofError, errors.New("proof on beacon and bridge are required"))
+	}
+	inst, err := hex.DecodeString(proof.Instruction)
+	if err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	beaconInstHash, err := instHashWithHeight(inst, proof.BeaconHeight)
+	if err != nil {
+		return err
+	}
+	if err := VerifyInstProof(beaconInstHash, proof.Beacon, beaconCommittee); err != nil {
+		return errors.Wrap(err, "proof on beacon")
+	}
+	bridgeInstHash, err := instHashWithHeight(inst, proof.BridgeHeight)
+	if err != nil {
+		return err
+	}
+	if err := VerifyInstProof(bridgeInstHash, proof.Bridge, bridgeCommittee); err != nil {
+		return errors.Wrap(err, "proof on bridge")
+	}
+	return nil
+}
+
+// instHashWithHeight hashes an instruction appended with the hex encoded height of block containing it (if any)
+func instHashWithHeight(inst []byte, height string) ([]byte, error) {
+	data := append([]byte{}, inst...)
+	if len(height) > 0 {
+		h, err := hex.DecodeString(height)
+		if err != nil {
+			return nil, NewBridgeProofError(DecodeProofError, err)
+		}
+		data = append(data, h...)
+	}
+	instHash := common.Keccak256(data)
+	return instHash[:], nil
+}
+
+// VerifyInstProof checks an instruction (given by its keccak256 hash) is in a block signed by committee
+func VerifyInstProof(instHash []byte, proof *InstProof, committee [][]byte) error {
+	instRoot, err := hex.DecodeString(proof.InstRoot)
+	if err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	instPath := make([][]byte, len(proof.InstPath))
+	for i, p := range proof.InstPath {
+		instPath[i], err = hex.DecodeString(p)
+		if err != nil {
+			return NewBridgeProofError(DecodeProofError, err)
+		}
+	}
+	if !InstructionInMerkleTree(instHash, instRoot, instPath, proof.InstPathIsLeft) {
+		return NewBridgeProofError(InstNotInMerkleTreeError, nil)
+	}
+
+	blkData, err := hex.DecodeString(proof.BlkData)
+	if err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	blkHash, err := hex.DecodeString(proof.BlkHash)
+	if err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	expectedBlkHash := common.Keccak256(blkData, instRoot)
+	if !bytes.Equal(expectedBlkHash[:], blkHash) {
+		return NewBridgeProofError(BlockHashMismatchError, nil)
+	}
+
+	return verifyCommitteeSig(blkHash, proof, committee)
+}
+
+// InstructionInMerkleTree checks a leaf is in a keccak256 merkle tree by hashing it along its path
+// and comparing the result with root
+func InstructionInMerkleTree(leaf []byte, root []byte, path [][]byte, left []bool) bool {
+	if len(path) != len(left) {
+		return false
+	}
+	buildRoot := leaf
+	for i := range path {
+		var h common.Hash
+		if left[i] {
+			h = common.Keccak256(path[i], buildRoot)
+		} else if len(path[i]) == 0 {
+			// No right sibling, node is hashed with itself
+			h = common.Keccak256(buildRoot, buildRoot)
+		} else {
+			h = common.Keccak256(buildRoot, path[i])
+		}
+		buildRoot = h[:]
+	}
+	return bytes.Equal(buildRoot, root)
+}
+
+// verifyCommitteeSig checks the aggregated signature of block hash is made by more than 2/3 of committee
+func verifyCommitteeSig(blkHash []byte, proof *InstProof, committee [][]byte) error {
+	if len(proof.Pubkeys) > 0 {
+		if len(proof.Pubkeys) != len(committee) {
+			return NewBridgeProofError(CommitteeMismatchError, nil)
+		}
+		for i, pk := range proof.Pubkeys {
+			if pk != hex.EncodeToString(committee[i]) {
+				return NewBridgeProofError(CommitteeMismatchError, fmt.Errorf("pubkey %d is %s", i, pk))
+			}
+		}
+	}
+	if len(proof.SigIdxs)*3 <= len(committee)*2 {
+		return NewBridgeProofError(NotEnoughSignersError, fmt.Errorf("%d of %d signers", len(proof.SigIdxs), len(committee)))
+	}
+	signers, err := pubkeysAtIdxs(committee, proof.SigIdxs)
+	if err != nil {
+		return err
+	}
+	rSigners, err := pubkeysAtIdxs(committee, proof.RIdxs)
+	if err != nil {
+		return err
+	}
+
+	rBytes, err := hex.DecodeString(proof.R)
+	if err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	rCombined := new(privacy.EllipticPoint)
+	rCombined.Set(big.NewInt(0), big.NewInt(0))
+	if err := rCombined.Decompress(rBytes); err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	sigBytes, err := hex.DecodeString(proof.SignerSig)
+	if err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	sig := new(privacy.SchnMultiSig)
+	if err := sig.SetBytes(sigBytes); err != nil {
+		return NewBridgeProofError(DecodeProofError, err)
+	}
+	ok, err := sig.VerifyMultiSig(blkHash, rSigners, signers, rCombined)
+	if err != nil || !ok {
+		return NewBridgeProofError(InvalidSignatureError, err)
+	}
+	return nil
+}
+
+// pubkeysAtIdxs returns pubkeys of committee at strictly increasing indices, so a member can not be counted twice
+func pubkeysAtIdxs(committee [][]byte, idxs []int) ([]*privacy.PublicKey, error) {
+	pubkeys := make([]*privacy.PublicKey, len(idxs))
+	for i, idx := range idxs {
+		if idx < 0 || idx >= len(committee) || (i > 0 && idx <= idxs[i-1]) {
+			return nil, NewBridgeProofError(InvalidSignerIndexError, fmt.Errorf("index %d", idx))
+		}
+		pk := privacy.PublicKey(committee[idx])
+		pubkeys[i] = &pk
+	}
+	return pubkeys, nil
+}
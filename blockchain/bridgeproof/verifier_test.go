@@ -0,0 +1,149 @@
+package bridgeproof
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+var testInsts = [][]string{
+	{"1", "shard", "first"},
+	{"2", "shard", "second"},
+	{"3", "shard", "third"},
+}
+
+func TestInstructionInMerkleTree(t *testing.T) {
+	flattenInsts, err := blockchain.FlattenAndConvertStringInst(testInsts)
+	assert.Equal(t, nil, err)
+	root := blockchain.GetKeccak256MerkleRoot(flattenInsts)
+	for id := range testInsts {
+		instProof, err := BuildInstProof(testInsts, id)
+		assert.Equal(t, nil, err)
+		instHash := common.Keccak256(flattenInsts[id])
+		assert.Equal(t, true, InstructionInMerkleTree(instHash[:], root, instProof.Path, instProof.Left))
+
+		otherHash := common.Keccak256(flattenInsts[(id+1)%len(testInsts)])
+		assert.Equal(t, false, InstructionInMerkleTree(otherHash[:], root, instProof.Path, instProof.Left))
+	}
+}
+
+// buildSignedInstProof builds proof of an instruction in a block signed by all members of a committee of n
+func buildSignedInstProof(t *testing.T, n int, id int) ([]byte, *InstProof, [][]byte) {
+	flattenInsts, err := blockchain.FlattenAndConvertStringInst(testInsts)
+	assert.Equal(t, nil, err)
+	instProof, err := BuildInstProof(testInsts, id)
+	assert.Equal(t, nil, err)
+	instRoot := blockchain.GetKeccak256MerkleRoot(flattenInsts)
+	blkData := common.Keccak256([]byte("block meta data"))
+	blkHash := common.Keccak256(blkData[:], instRoot)
+
+	keySets := make([]*privacy.MultiSigKeyset, n)
+	listPKs := make([]*privacy.PublicKey, n)
+	committee := make([][]byte, n)
+	pubkeys := make([]string, n)
+	idxs := make([]int, n)
+	for i := 0; i < n; i++ {
+		keySets[i] = new(privacy.MultiSigKeyset)
+		privateKey := privacy.GeneratePrivateKey(big.NewInt(int64(i + 1)).Bytes())
+		publicKey := privacy.GeneratePublicKey(privateKey)
+		keySets[i].Set(&privateKey, &publicKey)
+		listPKs[i] = &publicKey
+		committee[i] = publicKey
+		pubkeys[i] = hex.EncodeToString(publicKey)
+		idxs[i] = i
+	}
+	multiSigScheme := new(privacy.MultiSigScheme)
+	secretRandomness := make([]*big.Int, n)
+	publicRandomness := make([]*privacy.EllipticPoint, n)
+	rCombined := new(privacy.EllipticPoint)
+	rCombined.Zero()
+	for i := 0; i < n; i++ {
+		publicRandomness[i], secretRandomness[i] = multiSigScheme.GenerateRandomFromSeed(big.NewInt(int64(i + 10)))
+		rCombined = rCombined.Add(publicRandomness[i])
+	}
+	sigs := make([]*privacy.SchnMultiSig, n)
+	for i := 0; i < n; i++ {
+		sigs[i], err = keySets[i].SignMultiSig(blkHash[:], listPKs, publicRandomness, secretRandomness[i])
+		assert.Equal(t, nil, err)
+	}
+	sig, err := multiSigScheme.CombineMultiSig(sigs).Bytes()
+	assert.Equal(t, nil, err)
+
+	proof := &InstProof{
+		Inst:           testInsts[id],
+		InstPath:       instProof.GetPath(),
+		InstPathIsLeft: instProof.Left,
+		InstRoot:       hex.EncodeToString(instRoot),
+		BlkData:        hex.EncodeToString(blkData[:]),
+		BlkHash:        hex.EncodeToString(blkHash[:]),
+		SignerSig:      hex.EncodeToString(sig),
+		Pubkeys:        pubkeys,
+		SigIdxs:        idxs,
+		RIdxs:          idxs,
+		R:              hex.EncodeToString(rCombined.Compress()),
+	}
+	instHash := common.Keccak256(flattenInsts[id])
+	return instHash[:], proof, committee
+}
+
+func TestVerifyInstProof(t *testing.T) {
+	for id := range testInsts {
+		instHash, proof, committee := buildSignedInstProof(t, 4, id)
+		assert.Equal(t, nil, VerifyInstProof(instHash, proof, committee))
+	}
+
+	instHash, proof, committee := buildSignedInstProof(t, 4, 2)
+	otherHash := common.Keccak256([]byte("other inst"))
+	err := VerifyInstProof(otherHash[:], proof, committee)
+	assert.Equal(t, ErrCodeMessage[InstNotInMerkleTreeError].code, err.(*BridgeProofError).Code)
+
+	blkHash := proof.BlkHash
+	proof.BlkHash = hex.EncodeToString(otherHash[:])
+	err = VerifyInstProof(instHash, proof, committee)
+	assert.Equal(t, ErrCodeMessage[BlockHashMismatchError].code, err.(*BridgeProofError).Code)
+	proof.BlkHash = blkHash
+
+	err = VerifyInstProof(instHash, proof, append(committee[1:], committee[0]))
+	assert.Equal(t, ErrCodeMessage[CommitteeMismatchError].code, err.(*BridgeProofError).Code)
+
+	sigIdxs := proof.SigIdxs
+	proof.SigIdxs = sigIdxs[:2]
+	err = VerifyInstProof(instHash, proof, committee)
+	assert.Equal(t, ErrCodeMessage[NotEnoughSignersError].code, err.(*BridgeProofError).Code)
+	proof.SigIdxs = []int{0, 1, 1, 2}
+	err = VerifyInstProof(instHash, proof, committee)
+	assert.Equal(t, ErrCodeMessage[InvalidSignerIndexError].code, err.(*BridgeProofError).Code)
+	proof.SigIdxs = sigIdxs
+}
+
+func TestVerifyInstProofSignature(t *testing.T) {
+	instHash, proof, committee := buildSignedInstProof(t, 4, 0)
+	_, otherProof, _ := buildSignedInstProof(t, 4, 1)
+	// Signature of a block with other meta data
+	otherBlkData := common.Keccak256([]byte("other block meta data"))
+	instRoot, _ := hex.DecodeString(proof.InstRoot)
+	otherBlkHash := common.Keccak256(otherBlkData[:], instRoot)
+	proof.BlkData = hex.EncodeToString(otherBlkData[:])
+	proof.BlkHash = hex.EncodeToString(otherBlkHash[:])
+	proof.SignerSig = otherProof.SignerSig
+	err := VerifyInstProof(instHash, proof, committee)
+	assert.Equal(t, ErrCodeMessage[InvalidSignatureError].code, err.(*BridgeProofError).Code)
+}
+
+func TestProofVerify(t *testing.T) {
+	flattenInsts, _ := blockchain.FlattenAndConvertStringInst(testInsts)
+	_, beaconProof, beaconCommittee := buildSignedInstProof(t, 4, 1)
+	_, bridgeProof, bridgeCommittee := buildSignedInstProof(t, 7, 1)
+	proof := &Proof{
+		Instruction: hex.EncodeToString(flattenInsts[1]),
+		Beacon:      beaconProof,
+		Bridge:      bridgeProof,
+	}
+	assert.Equal(t, nil, proof.Verify(beaconCommittee, bridgeCommittee))
+	assert.NotEqual(t, nil, proof.Verify(bridgeCommittee, beaconCommittee))
+}
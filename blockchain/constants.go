@@ -24,6 +24,10 @@ const (
 	DefaultCacheCleanupTime   = 30 * time.Second // in second
 	WorkerNumber              = 5
 	MAX_S2B_BLOCK             = 50
+	MinPruneBlocks            = 1000  // min retention window of a pruning node
+	MaxPruneBlocksPerTime     = 100   // max block bodies pruned after one block is inserted
+	MaxOneTimeCoinsScan       = 10000 // max output coins with one-time public key read from database at a time while scanning for a readonly key
+	MaxOneTimeCoinScans       = 1000  // max readonly keys whose scan progress is kept
 )
 
 // CONSTANT for network MAINNET
@@ -41,6 +45,7 @@ const (
 	//board and proposal parameters
 	MainnetBasicReward                = 400000000 //40 mili PRV
 	MainnetRewardHalflife             = 3155760   //1 year, reduce 12.5% per year
+	MainnetMinCommitmentRingSize      = 8
 	MainnetGenesisblockPaymentAddress = "1Uv2zzR4LgfX8ToQe8ub3bYcCLk3uDU1sm9U9hiu9EKYXoS77UdikfT9s8d5YjhsTJm61eazsMwk2otFZBYpPHwiMn8z6bKWWJRspsLky"
 	// ------------- end Mainnet --------------------------------------
 )
@@ -78,6 +83,7 @@ const (
 	//board and proposal parameters
 	TestnetBasicReward                = 400000000 //40 mili PRV
 	TestnetRewardHalflife             = 3155760   //1 year, reduce 12.5% per year
+	TestnetMinCommitmentRingSize      = 8
 	TestnetGenesisBlockPaymentAddress = "1Uv46Pu4pqBvxCcPw7MXhHfiAD5Rmi2xgEE7XB6eQurFAt4vSYvfyGn3uMMB1xnXDq9nRTPeiAZv5gRFCBDroRNsXJF1sxPSjNQtivuHk"
 )
 
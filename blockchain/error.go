@@ -132,6 +132,11 @@ const (
 	UpdateDatabaseWithBlockRewardInfoError
 	CreateCrossShardBlockError
 	VerifyCrossShardBlockShardTxRootError
+	ExportStateSnapshotError
+	ImportStateSnapshotError
+	PruneBlockError
+	ProcessPrivacyTokenInstructionError
+	InvalidTxQueryCursorError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -247,6 +252,11 @@ var ErrCodeMessage = map[int]struct {
 	UpdateDatabaseWithBlockRewardInfoError:            {-1107, "Update Database With Block Reward Info Error"},
 	CreateCrossShardBlockError:                        {-1108, "Create Cross Shard Block Error"},
 	VerifyCrossShardBlockShardTxRootError:             {-1109, "Verify Cross Shard Block ShardTxRoot Error"},
+	ExportStateSnapshotError:                          {-1110, "Export State Snapshot Error"},
+	ImportStateSnapshotError:                          {-1111, "Import State Snapshot Error"},
+	PruneBlockError:                                   {-1112, "Prune Block Error"},
+	ProcessPrivacyTokenInstructionError:               {-1113, "Process Privacy Token Instruction Error"},
+	InvalidTxQueryCursorError:                         {-1114, "Invalid Tx Query Cursor Error"},
 }
 
 type BlockChainError struct {
@@ -83,6 +83,10 @@ type TxPool interface {
 
 	MaybeAcceptTransactionForBlockProducing(metadata.Transaction) (*metadata.TxDesc, error)
 	ValidateTxList(txs []metadata.Transaction) error
+
+	// ResetVerificationCache removes cached results of txs verified by itself,
+	// they are no longer trusted after chain state is reverted
+	ResetVerificationCache()
 	//CheckTransactionFee
 	// CheckTransactionFee(tx metadata.Transaction) (uint64, error)
 
@@ -65,6 +65,30 @@ func (blockchain *BlockChain) OnPeerStateReceived(beacon *ChainState, shard *map
 	blockchain.Synker.States.Unlock()
 }
 
+// OnPeerVersionReceived records whether peer is a pruning node, so blocks out of its retention window aren't requested from it
+func (blockchain *BlockChain) OnPeerVersionReceived(peerID libp2p.ID, pruneBlocks uint64) {
+	if blockchain.IsTest {
+		return
+	}
+	blockchain.Synker.States.Lock()
+	if pruneBlocks == 0 {
+		delete(blockchain.Synker.States.PeersPruneBlocks, peerID)
+	} else {
+		blockchain.Synker.States.PeersPruneBlocks[peerID] = pruneBlocks
+	}
+	blockchain.Synker.States.Unlock()
+}
+
+// OnPeerDisconnected forgets retention window of a disconnected peer, it is advertised again in version msg on reconnect
+func (blockchain *BlockChain) OnPeerDisconnected(peerID libp2p.ID) {
+	if blockchain.IsTest {
+		return
+	}
+	blockchain.Synker.States.Lock()
+	delete(blockchain.Synker.States.PeersPruneBlocks, peerID)
+	blockchain.Synker.States.Unlock()
+}
+
 func (blockchain *BlockChain) OnBlockShardReceived(newBlk *ShardBlock) {
 	if blockchain.IsTest {
 		return
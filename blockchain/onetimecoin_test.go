@@ -0,0 +1,87 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOneTimeOutputCoin(t *testing.T, receiver *incognitokey.KeySet) []byte {
+	oneTimePublicKey, txRandom, err := privacy.GenerateOneTimePublicKey(receiver.PaymentAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputCoin := new(privacy.OutputCoin)
+	outputCoin.CoinDetails = new(privacy.Coin).Init()
+	outputCoin.CoinDetails.SetPublicKey(oneTimePublicKey)
+	outputCoin.CoinDetails.SetTxRandom(txRandom)
+	outputCoin.CoinDetails.SetValue(10)
+	outputCoin.CoinDetails.SetSNDerivator(privacy.RandScalar())
+	outputCoin.CoinDetails.SetRandomness(privacy.RandScalar())
+	if err := outputCoin.CoinDetails.CommitAll(); err != nil {
+		t.Fatal(err)
+	}
+	return outputCoin.Bytes()
+}
+
+func TestScanOneTimeOutputCoins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "onetimecoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	chain := &BlockChain{config: Config{DataBase: db}}
+	keySet := new(incognitokey.KeySet).GenerateKey([]byte{1})
+	otherKeySet := new(incognitokey.KeySet).GenerateKey([]byte{2})
+	tokenID := &common.PRVCoinID
+	shardID := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+
+	coins := [][]byte{
+		newTestOneTimeOutputCoin(t, keySet),
+		newTestOneTimeOutputCoin(t, otherKeySet),
+		newTestOneTimeOutputCoin(t, keySet),
+	}
+	assert.Nil(t, db.StoreOneTimeOutputCoins(*tokenID, coins, shardID))
+	found, err := chain.scanOneTimeOutputCoins(keySet, shardID, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{coins[0], coins[2]}, found)
+
+	// next scan continues from the last scanned coin
+	newCoin := newTestOneTimeOutputCoin(t, keySet)
+	assert.Nil(t, db.StoreOneTimeOutputCoins(*tokenID, [][]byte{newCoin}, shardID))
+	found, err = chain.scanOneTimeOutputCoins(keySet, shardID, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{coins[0], coins[2], newCoin}, found)
+	scanKey := common.HashH(append(append(append(append([]byte{}, keySet.ReadonlyKey.Rk...), keySet.ReadonlyKey.Pk...), tokenID[:]...), shardID))
+	assert.Equal(t, uint64(4), chain.oneTimeCoinScans[scanKey].nextIndex)
+	// evicted progress is only a cache, key is scanned again from the first coin
+	chain.oneTimeCoinScans = nil
+	found, err = chain.scanOneTimeOutputCoins(keySet, shardID, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{coins[0], coins[2], newCoin}, found)
+
+	found, err = chain.scanOneTimeOutputCoins(otherKeySet, shardID, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{coins[1]}, found)
+
+	// coins of a reverted block are removed from index
+	assert.Nil(t, db.DeleteLastOneTimeOutputCoins(*tokenID, shardID, 1))
+	length, err := db.GetOneTimeOutputCoinsLength(*tokenID, shardID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), length)
+	remaining, err := db.GetOneTimeOutputCoins(*tokenID, shardID, 0, MaxOneTimeCoinsScan)
+	assert.Nil(t, err)
+	assert.Equal(t, coins, remaining)
+}
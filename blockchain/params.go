@@ -19,6 +19,7 @@ type Params struct {
 	GenesisShardBlock      *ShardBlock  // GenesisBlock defines the first block of the chain.
 	BasicReward            uint64
 	RewardHalflife         uint64
+	MinCommitmentRingSize  int // minimum ring size of one out of many proofs in privacy txs
 }
 
 type GenesisParams struct {
@@ -62,6 +63,8 @@ func init() {
 		GenesisShardBlock:  CreateShardGenesisBlock(1, genesisParamsTestnetNew),
 		BasicReward:        TestnetBasicReward,
 		RewardHalflife:     TestnetRewardHalflife,
+
+		MinCommitmentRingSize: TestnetMinCommitmentRingSize,
 	}
 	// END TESTNET
 	// FOR MAINNET
@@ -86,5 +89,7 @@ func init() {
 		GenesisShardBlock:  CreateShardGenesisBlock(1, genesisParamsMainnetNew),
 		BasicReward:        MainnetBasicReward,
 		RewardHalflife:     MainnetRewardHalflife,
+
+		MinCommitmentRingSize: MainnetMinCommitmentRingSize,
 	}
 }
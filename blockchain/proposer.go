@@ -0,0 +1,85 @@
+package blockchain
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// proposerRotation returns committee positions in proposing order after proposerIdx,
+// members which failed their last proposal are left out.
+// When too many members are left out, failed members are ignored and the plain rotation is used.
+func proposerRotation(committee []string, proposerIdx int, failedProposers []string) []int {
+	rotation := []int{}
+	plainRotation := []int{}
+	for i := 1; i <= len(committee); i++ {
+		position := (proposerIdx + i) % len(committee)
+		plainRotation = append(plainRotation, position)
+		if common.IndexOfStr(committee[position], failedProposers) < 0 {
+			rotation = append(rotation, position)
+		}
+	}
+	if len(committee)-len(rotation) > maxFailedProposers(len(committee)) {
+		return plainRotation
+	}
+	return rotation
+}
+
+// maxFailedProposers is number of committee members which can be skipped,
+// committee keeps a quorum of proposers whatever happens
+func maxFailedProposers(committeeSize int) int {
+	return committeeSize / 3
+}
+
+// GetProposerPosition returns position in committee of proposer of a round (starting from 1) at the next height.
+// Proposers of the next rounds follow the last proposer in committee, skipping members which failed their last proposal.
+func GetProposerPosition(committee []string, proposerIdx int, failedProposers []string, round int) int {
+	if len(committee) == 0 {
+		return -1
+	}
+	if round < 1 {
+		round = 1
+	}
+	rotation := proposerRotation(committee, proposerIdx, failedProposers)
+	return rotation[(round-1)%len(rotation)]
+}
+
+// UpdateFailedProposers returns members which failed their last proposal after a block of round is produced at the next height:
+// - proposers of the rounds before round failed
+// - producer of the block and members which have been skipped once by this block are cleared
+// Most recent failures are kept when there are more than committee can skip.
+func UpdateFailedProposers(committee []string, proposerIdx int, failedProposers []string, round int) []string {
+	if len(committee) == 0 {
+		return []string{}
+	}
+	if round < 1 {
+		round = 1
+	}
+	rotation := proposerRotation(committee, proposerIdx, failedProposers)
+	producerPosition := rotation[(round-1)%len(rotation)]
+	// members which came before producer in committee order were skipped (or failed) at this height
+	passed := []string{}
+	for i := 1; i <= len(committee); i++ {
+		position := (proposerIdx + i) % len(committee)
+		if position == producerPosition && round <= len(rotation) {
+			break
+		}
+		passed = append(passed, committee[position])
+	}
+	res := []string{}
+	for _, member := range failedProposers {
+		if common.IndexOfStr(member, committee) < 0 || common.IndexOfStr(member, passed) > -1 || member == committee[producerPosition] {
+			continue
+		}
+		res = append(res, member)
+	}
+	for r := 1; r < round; r++ {
+		member := committee[rotation[(r-1)%len(rotation)]]
+		if member == committee[producerPosition] || common.IndexOfStr(member, res) > -1 {
+			continue
+		}
+		res = append(res, member)
+	}
+	if maxFailed := maxFailedProposers(len(committee)); len(res) > maxFailed {
+		res = res[len(res)-maxFailed:]
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProposerPosition(t *testing.T) {
+	committee := []string{"a", "b", "c", "d", "e", "f", "g"}
+	// without failed proposers, proposer of round r follows last proposer by r
+	for round := 1; round <= 2*len(committee); round++ {
+		assert.Equal(t, (2+round)%len(committee), GetProposerPosition(committee, 2, []string{}, round))
+	}
+	// "d" failed its last proposal, "e" proposes round 1
+	assert.Equal(t, 4, GetProposerPosition(committee, 2, []string{"d"}, 1))
+	assert.Equal(t, 5, GetProposerPosition(committee, 2, []string{"d"}, 2))
+	// rotation wraps without "d"
+	assert.Equal(t, 4, GetProposerPosition(committee, 2, []string{"d"}, 7))
+	// too many failed proposers, plain rotation is used
+	assert.Equal(t, 3, GetProposerPosition(committee, 2, []string{"d", "e", "f"}, 1))
+}
+
+func TestUpdateFailedProposers(t *testing.T) {
+	committee := []string{"a", "b", "c", "d", "e", "f", "g"}
+	// "d" and "e" failed round 1 and 2, "f" produced block of round 3
+	failedProposers := UpdateFailedProposers(committee, 2, []string{}, 3)
+	assert.Equal(t, []string{"d", "e"}, failedProposers)
+	// next height: "d" and "e" are skipped, "g" follows "f"
+	assert.Equal(t, 6, GetProposerPosition(committee, 5, failedProposers, 1))
+	// "g" produced next block, "d" and "e" were not passed over yet
+	failedProposers = UpdateFailedProposers(committee, 5, failedProposers, 1)
+	assert.Equal(t, []string{"d", "e"}, failedProposers)
+	// "a", "b", "c" then "f" (skipping "d" and "e") produce, "d" and "e" get another chance
+	assert.Equal(t, 5, GetProposerPosition(committee, 2, failedProposers, 1))
+	failedProposers = UpdateFailedProposers(committee, 2, failedProposers, 1)
+	assert.Equal(t, []string{}, failedProposers)
+	// members which left committee are cleared
+	assert.Equal(t, []string{}, UpdateFailedProposers(committee, 2, []string{"x"}, 1))
+	// only most recent failures are kept
+	assert.Equal(t, []string{"e", "f"}, UpdateFailedProposers(committee, 2, []string{"b"}, 4))
+}
@@ -0,0 +1,199 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+/*
+PruneBlocks - number of latest blocks whose bodies are kept by this node, 0 means node is archival
+*/
+func (blockchain *BlockChain) PruneBlocks() uint64 {
+	return blockchain.config.PruneBlocks
+}
+
+/*
+startPruneShardBlocks - prune shard blocks in background after a block is inserted,
+best height is read here because best state may change while pruning
+*/
+func (blockchain *BlockChain) startPruneShardBlocks(shardID byte) {
+	if blockchain.config.PruneBlocks == 0 {
+		return
+	}
+	bestHeight := blockchain.BestState.Shard[shardID].ShardHeight
+	go func() {
+		if err := blockchain.pruneShardBlocks(shardID, bestHeight); err != nil {
+			Logger.log.Error(err)
+		}
+	}()
+}
+
+/*
+startPruneBeaconBlocks - prune beacon blocks in background after a block is inserted,
+beacon blocks which are not processed by synced shards yet are kept whatever retention window is
+*/
+func (blockchain *BlockChain) startPruneBeaconBlocks() {
+	if blockchain.config.PruneBlocks == 0 {
+		return
+	}
+	bestHeight := blockchain.BestState.Beacon.BeaconHeight
+	for _, shardID := range blockchain.Synker.GetCurrentSyncShards() {
+		if shardBestState, ok := blockchain.BestState.Shard[shardID]; ok && shardBestState.BeaconHeight < bestHeight {
+			bestHeight = shardBestState.BeaconHeight
+		}
+	}
+	go func() {
+		if err := blockchain.pruneBeaconBlocks(bestHeight); err != nil {
+			Logger.log.Error(err)
+		}
+	}()
+}
+
+/*
+pruneShardBlocks - remove body and tx indexes of shard blocks which are out of retention window below best height.
+Block header, block index and crypto data (serial numbers, commitments, output coins, snderivators) are kept,
+genesis block is never pruned
+*/
+func (blockchain *BlockChain) pruneShardBlocks(shardID byte, bestHeight uint64) error {
+	retention := blockchain.config.PruneBlocks
+	if retention == 0 {
+		return nil
+	}
+	// prune goroutines mustn't interleave, otherwise pruned height of a chain goes backward
+	blockchain.pruneLock.Lock()
+	defer blockchain.pruneLock.Unlock()
+	db := blockchain.config.DataBase
+	if bestHeight <= retention+1 {
+		return nil
+	}
+	prunedHeight, err := db.FetchPrunedHeight(false, shardID)
+	if err != nil {
+		return NewBlockChainError(PruneBlockError, err)
+	}
+	toHeight := bestHeight - retention
+	if toHeight > prunedHeight+MaxPruneBlocksPerTime {
+		toHeight = prunedHeight + MaxPruneBlocksPerTime
+	}
+	for height := prunedHeight + 1; height <= toHeight; height++ {
+		if height == 1 {
+			continue
+		}
+		blockHash, err := db.GetBlockByIndex(height, shardID)
+		if err != nil {
+			// block is not in database, e.g node started from a state snapshot
+			continue
+		}
+		if ok, _ := db.HasBlock(blockHash); !ok {
+			continue
+		}
+		block, _, err := blockchain.GetShardBlockByHash(blockHash)
+		if err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		for index, tx := range block.Body.Transactions {
+			if err := db.DeleteTransactionIndex(*tx.Hash()); err != nil {
+				return NewBlockChainError(PruneBlockError, err)
+			}
+			if err := blockchain.DeleteTxQueryIndex(tx, block, index); err != nil {
+				return NewBlockChainError(PruneBlockError, err)
+			}
+		}
+		header := *block
+		header.Body = ShardBody{}
+		if err := db.PruneBlockBody(blockHash, &header); err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+	}
+	if toHeight > prunedHeight {
+		if err := db.StorePrunedHeight(false, shardID, toHeight); err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		Logger.log.Debugf("SHARD %+v | Pruned block bodies up to height %+v", shardID, toHeight)
+	}
+	return nil
+}
+
+/*
+pruneBeaconBlocks - remove body of beacon blocks which are out of retention window below best height,
+genesis block is never pruned
+*/
+func (blockchain *BlockChain) pruneBeaconBlocks(bestHeight uint64) error {
+	retention := blockchain.config.PruneBlocks
+	if retention == 0 {
+		return nil
+	}
+	blockchain.pruneLock.Lock()
+	defer blockchain.pruneLock.Unlock()
+	db := blockchain.config.DataBase
+	if bestHeight <= retention+1 {
+		return nil
+	}
+	prunedHeight, err := db.FetchPrunedHeight(true, 0)
+	if err != nil {
+		return NewBlockChainError(PruneBlockError, err)
+	}
+	toHeight := bestHeight - retention
+	if toHeight > prunedHeight+MaxPruneBlocksPerTime {
+		toHeight = prunedHeight + MaxPruneBlocksPerTime
+	}
+	for height := prunedHeight + 1; height <= toHeight; height++ {
+		if height == 1 {
+			continue
+		}
+		blockHash, err := db.GetBeaconBlockHashByIndex(height)
+		if err != nil {
+			// block is not in database, e.g node started from a state snapshot
+			continue
+		}
+		if ok, _ := db.HasBeaconBlock(blockHash); !ok {
+			continue
+		}
+		block, _, err := blockchain.GetBeaconBlockByHash(blockHash)
+		if err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		header := *block
+		header.Body = BeaconBody{}
+		if err := db.PruneBlockBody(blockHash, &header); err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+	}
+	if toHeight > prunedHeight {
+		if err := db.StorePrunedHeight(true, 0, toHeight); err != nil {
+			return NewBlockChainError(PruneBlockError, err)
+		}
+		Logger.log.Debugf("BEACON | Pruned block bodies up to height %+v", toHeight)
+	}
+	return nil
+}
+
+/*
+GetShardBlockHeaderByHash - get header of a shard block, it works for pruned blocks as well
+*/
+func (blockchain *BlockChain) GetShardBlockHeaderByHash(hash common.Hash) (*ShardHeader, error) {
+	headerBytes, err := blockchain.config.DataBase.FetchBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	block := ShardBlock{}
+	if err := json.Unmarshal(headerBytes, &block); err != nil {
+		return nil, err
+	}
+	return &block.Header, nil
+}
+
+/*
+GetBeaconBlockHeaderByHash - get header of a beacon block, it works for pruned blocks as well
+*/
+func (blockchain *BlockChain) GetBeaconBlockHeaderByHash(hash common.Hash) (*BeaconHeader, error) {
+	headerBytes, err := blockchain.config.DataBase.FetchBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	block := BeaconBlock{}
+	if err := json.Unmarshal(headerBytes, &block); err != nil {
+		return nil, err
+	}
+	return &block.Header, nil
+}
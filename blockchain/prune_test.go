@@ -0,0 +1,158 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	libp2p "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPruneChain stores shard and beacon blocks from height 1 to bestHeight in a fresh database
+func newTestPruneChain(t *testing.T, dir string, pruneBlocks uint64, bestHeight uint64) (*BlockChain, []common.Hash, []common.Hash) {
+	db, err := database.Open("leveldb", filepath.Join(dir, "prune"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySet := new(incognitokey.KeySet).GenerateKey([]byte{1})
+	shardHashes := []common.Hash{{}}
+	beaconHashes := []common.Hash{{}}
+	for height := uint64(1); height <= bestHeight; height++ {
+		shardBlock := &ShardBlock{}
+		shardBlock.Header.Height = height
+		shardBlock.Header.Version = SHARD_BLOCK_VERSION
+		shardBlock.Header.Round = 1
+		shardBlock.Header.Epoch = 1
+		shardBlock.Header.BeaconHeight = 1
+		shardBlock.Header.TotalTxsFee = make(map[common.Hash]uint64)
+		if height > 1 {
+			shardBlock.Header.ProducerAddress = keySet.PaymentAddress
+			shardBlock.Header.PreviousBlockHash = shardHashes[height-1]
+			shardBlock.Header.CommitteeRoot = common.HashH([]byte("committee"))
+		}
+		shardHash := *shardBlock.Hash()
+		if err := db.StoreShardBlock(shardBlock, shardHash, 0); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.StoreShardBlockIndex(shardHash, height, 0); err != nil {
+			t.Fatal(err)
+		}
+		shardHashes = append(shardHashes, shardHash)
+
+		beaconBlock := &BeaconBlock{}
+		beaconBlock.Header.Height = height
+		beaconBlock.Header.PreviousBlockHash = beaconHashes[height-1]
+		beaconHash := *beaconBlock.Hash()
+		if err := db.StoreBeaconBlock(beaconBlock, beaconHash); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.StoreBeaconBlockIndex(beaconHash, height); err != nil {
+			t.Fatal(err)
+		}
+		beaconHashes = append(beaconHashes, beaconHash)
+	}
+	return &BlockChain{config: Config{DataBase: db, PruneBlocks: pruneBlocks}}, shardHashes, beaconHashes
+}
+
+func TestPruneShardBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	chain, hashes, _ := newTestPruneChain(t, dir, 3, 10)
+	db := chain.config.DataBase
+	defer db.Close()
+
+	assert.Nil(t, chain.pruneShardBlocks(0, 10))
+	prunedHeight, err := db.FetchPrunedHeight(false, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), prunedHeight, "bodies of latest 3 blocks are kept")
+	for height := uint64(1); height <= 10; height++ {
+		hasBody, _ := db.HasBlock(hashes[height])
+		// genesis block is never pruned
+		assert.Equal(t, height == 1 || height > 7, hasBody, "body of block %+v", height)
+		// headers of pruned blocks are still served
+		header, err := chain.GetShardBlockHeaderByHash(hashes[height])
+		if assert.Nil(t, err) {
+			assert.Equal(t, height, header.Height)
+		}
+	}
+	_, _, err = chain.GetShardBlockByHash(hashes[5])
+	assert.NotNil(t, err, "pruned block can't be served")
+
+	// pruning continues from pruned height as chain grows
+	assert.Nil(t, chain.pruneShardBlocks(0, 11))
+	prunedHeight, _ = db.FetchPrunedHeight(false, 0)
+	assert.Equal(t, uint64(8), prunedHeight)
+	hasBody, _ := db.HasBlock(hashes[8])
+	assert.False(t, hasBody)
+}
+
+func TestPruneShardBlocksArchival(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	chain, hashes, _ := newTestPruneChain(t, dir, 0, 5)
+	db := chain.config.DataBase
+	defer db.Close()
+
+	assert.Nil(t, chain.pruneShardBlocks(0, 5))
+	prunedHeight, _ := db.FetchPrunedHeight(false, 0)
+	assert.Equal(t, uint64(0), prunedHeight)
+	for height := uint64(1); height <= 5; height++ {
+		hasBody, _ := db.HasBlock(hashes[height])
+		assert.True(t, hasBody, "archival node keeps body of block %+v", height)
+	}
+}
+
+func TestPruneBeaconBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	chain, _, hashes := newTestPruneChain(t, dir, 3, 10)
+	db := chain.config.DataBase
+	defer db.Close()
+
+	// best height is lowered to beacon height of synced shards by caller
+	assert.Nil(t, chain.pruneBeaconBlocks(6))
+	prunedHeight, err := db.FetchPrunedHeight(true, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), prunedHeight)
+	for height := uint64(1); height <= 10; height++ {
+		hasBody, _ := db.HasBeaconBlock(hashes[height])
+		assert.Equal(t, height == 1 || height > 3, hasBody, "body of block %+v", height)
+		header, err := chain.GetBeaconBlockHeaderByHash(hashes[height])
+		if assert.Nil(t, err) {
+			assert.Equal(t, height, header.Height)
+		}
+	}
+	shardPrunedHeight, _ := db.FetchPrunedHeight(false, 0)
+	assert.Equal(t, uint64(0), shardPrunedHeight, "pruned heights of beacon and shard are kept apart")
+}
+
+func TestSynkerPeersPruneBlocks(t *testing.T) {
+	chain := &BlockChain{}
+	chain.Synker.States.PeersPruneBlocks = make(map[libp2p.ID]uint64)
+	peerID := libp2p.ID("pruning peer")
+
+	chain.OnPeerVersionReceived(peerID, 1000)
+	assert.False(t, chain.Synker.canServeBlocks(peerID, 2000, 1000))
+	assert.True(t, chain.Synker.canServeBlocks(peerID, 2000, 1001))
+	assert.True(t, chain.Synker.canServeBlocks(libp2p.ID("archival peer"), 2000, 1))
+
+	chain.OnPeerDisconnected(peerID)
+	_, ok := chain.Synker.States.PeersPruneBlocks[peerID]
+	assert.False(t, ok, "retention window is forgotten when peer disconnects")
+	assert.True(t, chain.Synker.canServeBlocks(peerID, 2000, 1))
+}
@@ -0,0 +1,87 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRevertShardBlock(height uint64, round int, previousBlock *ShardBlock) *ShardBlock {
+	shardBlock := &ShardBlock{}
+	shardBlock.Header.Height = height
+	shardBlock.Header.Version = SHARD_BLOCK_VERSION
+	shardBlock.Header.Round = round
+	shardBlock.Header.Epoch = 1
+	shardBlock.Header.BeaconHeight = 1
+	shardBlock.Header.TotalTxsFee = make(map[common.Hash]uint64)
+	if previousBlock != nil {
+		shardBlock.Header.ProducerAddress = new(incognitokey.KeySet).GenerateKey([]byte{1}).PaymentAddress
+		shardBlock.Header.PreviousBlockHash = *previousBlock.Hash()
+		shardBlock.Header.CommitteeRoot = common.HashH([]byte("committee"))
+	}
+	return shardBlock
+}
+
+func TestRevertShardStatePublishesDisconnectedBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "revert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	pubSubManager := pubsub.NewPubSubManager()
+	blockchain := &BlockChain{
+		config:    Config{DataBase: db, PubSubManager: pubSubManager},
+		BestState: &BestState{Shard: make(map[byte]*ShardBestState)},
+	}
+
+	prevBlock := newTestRevertShardBlock(1, 1, nil)
+	prevBestState, err := json.Marshal(&ShardBestState{BestBlock: prevBlock, ShardHeight: 1, BeaconHeight: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, db.StorePrevBestState(prevBestState, false, 0))
+	revertedBlock := newTestRevertShardBlock(2, 1, prevBlock)
+	blockchain.BestState.Shard[0] = &ShardBestState{BestBlock: revertedBlock, ShardHeight: 2, BeaconHeight: 1}
+
+	subId, subChan, err := pubSubManager.RegisterNewOrderedSubscriber([]string{pubsub.ShardBlockDisconnectedTopic, pubsub.NewShardblockTopic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pubSubManager.UnsubscribeOrdered(subId)
+	assert.Nil(t, blockchain.RevertShardState(0))
+	assert.Equal(t, uint64(1), blockchain.BestState.Shard[0].ShardHeight)
+	// block replacing reverted block is published after it
+	replacingBlock := newTestRevertShardBlock(2, 2, prevBlock)
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, replacingBlock))
+
+	expected := []struct {
+		topic string
+		block *ShardBlock
+	}{
+		{pubsub.ShardBlockDisconnectedTopic, revertedBlock},
+		{pubsub.NewShardblockTopic, replacingBlock},
+	}
+	for _, item := range expected {
+		select {
+		case msg := <-subChan:
+			assert.Equal(t, item.topic, msg.Topic)
+			assert.Equal(t, item.block, msg.Value)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no chain event")
+		}
+	}
+}
@@ -32,6 +32,7 @@ type ShardBestState struct {
 	MaxShardCommitteeSize  int               `json:"MaxShardCommitteeSize"`
 	MinShardCommitteeSize  int               `json:"MinShardCommitteeSize"`
 	ShardProposerIdx       int               `json:"ShardProposerIdx"`
+	ShardFailedProposers   []string          `json:"ShardFailedProposers"` // shard committee members which failed their last proposal, skipped by next proposer rotation
 	ShardCommittee         []string          `json:"ShardCommittee"`
 	ShardPendingValidator  []string          `json:"ShardPendingValidator"`
 	BestCrossShard         map[byte]uint64   `json:"BestCrossShard"` // Best cross shard block by heigh
@@ -114,6 +115,9 @@ func (shardBestState *ShardBestState) GetBytes() []byte {
 	proposerIdxBytes := make([]byte, 4)
 	binary.LittleEndian.PutUint32(proposerIdxBytes, uint32(shardBestState.ShardProposerIdx))
 	res = append(res, proposerIdxBytes...)
+	for _, value := range shardBestState.ShardFailedProposers {
+		res = append(res, []byte(value)...)
+	}
 	for _, value := range shardBestState.ShardCommittee {
 		res = append(res, []byte(value)...)
 	}
@@ -192,7 +196,7 @@ func (shardBestState *ShardBestState) SetMinShardCommitteeSize(minShardCommittee
 func (shardBestState *ShardBestState) GetPubkeyRole(pubkey string, round int) string {
 	found := common.IndexOfStr(pubkey, shardBestState.ShardCommittee)
 	if found > -1 {
-		tmpID := (shardBestState.ShardProposerIdx + round) % len(shardBestState.ShardCommittee)
+		tmpID := GetProposerPosition(shardBestState.ShardCommittee, shardBestState.ShardProposerIdx, shardBestState.ShardFailedProposers, round)
 		if found == tmpID {
 			return common.PROPOSER_ROLE
 		} else {
@@ -156,7 +156,9 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, isValidat
 	if err != nil {
 		return err
 	}
-	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
+	blockchain.startPruneShardBlocks(shardID)
+	// published synchronously like reverted blocks, so ordered subscribers get chain changes in order
+	blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBeststateTopic, blockchain.BestState.Shard[shardID]))
 	shardIDForMetric := strconv.Itoa(int(shardBlock.Header.ShardID))
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
@@ -165,7 +167,11 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, isValidat
 		metrics.Tag:              metrics.ShardIDTag,
 		metrics.TagValue:         metrics.Shard + shardIDForMetric,
 	})
-	Logger.log.Infof("SHARD %+v | 🔗 Finish Insert new block %d, with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
+	Logger.log.WithFields(common.LogFields{
+		Shard:     strconv.Itoa(int(shardBlock.Header.ShardID)),
+		Height:    shardBlock.Header.Height,
+		BlockHash: blockHash.String(),
+	}).Infof("SHARD %+v | 🔗 Finish Insert new block %d, with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 	return nil
 }
 
@@ -266,6 +272,12 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(shardBlock *ShardBlo
 			return NewBlockChainError(InstructionsHashError, fmt.Errorf("Expect instruction hash to be %+v", shardBlock.Header.InstructionsRoot))
 		}
 	}
+	// txs expired before beacon height of block can not be included
+	for _, tx := range shardBlock.Body.Transactions {
+		if tx.IsExpired(shardBlock.Header.BeaconHeight) {
+			return NewBlockChainError(TransactionFromNewBlockError, fmt.Errorf("Transaction %+v expired at beacon height %+v, block beacon height %+v", tx.Hash().String(), tx.GetExpiryHeight(), shardBlock.Header.BeaconHeight))
+		}
+	}
 	totalTxsFee := make(map[common.Hash]uint64)
 	for _, tx := range shardBlock.Body.Transactions {
 		totalTxsFee[*tx.GetTokenID()] += tx.GetTxFee()
@@ -510,7 +522,7 @@ func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(shardBlock *
 	hash := shardBlock.Header.Hash()
 	//verify producer via index
 	producerPublicKey := base58.Base58Check{}.Encode(shardBlock.Header.ProducerAddress.Pk, common.ZeroByte)
-	producerPosition := (shardBestState.ShardProposerIdx + shardBlock.Header.Round) % len(shardBestState.ShardCommittee)
+	producerPosition := GetProposerPosition(shardBestState.ShardCommittee, shardBestState.ShardProposerIdx, shardBestState.ShardFailedProposers, shardBlock.Header.Round)
 	Logger.log.Infof("SHARD %+v | Producer public key %+v and signature %+v, block hash to be signed %+v and encoded public key %+v", shardBlock.Header.ShardID, producerPublicKey, shardBlock.ProducerSig, hash.GetBytes(), base58.Base58Check{}.Encode(shardBlock.Header.ProducerAddress.Pk, common.ZeroByte))
 	tempProducer := shardBestState.ShardCommittee[producerPosition]
 	if strings.Compare(tempProducer, producerPublicKey) != 0 {
@@ -573,6 +585,7 @@ func (shardBestState *ShardBestState) updateShardBestState(shardBlock *ShardBloc
 	shardBestState.BeaconHeight = shardBlock.Header.BeaconHeight
 	shardBestState.TotalTxns += uint64(len(shardBlock.Body.Transactions))
 	shardBestState.NumTxns = uint64(len(shardBlock.Body.Transactions))
+	shardBestState.ShardFailedProposers = UpdateFailedProposers(shardBestState.ShardCommittee, shardBestState.ShardProposerIdx, shardBestState.ShardFailedProposers, shardBlock.Header.Round)
 	shardBestState.ShardProposerIdx = common.IndexOfStr(base58.Base58Check{}.Encode(shardBlock.Header.ProducerAddress.Pk, common.ZeroByte), shardBestState.ShardCommittee)
 	shardBestState.processBeaconBlocks(shardBlock, beaconBlocks)
 	err = shardBestState.processShardBlockInstruction(shardBlock)
@@ -606,6 +619,7 @@ func (shardBestState *ShardBestState) initShardBestState(genesisShardBlock *Shar
 	shardBestState.TotalTxns += uint64(len(genesisShardBlock.Body.Transactions))
 	shardBestState.NumTxns = uint64(len(genesisShardBlock.Body.Transactions))
 	shardBestState.ShardProposerIdx = 0
+	shardBestState.ShardFailedProposers = []string{}
 	shardBestState.processBeaconBlocks(genesisShardBlock, []*BeaconBlock{genesisBeaconBlock})
 	err := shardBestState.processShardBlockInstruction(genesisShardBlock)
 	if err != nil {
@@ -793,6 +807,9 @@ func (blockchain *BlockChain) processStoreShardBlockAndUpdateDatabase(shardBlock
 			Logger.log.Errorf("Transaction in block with hash %+v and index %+v: %+v, err %+v", blockHash, index, tx, err)
 			return NewBlockChainError(FetchAndStoreTransactionError, err)
 		}
+		if err := blockchain.StoreTxQueryIndex(tx, shardBlock, index); err != nil {
+			return NewBlockChainError(FetchAndStoreTransactionError, err)
+		}
 		// Process Transaction Metadata
 		metaType := tx.GetMetadataType()
 		if metaType == metadata.WithDrawRewardResponseMeta {
@@ -118,6 +118,7 @@ func CreateSwapAction(pendingValidator []string, commitees []string, committeeSi
 	Action Generate From Transaction:
 	- Stake
 	- Stable param: set, del,...
+	- Init privacy custom token
 */
 func CreateShardInstructionsFromTransactionAndInstruction(
 	transactions []metadata.Transaction,
@@ -133,6 +134,11 @@ func CreateShardInstructionsFromTransactionAndInstruction(
 	if err != nil {
 		return nil, err
 	}
+	privacyTokenActions, err := buildPrivacyTokenActions(transactions)
+	if err != nil {
+		return nil, err
+	}
+	instructions = append(instructions, privacyTokenActions...)
 	for _, tx := range transactions {
 		switch tx.GetMetadataType() {
 		case metadata.ShardStakingMeta:
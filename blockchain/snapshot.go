@@ -0,0 +1,346 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+const (
+	snapshotFilePrefix = "checkpoint-"
+	snapshotFileExt    = ".json"
+	nextBlockFileExt   = "-next.json"
+)
+
+/*
+StateSnapshot is the chain state of a node at a trusted checkpoint (last beacon block of an epoch):
+beacon best state, shard best states and state data (serial numbers, commitments, snd derivators,
+tokens, bridge, rewards...) stored in database.
+A new node can import it and sync from checkpoint instead of replaying blocks from genesis
+*/
+type StateSnapshot struct {
+	Beacon *BeaconBestState
+	Shard  map[byte]*ShardBestState
+	Data   []database.BatchData
+	// ExporterPubkey and Sig are public key and signature of node which exported snapshot on checkpoint hash
+	ExporterPubkey string
+	Sig            string
+}
+
+/*
+Hash - checkpoint hash of snapshot, it commits to everything import writes to database:
+beacon best state, shard best states and every key/value of state data.
+Shard chains reach beacon asynchronously, so snapshots of different nodes at the same beacon height
+have different hashes: trusted checkpoint is the hash of one snapshot published by a trusted exporter
+*/
+func (snapshot *StateSnapshot) Hash() (common.Hash, error) {
+	beaconBytes, err := json.Marshal(snapshot.Beacon)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	dataHash, err := snapshot.DataHash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HashH(append(common.HashB(beaconBytes), dataHash.GetBytes()...)), nil
+}
+
+// DataHash commits to shard best states and every key/value of state data, which depend on exporter node
+func (snapshot *StateSnapshot) DataHash() (common.Hash, error) {
+	record := []byte{}
+	shardIDs := []int{}
+	for shardID := range snapshot.Shard {
+		shardIDs = append(shardIDs, int(shardID))
+	}
+	sort.Ints(shardIDs)
+	for _, shardID := range shardIDs {
+		shardBytes, err := json.Marshal(snapshot.Shard[byte(shardID)])
+		if err != nil {
+			return common.Hash{}, err
+		}
+		record = append(record, byte(shardID))
+		record = append(record, common.HashB(shardBytes)...)
+	}
+	for _, item := range snapshot.Data {
+		record = append(record, common.HashB(item.Key)...)
+		record = append(record, common.HashB(item.Value)...)
+	}
+	return common.HashH(record), nil
+}
+
+// snapshotFile returns file path of snapshot at beacon height in dir,
+// next beacon block is stored along with it to verify committee signatures of checkpoint
+func snapshotFile(dir string, beaconHeight uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%d%s", snapshotFilePrefix, beaconHeight, snapshotFileExt))
+}
+
+func nextBlockFile(snapshotFile string) string {
+	return strings.TrimSuffix(snapshotFile, snapshotFileExt) + nextBlockFileExt
+}
+
+/*
+captureStateSnapshot - copy best states and open a reader of state data at the same moment,
+it must be called while holding chainLock so beacon best state doesn't change.
+It doesn't read state data so it is quick, caller reads it with completeStateSnapshot and releases reader
+*/
+func (blockchain *BlockChain) captureStateSnapshot() (*StateSnapshot, database.StateSnapshotReader, error) {
+	snapshot := &StateSnapshot{
+		Shard: make(map[byte]*ShardBestState),
+	}
+	beaconBytes, err := json.Marshal(blockchain.BestState.Beacon)
+	if err != nil {
+		return nil, nil, NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	snapshot.Beacon = &BeaconBestState{}
+	if err := json.Unmarshal(beaconBytes, snapshot.Beacon); err != nil {
+		return nil, nil, NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	// shard chains must not change until reader of state data is open
+	for shard := 0; shard < snapshot.Beacon.ActiveShards; shard++ {
+		shardBestState, ok := blockchain.BestState.Shard[byte(shard)]
+		if !ok {
+			return nil, nil, NewBlockChainError(ExportStateSnapshotError, fmt.Errorf("no best state of shard %+v", shard))
+		}
+		shardBestState.lock.RLock()
+		defer shardBestState.lock.RUnlock()
+	}
+	for shard := 0; shard < snapshot.Beacon.ActiveShards; shard++ {
+		shardID := byte(shard)
+		shardBytes, err := json.Marshal(blockchain.BestState.Shard[shardID])
+		if err != nil {
+			return nil, nil, NewBlockChainError(ExportStateSnapshotError, err)
+		}
+		shardBestState := &ShardBestState{}
+		if err := json.Unmarshal(shardBytes, shardBestState); err != nil {
+			return nil, nil, NewBlockChainError(ExportStateSnapshotError, err)
+		}
+		snapshot.Shard[shardID] = shardBestState
+	}
+	reader, err := blockchain.config.DataBase.ExportStateSnapshot()
+	if err != nil {
+		return nil, nil, NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	return snapshot, reader, nil
+}
+
+// completeStateSnapshot reads state data of a captured snapshot and signs it with user key if any
+func (blockchain *BlockChain) completeStateSnapshot(snapshot *StateSnapshot, reader database.StateSnapshotReader) error {
+	defer reader.Release()
+	var err error
+	snapshot.Data, err = reader.ReadAll()
+	if err != nil {
+		return NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	if blockchain.config.UserKeySet != nil {
+		signedHash, err := snapshot.Hash()
+		if err != nil {
+			return NewBlockChainError(ExportStateSnapshotError, err)
+		}
+		snapshot.ExporterPubkey = blockchain.config.UserKeySet.GetPublicKeyInBase58CheckEncode()
+		snapshot.Sig, err = blockchain.config.UserKeySet.SignDataInBase58CheckEncode(signedHash.GetBytes())
+		if err != nil {
+			return NewBlockChainError(ExportStateSnapshotError, err)
+		}
+	}
+	return nil
+}
+
+/*
+ExportStateSnapshot - make a snapshot of current chain state,
+it must be called while holding chainLock so beacon best state doesn't change
+*/
+func (blockchain *BlockChain) ExportStateSnapshot() (*StateSnapshot, error) {
+	snapshot, reader, err := blockchain.captureStateSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	if err := blockchain.completeStateSnapshot(snapshot, reader); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// writeStateSnapshot completes a captured snapshot and writes it to file, state data may be large,
+// so it runs in background and exports are serialized by snapshotLock
+func (blockchain *BlockChain) writeStateSnapshot(snapshot *StateSnapshot, reader database.StateSnapshotReader, file string) error {
+	blockchain.snapshotLock.Lock()
+	defer blockchain.snapshotLock.Unlock()
+	if err := blockchain.completeStateSnapshot(snapshot, reader); err != nil {
+		return err
+	}
+	checkpoint, err := snapshot.Hash()
+	if err != nil {
+		return NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	snapshotBytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	// write to a temporary file first, a partially written snapshot is never picked up
+	if err := ioutil.WriteFile(file+".tmp", snapshotBytes, 0600); err != nil {
+		return NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	if err := os.Rename(file+".tmp", file); err != nil {
+		return NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	Logger.log.Infof("BEACON | Export snapshot at checkpoint height %+v with hash %+v to %+v", snapshot.Beacon.BeaconHeight, checkpoint, file)
+	return nil
+}
+
+/*
+processExportCheckpoint - export snapshot when the last beacon block of an epoch is inserted,
+then store the first beacon block of next epoch along with it.
+Only best states are copied while block is inserted, state data is read and written in background
+*/
+func (blockchain *BlockChain) processExportCheckpoint(beaconBlock *BeaconBlock) error {
+	dir := blockchain.config.SnapshotDir
+	if dir == common.EmptyString || beaconBlock.Header.Height <= 1 {
+		return nil
+	}
+	if beaconBlock.Header.Height%common.EPOCH == 1 {
+		file := snapshotFile(dir, beaconBlock.Header.Height-1)
+		if _, err := os.Stat(dir); err != nil {
+			return nil
+		}
+		blockBytes, err := json.Marshal(beaconBlock)
+		if err != nil {
+			return NewBlockChainError(ExportStateSnapshotError, err)
+		}
+		if err := ioutil.WriteFile(nextBlockFile(file), blockBytes, 0600); err != nil {
+			return NewBlockChainError(ExportStateSnapshotError, err)
+		}
+		return nil
+	}
+	if beaconBlock.Header.Height%common.EPOCH != 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return NewBlockChainError(ExportStateSnapshotError, err)
+	}
+	snapshot, reader, err := blockchain.captureStateSnapshot()
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := blockchain.writeStateSnapshot(snapshot, reader, snapshotFile(dir, beaconBlock.Header.Height)); err != nil {
+			Logger.log.Error(err)
+		}
+	}()
+	return nil
+}
+
+/*
+ImportStateSnapshot - verify a snapshot exported by a trusted node and write it to an empty database,
+chain state is then loaded from database and node syncs from checkpoint:
+  - checkpoint hash of snapshot must match the trusted checkpoint, it covers every imported data
+    so nothing is written to database unless it is the exact snapshot trusted node published
+  - first beacon block after checkpoint must extend checkpoint block and be signed by beacon committee of snapshot
+*/
+func ImportStateSnapshot(db database.DatabaseInterface, file string, checkpoint common.Hash) error {
+	if _, err := db.FetchBeaconBestState(); err == nil {
+		return NewBlockChainError(ImportStateSnapshotError, errors.New("database is already initialized"))
+	}
+	snapshotBytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	snapshot := &StateSnapshot{}
+	if err := json.Unmarshal(snapshotBytes, snapshot); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	if snapshot.Beacon == nil {
+		return NewBlockChainError(ImportStateSnapshotError, errors.New("snapshot has no beacon best state"))
+	}
+	snapshotHash, err := snapshot.Hash()
+	if err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	if !snapshotHash.IsEqual(&checkpoint) {
+		return NewBlockChainError(ImportStateSnapshotError, fmt.Errorf("expect checkpoint hash %+v but get %+v", checkpoint, snapshotHash))
+	}
+	if snapshot.ExporterPubkey != common.EmptyString {
+		if err := incognitokey.ValidateDataB58(snapshot.ExporterPubkey, snapshot.Sig, snapshotHash.GetBytes()); err != nil {
+			return NewBlockChainError(ImportStateSnapshotError, err)
+		}
+	}
+	beaconBestState := snapshot.Beacon
+	beaconBlockHash := beaconBestState.BestBlock.Header.Hash()
+	if !beaconBlockHash.IsEqual(&beaconBestState.BestBlockHash) {
+		return NewBlockChainError(ImportStateSnapshotError, errors.New("beacon best block doesn't match beacon best state"))
+	}
+	if beaconBestState.BeaconHeight%common.EPOCH != 0 {
+		return NewBlockChainError(ImportStateSnapshotError, fmt.Errorf("beacon height %+v is not an epoch boundary", beaconBestState.BeaconHeight))
+	}
+	for shard := 0; shard < beaconBestState.ActiveShards; shard++ {
+		shardBestState, ok := snapshot.Shard[byte(shard)]
+		if !ok || shardBestState.BestBlock == nil {
+			return NewBlockChainError(ImportStateSnapshotError, fmt.Errorf("snapshot has no best state of shard %+v", shard))
+		}
+		shardBlockHash := shardBestState.BestBlock.Header.Hash()
+		if !shardBlockHash.IsEqual(&shardBestState.BestBlockHash) {
+			return NewBlockChainError(ImportStateSnapshotError, fmt.Errorf("shard %+v best block doesn't match shard best state", shard))
+		}
+		// shard state must contain every shard block confirmed by checkpoint beacon block
+		confirmedHeight := beaconBestState.BestShardHeight[byte(shard)]
+		if shardBestState.ShardHeight < confirmedHeight {
+			return NewBlockChainError(ImportStateSnapshotError, fmt.Errorf("shard %+v height %+v is below height %+v confirmed by beacon", shard, shardBestState.ShardHeight, confirmedHeight))
+		}
+		confirmedHash := beaconBestState.BestShardHash[byte(shard)]
+		if shardBestState.ShardHeight == confirmedHeight && !shardBlockHash.IsEqual(&confirmedHash) {
+			return NewBlockChainError(ImportStateSnapshotError, fmt.Errorf("shard %+v best block doesn't match block confirmed by beacon", shard))
+		}
+	}
+	// verify committee signatures of checkpoint
+	nextBlockBytes, err := ioutil.ReadFile(nextBlockFile(file))
+	if err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	nextBlock := &BeaconBlock{}
+	if err := json.Unmarshal(nextBlockBytes, nextBlock); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	if nextBlock.Header.Height != beaconBestState.BeaconHeight+1 || !nextBlock.Header.PreviousBlockHash.IsEqual(&beaconBlockHash) {
+		return NewBlockChainError(ImportStateSnapshotError, errors.New("next beacon block doesn't extend checkpoint block"))
+	}
+	if err := ValidateAggSignature(nextBlock.ValidatorsIndex, beaconBestState.BeaconCommittee, nextBlock.AggregatedSig, nextBlock.R, nextBlock.Hash()); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+
+	if err := db.ImportStateSnapshot(snapshot.Data); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	for shard := 0; shard < beaconBestState.ActiveShards; shard++ {
+		shardID := byte(shard)
+		shardBestState := snapshot.Shard[shardID]
+		if err := db.StoreShardBlock(shardBestState.BestBlock, shardBestState.BestBlockHash, shardID); err != nil {
+			return NewBlockChainError(ImportStateSnapshotError, err)
+		}
+		if err := db.StoreShardBlockIndex(shardBestState.BestBlockHash, shardBestState.ShardHeight, shardID); err != nil {
+			return NewBlockChainError(ImportStateSnapshotError, err)
+		}
+		if err := db.StoreShardBestState(shardBestState, shardID); err != nil {
+			return NewBlockChainError(ImportStateSnapshotError, err)
+		}
+	}
+	if err := db.StoreBeaconBlock(&beaconBestState.BestBlock, beaconBlockHash); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	if err := db.StoreBeaconBlockIndex(beaconBlockHash, beaconBestState.BeaconHeight); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	// beacon best state is stored last, it marks database as initialized
+	if err := db.StoreBeaconBestState(beaconBestState); err != nil {
+		return NewBlockChainError(ImportStateSnapshotError, err)
+	}
+	Logger.log.Infof("Import snapshot at checkpoint height %+v with hash %+v", beaconBestState.BeaconHeight, checkpoint)
+	return nil
+}
@@ -0,0 +1,341 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/mocks"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+func newTestSnapshot() *StateSnapshot {
+	beacon := NewBeaconBestState()
+	beacon.BeaconHeight = common.EPOCH
+	beacon.ActiveShards = 1
+	shard := NewShardBestState()
+	shard.ShardHeight = 10
+	return &StateSnapshot{
+		Beacon: beacon,
+		Shard:  map[byte]*ShardBestState{0: shard},
+		Data: []database.BatchData{
+			{Key: []byte("serinalnumbers-1"), Value: []byte{1}},
+			{Key: []byte("commitments-1"), Value: []byte{2}},
+		},
+	}
+}
+
+func TestStateSnapshotHash(t *testing.T) {
+	snapshot := newTestSnapshot()
+	hash1, err := snapshot.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// hash is kept after a json round trip
+	snapshotBytes, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &StateSnapshot{}
+	if err := json.Unmarshal(snapshotBytes, decoded); err != nil {
+		t.Fatal(err)
+	}
+	hash2, err := decoded.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hash1.IsEqual(&hash2) {
+		t.Errorf("expect hash %+v after json round trip but get %+v", hash1, hash2)
+	}
+	// checkpoint hash commits to shard states and state data too
+	decoded.Data[1].Value = []byte{3}
+	hash3, err := decoded.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash1.IsEqual(&hash3) {
+		t.Error("expect checkpoint hash changes when state data changes")
+	}
+	decoded.Data[1].Value = []byte{2}
+	decoded.Shard[0].ShardHeight = 11
+	hash4, err := decoded.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash1.IsEqual(&hash4) {
+		t.Error("expect checkpoint hash changes when shard states change")
+	}
+	decoded.Shard[0].ShardHeight = 10
+	decoded.Beacon.BeaconHeight++
+	hash5, err := decoded.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash1.IsEqual(&hash5) {
+		t.Error("expect checkpoint hash changes when beacon best state changes")
+	}
+	// key/value boundary is part of hash
+	decoded.Beacon.BeaconHeight--
+	decoded.Data[1].Key = []byte("commitments-")
+	decoded.Data[1].Value = []byte{'1', 2}
+	hash6, err := decoded.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash1.IsEqual(&hash6) {
+		t.Error("expect checkpoint hash changes when key/value boundary moves")
+	}
+}
+
+func TestImportStateSnapshotWrongCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	snapshotBytes, err := json.Marshal(newTestSnapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "checkpoint.json")
+	if err := ioutil.WriteFile(file, snapshotBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	db := &mocks.DatabaseInterface{}
+	db.On("FetchBeaconBestState").Return(nil, errors.New("not found"))
+	err = ImportStateSnapshot(db, file, common.HashH([]byte("wrong checkpoint")))
+	if err == nil {
+		t.Error("expect error when checkpoint hash doesn't match")
+	}
+	db.AssertNotCalled(t, "ImportStateSnapshot")
+}
+
+func TestImportStateSnapshotInitializedDatabase(t *testing.T) {
+	db := &mocks.DatabaseInterface{}
+	db.On("FetchBeaconBestState").Return([]byte{}, nil)
+	err := ImportStateSnapshot(db, "checkpoint.json", common.Hash{})
+	if err == nil {
+		t.Error("expect error when database is already initialized")
+	}
+}
+
+// newTestSnapshotChain returns a chain at the last beacon block of first epoch, beacon committee is the node key only
+func newTestSnapshotChain(t *testing.T, dir string) (*BlockChain, *incognitokey.KeySet) {
+	db, err := database.Open("leveldb", filepath.Join(dir, "export"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySet := new(incognitokey.KeySet).GenerateKey([]byte{1})
+	shardBlock := &ShardBlock{}
+	shardBlock.Header.Height = 1
+	shardBlock.Header.Version = SHARD_BLOCK_VERSION
+	shardBlock.Header.Round = 1
+	shardBlock.Header.Epoch = 1
+	shardBlock.Header.BeaconHeight = 1
+	shardBlock.Header.TotalTxsFee = make(map[common.Hash]uint64)
+	shard := NewShardBestState()
+	shard.ShardHeight = shardBlock.Header.Height
+	shard.BestBlock = shardBlock
+	shard.BestBlockHash = *shardBlock.Hash()
+	beacon := NewBeaconBestState()
+	beacon.ActiveShards = 1
+	beacon.BeaconHeight = common.EPOCH
+	beacon.BestBlock.Header.Height = common.EPOCH
+	beacon.BestBlockHash = *beacon.BestBlock.Hash()
+	beacon.BeaconCommittee = []string{keySet.GetPublicKeyInBase58CheckEncode()}
+	beacon.BestShardHeight = map[byte]uint64{}
+	beacon.BestShardHash = map[byte]common.Hash{}
+	beacon.BestShardHeight[0] = shard.ShardHeight
+	beacon.BestShardHash[0] = shard.BestBlockHash
+	for _, item := range newTestSnapshot().Data {
+		if err := db.Put(item.Key, item.Value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &BlockChain{
+		BestState: &BestState{Beacon: beacon, Shard: map[byte]*ShardBestState{0: shard}},
+		config:    Config{DataBase: db, UserKeySet: keySet, SnapshotDir: filepath.Join(dir, "snapshots")},
+	}, keySet
+}
+
+// newTestNextBeaconBlock returns first beacon block of next epoch signed by the only committee member
+func newTestNextBeaconBlock(t *testing.T, beacon *BeaconBestState, keySet *incognitokey.KeySet) *BeaconBlock {
+	block := &BeaconBlock{}
+	block.Header.Height = beacon.BeaconHeight + 1
+	block.Header.PreviousBlockHash = beacon.BestBlockHash
+	scheme := new(privacy.MultiSigScheme)
+	scheme.Init()
+	scheme.GetKeyset().Set(&keySet.PrivateKey, &keySet.PaymentAddress.Pk)
+	rPoint, r := scheme.GenerateRandom()
+	pubKey := new(privacy.PublicKey)
+	*pubKey = keySet.PaymentAddress.Pk
+	sig, err := scheme.GetKeyset().SignMultiSig(block.Hash().GetBytes(), []*privacy.PublicKey{pubKey}, []*privacy.EllipticPoint{rPoint}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggSig, err := scheme.CombineMultiSig([]*privacy.SchnMultiSig{sig}).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.AggregatedSig = encode58(aggSig)
+	block.R = encode58(rPoint.Compress())
+	block.ValidatorsIndex = [][]int{{0}, {0}}
+	return block
+}
+
+func TestStateSnapshotExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	chain, keySet := newTestSnapshotChain(t, dir)
+	defer chain.config.DataBase.Close()
+	if err := chain.processExportCheckpoint(&chain.BestState.Beacon.BestBlock); err != nil {
+		t.Fatal(err)
+	}
+	// state written after checkpoint block is not part of snapshot
+	if err := chain.config.DataBase.Put([]byte("commitments-2"), []byte{4}); err != nil {
+		t.Fatal(err)
+	}
+	nextBlock := newTestNextBeaconBlock(t, chain.BestState.Beacon, keySet)
+	if err := chain.processExportCheckpoint(nextBlock); err != nil {
+		t.Fatal(err)
+	}
+	// snapshot is written in background
+	file := snapshotFile(chain.config.SnapshotDir, common.EPOCH)
+	for i := 0; i < 100; i++ {
+		if _, err = os.Stat(file); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotBytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &StateSnapshot{}
+	if err := json.Unmarshal(snapshotBytes, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := snapshot.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// state data out of checkpoint is rejected before anything is written
+	tampered := &StateSnapshot{}
+	if err := json.Unmarshal(snapshotBytes, tampered); err != nil {
+		t.Fatal(err)
+	}
+	tampered.Data = append(tampered.Data, database.BatchData{Key: []byte("commitments-3"), Value: []byte{5}})
+	tamperedBytes, err := json.Marshal(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tamperedFile := snapshotFile(dir, common.EPOCH)
+	if err := ioutil.WriteFile(tamperedFile, tamperedBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	nextBlockBytes, err := json.Marshal(nextBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(nextBlockFile(tamperedFile), nextBlockBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	mockDB := &mocks.DatabaseInterface{}
+	mockDB.On("FetchBeaconBestState").Return(nil, errors.New("not found"))
+	if err := ImportStateSnapshot(mockDB, tamperedFile, checkpoint); err == nil {
+		t.Error("expect error when state data is not covered by checkpoint")
+	}
+	mockDB.AssertNotCalled(t, "ImportStateSnapshot")
+
+	db, err := database.Open("leveldb", filepath.Join(dir, "import"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := ImportStateSnapshot(db, file, checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	beaconBytes, err := db.FetchBeaconBestState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	beacon := &BeaconBestState{}
+	if err := json.Unmarshal(beaconBytes, beacon); err != nil {
+		t.Fatal(err)
+	}
+	if !beacon.BestBlockHash.IsEqual(&chain.BestState.Beacon.BestBlockHash) {
+		t.Errorf("expect beacon best block %+v but get %+v", chain.BestState.Beacon.BestBlockHash, beacon.BestBlockHash)
+	}
+	if _, err := db.FetchShardBestState(0); err != nil {
+		t.Error(err)
+	}
+	for _, item := range newTestSnapshot().Data {
+		value, err := db.Get(item.Key)
+		if err != nil || !bytes.Equal(value, item.Value) {
+			t.Errorf("expect value %+v of key %s but get %+v, %+v", item.Value, item.Key, value, err)
+		}
+	}
+	if has, _ := db.HasValue([]byte("commitments-2")); has {
+		t.Error("expect state written after checkpoint is not imported")
+	}
+	// importing twice is rejected
+	if err := ImportStateSnapshot(db, file, checkpoint); err == nil {
+		t.Error("expect error when database is already initialized")
+	}
+}
+
+func TestImportStateSnapshotStaleShardState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	chain, keySet := newTestSnapshotChain(t, dir)
+	defer chain.config.DataBase.Close()
+	// beacon confirmed a shard block the exporter doesn't have
+	chain.BestState.Beacon.BestShardHeight[0]++
+	snapshot, err := chain.ExportStateSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotBytes, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := snapshotFile(dir, common.EPOCH)
+	if err := ioutil.WriteFile(file, snapshotBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	blockBytes, err := json.Marshal(newTestNextBeaconBlock(t, chain.BestState.Beacon, keySet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(nextBlockFile(file), blockBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := snapshot.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := &mocks.DatabaseInterface{}
+	db.On("FetchBeaconBestState").Return(nil, errors.New("not found"))
+	if err := ImportStateSnapshot(db, file, checkpoint); err == nil {
+		t.Error("expect error when shard state is behind beacon")
+	}
+	db.AssertNotCalled(t, "ImportStateSnapshot")
+}
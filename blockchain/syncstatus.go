@@ -0,0 +1,168 @@
+package blockchain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// syncSpeedSmoothing is weight of the latest sample in moving average of sync speed
+	syncSpeedSmoothing = 0.3
+	// SyncedHeightTolerance is how many blocks a chain can be behind peers and still be synced,
+	// a new block reaches peers at different times
+	SyncedHeightTolerance = 1
+	// BeaconChainID is chain id of beacon in sync status, shards use their shard id
+	BeaconChainID = -1
+)
+
+// syncProgress tracks local height of a chain at each state update of synker to estimate speed of sync
+type syncProgress struct {
+	Height          uint64
+	Time            time.Time
+	BlocksPerSecond float64
+}
+
+// update takes a sample of height, speed is a moving average so that one slow or fast update doesn't swing it
+func (progress *syncProgress) update(height uint64, now time.Time) {
+	if !progress.Time.IsZero() && now.After(progress.Time) {
+		if height < progress.Height {
+			// chain is reverted, speed of previous samples is meaningless
+			progress.BlocksPerSecond = 0
+		} else {
+			speed := float64(height-progress.Height) / now.Sub(progress.Time).Seconds()
+			progress.BlocksPerSecond = syncSpeedSmoothing*speed + (1-syncSpeedSmoothing)*progress.BlocksPerSecond
+		}
+	}
+	progress.Height = height
+	progress.Time = now
+}
+
+// ChainSyncStatus is sync progress of beacon or a shard
+type ChainSyncStatus struct {
+	ChainID int
+	// Height is height of local best block
+	Height uint64
+	// PeerHeight is the highest height reported by peers at last state update, 0 if no peer reported
+	PeerHeight      uint64
+	BlocksPerSecond float64
+	// ETA is estimated seconds to reach PeerHeight, 0 if chain is synced and -1 if it can't be estimated
+	ETA int64
+	// IsLatest is the flag of synker telling consensus that chain is caught up
+	IsLatest bool
+	// IsSynced is set if some peer reported its height and chain is at most SyncedHeightTolerance blocks behind
+	IsSynced bool
+}
+
+// SyncStatus is sync progress of beacon and of shards being synced by node, sorted by shard id
+type SyncStatus struct {
+	Beacon ChainSyncStatus
+	Shards []ChainSyncStatus
+}
+
+// IsSynced returns true if beacon and all shards being synced are synced
+func (status SyncStatus) IsSynced() bool {
+	if !status.Beacon.IsSynced {
+		return false
+	}
+	for _, shard := range status.Shards {
+		if !shard.IsSynced {
+			return false
+		}
+	}
+	return true
+}
+
+func newChainSyncStatus(chainID int, height uint64, peerHeight uint64, progress *syncProgress, isLatest bool) ChainSyncStatus {
+	status := ChainSyncStatus{
+		ChainID:    chainID,
+		Height:     height,
+		PeerHeight: peerHeight,
+		ETA:        -1,
+		IsLatest:   isLatest,
+		IsSynced:   peerHeight > 0 && height+SyncedHeightTolerance >= peerHeight,
+	}
+	if progress != nil {
+		status.BlocksPerSecond = progress.BlocksPerSecond
+	}
+	if status.IsSynced || height >= peerHeight {
+		status.ETA = 0
+	} else if peerHeight > 0 && status.BlocksPerSecond > 0 {
+		status.ETA = int64(math.Ceil(float64(peerHeight-height) / status.BlocksPerSecond))
+	}
+	return status
+}
+
+// updateSyncProgress records heights reported by peers and samples local heights, it is called by UpdateState with States locked
+func (synker *synker) updateSyncProgress(reportedState *reportedChainState, hasPeers bool, beaconHeight uint64, shardsHeight map[byte]uint64) {
+	if hasPeers {
+		synker.States.BestPeersState.Beacon = reportedState.BestBeaconState.Height
+		for shardID := range synker.States.BestPeersState.Shards {
+			delete(synker.States.BestPeersState.Shards, shardID)
+		}
+		for shardID, state := range reportedState.BestShardsState {
+			synker.States.BestPeersState.Shards[shardID] = state.Height
+		}
+	}
+	now := time.Now()
+	synker.States.SyncProgress.Beacon.update(beaconHeight, now)
+	for shardID, height := range shardsHeight {
+		progress, ok := synker.States.SyncProgress.Shards[shardID]
+		if !ok {
+			progress = &syncProgress{}
+			synker.States.SyncProgress.Shards[shardID] = progress
+		}
+		progress.update(height, now)
+	}
+}
+
+// GetSyncStatus returns sync progress of beacon and of shards being synced, heights of peers are those of last state update
+func (blockchain *BlockChain) GetSyncStatus() SyncStatus {
+	synker := &blockchain.Synker
+	shardIDs := synker.GetCurrentSyncShards()
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+
+	beaconHeight := uint64(0)
+	shardsHeight := make(map[byte]uint64)
+	if blockchain.BestState != nil {
+		if blockchain.BestState.Beacon != nil {
+			beaconHeight = blockchain.BestState.Beacon.BeaconHeight
+		}
+		for _, shardID := range shardIDs {
+			if shardBestState, ok := blockchain.BestState.Shard[shardID]; ok {
+				shardsHeight[shardID] = shardBestState.ShardHeight
+			}
+		}
+	}
+
+	synker.States.Lock()
+	beaconProgress := synker.States.SyncProgress.Beacon
+	result := SyncStatus{
+		Beacon: newChainSyncStatus(BeaconChainID, beaconHeight, synker.States.BestPeersState.Beacon, &beaconProgress, synker.IsLatest(false, 0)),
+		Shards: make([]ChainSyncStatus, 0, len(shardIDs)),
+	}
+	for _, shardID := range shardIDs {
+		var shardProgress *syncProgress
+		if progress, ok := synker.States.SyncProgress.Shards[shardID]; ok {
+			progressClone := *progress
+			shardProgress = &progressClone
+		}
+		result.Shards = append(result.Shards, newChainSyncStatus(int(shardID), shardsHeight[shardID], synker.States.BestPeersState.Shards[shardID], shardProgress, synker.IsLatest(true, shardID)))
+	}
+	synker.States.Unlock()
+	return result
+}
+
+// bestPeerShardHeights raises states in best to the higher states of shards reported by a peer
+func bestPeerShardHeights(peersState map[byte]*ChainState, best map[byte]ChainState) {
+	for shardID, state := range peersState {
+		if state == nil {
+			continue
+		}
+		if state.Height > best[shardID].Height {
+			best[shardID] = *state
+		}
+	}
+}
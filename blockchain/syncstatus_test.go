@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSyncProgressUpdate(t *testing.T) {
+	progress := &syncProgress{}
+	now := time.Now()
+	progress.update(100, now)
+	if progress.BlocksPerSecond != 0 {
+		t.Fatalf("speed of first sample is %f", progress.BlocksPerSecond)
+	}
+	progress.update(110, now.Add(10*time.Second))
+	if progress.BlocksPerSecond != syncSpeedSmoothing {
+		t.Fatalf("expect speed %f but get %f", syncSpeedSmoothing, progress.BlocksPerSecond)
+	}
+	progress.update(110, now.Add(20*time.Second))
+	if math.Abs(progress.BlocksPerSecond-(1-syncSpeedSmoothing)*syncSpeedSmoothing) > 1e-9 {
+		t.Fatalf("expect speed %f but get %f", (1-syncSpeedSmoothing)*syncSpeedSmoothing, progress.BlocksPerSecond)
+	}
+	// revert resets speed
+	progress.update(105, now.Add(30*time.Second))
+	if progress.BlocksPerSecond != 0 || progress.Height != 105 {
+		t.Fatalf("unexpected progress after revert %+v", progress)
+	}
+}
+
+func TestNewChainSyncStatus(t *testing.T) {
+	cases := []struct {
+		height     uint64
+		peerHeight uint64
+		speed      float64
+		eta        int64
+		isSynced   bool
+	}{
+		// no peer reported its height
+		{10, 0, 1, 0, false},
+		{10, 10, 0, 0, true},
+		{10, 11, 0, 0, true},
+		{20, 11, 0, 0, true},
+		{10, 20, 0, -1, false},
+		{10, 20, 3, 4, false},
+	}
+	for _, testCase := range cases {
+		status := newChainSyncStatus(0, testCase.height, testCase.peerHeight, &syncProgress{BlocksPerSecond: testCase.speed}, false)
+		if status.ETA != testCase.eta || status.IsSynced != testCase.isSynced {
+			t.Errorf("height %d, peer height %d: unexpected status %+v", testCase.height, testCase.peerHeight, status)
+		}
+	}
+}
+
+func TestBlockChainGetSyncStatus(t *testing.T) {
+	beacon := NewBeaconBestState()
+	beacon.BeaconHeight = 100
+	shard := NewShardBestState()
+	shard.ShardHeight = 50
+	bc := &BlockChain{BestState: &BestState{Beacon: beacon, Shard: map[byte]*ShardBestState{0: shard}}}
+	// synker is not started
+	status := bc.GetSyncStatus()
+	if status.Beacon.Height != 100 || status.Beacon.ChainID != BeaconChainID || len(status.Shards) != 0 || status.IsSynced() {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	bc.Synker.Status.Shards = map[byte]struct{}{0: {}}
+	bc.Synker.States.BestPeersState.Shards = make(map[byte]uint64)
+	bc.Synker.States.SyncProgress.Shards = make(map[byte]*syncProgress)
+	reportedState := &reportedChainState{BestShardsState: make(map[byte]ChainState)}
+	reportedState.BestBeaconState.Height = 101
+	bestPeerShardHeights(map[byte]*ChainState{0: {Height: 60}, 1: {Height: 5}}, reportedState.BestShardsState)
+	bestPeerShardHeights(map[byte]*ChainState{0: {Height: 70}}, reportedState.BestShardsState)
+	bc.Synker.updateSyncProgress(reportedState, true, 100, map[byte]uint64{0: 50})
+	status = bc.GetSyncStatus()
+	if !status.Beacon.IsSynced || status.Beacon.PeerHeight != 101 {
+		t.Fatalf("unexpected beacon status %+v", status.Beacon)
+	}
+	if len(status.Shards) != 1 || status.Shards[0].PeerHeight != 70 || status.Shards[0].IsSynced || status.IsSynced() {
+		t.Fatalf("unexpected shards status %+v", status.Shards)
+	}
+}
@@ -36,6 +36,9 @@ type reportedChainState struct {
 	ClosestShardsState map[byte]ChainState
 	ShardToBeaconBlks  map[byte]map[libp2p.ID][]uint64
 	CrossShardBlks     map[byte]map[libp2p.ID][]uint64
+	// BestBeaconState and BestShardsState are the highest states reported by peers, which are targets of sync
+	BestBeaconState ChainState
+	BestShardsState map[byte]ChainState
 }
 
 type synker struct {
@@ -51,8 +54,11 @@ type synker struct {
 		}
 	}
 	States struct {
-		PeersState   map[libp2p.ID]*peerState
-		ClosestState struct {
+		PeersState map[libp2p.ID]*peerState
+		// PeersPruneBlocks holds retention window of pruning peers (advertised in version msg),
+		// these peers can't serve blocks out of their window
+		PeersPruneBlocks map[libp2p.ID]uint64
+		ClosestState     struct {
 			ClosestBeaconState uint64
 			ClosestShardsState map[byte]uint64
 			ShardToBeaconPool  map[byte]uint64
@@ -65,6 +71,15 @@ type synker struct {
 			ShardsPool        map[byte][]uint64
 			sync.Mutex
 		}
+		// BestPeersState holds heights of BestBeaconState and BestShardsState of last state update having reports of peers
+		BestPeersState struct {
+			Beacon uint64
+			Shards map[byte]uint64
+		}
+		SyncProgress struct {
+			Beacon syncProgress
+			Shards map[byte]*syncProgress
+		}
 		sync.Mutex
 	}
 	Event struct {
@@ -84,6 +99,7 @@ func newSyncker(cQuit chan struct{}, blockchain *BlockChain, pubSubManager *pubs
 		cQuit:         cQuit,
 		pubSubManager: pubSubManager,
 	}
+	s.States.PeersPruneBlocks = make(map[libp2p.ID]uint64)
 	_, s.Event.requestSyncShardBlockByHashEvent, _ = pubSubManager.RegisterNewSubscriber(pubsub.RequestShardBlockByHashTopic)
 	_, s.Event.requestSyncShardBlockByHeightEvent, _ = pubSubManager.RegisterNewSubscriber(pubsub.RequestShardBlockByHeightTopic)
 	_, s.Event.requestSyncBeaconBlockByHashEvent, _ = pubSubManager.RegisterNewSubscriber(pubsub.RequestBeaconBlockByHashTopic)
@@ -105,6 +121,8 @@ func (synker *synker) Start() {
 	synker.States.PoolsState.ShardToBeaconPool = make(map[byte][]uint64)
 	synker.States.PoolsState.CrossShardPool = make(map[byte][]uint64)
 	synker.States.PoolsState.ShardsPool = make(map[byte][]uint64)
+	synker.States.BestPeersState.Shards = make(map[byte]uint64)
+	synker.States.SyncProgress.Shards = make(map[byte]*syncProgress)
 	synker.Status.Lock()
 	synker.startSyncRelayShards()
 	synker.Status.Unlock()
@@ -291,6 +309,7 @@ func (synker *synker) UpdateState() {
 		ClosestShardsState: make(map[byte]ChainState),
 		ShardToBeaconBlks:  make(map[byte]map[libp2p.ID][]uint64),
 		CrossShardBlks:     make(map[byte]map[libp2p.ID][]uint64),
+		BestShardsState:    make(map[byte]ChainState),
 	}
 
 	bestShardsHeight := beaconStateClone.GetBestShardHeight()
@@ -317,6 +336,11 @@ func (synker *synker) UpdateState() {
 	}
 
 	for peerID, peerState := range synker.States.PeersState {
+		if peerState.Beacon != nil && peerState.Beacon.Height > RCS.BestBeaconState.Height {
+			RCS.BestBeaconState = *peerState.Beacon
+		}
+		bestPeerShardHeights(peerState.Shard, RCS.BestShardsState)
+
 		for shardID := range synker.Status.Shards {
 			if shardState, ok := peerState.Shard[shardID]; ok {
 				if shardState.Height >= GetBeaconBestState().GetBestHeightOfShard(shardID) && shardState.Height > GetBestStateShard(shardID).ShardHeight {
@@ -416,6 +440,11 @@ func (synker *synker) UpdateState() {
 	for shardID, state := range RCS.ClosestShardsState {
 		synker.States.ClosestState.ClosestShardsState[shardID] = state.Height
 	}
+	shardsHeight := make(map[byte]uint64)
+	for shardID := range shardsStateClone {
+		shardsHeight[shardID] = shardsStateClone[shardID].ShardHeight
+	}
+	synker.updateSyncProgress(&RCS, len(synker.States.PeersState) > 0, beaconStateClone.BeaconHeight, shardsHeight)
 
 	if len(synker.States.PeersState) > 0 {
 		if userRole != common.SHARD_ROLE && RCS.ClosestBeaconState.Height == beaconStateClone.BeaconHeight {
@@ -449,7 +478,7 @@ func (synker *synker) UpdateState() {
 					if GetBeaconBestState().GetBestHeightOfShard(shardID) < RCS.ClosestShardsState[shardID].Height {
 						currentShardReqHeight := GetBeaconBestState().GetBestHeightOfShard(shardID) + 1
 						for peerID, peerState := range synker.States.PeersState {
-							if _, ok := peerState.Shard[shardID]; ok {
+							if shardState, ok := peerState.Shard[shardID]; ok && synker.canServeBlocks(peerID, shardState.Height, currentShardReqHeight) {
 								if currentShardReqHeight+DefaultMaxBlkReqPerPeer-1 >= RCS.ClosestShardsState[shardID].Height {
 									synker.SyncBlkShardToBeacon(shardID, false, false, false, nil, nil, currentShardReqHeight, RCS.ClosestShardsState[shardID].Height, peerID)
 								} else {
@@ -479,7 +508,10 @@ func (synker *synker) UpdateState() {
 	if RCS.ClosestBeaconState.Height-beaconStateClone.BeaconHeight > DefaultMaxBlkReqPerTime {
 		RCS.ClosestBeaconState.Height = beaconStateClone.BeaconHeight + DefaultMaxBlkReqPerTime
 	}
-	for peerID := range synker.States.PeersState {
+	for peerID, peerState := range synker.States.PeersState {
+		if !synker.canServeBlocks(peerID, peerState.Beacon.Height, currentBcnReqHeight) {
+			continue
+		}
 		if currentBcnReqHeight+DefaultMaxBlkReqPerPeer-1 >= RCS.ClosestBeaconState.Height {
 			//fmt.Println("SyncBlk1:", currentBcnReqHeight, RCS.ClosestBeaconState.Height)
 			synker.SyncBlkBeacon(false, false, false, nil, nil, currentBcnReqHeight, RCS.ClosestBeaconState.Height, peerID)
@@ -503,7 +535,7 @@ func (synker *synker) UpdateState() {
 		for peerID := range synker.States.PeersState {
 			if shardState, ok := synker.States.PeersState[peerID].Shard[shardID]; ok {
 				fmt.Println("SyncShard state from other shard", shardID, shardState.Height)
-				if shardState.Height >= currentShardReqHeight {
+				if shardState.Height >= currentShardReqHeight && synker.canServeBlocks(peerID, shardState.Height, currentShardReqHeight) {
 					if currentShardReqHeight+DefaultMaxBlkReqPerPeer-1 >= RCS.ClosestShardsState[shardID].Height {
 						fmt.Println("SyncShard 1234 ", currentShardReqHeight, RCS.ClosestShardsState[shardID].Height)
 						synker.SyncBlkShard(shardID, false, false, false, nil, nil, currentShardReqHeight, RCS.ClosestShardsState[shardID].Height, peerID)
@@ -529,6 +561,15 @@ func (synker *synker) UpdateState() {
 	synker.States.Unlock()
 }
 
+// canServeBlocks returns false if peer is a pruning node and block at height is out of its retention window
+func (synker *synker) canServeBlocks(peerID libp2p.ID, peerHeight uint64, height uint64) bool {
+	pruneBlocks, ok := synker.States.PeersPruneBlocks[peerID]
+	if !ok {
+		return true
+	}
+	return height+pruneBlocks > peerHeight
+}
+
 //SyncBlkBeacon Send a req to sync beacon block
 /*
 	- by Hash + blksHash: get by hash
@@ -0,0 +1,269 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// buildPrivacyTokenActions builds at shard an action for each tx init privacy custom token,
+// beacon confirms them so that registry records and supply of tokens are agreed by all nodes
+func buildPrivacyTokenActions(transactions []metadata.Transaction) ([][]string, error) {
+	actions := [][]string{}
+	for _, tx := range transactions {
+		tokenTx, ok := tx.(*transaction.TxCustomTokenPrivacy)
+		if !ok || tokenTx.TxTokenPrivacyData.Type != transaction.CustomTokenInit {
+			continue
+		}
+		tokenData := tokenTx.TxTokenPrivacyData
+		info := lvdb.PrivacyTokenInfo{
+			TokenID:      &tokenData.PropertyID,
+			Name:         tokenData.PropertyName,
+			Symbol:       tokenData.PropertySymbol,
+			Decimals:     tokenData.Decimals,
+			Description:  tokenData.Description,
+			IssuerKey:    tokenData.IssuerKey,
+			SupplyPolicy: tokenData.SupplyPolicy,
+			Mintable:     tokenData.Mintable,
+			InitAmount:   tokenData.Amount,
+			InitTxHash:   tokenTx.Hash(),
+		}
+		infoBytes, err := json.Marshal(info)
+		if err != nil {
+			return nil, NewBlockChainError(MashallJsonError, err)
+		}
+		actions = append(actions, []string{strconv.Itoa(metadata.PrivacyTokenInitMeta), base64.StdEncoding.EncodeToString(infoBytes)})
+	}
+	return actions, nil
+}
+
+// TokenBurnReqAction is content of the action of a tx burning privacy custom token
+type TokenBurnReqAction struct {
+	Meta          metadata.TokenBurnRequest `json:"meta"`
+	RequestedTxID *common.Hash              `json:"RequestedTxID"`
+}
+
+// buildPrivacyTokenInstructions builds at beacon instructions confirming privacy token actions of a shard block
+// ["{PrivacyTokenInitMeta}" "{shardID}" "{base64 of registry record}"]
+// ["{TokenBurnRequestMeta}" "{shardID}" "{base64 of TokenBurnReqAction}"]
+func buildPrivacyTokenInstructions(shardID byte, shardBlockInstructions [][]string) [][]string {
+	instructions := [][]string{}
+	for _, inst := range shardBlockInstructions {
+		if len(inst) != 2 {
+			continue
+		}
+		var err error
+		switch inst[0] {
+		case strconv.Itoa(metadata.PrivacyTokenInitMeta):
+			_, err = decodePrivacyTokenInitInst([]string{inst[0], strconv.Itoa(int(shardID)), inst[1]})
+		case strconv.Itoa(metadata.TokenBurnRequestMeta):
+			_, err = decodeTokenBurnInst([]string{inst[0], strconv.Itoa(int(shardID)), inst[1]})
+		default:
+			continue
+		}
+		if err != nil {
+			Logger.log.Errorf("Invalid privacy token action %s of shard %d: %+v", inst, shardID, err)
+			continue
+		}
+		instructions = append(instructions, []string{inst[0], strconv.Itoa(int(shardID)), inst[1]})
+	}
+	return instructions
+}
+
+// processPrivacyTokenInstructions stores registry records and supply of privacy custom tokens confirmed by beacon block.
+// Bridge tokens are inited again for each issuance, only their first init creates the record
+func (blockchain *BlockChain) processPrivacyTokenInstructions(block *BeaconBlock) error {
+	db := blockchain.config.DataBase
+	for _, inst := range block.Body.Instructions {
+		if len(inst) != 3 {
+			continue
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.PrivacyTokenInitMeta):
+			info, err := decodePrivacyTokenInitInst(inst)
+			if err != nil {
+				return err
+			}
+			storedInfo, err := blockchain.GetPrivacyTokenInfo(*info.TokenID)
+			if err != nil {
+				return err
+			}
+			if storedInfo == nil {
+				infoBytes, err := json.Marshal(info)
+				if err != nil {
+					return NewBlockChainError(MashallJsonError, err)
+				}
+				err = db.StorePrivacyTokenInfo(*info.TokenID, infoBytes)
+				if err != nil {
+					return err
+				}
+			}
+			err = db.UpdatePrivacyTokenSupply(*info.TokenID, info.InitAmount, "+")
+			if err != nil {
+				return err
+			}
+		case strconv.Itoa(metadata.TokenBurnRequestMeta):
+			burnReqAction, err := decodeTokenBurnInst(inst)
+			if err != nil {
+				return err
+			}
+			burnReq := burnReqAction.Meta
+			// shards reject burns over supply, but burns of a beacon block may exceed it together,
+			// the block is still valid so such a burn is skipped (and remembered for revert) instead of failing the beacon chain
+			supply, err := db.GetPrivacyTokenSupply(burnReq.TokenID)
+			if err != nil {
+				return err
+			}
+			if burnReq.Amount > supply {
+				Logger.log.Warnf("Skip burning %d of token %s with supply %d by tx %s", burnReq.Amount, burnReq.TokenID.String(), supply, burnReqAction.RequestedTxID.String())
+				err = db.Put(skippedTokenBurnKey(burnReqAction.RequestedTxID), []byte{1})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			err = db.UpdatePrivacyTokenSupply(burnReq.TokenID, burnReq.Amount, "-")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// revertPrivacyTokenInstructions reverts processPrivacyTokenInstructions of a reverted beacon block
+func (blockchain *BlockChain) revertPrivacyTokenInstructions(block *BeaconBlock) error {
+	db := blockchain.config.DataBase
+	for i := len(block.Body.Instructions) - 1; i >= 0; i-- {
+		inst := block.Body.Instructions[i]
+		if len(inst) != 3 {
+			continue
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.PrivacyTokenInitMeta):
+			info, err := decodePrivacyTokenInitInst(inst)
+			if err != nil {
+				return err
+			}
+			storedInfo, err := blockchain.GetPrivacyTokenInfo(*info.TokenID)
+			if err != nil {
+				return err
+			}
+			if storedInfo != nil && storedInfo.InitTxHash != nil && storedInfo.InitTxHash.IsEqual(info.InitTxHash) {
+				err = db.DeletePrivacyTokenInfo(*info.TokenID)
+				if err != nil {
+					return err
+				}
+			}
+			err = db.UpdatePrivacyTokenSupply(*info.TokenID, info.InitAmount, "-")
+			if err != nil {
+				return err
+			}
+		case strconv.Itoa(metadata.TokenBurnRequestMeta):
+			burnReqAction, err := decodeTokenBurnInst(inst)
+			if err != nil {
+				return err
+			}
+			key := skippedTokenBurnKey(burnReqAction.RequestedTxID)
+			skipped, err := db.HasValue(key)
+			if err != nil {
+				return err
+			}
+			if skipped {
+				err = db.Delete(key)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			err = db.UpdatePrivacyTokenSupply(burnReqAction.Meta.TokenID, burnReqAction.Meta.Amount, "+")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodePrivacyTokenInitInst decodes registry record of a token from instruction built by buildPrivacyTokenInstructions
+func decodePrivacyTokenInitInst(inst []string) (*lvdb.PrivacyTokenInfo, error) {
+	shardID, err := strconv.Atoi(inst[1])
+	if err != nil {
+		return nil, NewBlockChainError(ProcessPrivacyTokenInstructionError, err)
+	}
+	info := new(lvdb.PrivacyTokenInfo)
+	err = decodeContent(inst[2], info)
+	if err != nil {
+		return nil, NewBlockChainError(ProcessPrivacyTokenInstructionError, err)
+	}
+	if info.TokenID == nil || info.InitTxHash == nil {
+		return nil, NewBlockChainError(ProcessPrivacyTokenInstructionError, errors.New("token id and init tx hash of token must be set"))
+	}
+	info.ShardID = byte(shardID)
+	return info, nil
+}
+
+// decodeTokenBurnInst decodes burn request of a token from instruction built by buildPrivacyTokenInstructions
+func decodeTokenBurnInst(inst []string) (*TokenBurnReqAction, error) {
+	var burnReqAction TokenBurnReqAction
+	err := decodeContent(inst[2], &burnReqAction)
+	if err != nil {
+		return nil, NewBlockChainError(ProcessPrivacyTokenInstructionError, err)
+	}
+	if burnReqAction.Meta.Type != metadata.TokenBurnRequestMeta || burnReqAction.RequestedTxID == nil {
+		return nil, NewBlockChainError(ProcessPrivacyTokenInstructionError, errors.New("invalid token burn request"))
+	}
+	return &burnReqAction, nil
+}
+
+// skippedTokenBurnKey is key of a burn skipped by processPrivacyTokenInstructions
+func skippedTokenBurnKey(requestedTxID *common.Hash) []byte {
+	return append(append([]byte{}, lvdb.SkippedTokenBurnPrefix...), requestedTxID[:]...)
+}
+
+// GetPrivacyTokenInfo returns registry record of privacy custom token,
+// or nil if the token was inited before registry
+func (blockchain *BlockChain) GetPrivacyTokenInfo(tokenID common.Hash) (*lvdb.PrivacyTokenInfo, error) {
+	infoBytes, err := blockchain.config.DataBase.GetPrivacyTokenInfo(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if len(infoBytes) == 0 {
+		return nil, nil
+	}
+	info := new(lvdb.PrivacyTokenInfo)
+	err = json.Unmarshal(infoBytes, info)
+	if err != nil {
+		return nil, NewBlockChainError(UnExpectedError, err)
+	}
+	return info, nil
+}
+
+// ListPrivacyTokenInfo returns registry records of all privacy custom tokens
+func (blockchain *BlockChain) ListPrivacyTokenInfo() (map[common.Hash]*lvdb.PrivacyTokenInfo, error) {
+	data, err := blockchain.config.DataBase.ListPrivacyTokenInfo()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[common.Hash]*lvdb.PrivacyTokenInfo)
+	for _, infoBytes := range data {
+		info := new(lvdb.PrivacyTokenInfo)
+		err = json.Unmarshal(infoBytes, info)
+		if err != nil {
+			return nil, NewBlockChainError(UnExpectedError, err)
+		}
+		result[*info.TokenID] = info
+	}
+	return result, nil
+}
+
+// GetPrivacyTokenSupply returns circulating supply of privacy custom token, issued minus burned units in all shards
+func (blockchain *BlockChain) GetPrivacyTokenSupply(tokenID common.Hash) (uint64, error) {
+	return blockchain.config.DataBase.GetPrivacyTokenSupply(tokenID)
+}
@@ -0,0 +1,165 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTokenInitTx(tokenID common.Hash, amount uint64, name string) *transaction.TxCustomTokenPrivacy {
+	tx := &transaction.TxCustomTokenPrivacy{}
+	tx.Tx.Type = common.TxCustomTokenPrivacyType
+	tx.TxTokenPrivacyData.Type = transaction.CustomTokenInit
+	tx.TxTokenPrivacyData.PropertyID = tokenID
+	tx.TxTokenPrivacyData.PropertyName = name
+	tx.TxTokenPrivacyData.PropertySymbol = "TKN"
+	tx.TxTokenPrivacyData.Amount = amount
+	return tx
+}
+
+func TestPrivacyTokenInstructions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokenregistry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	blockchain := &BlockChain{config: Config{DataBase: db}}
+
+	tokenID := common.HashH([]byte("token"))
+	txs := []metadata.Transaction{
+		newTestTokenInitTx(tokenID, 100, "first"),
+		&transaction.Tx{},
+		newTestTokenInitTx(tokenID, 50, "second"),
+	}
+	actions, err := buildPrivacyTokenActions(txs)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(actions))
+
+	// actions of shard block are confirmed by beacon, invalid ones are dropped
+	shardInsts := append(actions, []string{strconv.Itoa(metadata.PrivacyTokenInitMeta), "invalid"})
+	beaconBlock := &BeaconBlock{}
+	beaconBlock.Body.Instructions = buildPrivacyTokenInstructions(3, shardInsts)
+	assert.Equal(t, 2, len(beaconBlock.Body.Instructions))
+
+	assert.Nil(t, blockchain.processPrivacyTokenInstructions(beaconBlock))
+	info, err := blockchain.GetPrivacyTokenInfo(tokenID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "first", info.Name)
+		assert.Equal(t, byte(3), info.ShardID)
+		assert.Equal(t, uint64(100), info.InitAmount)
+	}
+	supply, err := blockchain.GetPrivacyTokenSupply(tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(150), supply)
+
+	assert.Nil(t, blockchain.revertPrivacyTokenInstructions(beaconBlock))
+	info, err = blockchain.GetPrivacyTokenInfo(tokenID)
+	assert.Nil(t, err)
+	assert.Nil(t, info)
+	supply, err = blockchain.GetPrivacyTokenSupply(tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), supply)
+}
+
+func TestPrivacyTokenBurnInstructions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokenregistry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	blockchain := &BlockChain{config: Config{DataBase: db}}
+
+	tokenID := common.HashH([]byte("token"))
+	shardInsts, err := buildPrivacyTokenActions([]metadata.Transaction{newTestTokenInitTx(tokenID, 100, "token")})
+	assert.Nil(t, err)
+	burnReq, err := metadata.NewTokenBurnRequest(tokenID, 30, metadata.TokenBurnRequestMeta)
+	assert.Nil(t, err)
+	burnActions, err := burnReq.BuildReqActions(&transaction.Tx{}, nil, 0)
+	assert.Nil(t, err)
+	shardInsts = append(shardInsts, burnActions...)
+
+	beaconBlock := &BeaconBlock{}
+	beaconBlock.Body.Instructions = buildPrivacyTokenInstructions(0, shardInsts)
+	assert.Equal(t, 2, len(beaconBlock.Body.Instructions))
+	assert.Nil(t, blockchain.processPrivacyTokenInstructions(beaconBlock))
+	supply, err := blockchain.GetPrivacyTokenSupply(tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(70), supply)
+
+	// burning more than supply is skipped, the beacon block is still processed
+	burnReq.Amount = 1000
+	burnActions, err = burnReq.BuildReqActions(&transaction.Tx{Fee: 1}, nil, 0)
+	assert.Nil(t, err)
+	overBurnBlock := &BeaconBlock{}
+	overBurnBlock.Body.Instructions = buildPrivacyTokenInstructions(0, burnActions)
+	assert.Nil(t, blockchain.processPrivacyTokenInstructions(overBurnBlock))
+	supply, err = blockchain.GetPrivacyTokenSupply(tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(70), supply)
+	// reverting the skipped burn adds nothing back
+	assert.Nil(t, blockchain.revertPrivacyTokenInstructions(overBurnBlock))
+	supply, err = blockchain.GetPrivacyTokenSupply(tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(70), supply)
+
+	assert.Nil(t, blockchain.revertPrivacyTokenInstructions(beaconBlock))
+	supply, err = blockchain.GetPrivacyTokenSupply(tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), supply)
+}
+
+func TestValidateTokenBurnRequest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokenregistry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	blockchain := &BlockChain{config: Config{DataBase: db}}
+
+	tokenID := common.HashH([]byte("token"))
+	assert.Nil(t, db.StorePrivacyCustomToken(tokenID, tokenID[:]))
+	burnReq, err := metadata.NewTokenBurnRequest(tokenID, 30, metadata.TokenBurnRequestMeta)
+	assert.Nil(t, err)
+	// token inited before registry has no tracked supply
+	ok, err := burnReq.ValidateTxWithBlockChain(nil, nil, 0, db)
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+
+	shardInsts, err := buildPrivacyTokenActions([]metadata.Transaction{newTestTokenInitTx(tokenID, 100, "token")})
+	assert.Nil(t, err)
+	beaconBlock := &BeaconBlock{}
+	beaconBlock.Body.Instructions = buildPrivacyTokenInstructions(0, shardInsts)
+	assert.Nil(t, blockchain.processPrivacyTokenInstructions(beaconBlock))
+	ok, err = burnReq.ValidateTxWithBlockChain(nil, nil, 0, db)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	burnReq.Amount = 101
+	ok, err = burnReq.ValidateTxWithBlockChain(nil, nil, 0, db)
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/blockchain/bridgeproof"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+// verifyBridgeProof validates offline a proof returned by getburnproof, getbeaconswapproof or getbridgeswapproof RPC
+// against the committees known by the Ethereum contract, proofFile holds either the whole RPC response or its Result only
+func verifyBridgeProof(proofFile string, beaconCommitteeStr string, bridgeCommitteeStr string) error {
+	data, err := ioutil.ReadFile(proofFile)
+	if err != nil {
+		return err
+	}
+	response := struct {
+		Result json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &response); err == nil && len(response.Result) > 0 {
+		data = response.Result
+	}
+	result := jsonresult.GetInstructionProof{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return err
+	}
+
+	beaconCommittee, err := bridgeproof.DecodeCommittee(strings.Split(beaconCommitteeStr, ","))
+	if err != nil {
+		return err
+	}
+	bridgeCommittee, err := bridgeproof.DecodeCommittee(strings.Split(bridgeCommitteeStr, ","))
+	if err != nil {
+		return err
+	}
+	return newBridgeProof(result).Verify(beaconCommittee, bridgeCommittee)
+}
+
+func newBridgeProof(result jsonresult.GetInstructionProof) *bridgeproof.Proof {
+	return &bridgeproof.Proof{
+		Instruction:  result.Instruction,
+		BeaconHeight: result.BeaconHeight,
+		BridgeHeight: result.BridgeHeight,
+		Beacon: &bridgeproof.InstProof{
+			InstPath:       result.BeaconInstPath,
+			InstPathIsLeft: result.BeaconInstPathIsLeft,
+			InstRoot:       result.BeaconInstRoot,
+			BlkData:        result.BeaconBlkData,
+			BlkHash:        result.BeaconBlkHash,
+			SignerSig:      result.BeaconSignerSig,
+			Pubkeys:        result.BeaconPubkeys,
+			RIdxs:          result.BeaconRIdxs,
+			SigIdxs:        result.BeaconSigIdxs,
+			R:              result.BeaconR,
+		},
+		Bridge: &bridgeproof.InstProof{
+			InstPath:       result.BridgeInstPath,
+			InstPathIsLeft: result.BridgeInstPathIsLeft,
+			InstRoot:       result.BridgeInstRoot,
+			BlkData:        result.BridgeBlkData,
+			BlkHash:        result.BridgeBlkHash,
+			SignerSig:      result.BridgeSignerSig,
+			Pubkeys:        result.BridgePubkeys,
+			RIdxs:          result.BridgeRIdxs,
+			SigIdxs:        result.BridgeSigIdxs,
+			R:              result.BridgeR,
+		},
+	}
+}
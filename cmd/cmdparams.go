@@ -48,6 +48,15 @@ type params struct {
 	// pToken
 	PNetwork string `long:"pNetwork" description:"Bridge network"`
 	PToken   string `long:"pToken" description:"Bridge token"`
+
+	// offline signing
+	UnsignedTx string `long:"unsignedtx" description:"Unsigned tx template created by createunsignedtransaction RPC"`
+	PrivateKey string `long:"privatekey" description:"Private key of sender"`
+
+	// bridge proof
+	Proof           string `long:"proof" description:"File of proof returned by getburnproof, getbeaconswapproof or getbridgeswapproof RPC"`
+	BeaconCommittee string `long:"beaconcommittee" description:"Comma separated pubkeys of beacon committee known by Ethereum contract"`
+	BridgeCommittee string `long:"bridgecommittee" description:"Comma separated pubkeys of bridge committee known by Ethereum contract"`
 }
 
 // newConfigParser returns a new command line flags parser.
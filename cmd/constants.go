@@ -8,6 +8,8 @@ const (
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	signTransactionCmd     = "signtransaction"
+	verifyBridgeProofCmd   = "verifybridgeproof"
 )
 
-var CmdList = []string{createWalletCmd, listWalletAccountCmd, getWalletAccountCmd, createWalletAccountCmd, getPrivacyTokenID, backupChain, restoreChain}
+var CmdList = []string{createWalletCmd, listWalletAccountCmd, getWalletAccountCmd, createWalletAccountCmd, getPrivacyTokenID, backupChain, restoreChain, signTransactionCmd, verifyBridgeProofCmd}
@@ -186,6 +186,37 @@ func main() {
 					}
 				}
 			}
+		case signTransactionCmd:
+			{
+				if cfg.UnsignedTx == "" || cfg.PrivateKey == "" {
+					log.Println("Wrong param")
+					return
+				}
+				tx, err := signTransaction(cfg.UnsignedTx, cfg.PrivateKey)
+				if err != nil {
+					log.Println(err)
+					return
+				}
+				result, err := parseToJsonString(tx)
+				if err != nil {
+					log.Println(err)
+					return
+				}
+				log.Println(string(result))
+			}
+		case verifyBridgeProofCmd:
+			{
+				if cfg.Proof == "" || cfg.BeaconCommittee == "" || cfg.BridgeCommittee == "" {
+					log.Println("Wrong param")
+					return
+				}
+				err := verifyBridgeProof(cfg.Proof, cfg.BeaconCommittee, cfg.BridgeCommittee)
+				if err != nil {
+					log.Println("Proof is invalid:", err)
+					return
+				}
+				log.Println("Proof is valid")
+			}
 		case restoreChain:
 			{
 				if cfg.FileName == "" {
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+type signedTxOutput struct {
+	PaymentAddress string
+	Amount         uint64
+}
+
+type signedTx struct {
+	TxID            string
+	Base58CheckData string // sent to a node by sendtransaction RPC
+	Fee             uint64
+	Outputs         []signedTxOutput // last output is change of sender, if any
+}
+
+// signTransaction creates proof and signature of an unsigned tx template (from createunsignedtransaction RPC)
+// with private key of sender, it does not connect to any node so it can run on an offline device
+func signTransaction(unsignedTxStr string, privateKeyStr string) (*signedTx, error) {
+	unsignedTxBytes, _, err := base58.Base58Check{}.Decode(unsignedTxStr)
+	if err != nil {
+		return nil, err
+	}
+	unsignedTx := new(transaction.UnsignedTx)
+	err = json.Unmarshal(unsignedTxBytes, unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := wallet.Base58CheckDeserialize(privateKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(key.KeySet.PrivateKey) == 0 {
+		return nil, errors.New("key is not a private key")
+	}
+
+	tx := new(transaction.Tx)
+	err = tx.InitFromUnsignedTx(unsignedTx, &key.KeySet.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &signedTx{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(txBytes, 0x00),
+		Fee:             unsignedTx.Fee,
+	}
+	for _, paymentInfo := range unsignedTx.PaymentInfos {
+		receiver := wallet.KeyWallet{}
+		receiver.KeySet.PaymentAddress = paymentInfo.PaymentAddress
+		result.Outputs = append(result.Outputs, signedTxOutput{
+			PaymentAddress: receiver.Base58CheckSerialize(wallet.PaymentAddressType),
+			Amount:         paymentInfo.Amount,
+		})
+	}
+	return result, nil
+}
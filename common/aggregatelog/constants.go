@@ -1,7 +1,5 @@
 package aggregatelog
 
-import "os"
-
 const (
 	SENTRY_LOG_SERVICENAME  = "sentry"
 	ELASTIC_LOG_SERVICENAME = "elastic"
@@ -12,6 +10,3 @@ const (
 	DEBUG_LEVEL   = "debug"
 	FATAL_LEVEL   = "fatal"
 )
-
-var SENTRY_DSN = os.Getenv("SENTRY_DSN")
-var ELASTIC_URL = os.Getenv("ELASTIC_URL")
@@ -18,35 +18,41 @@ var LevelMap = map[string]string{
 var ElasticLogService *aggregatelog.LogService
 var SentryLogService *aggregatelog.LogService
 
-func AggregationLogInit() {
+// AggregationLogInit initializes aggregation log services, a service is only
+// initialized when its endpoint is set
+func AggregationLogInit(elasticURL string, sentryDSN string) {
 	// INIT ELASTIC LOG SERVICE
-	logService, err := aggregatelog.GetService(aggregatelog.ELASTIC_LOG_SERVICENAME)
-	if err != nil {
-		log.Println("GEt elastic service error:", err)
-	} else {
-		logParams := map[string]interface{}{
-			"elastic_url": aggregatelog.ELASTIC_URL,
-		}
-		err := logService.InitService(logParams)
+	if elasticURL != "" {
+		logService, err := aggregatelog.GetService(aggregatelog.ELASTIC_LOG_SERVICENAME)
 		if err != nil {
-			log.Println("aggregation log service init err", err)
+			log.Println("Get elastic service error:", err)
 		} else {
-			ElasticLogService = logService
+			logParams := map[string]interface{}{
+				"elastic_url": elasticURL,
+			}
+			err := logService.InitService(logParams)
+			if err != nil {
+				log.Println("aggregation log service init err", err)
+			} else {
+				ElasticLogService = logService
+			}
 		}
 	}
 	// INIT SENTRY LOG SERVICE
-	logService, err = aggregatelog.GetService(aggregatelog.SENTRY_LOG_SERVICENAME)
-	if err != nil {
-		log.Println("GEt elastic service error:", err)
-	} else {
-		logParams := map[string]interface{}{
-			"DSN": aggregatelog.SENTRY_DSN,
-		}
-		err := logService.InitService(logParams)
+	if sentryDSN != "" {
+		logService, err := aggregatelog.GetService(aggregatelog.SENTRY_LOG_SERVICENAME)
 		if err != nil {
-			log.Println("aggregation log service init err", err)
+			log.Println("Get sentry service error:", err)
 		} else {
-			SentryLogService = logService
+			logParams := map[string]interface{}{
+				"DSN": sentryDSN,
+			}
+			err := logService.InitService(logParams)
+			if err != nil {
+				log.Println("aggregation log service init err", err)
+			} else {
+				SentryLogService = logService
+			}
 		}
 	}
 }
@@ -56,20 +62,30 @@ func HandleCaptureMessage(message, level string) error {
 	if !ok {
 		return nil
 	}
-	if ElasticLogService == nil || SentryLogService == nil {
-		return nil
+	var err error
+	for _, logService := range []*aggregatelog.LogService{ElasticLogService, SentryLogService} {
+		if logService == nil {
+			continue
+		}
+		if captureErr := captureMessage(logService, message, lvl); captureErr != nil {
+			err = captureErr
+		}
 	}
+	return err
+}
+
+func captureMessage(logService *aggregatelog.LogService, message, lvl string) error {
 	switch lvl {
 	case aggregatelog.ERROR_LEVEL:
-		return ElasticLogService.CaptureError(errors.New(message))
+		return logService.CaptureError(errors.New(message))
 	case aggregatelog.INFO_LEVEL:
-		return ElasticLogService.CaptureMessage(message)
+		return logService.CaptureMessage(message)
 	case aggregatelog.DEBUG_LEVEL:
-		return ElasticLogService.CaptureDebug(message)
+		return logService.CaptureDebug(message)
 	case aggregatelog.WARNING_LEVEL:
-		return ElasticLogService.CaptureWarning(message)
+		return logService.CaptureWarning(message)
 	case aggregatelog.FATAL_LEVEL:
-		return ElasticLogService.CaptureFatal(message)
+		return logService.CaptureFatal(message)
 	}
 	return nil
 }
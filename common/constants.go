@@ -68,6 +68,12 @@ var (
 	PRVCoinID = Hash{4} // To send PRV in custom token
 )
 
+// supply policies of privacy custom token
+const (
+	TokenSupplyFixed          = iota // no more units after init, except bridge tokens
+	TokenSupplyIssuerMintable        // issuer can mint more units
+)
+
 // centralized website's pubkey
 var (
 	CentralizedWebsitePubKey = []byte{2, 194, 130, 176, 102, 36, 183, 114, 109, 135, 49, 114, 177, 92, 214, 31, 25, 4, 72, 103, 196, 161, 36, 69, 121, 102, 159, 24, 31, 131, 101, 20, 0}
@@ -2,10 +2,10 @@ package common
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"os"
 	"runtime"
 	"strings"
@@ -73,8 +73,29 @@ type Logger interface {
 
 	// SetLevel changes the logging level to the passed level.
 	SetLevel(level Level)
+
+	// WithFields returns a logger of the same subsystem which attaches
+	// fields to all of its messages.  It shares logging level with the
+	// original logger.
+	WithFields(fields LogFields) Logger
+}
+
+// LogFields are structured data attached to log messages, empty fields are
+// omitted from the output.
+type LogFields struct {
+	Shard     string `json:"shard,omitempty"`
+	Height    uint64 `json:"height,omitempty"`
+	BlockHash string `json:"blockhash,omitempty"`
+	PeerID    string `json:"peerid,omitempty"`
+	TxHash    string `json:"txhash,omitempty"`
 }
 
+// Log output formats of a Backend.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
 // defaultFlags specifies changes to the default logger behavior.  It is set
 // during package init and configured using the LOGFLAGS environment variable.
 // Zero logger backends can override these default flags using WithFlags.
@@ -167,7 +188,7 @@ func (l Level) String() string {
 
 // NewBackend creates a logger backend from a Writer.
 func NewBackend(w io.Writer, opts ...BackendOption) *Backend {
-	b := &Backend{w: w, flag: defaultFlags}
+	b := &Backend{w: w, flag: defaultFlags, format: LogFormatText}
 	for _, o := range opts {
 		o(b)
 	}
@@ -178,9 +199,10 @@ func NewBackend(w io.Writer, opts ...BackendOption) *Backend {
 // the backend's Writer.  Backend provides atomic writes to the Writer from all
 // subsystems.
 type Backend struct {
-	w    io.Writer
-	mu   sync.Mutex // ensures atomic writes
-	flag uint32
+	w      io.Writer
+	mu     sync.Mutex // ensures atomic writes
+	flag   uint32
+	format string
 }
 
 // BackendOption is a function used to modify the behavior of a Backend.
@@ -195,6 +217,26 @@ func WithFlags(flags uint32) BackendOption {
 	}
 }
 
+// WithFormat configures a Backend to write messages in the specified format,
+// either LogFormatText (default) or LogFormatJSON.
+func WithFormat(format string) BackendOption {
+	return func(b *Backend) {
+		b.format = format
+	}
+}
+
+// SetFormat changes the output format of the backend.  It must be called
+// before the backend is used by concurrent subsystems.
+func (b *Backend) SetFormat(format string) error {
+	if format != LogFormatText && format != LogFormatJSON {
+		return fmt.Errorf("unsupported log format %s", format)
+	}
+	b.mu.Lock()
+	b.format = format
+	b.mu.Unlock()
+	return nil
+}
+
 // bufferPool defines a concurrent safe free list of byte slices used to provide
 // temporary buffers for formatting log messages prior to outputting them.
 var bufferPool = sync.Pool{
@@ -275,6 +317,49 @@ func formatHeader(buf *[]byte, t time.Time, lvl, tag string, file string, line i
 	*buf = append(*buf, ": "...)
 }
 
+// appendFields appends non-empty fields in the format 'key=value ' to buf.
+func appendFields(buf *[]byte, fields *LogFields) {
+	if fields == nil {
+		return
+	}
+	if fields.Shard != "" {
+		*buf = append(*buf, "shard="...)
+		*buf = append(*buf, fields.Shard...)
+		*buf = append(*buf, ' ')
+	}
+	if fields.Height != 0 {
+		*buf = append(*buf, "height="...)
+		itoa(buf, int(fields.Height), -1)
+		*buf = append(*buf, ' ')
+	}
+	if fields.BlockHash != "" {
+		*buf = append(*buf, "blockhash="...)
+		*buf = append(*buf, fields.BlockHash...)
+		*buf = append(*buf, ' ')
+	}
+	if fields.PeerID != "" {
+		*buf = append(*buf, "peerid="...)
+		*buf = append(*buf, fields.PeerID...)
+		*buf = append(*buf, ' ')
+	}
+	if fields.TxHash != "" {
+		*buf = append(*buf, "txhash="...)
+		*buf = append(*buf, fields.TxHash...)
+		*buf = append(*buf, ' ')
+	}
+}
+
+// jsonRecord is a log message in json format.
+type jsonRecord struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Subsystem string `json:"subsystem"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Message   string `json:"message"`
+	*LogFields
+}
+
 // calldepth is the call depth of the callsite function relative to the
 // caller of the subsystem logger.  It is used to recover the filename and line
 // number of the logging call if either the short or long file flags are
@@ -305,61 +390,69 @@ func callsite(flag uint32) (string, int) {
 // creating a prefix for the given level and tag according to the formatHeader
 // function and formatting the provided arguments using the default formatting
 // rules.
-func (b *Backend) print(lvl, tag string, args ...interface{}) {
+func (b *Backend) print(lvl, tag string, fields *LogFields, args ...interface{}) {
 	t := time.Now() // get as early as possible
 
-	bytebuf := buffer()
-
 	var file string
 	var line int
 	if b.flag&(Lshortfile|Llongfile) != 0 {
 		file, line = callsite(b.flag)
 	}
-
-	formatHeader(bytebuf, t, lvl, tag, file, line)
-	buf := bytes.NewBuffer(*bytebuf)
-	fmt.Fprintln(buf, args...)
-	*bytebuf = buf.Bytes()
-
-	b.colorPrint(lvl, *bytebuf)
-	// @hunghd SEND LOG TO AGGREGATION LOG SERVER
-	if isAggregationLogMode() {
-		loggerSeparator := "[" + lvl + "]"
-		mes := bytes.Split(*bytebuf, []byte(loggerSeparator))
-		if len(mes) >= 2 {
-			HandleCaptureMessage(string(mes[1]), lvl)
-		}
-	}
-	recycleBuffer(bytebuf)
+	message := fmt.Sprintln(args...)
+	b.write(t, lvl, tag, file, line, fields, message[:len(message)-1])
 }
 
 // printf outputs a log message to the writer associated with the backend after
 // creating a prefix for the given level and tag according to the formatHeader
 // function and formatting the provided arguments according to the given format
 // specifier.
-func (b *Backend) printf(lvl, tag string, format string, args ...interface{}) {
+func (b *Backend) printf(lvl, tag string, fields *LogFields, format string, args ...interface{}) {
 	t := time.Now() // get as early as possible
 
-	bytebuf := buffer()
-
 	var file string
 	var line int
 	if b.flag&(Lshortfile|Llongfile) != 0 {
 		file, line = callsite(b.flag)
 	}
-	formatHeader(bytebuf, t, lvl, tag, file, line)
-	buf := bytes.NewBuffer(*bytebuf)
-	fmt.Fprintf(buf, format, args...)
-	*bytebuf = append(buf.Bytes(), '\n')
+	b.write(t, lvl, tag, file, line, fields, fmt.Sprintf(format, args...))
+}
+
+// write formats a log message according to the backend format and outputs it
+func (b *Backend) write(t time.Time, lvl, tag string, file string, line int, fields *LogFields, message string) {
+	bytebuf := buffer()
 
-	b.colorPrint(lvl, *bytebuf)
+	b.mu.Lock()
+	format := b.format
+	b.mu.Unlock()
+	if format == LogFormatJSON {
+		record := jsonRecord{
+			Time:      t.Format(time.RFC3339Nano),
+			Level:     lvl,
+			Subsystem: tag,
+			File:      file,
+			Line:      line,
+			Message:   message,
+			LogFields: fields,
+		}
+		buf := bytes.NewBuffer(*bytebuf)
+		if err := json.NewEncoder(buf).Encode(record); err != nil {
+			recycleBuffer(bytebuf)
+			return
+		}
+		*bytebuf = buf.Bytes()
+		b.mu.Lock()
+		b.w.Write(*bytebuf)
+		b.mu.Unlock()
+	} else {
+		formatHeader(bytebuf, t, lvl, tag, file, line)
+		appendFields(bytebuf, fields)
+		*bytebuf = append(*bytebuf, message...)
+		*bytebuf = append(*bytebuf, '\n')
+		b.colorPrint(lvl, *bytebuf)
+	}
 	// @hunghd SEND LOG TO AGGREGATION LOG SERVER
 	if isAggregationLogMode() {
-		loggerSeparator := "[" + lvl + "]"
-		mes := bytes.Split(*bytebuf, []byte(loggerSeparator))
-		if len(mes) >= 2 {
-			HandleCaptureMessage(string(mes[1]), lvl)
-		}
+		HandleCaptureMessage(tag+": "+message, lvl)
 	}
 	recycleBuffer(bytebuf)
 }
@@ -397,7 +490,7 @@ func (b *Backend) colorPrint(lvl string, bytebuf []byte) {
 // Backend b.  A tag describes the subsystem and is included in all log
 // messages.  The logger uses the info verbosity level by default.
 func (b *Backend) Logger(subsystemTag string, disable bool) Logger {
-	return &slog{LevelInfo, subsystemTag, b, disable}
+	return &slog{lvl: LevelInfo, tag: subsystemTag, b: b, disable: disable}
 }
 
 // slog is a subsystem logger for a Backend.  Implements the Logger interface.
@@ -406,6 +499,10 @@ type slog struct {
 	tag     string
 	b       *Backend
 	disable bool
+	// parent is the logger this one was derived from by WithFields,
+	// level is read from and written to the parent
+	parent *slog
+	fields *LogFields
 }
 
 // Trace formats message using the default formats for its operands, prepends
@@ -419,7 +516,7 @@ func (l *slog) Trace(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelTrace {
 		if !l.disable {
-			l.b.print("TRC", l.tag, args...)
+			l.b.print("TRC", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -435,7 +532,7 @@ func (l *slog) Tracef(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelTrace {
 		if !l.disable {
-			l.b.printf("TRC", l.tag, format, args...)
+			l.b.printf("TRC", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -451,7 +548,7 @@ func (l *slog) Debug(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelDebug {
 		if !l.disable {
-			l.b.print("DBG", l.tag, args...)
+			l.b.print("DBG", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -467,7 +564,7 @@ func (l *slog) Debugf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelDebug {
 		if !l.disable {
-			l.b.printf("DBG", l.tag, format, args...)
+			l.b.printf("DBG", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -483,7 +580,7 @@ func (l *slog) Info(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelInfo {
 		if !l.disable {
-			l.b.print("INF", l.tag, args...)
+			l.b.print("INF", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -499,7 +596,7 @@ func (l *slog) Infof(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelInfo {
 		if !l.disable {
-			l.b.printf("INF", l.tag, format, args...)
+			l.b.printf("INF", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -515,7 +612,7 @@ func (l *slog) Warn(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelWarn {
 		if !l.disable {
-			l.b.print("WRN", l.tag, args...)
+			l.b.print("WRN", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -531,7 +628,7 @@ func (l *slog) Warnf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelWarn {
 		if !l.disable {
-			l.b.printf("WRN", l.tag, format, args...)
+			l.b.printf("WRN", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -547,7 +644,7 @@ func (l *slog) Error(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelError {
 		if !l.disable {
-			l.b.print("ERR", l.tag, args...)
+			l.b.print("ERR", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -563,7 +660,7 @@ func (l *slog) Errorf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelError {
 		if !l.disable {
-			l.b.printf("ERR", l.tag, format, args...)
+			l.b.printf("ERR", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -579,7 +676,7 @@ func (l *slog) Critical(args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelCritical {
 		if !l.disable {
-			l.b.print("CRT", l.tag, args...)
+			l.b.print("CRT", l.tag, l.fields, args...)
 		}
 	}
 }
@@ -595,7 +692,7 @@ func (l *slog) Criticalf(format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= LevelCritical {
 		if !l.disable {
-			l.b.printf("CRT", l.tag, format, args...)
+			l.b.printf("CRT", l.tag, l.fields, format, args...)
 		}
 	}
 }
@@ -604,6 +701,9 @@ func (l *slog) Criticalf(format string, args ...interface{}) {
 //
 // This is part of the Logger interface implementation.
 func (l *slog) Level() Level {
+	if l.parent != nil {
+		return l.parent.Level()
+	}
 	return Level(atomic.LoadUint32((*uint32)(&l.lvl)))
 }
 
@@ -611,23 +711,31 @@ func (l *slog) Level() Level {
 //
 // This is part of the Logger interface implementation.
 func (l *slog) SetLevel(level Level) {
+	if l.parent != nil {
+		l.parent.SetLevel(level)
+		return
+	}
 	atomic.StoreUint32((*uint32)(&l.lvl), uint32(level))
 }
 
+// WithFields returns a logger which attaches fields to all of its messages.
+//
+// This is part of the Logger interface implementation.
+func (l *slog) WithFields(fields LogFields) Logger {
+	parent := l
+	if l.parent != nil {
+		parent = l.parent
+	}
+	return &slog{tag: l.tag, b: l.b, disable: l.disable, parent: parent, fields: &fields}
+}
+
 // Disabled is a Logger that will never output anything.
 var Disabled Logger
 
 func init() {
 	Disabled = &slog{lvl: LevelOff, b: NewBackend(ioutil.Discard)}
-
-	// @hunghd SEND LOG TO AGGREGATION LOG SERVER
-	if isAggregationLogMode() {
-		log.Println("init aggreation log mode")
-		AggregationLogInit()
-	}
 }
 
 func isAggregationLogMode() bool {
-	aggreLogMode := os.Getenv("AGGRE_LOG_MODE")
-	return aggreLogMode == "true"
+	return ElasticLogService != nil || SentryLogService != nil
 }
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackendJSONFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	backend := NewBackend(buf, WithFlags(0), WithFormat(LogFormatJSON))
+	logger := backend.Logger("Test log", false)
+	logger.WithFields(LogFields{Shard: "1", Height: 10, TxHash: "abc"}).Infof("hello %s", "world")
+
+	record := make(map[string]interface{})
+	err := json.Unmarshal(buf.Bytes(), &record)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "INF", record["level"])
+	assert.Equal(t, "Test log", record["subsystem"])
+	assert.Equal(t, "hello world", record["message"])
+	assert.Equal(t, "1", record["shard"])
+	assert.Equal(t, float64(10), record["height"])
+	assert.Equal(t, "abc", record["txhash"])
+	_, ok := record["blockhash"]
+	assert.Equal(t, false, ok)
+}
+
+func TestBackendTextFormatWithFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	backend := NewBackend(buf, WithFlags(0))
+	logger := backend.Logger("Test log", false)
+	logger.WithFields(LogFields{PeerID: "peer1"}).Info("hello")
+	assert.Equal(t, true, strings.Contains(buf.String(), "Test log: peerid=peer1 hello"))
+}
+
+func TestLoggerWithFieldsSharesLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	backend := NewBackend(buf, WithFlags(0), WithFormat(LogFormatJSON))
+	logger := backend.Logger("Test log", false)
+	fieldsLogger := logger.WithFields(LogFields{Shard: "0"})
+	logger.SetLevel(LevelError)
+	assert.Equal(t, LevelError, fieldsLogger.Level())
+	fieldsLogger.Info("filtered")
+	assert.Equal(t, 0, buf.Len())
+	fieldsLogger.SetLevel(LevelDebug)
+	assert.Equal(t, LevelDebug, logger.Level())
+}
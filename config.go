@@ -15,7 +15,9 @@ import (
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/jessevdk/go-flags"
@@ -28,6 +30,7 @@ const (
 	DefaultDatabaseDirname        = "block"
 	DefaultDatabaseMempoolDirname = "mempool"
 	DefaultLogLevel               = "info"
+	DefaultLogFormat              = common.LogFormatText
 	DefaultLogDirname             = "logs"
 	DefaultLogFilename            = "log.log"
 	DefaultMaxPeers               = 125
@@ -53,6 +56,11 @@ const (
 	DefaultPersistMempool = false
 	DefaultBtcClient      = 0
 	DefaultBtcClientPort  = "8332"
+	// For consensus
+	DefaultConsensus    = consensus.PBFT
+	DefaultSealInterval = uint(10) // in second
+	// For tx verification cache
+	DefaultTxVerificationCacheSize = 50000
 )
 
 var (
@@ -78,6 +86,9 @@ type config struct {
 	DatabaseMempoolDir string `short:"m" long:"datamempool" description:"Mempool Database Dir"`
 	LogDir             string `short:"l" long:"logdir" description:"Directory to log output."`
 	LogLevel           string `long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	LogFormat          string `long:"logformat" description:"Log output format {text, json}"`
+	ElasticURL         string `long:"elasticurl" description:"URL of elastic search server to send aggregation log to"`
+	SentryDSN          string `long:"sentrydsn" description:"DSN of sentry project to send aggregation log to"`
 
 	AddPeers             []string `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
 	ConnectPeers         []string `short:"c" long:"connect" description:"Connect only to the specified peers at startup"`
@@ -96,20 +107,22 @@ type config struct {
 
 	ExternalAddress string `long:"externaladdress" description:"External address"`
 
-	RPCDisableAuth  bool     `long:"norpcauth" description:"Disable RPC authorization by username/password"`
-	RPCUser         string   `short:"u" long:"rpcuser" description:"Username for RPC connections"`
-	RPCPass         string   `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
-	RPCLimitUser    string   `long:"rpclimituser" description:"Username for limited RPC connections"`
-	RPCLimitPass    string   `long:"rpclimitpass" default-mask:"-" description:"Password for limited RPC connections"`
-	RPCListeners    []string `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 9334, testnet: 9334)"`
-	RPCWSListeners  []string `long:"rpcwslisten" description:"Add an interface/port to listen for RPC Websocket connections (default port: 19334, testnet: 19334)"`
-	RPCCert         string   `long:"rpccert" description:"File containing the certificate file"`
-	RPCKey          string   `long:"rpckey" description:"File containing the certificate key"`
-	RPCMaxClients   int      `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
-	RPCMaxWSClients int      `long:"rpcmaxwsclients" description:"Max number of RPC clients for standard connections"`
-	RPCQuirks       bool     `long:"rpcquirks" description:"Mirror some JSON-RPC quirks of coin Core -- NOTE: Discouraged unless interoperability issues need to be worked around"`
-	DisableRPC      bool     `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
-	DisableTLS      bool     `long:"notls" description:"Disable TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
+	RPCDisableAuth   bool     `long:"norpcauth" description:"Disable RPC authorization by username/password"`
+	RPCUser          string   `short:"u" long:"rpcuser" description:"Username for RPC connections"`
+	RPCPass          string   `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	RPCLimitUser     string   `long:"rpclimituser" description:"Username for limited RPC connections"`
+	RPCLimitPass     string   `long:"rpclimitpass" default-mask:"-" description:"Password for limited RPC connections"`
+	RPCListeners     []string `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 9334, testnet: 9334)"`
+	RPCWSListeners   []string `long:"rpcwslisten" description:"Add an interface/port to listen for RPC Websocket connections (default port: 19334, testnet: 19334)"`
+	RPCGrpcListeners []string `long:"rpcgrpclisten" description:"Add an interface/port to listen for gRPC connections, gRPC is disabled if none is specified"`
+	RPCCert          string   `long:"rpccert" description:"File containing the certificate file"`
+	RPCKey           string   `long:"rpckey" description:"File containing the certificate key"`
+	RPCMaxClients    int      `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
+	RPCMaxWSClients  int      `long:"rpcmaxwsclients" description:"Max number of RPC clients for standard connections"`
+	RPCAPIKeyFile    string   `long:"rpcapikeyfile" description:"File of RPC api keys with their allowed methods and rate limits, it is reloaded when it changes"`
+	RPCQuirks        bool     `long:"rpcquirks" description:"Mirror some JSON-RPC quirks of coin Core -- NOTE: Discouraged unless interoperability issues need to be worked around"`
+	DisableRPC       bool     `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
+	DisableTLS       bool     `long:"notls" description:"Disable TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
 
 	Proxy     string `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser string `long:"proxyuser" description:"Username for proxy server"`
@@ -131,11 +144,19 @@ type config struct {
 
 	FastStartup bool `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
 
+	SnapshotDir    string `long:"snapshotdir" description:"Directory to export state snapshot at every epoch boundary, a new node can sync from it with --importsnapshot"`
+	ImportSnapshot string `long:"importsnapshot" description:"State snapshot file to bootstrap an empty database from, --checkpoint must be set"`
+	Checkpoint     string `long:"checkpoint" description:"Trusted checkpoint hash of the state snapshot to import"`
+
+	PruneBlocks uint64 `long:"pruneblocks" description:"Prune block bodies and transaction indexes older than this number of latest blocks, 0 keeps every block (archival node)"`
+
 	TxPoolTTL     uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
 	TxPoolMaxTx   uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
 	LimitFee      uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
 	LimitFeeToken uint64 `long:"limitfeetoken" description:"Limited fee for tx(per Kb data), default is 0 token"`
 
+	TxVerificationCacheSize int `long:"txverificationcachesize" description:"Maximum number of txs whose verification result is kept to skip verifying them again in block validation, 0 disables cache"`
+
 	LoadMempool       bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
 	PersistMempool    bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
 	MetricUrl         string `long:"metricurl" description:"Metric URL"`
@@ -147,6 +168,9 @@ type config struct {
 	EnableMining      bool   `long:"mining" description:"enable mining"`
 
 	Accelerator bool `long:"accelerator" description:"Relay Node Configuration For Consensus"`
+
+	Consensus    string `long:"consensus" description:"Consensus engine of this node (pbft/instantseal | default is 'pbft', 'instantseal' produces every block with node key, only for local development, beacon and shard committees must be only the node key)"`
+	SealInterval uint   `long:"sealinterval" description:"Seconds between two blocks produced by 'instantseal' consensus, 0 means blocks are only produced on demand by generateblocks RPC"`
 }
 
 // serviceOptions defines the configuration options for the daemon as a service on
@@ -293,6 +317,7 @@ func loadConfig() (*config, []string, error) {
 	cfg := config{
 		ConfigFile:           defaultConfigFile,
 		LogLevel:             DefaultLogLevel,
+		LogFormat:            DefaultLogFormat,
 		MaxOutPeers:          DefaultMaxPeers,
 		MaxInPeers:           DefaultMaxPeers,
 		MaxPeers:             DefaultMaxPeers,
@@ -329,6 +354,10 @@ func loadConfig() (*config, []string, error) {
 		BtcClient:            DefaultBtcClient,
 		BtcClientPort:        DefaultBtcClientPort,
 		EnableMining:         DefaultEnableMining,
+		Consensus:            DefaultConsensus,
+		SealInterval:         DefaultSealInterval,
+
+		TxVerificationCacheSize: DefaultTxVerificationCacheSize,
 	}
 
 	// Service options which are only added on Windows.
@@ -463,6 +492,19 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Set log output format
+	if err := backendLog.SetFormat(cfg.LogFormat); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err.Error())
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Send logs to aggregation log services
+	if cfg.ElasticURL != "" || cfg.SentryDSN != "" {
+		common.AggregationLogInit(cfg.ElasticURL, cfg.SentryDSN)
+	}
+
 	// --addPeer and --connect do not mix.
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: the --addpeer and --connect options can not be mixed"
@@ -571,6 +613,10 @@ func loadConfig() (*config, []string, error) {
 	// duplicate addresses.
 	cfg.RPCWSListeners = normalizeAddresses(cfg.RPCWSListeners,
 		activeNetParams.wsPort)
+	// Add default port to all gRPC listener addresses if needed and remove
+	// duplicate addresses.
+	cfg.RPCGrpcListeners = normalizeAddresses(cfg.RPCGrpcListeners,
+		activeNetParams.grpcPort)
 
 	// Only allow TLS to be disabled if the RPC is bound to localhost
 	// addresses.
@@ -618,6 +664,56 @@ func loadConfig() (*config, []string, error) {
 				return nil, nil, err
 			}
 		}
+		for _, addr := range cfg.RPCGrpcListeners {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				str := "%s: gRPC listen interface '%s' is " +
+					"invalid: %v"
+				err := fmt.Errorf(str, funcName, addr, err)
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Fprintln(os.Stderr, usageMessage)
+				return nil, nil, err
+			}
+			if _, ok := allowedTLSListeners[host]; !ok {
+				str := "%s: the --notls option may not be used when binding gRPC to non localhost addresses: %s"
+				err := fmt.Errorf(str, funcName, addr)
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Fprintln(os.Stderr, usageMessage)
+				return nil, nil, err
+			}
+		}
+	}
+
+	if cfg.Consensus != consensus.PBFT && cfg.Consensus != consensus.InstantSeal {
+		str := "%s: unknown consensus engine %s"
+		err := fmt.Errorf(str, funcName, cfg.Consensus)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.ImportSnapshot != common.EmptyString && cfg.Checkpoint == common.EmptyString {
+		str := "%s: the --importsnapshot option requires a trusted --checkpoint hash"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.PruneBlocks != 0 && cfg.PruneBlocks < blockchain.MinPruneBlocks {
+		str := "%s: the --pruneblocks option must be 0 or at least %d"
+		err := fmt.Errorf(str, funcName, blockchain.MinPruneBlocks)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.TxVerificationCacheSize < 0 {
+		str := "%s: the --txverificationcachesize option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
 	}
 
 	if cfg.DiscoverPeers {
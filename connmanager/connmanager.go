@@ -64,6 +64,9 @@ type Config struct {
 	//OnOutboundDisconnection is a callback that is fired when an outbound connection is disconnected
 	OnOutboundDisconnection func(peerConn *peer.PeerConn)
 
+	//OnInboundDisconnection is a callback that is fired when an inbound connection is disconnected
+	OnInboundDisconnection func(peerConn *peer.PeerConn)
+
 	DiscoverPeers        bool
 	DiscoverPeersAddress string
 	ConsensusState       *ConsensusState
@@ -297,25 +300,35 @@ func (connManager *ConnManager) listenHandler(listen *peer.Peer) {
 }
 
 func (connManager *ConnManager) handleConnected(peerConn *peer.PeerConn) {
-	Logger.log.Infof("handleConnected %s", peerConn.GetRemotePeerID().Pretty())
+	peerLog := Logger.log.WithFields(common.LogFields{PeerID: peerConn.GetRemotePeerID().Pretty()})
+	peerLog.Infof("handleConnected %s", peerConn.GetRemotePeerID().Pretty())
 	if peerConn.GetIsOutbound() {
-		Logger.log.Infof("handleConnected OUTBOUND %s", peerConn.GetRemotePeerID().Pretty())
+		peerLog.Infof("handleConnected OUTBOUND %s", peerConn.GetRemotePeerID().Pretty())
 
 		if connManager.config.OnOutboundConnection != nil {
 			connManager.config.OnOutboundConnection(peerConn)
 		}
 
 	} else {
-		Logger.log.Infof("handleConnected INBOUND %s", peerConn.GetRemotePeerID().Pretty())
+		peerLog.Infof("handleConnected INBOUND %s", peerConn.GetRemotePeerID().Pretty())
 	}
 }
 
 func (connManager *ConnManager) handleDisconnected(peerConn *peer.PeerConn) {
-	Logger.log.Warnf("handleDisconnected %s", peerConn.GetRemotePeerID().Pretty())
+	Logger.log.WithFields(common.LogFields{PeerID: peerConn.GetRemotePeerID().Pretty()}).Warnf("handleDisconnected %s", peerConn.GetRemotePeerID().Pretty())
+	if peerConn.GetIsOutbound() {
+		if connManager.config.OnOutboundDisconnection != nil {
+			connManager.config.OnOutboundDisconnection(peerConn)
+		}
+	} else {
+		if connManager.config.OnInboundDisconnection != nil {
+			connManager.config.OnInboundDisconnection(peerConn)
+		}
+	}
 }
 
 func (connManager *ConnManager) handleFailed(peerConn *peer.PeerConn) {
-	Logger.log.Warnf("handleFailed %s", peerConn.GetRemotePeerID().Pretty())
+	Logger.log.WithFields(common.LogFields{PeerID: peerConn.GetRemotePeerID().Pretty()}).Warnf("handleFailed %s", peerConn.GetRemotePeerID().Pretty())
 }
 
 // DiscoverPeers - connect to bootnode
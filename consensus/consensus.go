@@ -0,0 +1,29 @@
+package consensus
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+// list of consensus engines which node can run
+const (
+	PBFT        = "pbft"
+	InstantSeal = "instantseal"
+)
+
+// ConsensusEngine is implemented by every engine which produces blocks for node
+type ConsensusEngine interface {
+	Start() error
+	Stop() error
+	// OnBFTMsg receives consensus message from peers
+	OnBFTMsg(wire.Message)
+	// NotifyBeaconRole and NotifyShardRole broadcast current role of node to other components
+	NotifyBeaconRole(beaconRole bool)
+	NotifyShardRole(shardRole int)
+}
+
+// BlockSealer is implemented by engines which can produce blocks on demand (dev consensus)
+// chainID -1 is beacon chain, otherwise it is shardID
+type BlockSealer interface {
+	GenerateBlocks(chainID int, numBlocks int) ([]common.Hash, error)
+}
@@ -0,0 +1,347 @@
+package instantseal
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/mubft"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/wire"
+	libp2p "github.com/libp2p/go-libp2p-peer"
+)
+
+// Engine is a single-node consensus for local development and deterministic tests:
+// it seals beacon and shard blocks with one key, on a timer or on demand,
+// without exchanging any BFT message. Blocks are signed by the node key only,
+// so beacon and every shard committee must be exactly the node key
+type Engine struct {
+	sync.Mutex
+	sealLock sync.Mutex
+	started  bool
+	cQuit    chan struct{}
+	config   EngineConfig
+	userPk   string
+}
+
+type EngineConfig struct {
+	BlockChain        *blockchain.BlockChain
+	BlockGen          *blockchain.BlockGenerator
+	UserKeySet        *incognitokey.KeySet
+	Server            serverInterface
+	ShardToBeaconPool blockchain.ShardToBeaconPool
+	CrossShardPool    map[byte]blockchain.CrossShardPool
+	PubSubManager     *pubsub.PubSubManager
+	// SealInterval is period between two sealing rounds, 0 means blocks are only produced on demand
+	SealInterval time.Duration
+}
+
+type serverInterface interface {
+	PushMessageToAll(wire.Message) error
+	PushMessageToShard(wire.Message, byte, map[libp2p.ID]bool) error
+	PushMessageToBeacon(wire.Message, map[libp2p.ID]bool) error
+	IsEnableMining() bool
+}
+
+var _ consensus.ConsensusEngine = (*Engine)(nil)
+var _ consensus.BlockSealer = (*Engine)(nil)
+
+// NewEngine apply configuration to instant seal engine
+func NewEngine(cfg *EngineConfig) (*Engine, error) {
+	if cfg.SealInterval < 0 {
+		return nil, errors.New("seal interval can't be negative")
+	}
+	return &Engine{
+		config: *cfg,
+	}, nil
+}
+
+func (engine *Engine) Start() error {
+	engine.Lock()
+	defer engine.Unlock()
+	if engine.started {
+		return errors.New("Consensus engine is already started")
+	}
+	if engine.config.UserKeySet == nil {
+		return errors.New("UserKeyset can't be empty")
+	}
+	engine.userPk = engine.config.UserKeySet.GetPublicKeyInBase58CheckEncode()
+	if err := engine.checkCommittees(); err != nil {
+		return err
+	}
+	engine.cQuit = make(chan struct{})
+	//Start block generator
+	go engine.config.BlockGen.Start(engine.cQuit)
+	engine.started = true
+	Logger.log.Info("Start instant seal consensus with key", engine.userPk)
+	if engine.config.SealInterval == 0 {
+		Logger.log.Info("Instant seal consensus produces blocks on demand only")
+		return nil
+	}
+	go func(cQuit chan struct{}) {
+		ticker := time.NewTicker(engine.config.SealInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-cQuit:
+				return
+			case <-ticker.C:
+				if !engine.config.Server.IsEnableMining() {
+					continue
+				}
+				engine.sealAllChains()
+			}
+		}
+	}(engine.cQuit)
+	return nil
+}
+
+func (engine *Engine) Stop() error {
+	engine.Lock()
+	defer engine.Unlock()
+	if !engine.started {
+		return errors.New("Consensus engine is already stopped")
+	}
+	engine.started = false
+	close(engine.cQuit)
+	return nil
+}
+
+// OnBFTMsg drops every consensus message because there is no other validator to talk to
+func (engine *Engine) OnBFTMsg(msg wire.Message) {
+	Logger.log.Debugf("Instant seal ignores BFT message %+v", msg.MessageType())
+}
+
+func (engine *Engine) NotifyBeaconRole(beaconRole bool) {
+	engine.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.BeaconRoleTopic, beaconRole))
+}
+
+func (engine *Engine) NotifyShardRole(shardRole int) {
+	engine.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardRoleTopic, shardRole))
+}
+
+// GenerateBlocks seals numBlocks blocks on chain chainID (-1 for beacon) and returns their hashes
+func (engine *Engine) GenerateBlocks(chainID int, numBlocks int) ([]common.Hash, error) {
+	engine.Lock()
+	started := engine.started
+	engine.Unlock()
+	if !started {
+		return nil, NewInstantSealError(ErrNotStarted, nil)
+	}
+	if chainID < -1 || chainID >= engine.config.BlockChain.BestState.Beacon.ActiveShards {
+		return nil, NewInstantSealError(ErrInvalidChainID, fmt.Errorf("chain id %+v", chainID))
+	}
+	hashes := []common.Hash{}
+	for i := 0; i < numBlocks; i++ {
+		var (
+			blockHash common.Hash
+			err       error
+		)
+		if chainID == -1 {
+			blockHash, err = engine.sealBeaconBlock()
+		} else {
+			blockHash, err = engine.sealShardBlock(byte(chainID))
+		}
+		if err != nil {
+			return hashes, err
+		}
+		hashes = append(hashes, blockHash)
+	}
+	return hashes, nil
+}
+
+// sealAllChains produces one block on every chain whose committee contains user key,
+// shard blocks go first so that beacon block can include their shard to beacon blocks
+func (engine *Engine) sealAllChains() {
+	for shardID := 0; shardID < engine.config.BlockChain.BestState.Beacon.ActiveShards; shardID++ {
+		shardBestState, ok := engine.config.BlockChain.BestState.Shard[byte(shardID)]
+		if !ok || common.IndexOfStr(engine.userPk, shardBestState.ShardCommittee) < 0 {
+			continue
+		}
+		go engine.NotifyShardRole(shardID)
+		if _, err := engine.sealShardBlock(byte(shardID)); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+	if common.IndexOfStr(engine.userPk, engine.config.BlockChain.BestState.Beacon.BeaconCommittee) >= 0 {
+		go engine.NotifyBeaconRole(true)
+		if _, err := engine.sealBeaconBlock(); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+}
+
+func (engine *Engine) sealBeaconBlock() (common.Hash, error) {
+	engine.sealLock.Lock()
+	defer engine.sealLock.Unlock()
+	bc := engine.config.BlockChain
+	committee := make([]string, len(bc.BestState.Beacon.BeaconCommittee))
+	copy(committee, bc.BestState.Beacon.BeaconCommittee)
+	shardsToBeaconLimit := make(map[byte]uint64)
+	if engine.config.ShardToBeaconPool != nil {
+		shardsToBeaconLimit = engine.config.ShardToBeaconPool.GetLatestValidPendingBlockHeight()
+	}
+	bc.ConsensusOngoing = true
+	defer func() { bc.ConsensusOngoing = false }()
+	newBlock, err := engine.config.BlockGen.NewBlockBeacon(&engine.config.UserKeySet.PaymentAddress, 1, shardsToBeaconLimit)
+	if err != nil {
+		return common.Hash{}, NewInstantSealError(ErrCreateBlock, err)
+	}
+	if err := engine.config.BlockGen.FinalizeBeaconBlock(newBlock, engine.config.UserKeySet); err != nil {
+		return common.Hash{}, NewInstantSealError(ErrCreateBlock, err)
+	}
+	aggSig, R, validatorsIdx, err := engine.signBlock(newBlock.Header.Hash(), committee)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	newBlock.AggregatedSig = aggSig
+	newBlock.R = R
+	newBlock.ValidatorsIndex = validatorsIdx
+	if err := bc.InsertBeaconBlock(newBlock, true); err != nil {
+		return common.Hash{}, NewInstantSealError(ErrInsertBlock, err)
+	}
+	Logger.log.Infof("Sealed beacon block height %+v hash %+v", newBlock.Header.Height, newBlock.Header.Hash())
+	newBeaconBlockMsg, err := mubft.MakeMsgBeaconBlock(newBlock)
+	if err != nil {
+		Logger.log.Error("Make new beacon block message error", err)
+	} else {
+		go engine.config.Server.PushMessageToAll(newBeaconBlockMsg)
+	}
+	return newBlock.Header.Hash(), nil
+}
+
+func (engine *Engine) sealShardBlock(shardID byte) (common.Hash, error) {
+	engine.sealLock.Lock()
+	defer engine.sealLock.Unlock()
+	bc := engine.config.BlockChain
+	shardBestState, ok := bc.BestState.Shard[shardID]
+	if !ok {
+		return common.Hash{}, NewInstantSealError(ErrInvalidChainID, fmt.Errorf("shard %+v not found", shardID))
+	}
+	committee := make([]string, len(shardBestState.ShardCommittee))
+	copy(committee, shardBestState.ShardCommittee)
+	crossShards := make(map[byte]uint64)
+	if crossShardPool, ok := engine.config.CrossShardPool[shardID]; ok && crossShardPool != nil {
+		crossShards = crossShardPool.GetLatestValidBlockHeight()
+	}
+	bc.ConsensusOngoing = true
+	defer func() { bc.ConsensusOngoing = false }()
+	start := time.Now()
+	newBlock, err := engine.config.BlockGen.NewBlockShard(engine.config.UserKeySet, shardID, 1, crossShards, bc.BestState.Beacon.BeaconHeight, start)
+	if err != nil {
+		return common.Hash{}, NewInstantSealError(ErrCreateBlock, err)
+	}
+	if err := engine.config.BlockGen.FinalizeShardBlock(newBlock, engine.config.UserKeySet); err != nil {
+		return common.Hash{}, NewInstantSealError(ErrCreateBlock, err)
+	}
+	aggSig, R, validatorsIdx, err := engine.signBlock(newBlock.Header.Hash(), committee)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	newBlock.AggregatedSig = aggSig
+	newBlock.R = R
+	newBlock.ValidatorsIndex = validatorsIdx
+	if err := bc.InsertShardBlock(newBlock, true); err != nil {
+		return common.Hash{}, NewInstantSealError(ErrInsertBlock, err)
+	}
+	Logger.log.Infof("Sealed shard %+v block height %+v hash %+v", shardID, newBlock.Header.Height, newBlock.Header.Hash())
+	engine.relayShardBlock(newBlock)
+	return newBlock.Header.Hash(), nil
+}
+
+// relayShardBlock feeds local pools with shard to beacon and cross shard blocks
+// (there is no peer to send them back to us) then pushes them to network
+func (engine *Engine) relayShardBlock(shardBlock *blockchain.ShardBlock) {
+	newShardToBeaconBlock := shardBlock.CreateShardToBeaconBlock(engine.config.BlockChain)
+	if newShardToBeaconBlock != nil {
+		if engine.config.ShardToBeaconPool != nil {
+			if _, _, err := engine.config.ShardToBeaconPool.AddShardToBeaconBlock(newShardToBeaconBlock); err != nil {
+				Logger.log.Error(err)
+			}
+		}
+		newShardToBeaconMsg, err := mubft.MakeMsgShardToBeaconBlock(newShardToBeaconBlock)
+		if err == nil {
+			go engine.config.Server.PushMessageToBeacon(newShardToBeaconMsg, map[libp2p.ID]bool{})
+		}
+	}
+	newCrossShardBlocks := shardBlock.CreateAllCrossShardBlock(engine.config.BlockChain.BestState.Beacon.ActiveShards)
+	for sID, newCrossShardBlock := range newCrossShardBlocks {
+		if crossShardPool, ok := engine.config.CrossShardPool[sID]; ok && crossShardPool != nil {
+			if _, _, err := crossShardPool.AddCrossShardBlock(newCrossShardBlock); err != nil {
+				Logger.log.Error(err)
+			}
+		}
+		newCrossShardMsg, err := mubft.MakeMsgCrossShardBlock(newCrossShardBlock)
+		if err == nil {
+			go engine.config.Server.PushMessageToShard(newCrossShardMsg, sID, map[libp2p.ID]bool{})
+		}
+	}
+	newShardBlockMsg, err := mubft.MakeMsgShardBlock(shardBlock)
+	if err != nil {
+		Logger.log.Error("Make new shard block message error", err)
+	} else {
+		go engine.config.Server.PushMessageToAll(newShardBlockMsg)
+	}
+}
+
+// checkCommittees makes sure that node key alone is committee of beacon and of every shard,
+// a block sealed by one key is not valid for a committee with more members
+func (engine *Engine) checkCommittees() error {
+	bestState := engine.config.BlockChain.BestState
+	if bestState == nil || bestState.Beacon == nil {
+		return nil
+	}
+	if err := engine.checkCommittee(bestState.Beacon.BeaconCommittee); err != nil {
+		return NewInstantSealError(ErrInvalidCommittee, fmt.Errorf("beacon committee: %+v", err))
+	}
+	for shardID, shardBestState := range bestState.Shard {
+		if err := engine.checkCommittee(shardBestState.ShardCommittee); err != nil {
+			return NewInstantSealError(ErrInvalidCommittee, fmt.Errorf("shard %+v committee: %+v", shardID, err))
+		}
+	}
+	return nil
+}
+
+func (engine *Engine) checkCommittee(committee []string) error {
+	if len(committee) != 1 || committee[0] != engine.userPk {
+		return fmt.Errorf("committee %+v is not only key %+v", committee, engine.userPk)
+	}
+	return nil
+}
+
+// signBlock creates a multisig of a committee with only one signer (user key),
+// so sealed blocks have the same signature format as blocks agreed by PBFT
+func (engine *Engine) signBlock(blockHash common.Hash, committee []string) (string, string, [][]int, error) {
+	userIdx := common.IndexOfStr(engine.userPk, committee)
+	if userIdx < 0 {
+		return "", "", nil, NewInstantSealError(ErrNotInCommittee, fmt.Errorf("key %+v", engine.userPk))
+	}
+	// committee may have changed since start, e.g. by staking
+	if err := engine.checkCommittee(committee); err != nil {
+		return "", "", nil, NewInstantSealError(ErrInvalidCommittee, err)
+	}
+	scheme := new(privacy.MultiSigScheme)
+	scheme.Init()
+	scheme.GetKeyset().Set(&engine.config.UserKeySet.PrivateKey, &engine.config.UserKeySet.PaymentAddress.Pk)
+	RPoint, r := scheme.GenerateRandom()
+	userPubKey := new(privacy.PublicKey)
+	*userPubKey = engine.config.UserKeySet.PaymentAddress.Pk
+	sig, err := scheme.GetKeyset().SignMultiSig(blockHash.GetBytes(), []*privacy.PublicKey{userPubKey}, []*privacy.EllipticPoint{RPoint}, r)
+	if err != nil {
+		return "", "", nil, NewInstantSealError(ErrSignBlock, err)
+	}
+	aggregatedSig := scheme.CombineMultiSig([]*privacy.SchnMultiSig{sig})
+	aggregatedSigInBytes, err := aggregatedSig.Bytes()
+	if err != nil {
+		return "", "", nil, NewInstantSealError(ErrSignBlock, err)
+	}
+	validatorsIdx := [][]int{{userIdx}, {userIdx}}
+	return base58.Base58Check{}.Encode(aggregatedSigInBytes, common.ZeroByte), base58.Base58Check{}.Encode(RPoint.Compress(), common.ZeroByte), validatorsIdx, nil
+}
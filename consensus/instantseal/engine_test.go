@@ -0,0 +1,101 @@
+package instantseal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestEngine(t *testing.T, beaconCommittee []string, shardCommittee []string) *Engine {
+	keySet := new(incognitokey.KeySet).GenerateKey([]byte{1})
+	userPk := keySet.GetPublicKeyInBase58CheckEncode()
+	if beaconCommittee == nil {
+		beaconCommittee = []string{userPk}
+	}
+	if shardCommittee == nil {
+		shardCommittee = []string{userPk}
+	}
+	beaconBestState := blockchain.NewBeaconBestState()
+	beaconBestState.ActiveShards = 1
+	beaconBestState.BeaconCommittee = beaconCommittee
+	shardBestState := blockchain.NewShardBestState()
+	shardBestState.ShardCommittee = shardCommittee
+	engine, err := NewEngine(&EngineConfig{
+		BlockChain: &blockchain.BlockChain{BestState: &blockchain.BestState{
+			Beacon: beaconBestState,
+			Shard:  map[byte]*blockchain.ShardBestState{0: shardBestState},
+		}},
+		BlockGen:   &blockchain.BlockGenerator{},
+		UserKeySet: keySet,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.userPk = userPk
+	return engine
+}
+
+func TestNewEngine(t *testing.T) {
+	_, err := NewEngine(&EngineConfig{SealInterval: -time.Second})
+	assert.NotNil(t, err)
+	engine, err := NewEngine(&EngineConfig{})
+	assert.Nil(t, err)
+	assert.NotNil(t, engine.Start(), "engine can't start without user key")
+}
+
+func TestEngineCheckCommittees(t *testing.T) {
+	otherPk := new(incognitokey.KeySet).GenerateKey([]byte{2}).GetPublicKeyInBase58CheckEncode()
+	engine := newTestEngine(t, nil, nil)
+	assert.Nil(t, engine.checkCommittees())
+
+	engine = newTestEngine(t, []string{engine.userPk, otherPk}, nil)
+	err := engine.Start()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, ErrCodeMessage[ErrInvalidCommittee].code, err.(*InstantSealError).Code)
+	}
+	engine = newTestEngine(t, nil, []string{otherPk})
+	assert.NotNil(t, engine.checkCommittees())
+}
+
+func TestEngineGenerateBlocksNotStarted(t *testing.T) {
+	engine := newTestEngine(t, nil, nil)
+	_, err := engine.GenerateBlocks(-1, 1)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, ErrCodeMessage[ErrNotStarted].code, err.(*InstantSealError).Code)
+	}
+
+	engine.started = true
+	for _, chainID := range []int{-2, 1} {
+		_, err = engine.GenerateBlocks(chainID, 1)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, ErrCodeMessage[ErrInvalidChainID].code, err.(*InstantSealError).Code)
+		}
+	}
+}
+
+func TestEngineSignBlock(t *testing.T) {
+	engine := newTestEngine(t, nil, nil)
+	blockHash := common.HashH([]byte("block"))
+	committee := []string{engine.userPk}
+	aggSig, R, validatorsIdx, err := engine.signBlock(blockHash, committee)
+	assert.Nil(t, err)
+	assert.Nil(t, blockchain.ValidateAggSignature(validatorsIdx, committee, aggSig, R, &blockHash))
+
+	// signature of other block is rejected
+	otherHash := common.HashH([]byte("other block"))
+	assert.NotNil(t, blockchain.ValidateAggSignature(validatorsIdx, committee, aggSig, R, &otherHash))
+
+	otherPk := new(incognitokey.KeySet).GenerateKey([]byte{2}).GetPublicKeyInBase58CheckEncode()
+	_, _, _, err = engine.signBlock(blockHash, []string{otherPk})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, ErrCodeMessage[ErrNotInCommittee].code, err.(*InstantSealError).Code)
+	}
+	_, _, _, err = engine.signBlock(blockHash, []string{otherPk, engine.userPk})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, ErrCodeMessage[ErrInvalidCommittee].code, err.(*InstantSealError).Code)
+	}
+}
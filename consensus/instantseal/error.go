@@ -0,0 +1,50 @@
+package instantseal
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ErrUnexpected = iota
+	ErrNotStarted
+	ErrNotInCommittee
+	ErrCreateBlock
+	ErrSignBlock
+	ErrInsertBlock
+	ErrInvalidChainID
+	ErrInvalidCommittee
+)
+
+var ErrCodeMessage = map[int]struct {
+	code    int
+	message string
+}{
+	ErrUnexpected:       {-1, "Unexpected error"},
+	ErrNotStarted:       {-2, "instant seal engine is not started"},
+	ErrNotInCommittee:   {-3, "user not in committee"},
+	ErrCreateBlock:      {-4, "can't create block"},
+	ErrSignBlock:        {-5, "can't sign block"},
+	ErrInsertBlock:      {-6, "can't insert block"},
+	ErrInvalidChainID:   {-7, "invalid chain id"},
+	ErrInvalidCommittee: {-8, "instant seal requires committees of only the node key"},
+}
+
+type InstantSealError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e InstantSealError) Error() string {
+	return fmt.Sprintf("%d: %s %+v", e.Code, e.Message, e.Err)
+}
+
+func NewInstantSealError(key int, err error) *InstantSealError {
+	return &InstantSealError{
+		Code:    ErrCodeMessage[key].code,
+		Message: ErrCodeMessage[key].message,
+		Err:     errors.Wrap(err, ErrCodeMessage[key].message),
+	}
+}
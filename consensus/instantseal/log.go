@@ -0,0 +1,14 @@
+package instantseal
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type instantSealLogger struct {
+	log common.Logger
+}
+
+func (instantSealLogger *instantSealLogger) Init(inst common.Logger) {
+	instantSealLogger.log = inst
+}
+
+// Global instant to use
+var Logger = instantSealLogger{}
@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/metrics"
 	peer "github.com/libp2p/go-libp2p-peer"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -32,6 +33,12 @@ type BFTProtocol struct {
 		Committee       []string
 		// ClosestPoolState map[byte]uint64
 		Round int
+		// TimeoutBackoff multiplies phase timeouts, it grows after each failed round
+		TimeoutBackoff time.Duration
+		// PreparedBlock is the block committee is locked on at this height (nil if none),
+		// proposer re-proposes it and validators only agree on the block of PreparedBlockHash
+		PreparedBlock     json.RawMessage
+		PreparedBlockHash common.Hash
 	}
 	multiSigScheme *multiSigScheme
 
@@ -59,14 +66,6 @@ func (protocol *BFTProtocol) Start() (interface{}, error) {
 		return nil, err
 	}
 	go protocol.earlyMsgHandler()
-	//    single-node start    //
-	// go protocol.CreateBlockMsg()
-	// <-protocol.proposeCh
-	// if protocol.pendingBlock != nil {
-	// 	return protocol.pendingBlock, nil
-	// }
-	// return nil, errors.New("can't produce block")
-	//    single-node end    //
 	for {
 		protocol.startTime = time.Now()
 		fmt.Println("BFT: New Phase", time.Since(protocol.startTime).Seconds())
@@ -97,7 +96,9 @@ func (protocol *BFTProtocol) CreateBlockMsg() {
 	start := time.Now()
 	var elasped time.Duration
 	var msg wire.Message
-	if protocol.RoundData.Layer == common.BEACON_ROLE {
+	if len(protocol.RoundData.PreparedBlock) > 0 {
+		msg = protocol.reproposePreparedBlock()
+	} else if protocol.RoundData.Layer == common.BEACON_ROLE {
 
 		newBlock, err := protocol.EngineCfg.BlockGen.NewBlockBeacon(&protocol.EngineCfg.UserKeySet.PaymentAddress, protocol.RoundData.Round, protocol.EngineCfg.BlockChain.Synker.GetClosestShardToBeaconPoolState())
 		go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
@@ -182,6 +183,41 @@ func (protocol *BFTProtocol) CreateBlockMsg() {
 	}
 }
 
+// reproposePreparedBlock makes a propose msg of the block committee is locked on,
+// the block keeps round and producer of the round it was created in
+func (protocol *BFTProtocol) reproposePreparedBlock() wire.Message {
+	var pendingBlock interface{}
+	if protocol.RoundData.Layer == common.BEACON_ROLE {
+		beaconBlock := &blockchain.BeaconBlock{}
+		if err := beaconBlock.UnmarshalJSON(protocol.RoundData.PreparedBlock); err != nil {
+			Logger.log.Error(err)
+			return nil
+		}
+		pendingBlock = beaconBlock
+	} else {
+		shardBlock := &blockchain.ShardBlock{}
+		if err := shardBlock.UnmarshalJSON(protocol.RoundData.PreparedBlock); err != nil {
+			Logger.log.Error(err)
+			return nil
+		}
+		pendingBlock = shardBlock
+	}
+	msg, err := MakeMsgBFTPropose(protocol.RoundData.PreparedBlock, protocol.RoundData.Layer, protocol.RoundData.ShardID, protocol.EngineCfg.UserKeySet)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	Logger.log.Infof("BFT: re-propose prepared block %+v", protocol.RoundData.PreparedBlockHash)
+	protocol.pendingBlock = pendingBlock
+	protocol.multiSigScheme.dataToSig = protocol.RoundData.PreparedBlockHash
+	return msg
+}
+
+// isLockedOn returns false if committee is locked on a prepared block other than blockHash
+func (protocol *BFTProtocol) isLockedOn(blockHash common.Hash) bool {
+	return len(protocol.RoundData.PreparedBlock) == 0 || protocol.RoundData.PreparedBlockHash == blockHash
+}
+
 func (protocol *BFTProtocol) forwardMsg(msg wire.Message) {
 	if protocol.RoundData.Layer == common.BEACON_ROLE {
 		go protocol.EngineCfg.Server.PushMessageToBeacon(msg, map[peer.ID]bool{})
@@ -270,17 +306,20 @@ func (protocol *BFTProtocol) earlyMsgHandler() {
 	}
 }
 
-func getTimeout(phase string, committeeSize int) time.Duration {
+func getTimeout(phase string, committeeSize int, backoff time.Duration) time.Duration {
+	if backoff < 1 {
+		backoff = 1
+	}
 	assumedDelay := time.Duration(committeeSize) * MaxNetworkDelayTime
 	switch phase {
 	case BFT_PROPOSE:
-		return assumedDelay + ListenTimeout
+		return (assumedDelay + ListenTimeout) * backoff
 	case BFT_LISTEN:
-		return assumedDelay + ListenTimeout
+		return (assumedDelay + ListenTimeout) * backoff
 	case BFT_AGREE:
-		return assumedDelay + AgreeTimeout
+		return (assumedDelay + AgreeTimeout) * backoff
 	case BFT_COMMIT:
-		return assumedDelay + CommitTimeout
+		return (assumedDelay + CommitTimeout) * backoff
 	}
 	return 0
 }
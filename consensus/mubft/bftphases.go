@@ -14,7 +14,7 @@ import (
 
 func (protocol *BFTProtocol) phasePropose() error {
 	go protocol.CreateBlockMsg()
-	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee))
+	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee), protocol.RoundData.TimeoutBackoff)
 	if protocol.RoundData.Layer == common.BEACON_ROLE {
 		phaseDuration += common.MinBeaconBlkInterval
 	} else {
@@ -115,7 +115,7 @@ func (protocol *BFTProtocol) phaseListen() error {
 	}
 	fmt.Println("BFT: Listen phase", time.Since(protocol.startTime).Seconds())
 
-	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee))
+	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee), protocol.RoundData.TimeoutBackoff)
 	timeout := time.AfterFunc(phaseDuration+additionalWaitTime, func() {
 		fmt.Println("BFT: Listen phase timeout", time.Since(protocol.startTime).Seconds())
 		protocol.closeTimeoutCh()
@@ -137,6 +137,10 @@ phase:
 						Logger.log.Error(err)
 						continue
 					}
+					if !protocol.isLockedOn(pendingBlk.Header.Hash()) {
+						Logger.log.Errorf("BFT: proposed block is not prepared block %+v", protocol.RoundData.PreparedBlockHash)
+						continue
+					}
 					verifyTime := time.Now()
 					err = protocol.EngineCfg.BlockChain.VerifyPreSignBeaconBlock(&pendingBlk, true)
 					if err != nil {
@@ -154,6 +158,10 @@ phase:
 						Logger.log.Error(err)
 						continue
 					}
+					if !protocol.isLockedOn(pendingBlk.Header.Hash()) {
+						Logger.log.Errorf("BFT: proposed block is not prepared block %+v", protocol.RoundData.PreparedBlockHash)
+						continue
+					}
 					verifyTime := time.Now()
 					err = protocol.EngineCfg.BlockChain.VerifyPreSignShardBlock(&pendingBlk, protocol.RoundData.ShardID)
 					if err != nil {
@@ -204,7 +212,7 @@ phase:
 
 func (protocol *BFTProtocol) phaseAgree() error {
 	fmt.Println("BFT: Agree phase", time.Since(protocol.startTime).Seconds())
-	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee))
+	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee), protocol.RoundData.TimeoutBackoff)
 	timeout := time.AfterFunc(phaseDuration+(protocol.blockCreateTime*4/5), func() {
 		fmt.Println("BFT: Agree phase timeout", time.Since(protocol.startTime).Seconds())
 		protocol.closeTimeoutCh()
@@ -265,7 +273,7 @@ phase:
 
 func (protocol *BFTProtocol) phaseCommit() error {
 	fmt.Println("BFT: Commit phase", time.Since(protocol.startTime).Seconds())
-	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee))
+	phaseDuration := getTimeout(protocol.phase, len(protocol.RoundData.Committee), protocol.RoundData.TimeoutBackoff)
 	cmTimeout := time.AfterFunc(phaseDuration, func() {
 		fmt.Println("BFT: Commit phase timeout", time.Since(protocol.startTime).Seconds())
 		protocol.closeTimeoutCh()
@@ -8,6 +8,8 @@ const (
 	CommitTimeout       = 3 * time.Second        //in s
 	MaxNetworkDelayTime = 150 * time.Millisecond // in ms
 	MaxNormalRetryTime  = 2
+	// phase timeouts are doubled after each failed round, up to 2^MaxTimeoutBackoffExp times
+	MaxTimeoutBackoffExp = 4
 )
 
 const (
@@ -11,6 +11,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -28,6 +29,9 @@ type Engine struct {
 	userLayer string
 	retries   int
 	userPk    string
+	// view change state of each chain, key -1 is beacon chain, others are shardID
+	viewChangeLock sync.Mutex
+	viewChanges    map[int]*viewChangeState
 }
 
 type EngineConfig struct {
@@ -46,10 +50,13 @@ type EngineConfig struct {
 	CRoleInCommitteesShardPool  []chan int
 }
 
+var _ consensus.ConsensusEngine = (*Engine)(nil)
+
 //Init apply configuration to consensus engine
 func (engine Engine) Init(cfg *EngineConfig) (*Engine, error) {
 	return &Engine{
-		config: *cfg,
+		config:      *cfg,
+		viewChanges: make(map[int]*viewChangeState),
 	}, nil
 }
 
@@ -161,11 +168,11 @@ func (engine *Engine) execBeaconRole() {
 		cBFTMsg:   engine.cBFTMsg,
 		EngineCfg: &engine.config,
 	}
-	bftProtocol.RoundData.Round = engine.currentBFTRound
 	bftProtocol.RoundData.BestStateHash = engine.config.BlockChain.BestState.Beacon.Hash()
 	bftProtocol.RoundData.Layer = common.BEACON_ROLE
 	bftProtocol.RoundData.Committee = make([]string, len(engine.config.BlockChain.BestState.Beacon.BeaconCommittee))
 	copy(bftProtocol.RoundData.Committee, engine.config.BlockChain.BestState.Beacon.BeaconCommittee)
+	engine.syncRound(bftProtocol)
 	roundRole, _ := engine.config.BlockChain.BestState.Beacon.GetPubkeyRole(engine.userPk, bftProtocol.RoundData.Round)
 	var (
 		err    error
@@ -179,18 +186,16 @@ func (engine *Engine) execBeaconRole() {
 		engine.currentBFTBlkHeight = engine.config.BlockChain.BestState.Beacon.BeaconHeight + 1
 		resBlk, err = bftProtocol.Start()
 		if err != nil {
-			engine.currentBFTRound++
+			engine.onRoundFailed(bftProtocol)
 			engine.retries++
-			// engine.prevRoundUserLayer = engine.userLayer
 		}
 	case common.VALIDATOR_ROLE:
 		bftProtocol.RoundData.IsProposer = false
 		engine.currentBFTBlkHeight = engine.config.BlockChain.BestState.Beacon.BeaconHeight + 1
 		resBlk, err = bftProtocol.Start()
 		if err != nil {
-			engine.currentBFTRound++
+			engine.onRoundFailed(bftProtocol)
 			engine.retries++
-			// engine.prevRoundUserLayer = engine.userLayer
 		}
 	default:
 		err = errors.New("Not your turn yet")
@@ -235,12 +240,12 @@ func (engine *Engine) execShardRole(shardID byte) {
 		EngineCfg: &engine.config,
 	}
 	bftProtocol.RoundData.MinBeaconHeight = engine.config.BlockChain.BestState.Beacon.BeaconHeight
-	bftProtocol.RoundData.Round = engine.currentBFTRound
 	bftProtocol.RoundData.BestStateHash = engine.config.BlockChain.BestState.Shard[shardID].Hash()
 	bftProtocol.RoundData.Layer = common.SHARD_ROLE
 	bftProtocol.RoundData.ShardID = shardID
 	bftProtocol.RoundData.Committee = make([]string, len(engine.config.BlockChain.BestState.Shard[shardID].ShardCommittee))
 	copy(bftProtocol.RoundData.Committee, engine.config.BlockChain.BestState.Shard[shardID].ShardCommittee)
+	engine.syncRound(bftProtocol)
 	var (
 		err    error
 		resBlk interface{}
@@ -255,18 +260,16 @@ func (engine *Engine) execShardRole(shardID byte) {
 		engine.currentBFTBlkHeight = engine.config.BlockChain.BestState.Shard[shardID].ShardHeight + 1
 		resBlk, err = bftProtocol.Start()
 		if err != nil {
-			engine.currentBFTRound++
+			engine.onRoundFailed(bftProtocol)
 			engine.retries++
-			// engine.prevRoundUserLayer = engine.userLayer
 		}
 	case common.VALIDATOR_ROLE:
 		bftProtocol.RoundData.IsProposer = false
 		engine.currentBFTBlkHeight = engine.config.BlockChain.BestState.Shard[shardID].ShardHeight + 1
 		resBlk, err = bftProtocol.Start()
 		if err != nil {
-			engine.currentBFTRound++
+			engine.onRoundFailed(bftProtocol)
 			engine.retries++
-			// engine.prevRoundUserLayer = engine.userLayer
 		}
 	default:
 		err = errors.New("Not your turn yet")
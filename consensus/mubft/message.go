@@ -14,6 +14,10 @@ import (
 
 func (engine *Engine) OnBFTMsg(msg wire.Message) {
 	if engine.started {
+		if msg.MessageType() == wire.CmdBFTViewChange {
+			engine.onViewChange(msg.(*wire.MessageBFTViewChange))
+			return
+		}
 		engine.cBFTMsg <- msg
 	}
 }
@@ -102,6 +106,27 @@ func MakeMsgBFTCommit(commitSig string, R string, validatorsIdx []int, userKeySe
 	return msg, nil
 }
 
+func MakeMsgBFTViewChange(layer string, shardID byte, bestStateHash common.Hash, round int, nextRound int, preparedRound int, preparedBlock json.RawMessage, userKeySet *incognitokey.KeySet) (wire.Message, error) {
+	msg, err := wire.MakeEmptyMessage(wire.CmdBFTViewChange)
+	if err != nil {
+		Logger.log.Error(err)
+		return msg, err
+	}
+	msg.(*wire.MessageBFTViewChange).Layer = layer
+	msg.(*wire.MessageBFTViewChange).ShardID = shardID
+	msg.(*wire.MessageBFTViewChange).BestStateHash = bestStateHash
+	msg.(*wire.MessageBFTViewChange).Round = round
+	msg.(*wire.MessageBFTViewChange).NextRound = nextRound
+	msg.(*wire.MessageBFTViewChange).PreparedRound = preparedRound
+	msg.(*wire.MessageBFTViewChange).PreparedBlock = preparedBlock
+	msg.(*wire.MessageBFTViewChange).Pubkey = userKeySet.GetPublicKeyInBase58CheckEncode()
+	err = msg.(*wire.MessageBFTViewChange).SignMsg(userKeySet)
+	if err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
 func MakeMsgBeaconBlock(block *blockchain.BeaconBlock) (wire.Message, error) {
 	msg, err := wire.MakeEmptyMessage(wire.CmdBlockBeacon)
 	if err != nil {
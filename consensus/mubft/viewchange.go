@@ -0,0 +1,338 @@
+package mubft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wire"
+	libp2p "github.com/libp2p/go-libp2p-peer"
+)
+
+// viewChangeState keeps track of failed rounds of one chain (beacon or a shard) at current best state.
+// Proposer of round r is the r-th member after last proposer, skipping members which failed their last
+// proposal (see blockchain.GetProposerPosition), so a proposer is skipped by moving to a higher round,
+// and a round is only entered with a certificate: view change msgs of a quorum
+// of committee members asking for it (or for a higher round). Members holding the same view change msgs
+// are in the same round, whatever each of them saw locally.
+type viewChangeState struct {
+	sync.Mutex
+	// bestStateHash is the best state all data below refer to
+	bestStateHash common.Hash
+	// failedRounds is number of consecutive failed rounds at current height, used for timeout backoff
+	failedRounds int
+	// votes map[next round]map[pubkey]: view change msgs of committee members asking to move to next round
+	votes map[int]map[string]struct{}
+	// certifiedRound is the highest round with a certificate
+	certifiedRound int
+	// votedRound is the highest round this node asked for
+	votedRound int
+	// preparedBlock is the block of the highest round which collected agree msgs of a quorum of committee,
+	// members are locked on it: proposers re-propose it and validators only agree on it
+	preparedRound int
+	preparedBlock json.RawMessage
+	preparedHash  common.Hash
+}
+
+func newViewChangeState() *viewChangeState {
+	return &viewChangeState{
+		votes: make(map[int]map[string]struct{}),
+	}
+}
+
+// certificateQuorum is number of view change msgs needed to enter a round, same as agree and commit quorum
+func certificateQuorum(committeeSize int) int {
+	return 2*committeeSize/3 + 1
+}
+
+// joinQuorum is number of view change msgs which makes a member ask for a round too,
+// at least one of them is from an honest member
+func joinQuorum(committeeSize int) int {
+	return committeeSize/3 + 1
+}
+
+// reset clears round data when chain moves to a new best state
+func (state *viewChangeState) reset(bestStateHash common.Hash) {
+	if state.bestStateHash == bestStateHash {
+		return
+	}
+	state.bestStateHash = bestStateHash
+	state.failedRounds = 0
+	state.votes = make(map[int]map[string]struct{})
+	state.certifiedRound = 0
+	state.votedRound = 0
+	state.preparedRound = 0
+	state.preparedBlock = nil
+	state.preparedHash = common.Hash{}
+}
+
+// addVote records that member asked to move to round
+func (state *viewChangeState) addVote(round int, member string) {
+	if _, ok := state.votes[round]; !ok {
+		state.votes[round] = make(map[string]struct{})
+	}
+	state.votes[round][member] = struct{}{}
+}
+
+// highestRoundVotedBy returns the highest round which at least quorum members asked for (or for a higher round)
+func (state *viewChangeState) highestRoundVotedBy(quorum int) int {
+	highestRound := 0
+	for round := range state.votes {
+		if round > highestRound {
+			highestRound = round
+		}
+	}
+	for round := highestRound; round > 0; round-- {
+		voters := make(map[string]struct{})
+		for r, votes := range state.votes {
+			if r < round {
+				continue
+			}
+			for member := range votes {
+				voters[member] = struct{}{}
+			}
+		}
+		if len(voters) >= quorum {
+			return round
+		}
+	}
+	return 0
+}
+
+// certify raises certifiedRound to the highest round with a certificate, it returns true if it changed
+func (state *viewChangeState) certify(committeeSize int) bool {
+	round := state.highestRoundVotedBy(certificateQuorum(committeeSize))
+	if round <= state.certifiedRound {
+		return false
+	}
+	state.certifiedRound = round
+	return true
+}
+
+// roundToJoin returns the round this node should ask for because enough members asked for it,
+// 0 if this node already asked for it
+func (state *viewChangeState) roundToJoin(committeeSize int) int {
+	round := state.highestRoundVotedBy(joinQuorum(committeeSize))
+	if round <= state.votedRound {
+		return 0
+	}
+	return round
+}
+
+// prepare locks state on block if it is prepared in a higher round than the current lock
+func (state *viewChangeState) prepare(round int, block json.RawMessage, blockHash common.Hash) {
+	if round <= state.preparedRound || len(block) == 0 {
+		return
+	}
+	state.preparedRound = round
+	state.preparedBlock = block
+	state.preparedHash = blockHash
+}
+
+// timeoutBackoff doubles phase timeouts after each failed round at the same height
+func (state *viewChangeState) timeoutBackoff() time.Duration {
+	exp := state.failedRounds
+	if exp > MaxTimeoutBackoffExp {
+		exp = MaxTimeoutBackoffExp
+	}
+	return time.Duration(1 << uint(exp))
+}
+
+// preparedBlockInfo returns hash and round of block in a view change msg of layer
+func preparedBlockInfo(layer string, block json.RawMessage) (common.Hash, int, error) {
+	if layer == common.BEACON_ROLE {
+		beaconBlock := blockchain.BeaconBlock{}
+		if err := beaconBlock.UnmarshalJSON(block); err != nil {
+			return common.Hash{}, 0, err
+		}
+		return beaconBlock.Header.Hash(), beaconBlock.Header.Round, nil
+	}
+	shardBlock := blockchain.ShardBlock{}
+	if err := shardBlock.UnmarshalJSON(block); err != nil {
+		return common.Hash{}, 0, err
+	}
+	return shardBlock.Header.Hash(), shardBlock.Header.Round, nil
+}
+
+func (engine *Engine) getViewChangeState(layer string, shardID byte) *viewChangeState {
+	engine.viewChangeLock.Lock()
+	defer engine.viewChangeLock.Unlock()
+	chainID := -1
+	if layer == common.SHARD_ROLE {
+		chainID = int(shardID)
+	}
+	state, ok := engine.viewChanges[chainID]
+	if !ok {
+		state = newViewChangeState()
+		engine.viewChanges[chainID] = state
+	}
+	return state
+}
+
+// onRoundFailed updates view change state after bft protocol failed at round
+// then broadcasts a view change msg asking committee to move to next round
+func (engine *Engine) onRoundFailed(protocol *BFTProtocol) {
+	state := engine.getViewChangeState(protocol.RoundData.Layer, protocol.RoundData.ShardID)
+	state.Lock()
+	state.reset(protocol.RoundData.BestStateHash)
+	state.failedRounds++
+	round := protocol.RoundData.Round
+	// block is prepared once agree msgs of a quorum are collected, i.e. protocol reached commit phase
+	if protocol.phase == BFT_COMMIT && protocol.pendingBlock != nil {
+		preparedBlock, err := json.Marshal(protocol.pendingBlock)
+		if err == nil {
+			state.prepare(round, preparedBlock, protocol.multiSigScheme.dataToSig)
+		}
+	}
+	state.Unlock()
+	engine.sendViewChange(protocol.RoundData.Layer, protocol.RoundData.ShardID, protocol.RoundData.BestStateHash, round+1, len(protocol.RoundData.Committee))
+}
+
+// sendViewChange votes for nextRound and broadcasts the vote with prepared block of this node
+func (engine *Engine) sendViewChange(layer string, shardID byte, bestStateHash common.Hash, nextRound int, committeeSize int) {
+	state := engine.getViewChangeState(layer, shardID)
+	state.Lock()
+	if state.bestStateHash != bestStateHash || state.votedRound >= nextRound {
+		state.Unlock()
+		return
+	}
+	state.votedRound = nextRound
+	state.addVote(nextRound, engine.userPk)
+	if state.certify(committeeSize) {
+		Logger.log.Infof("BFT: view change to round %+v", state.certifiedRound)
+	}
+	preparedRound := state.preparedRound
+	preparedBlock := state.preparedBlock
+	state.Unlock()
+
+	Logger.log.Infof("BFT: ask committee to move to round %+v", nextRound)
+	msg, err := MakeMsgBFTViewChange(layer, shardID, bestStateHash, nextRound-1, nextRound, preparedRound, preparedBlock, engine.config.UserKeySet)
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	if engine.config.Server == nil {
+		return
+	}
+	if layer == common.BEACON_ROLE {
+		go engine.config.Server.PushMessageToBeacon(msg, map[libp2p.ID]bool{})
+	} else {
+		go engine.config.Server.PushMessageToShard(msg, shardID, map[libp2p.ID]bool{})
+	}
+}
+
+// verifyPreparedBlock checks that block in a view change msg is valid at current best state
+// and was prepared in a round not after msg round, so a member can't lock others on a fake block
+func (engine *Engine) verifyPreparedBlock(msg *wire.MessageBFTViewChange) (common.Hash, error) {
+	if msg.PreparedRound > msg.Round {
+		return common.Hash{}, fmt.Errorf("prepared round %+v is after round %+v", msg.PreparedRound, msg.Round)
+	}
+	blockHash, blockRound, err := preparedBlockInfo(msg.Layer, msg.PreparedBlock)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if blockRound > msg.PreparedRound {
+		return common.Hash{}, fmt.Errorf("block of round %+v can't be prepared in round %+v", blockRound, msg.PreparedRound)
+	}
+	if engine.config.BlockChain == nil {
+		return blockHash, nil
+	}
+	if msg.Layer == common.BEACON_ROLE {
+		beaconBlock := blockchain.BeaconBlock{}
+		if err := beaconBlock.UnmarshalJSON(msg.PreparedBlock); err != nil {
+			return common.Hash{}, err
+		}
+		err = engine.config.BlockChain.VerifyPreSignBeaconBlock(&beaconBlock, true)
+	} else {
+		shardBlock := blockchain.ShardBlock{}
+		if err := shardBlock.UnmarshalJSON(msg.PreparedBlock); err != nil {
+			return common.Hash{}, err
+		}
+		err = engine.config.BlockChain.VerifyPreSignShardBlock(&shardBlock, msg.ShardID)
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return blockHash, nil
+}
+
+// onViewChange handles view change msg of other committee members:
+//   - a round is entered when a quorum (more than 2/3) of committee asked for it or a higher round
+//   - a member asks for a round too when more than 1/3 of committee asked for it,
+//     so one slow member doesn't keep committee from reaching the quorum
+//   - a prepared block in the msg locks this node on it if it is prepared in a higher round than the current lock
+func (engine *Engine) onViewChange(msg *wire.MessageBFTViewChange) {
+	var (
+		bestStateHash common.Hash
+		committee     []string
+	)
+	if msg.Layer == common.BEACON_ROLE {
+		beaconBestState := engine.config.BlockChain.BestState.Beacon
+		bestStateHash = beaconBestState.Hash()
+		committee = beaconBestState.BeaconCommittee
+	} else {
+		shardBestState, ok := engine.config.BlockChain.BestState.Shard[msg.ShardID]
+		if !ok {
+			return
+		}
+		bestStateHash = shardBestState.Hash()
+		committee = shardBestState.ShardCommittee
+	}
+	if msg.BestStateHash != bestStateHash || len(committee) == 0 || common.IndexOfStr(msg.Pubkey, committee) == -1 {
+		return
+	}
+
+	state := engine.getViewChangeState(msg.Layer, msg.ShardID)
+	state.Lock()
+	state.reset(bestStateHash)
+	isHigherPrepared := len(msg.PreparedBlock) > 0 && msg.PreparedRound > state.preparedRound
+	state.Unlock()
+	var preparedHash common.Hash
+	if isHigherPrepared {
+		var err error
+		if preparedHash, err = engine.verifyPreparedBlock(msg); err != nil {
+			Logger.log.Error(errors.New("BFT: invalid prepared block in view change msg"), err)
+			isHigherPrepared = false
+		}
+	}
+
+	state.Lock()
+	state.reset(bestStateHash)
+	if isHigherPrepared {
+		state.prepare(msg.PreparedRound, msg.PreparedBlock, preparedHash)
+	}
+	state.addVote(msg.NextRound, msg.Pubkey)
+	if state.certify(len(committee)) {
+		Logger.log.Infof("BFT: view change to round %+v", state.certifiedRound)
+	}
+	roundToJoin := state.roundToJoin(len(committee))
+	state.Unlock()
+
+	if roundToJoin > 0 && common.IndexOfStr(engine.userPk, committee) != -1 {
+		engine.sendViewChange(msg.Layer, msg.ShardID, bestStateHash, roundToJoin, len(committee))
+	}
+}
+
+// syncRound applies view change state of chain to protocol before a new round starts:
+// it moves engine to the certified round if it is higher, sets timeout backoff of the round
+// and the prepared block which committee is locked on
+func (engine *Engine) syncRound(protocol *BFTProtocol) {
+	state := engine.getViewChangeState(protocol.RoundData.Layer, protocol.RoundData.ShardID)
+	state.Lock()
+	defer state.Unlock()
+	state.reset(protocol.RoundData.BestStateHash)
+	if state.certifiedRound > engine.currentBFTRound {
+		engine.currentBFTRound = state.certifiedRound
+	}
+	if engine.currentBFTRound < 1 {
+		engine.currentBFTRound = 1
+	}
+	protocol.RoundData.Round = engine.currentBFTRound
+	protocol.RoundData.TimeoutBackoff = state.timeoutBackoff()
+	protocol.RoundData.PreparedBlock = state.preparedBlock
+	protocol.RoundData.PreparedBlockHash = state.preparedHash
+}
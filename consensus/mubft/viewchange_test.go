@@ -0,0 +1,229 @@
+package mubft
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func newTestCommittee(size int) []*incognitokey.KeySet {
+	keySets := []*incognitokey.KeySet{}
+	for i := 0; i < size; i++ {
+		keySets = append(keySets, new(incognitokey.KeySet).GenerateKey([]byte{byte(i + 1)}))
+	}
+	return keySets
+}
+
+func newTestEngine(keySets []*incognitokey.KeySet) *Engine {
+	beaconBestState := blockchain.NewBeaconBestState()
+	for _, keySet := range keySets {
+		beaconBestState.BeaconCommittee = append(beaconBestState.BeaconCommittee, keySet.GetPublicKeyInBase58CheckEncode())
+	}
+	engine, _ := Engine{}.Init(&EngineConfig{
+		BlockChain: &blockchain.BlockChain{BestState: &blockchain.BestState{Beacon: beaconBestState}},
+		UserKeySet: keySets[0],
+	})
+	engine.userPk = keySets[0].GetPublicKeyInBase58CheckEncode()
+	return engine
+}
+
+func newTestBeaconBlock(t *testing.T, round int) (json.RawMessage, common.Hash) {
+	block := blockchain.NewBeaconBlock()
+	block.Header.Height = 2
+	block.Header.Round = round
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blockBytes, block.Header.Hash()
+}
+
+func TestViewChangeStateCertify(t *testing.T) {
+	state := newViewChangeState()
+	state.reset(common.HashH([]byte{1}))
+	// committee of 4: 3 votes make a certificate, 2 votes make members join
+	state.addVote(2, "a")
+	assert.False(t, state.certify(4))
+	assert.Equal(t, 0, state.roundToJoin(4))
+	state.addVote(3, "b")
+	assert.Equal(t, 2, state.roundToJoin(4), "votes for a higher round count for lower rounds")
+	state.votedRound = 2
+	assert.Equal(t, 0, state.roundToJoin(4))
+	state.addVote(2, "c")
+	assert.True(t, state.certify(4))
+	assert.Equal(t, 2, state.certifiedRound)
+	// duplicate votes of a member are counted once
+	state.addVote(3, "b")
+	state.addVote(4, "b")
+	assert.False(t, state.certify(4))
+	assert.Equal(t, 2, state.certifiedRound)
+
+	state.failedRounds = 10
+	assert.Equal(t, int64(1<<MaxTimeoutBackoffExp), int64(state.timeoutBackoff()))
+	state.reset(common.HashH([]byte{2}))
+	assert.Equal(t, 0, state.certifiedRound)
+	assert.Equal(t, 0, state.failedRounds)
+	assert.Empty(t, state.votes)
+}
+
+func TestViewChangeStatePrepare(t *testing.T) {
+	state := newViewChangeState()
+	block1, hash1 := newTestBeaconBlock(t, 1)
+	block2, hash2 := newTestBeaconBlock(t, 2)
+	state.prepare(2, block2, hash2)
+	state.prepare(1, block1, hash1)
+	assert.Equal(t, hash2, state.preparedHash, "lock only moves to a block prepared in a higher round")
+	state.prepare(3, nil, common.Hash{})
+	assert.Equal(t, 2, state.preparedRound)
+	state.reset(common.HashH([]byte{1}))
+	assert.Nil(t, state.preparedBlock)
+}
+
+func TestEngineOnViewChange(t *testing.T) {
+	keySets := newTestCommittee(4)
+	engine := newTestEngine(keySets)
+	bestStateHash := engine.config.BlockChain.BestState.Beacon.Hash()
+	viewChange := func(keySet *incognitokey.KeySet, round int) *wire.MessageBFTViewChange {
+		msg, err := MakeMsgBFTViewChange(common.BEACON_ROLE, 0, bestStateHash, round-1, round, 0, nil, keySet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msg.(*wire.MessageBFTViewChange)
+	}
+
+	// msgs of other best state or of members out of committee are ignored
+	msg := viewChange(keySets[1], 2)
+	msg.BestStateHash = common.HashH([]byte{1})
+	engine.onViewChange(msg)
+	engine.onViewChange(viewChange(new(incognitokey.KeySet).GenerateKey([]byte{10}), 2))
+	state := engine.getViewChangeState(common.BEACON_ROLE, 0)
+	assert.Empty(t, state.votes)
+
+	// a vote doesn't move engine, a second one makes it ask for the round too and completes the certificate
+	engine.onViewChange(viewChange(keySets[1], 2))
+	assert.Equal(t, 0, state.certifiedRound)
+	engine.onViewChange(viewChange(keySets[2], 2))
+	assert.Equal(t, 2, state.votedRound)
+	assert.Equal(t, 2, state.certifiedRound)
+
+	protocol := &BFTProtocol{}
+	protocol.RoundData.Layer = common.BEACON_ROLE
+	protocol.RoundData.BestStateHash = bestStateHash
+	engine.currentBFTRound = 1
+	engine.syncRound(protocol)
+	assert.Equal(t, 2, protocol.RoundData.Round)
+	assert.Equal(t, 2, engine.currentBFTRound)
+}
+
+func TestEngineOnRoundFailed(t *testing.T) {
+	keySets := newTestCommittee(4)
+	engine := newTestEngine(keySets)
+	protocol := &BFTProtocol{multiSigScheme: new(multiSigScheme)}
+	protocol.RoundData.Layer = common.BEACON_ROLE
+	protocol.RoundData.BestStateHash = engine.config.BlockChain.BestState.Beacon.Hash()
+	protocol.RoundData.Committee = engine.config.BlockChain.BestState.Beacon.BeaconCommittee
+	protocol.RoundData.Round = 1
+	block, blockHash := newTestBeaconBlock(t, 1)
+	pendingBlock := blockchain.NewBeaconBlock()
+	if err := pendingBlock.UnmarshalJSON(block); err != nil {
+		t.Fatal(err)
+	}
+	protocol.pendingBlock = pendingBlock
+	protocol.multiSigScheme.dataToSig = blockHash
+
+	// proposal received but agree quorum missed: block is not prepared
+	protocol.phase = BFT_AGREE
+	engine.onRoundFailed(protocol)
+	state := engine.getViewChangeState(common.BEACON_ROLE, 0)
+	assert.Equal(t, 2, state.votedRound)
+	assert.Equal(t, 0, state.certifiedRound, "a node can't enter a round alone")
+	assert.Nil(t, state.preparedBlock)
+
+	protocol.phase = BFT_COMMIT
+	protocol.RoundData.Round = 2
+	engine.onRoundFailed(protocol)
+	assert.Equal(t, 3, state.votedRound)
+	assert.Equal(t, 2, state.preparedRound)
+	assert.Equal(t, blockHash, state.preparedHash)
+	assert.Equal(t, 2, state.failedRounds)
+
+	// next round re-proposes the prepared block and only agrees on it
+	protocol = &BFTProtocol{multiSigScheme: new(multiSigScheme), EngineCfg: &engine.config}
+	protocol.RoundData.Layer = common.BEACON_ROLE
+	protocol.RoundData.BestStateHash = engine.config.BlockChain.BestState.Beacon.Hash()
+	engine.syncRound(protocol)
+	assert.Equal(t, blockHash, protocol.RoundData.PreparedBlockHash)
+	assert.True(t, protocol.isLockedOn(blockHash))
+	assert.False(t, protocol.isLockedOn(common.HashH([]byte{1})))
+	msg := protocol.reproposePreparedBlock()
+	if assert.NotNil(t, msg) {
+		assert.Equal(t, []byte(block), []byte(msg.(*wire.MessageBFTPropose).Block))
+		assert.Equal(t, blockHash, protocol.multiSigScheme.dataToSig)
+		assert.Equal(t, blockHash, protocol.pendingBlock.(*blockchain.BeaconBlock).Header.Hash())
+	}
+}
+
+func TestVerifyPreparedBlock(t *testing.T) {
+	engine := &Engine{}
+	block, blockHash := newTestBeaconBlock(t, 2)
+	msg := &wire.MessageBFTViewChange{Layer: common.BEACON_ROLE, Round: 3, NextRound: 4, PreparedRound: 2, PreparedBlock: block}
+	hash, err := engine.verifyPreparedBlock(msg)
+	assert.Nil(t, err)
+	assert.Equal(t, blockHash, hash)
+
+	msg.PreparedRound = 4
+	_, err = engine.verifyPreparedBlock(msg)
+	assert.NotNil(t, err, "block can't be prepared after round of msg")
+	msg.PreparedRound = 1
+	_, err = engine.verifyPreparedBlock(msg)
+	assert.NotNil(t, err, "block can't be prepared before its own round")
+	msg.PreparedRound = 2
+	msg.PreparedBlock = []byte("{")
+	_, err = engine.verifyPreparedBlock(msg)
+	assert.NotNil(t, err)
+}
+
+func TestFailedProposerSkipped(t *testing.T) {
+	keySets := newTestCommittee(4)
+	engine := newTestEngine(keySets)
+	beaconBestState := engine.config.BlockChain.BestState.Beacon
+	committee := beaconBestState.BeaconCommittee
+	beaconBestState.BeaconProposerIndex = 3
+	// member 0 proposes round 1 after member 3
+	role, _ := beaconBestState.GetPubkeyRole(committee[0], 1)
+	assert.Equal(t, common.PROPOSER_ROLE, role)
+
+	// member 0 failed round 1, member 1 produced block of round 2: member 0 is skipped at next height
+	beaconBestState.BeaconFailedProposers = blockchain.UpdateFailedProposers(committee, 3, beaconBestState.BeaconFailedProposers, 2)
+	beaconBestState.BeaconProposerIndex = 1
+	assert.Equal(t, []string{committee[0]}, beaconBestState.BeaconFailedProposers)
+	for round, member := range []int{2, 3, 1, 2} {
+		role, _ := beaconBestState.GetPubkeyRole(committee[member], round+1)
+		assert.Equal(t, common.PROPOSER_ROLE, role, "round %+v", round+1)
+		role, _ = beaconBestState.GetPubkeyRole(committee[0], round+1)
+		assert.Equal(t, common.VALIDATOR_ROLE, role, "round %+v", round+1)
+	}
+}
+
+func TestViewChangeSignedRounds(t *testing.T) {
+	keySets := newTestCommittee(1)
+	msg, err := MakeMsgBFTViewChange(common.BEACON_ROLE, 0, common.Hash{}, 1, 23, 45, nil, keySets[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	viewChange := msg.(*wire.MessageBFTViewChange)
+	assert.Nil(t, viewChange.VerifyMsgSanity())
+	// same digits in same order, other rounds: signature doesn't match
+	viewChange.Round, viewChange.NextRound, viewChange.PreparedRound = 12, 34, 5
+	assert.NotNil(t, viewChange.VerifyMsgSanity())
+}
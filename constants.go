@@ -1,8 +1,10 @@
 package main
 
 const (
-	MainnetRpcServerPort = "9334"
-	TestnetRpcServerPort = "9334"
-	MainnetWsServerPort  = "19334"
-	TestnetWsServerPort  = "19334"
+	MainnetRpcServerPort  = "9334"
+	TestnetRpcServerPort  = "9334"
+	MainnetWsServerPort   = "19334"
+	TestnetWsServerPort   = "19334"
+	MainnetGrpcServerPort = "29334"
+	TestnetGrpcServerPort = "29334"
 )
@@ -20,6 +20,8 @@ const (
 	BlockExisted
 	UnexpectedError
 	KeyExisted
+	InvalidSnapshotKey
+	InvalidCursor
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -36,10 +38,12 @@ var ErrCodeMessage = map[int]struct {
 	LvDbNotFound:  {-2002, "lvdb not found"},
 
 	// -3xxx blockchain
-	NotImplHashMethod: {-3000, "Data does not implement Hash() method"},
-	BlockExisted:      {-3001, "Block already existed"},
-	UnexpectedError:   {-3002, "Unexpected error"},
-	KeyExisted:        {-3003, "PubKey already existed in database"},
+	NotImplHashMethod:  {-3000, "Data does not implement Hash() method"},
+	BlockExisted:       {-3001, "Block already existed"},
+	UnexpectedError:    {-3002, "Unexpected error"},
+	KeyExisted:         {-3003, "PubKey already existed in database"},
+	InvalidSnapshotKey: {-3004, "Key is not a state snapshot key"},
+	InvalidCursor:      {-3005, "Cursor does not belong to the query"},
 }
 
 type DatabaseError struct {
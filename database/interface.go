@@ -11,6 +11,13 @@ type BatchData struct {
 	Value []byte
 }
 
+// StateSnapshotReader reads chain state of database as it was when reader was created,
+// database can keep changing meanwhile, reader must be released after use
+type StateSnapshotReader interface {
+	ReadAll() ([]BatchData, error)
+	Release()
+}
+
 // DatabaseInterface provides the interface that is used to store blocks, txs, or any data of Incognito network.
 type DatabaseInterface interface {
 	// basic function
@@ -27,6 +34,12 @@ type DatabaseInterface interface {
 	HasBlock(common.Hash) (bool, error)
 	DeleteBlock(common.Hash, uint64, byte) error
 
+	// Pruning of old block bodies (shard and beacon)
+	PruneBlockBody(common.Hash, interface{}) error
+	FetchBlockHeader(common.Hash) ([]byte, error)
+	StorePrunedHeight(bool, byte, uint64) error
+	FetchPrunedHeight(bool, byte) (uint64, error)
+
 	// Process on Incomming Cross shard data
 	StoreIncomingCrossShard(shardID byte, crossShardID byte, blkHeight uint64, crossBlkHash common.Hash) error
 	HasIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error
@@ -108,6 +121,10 @@ type DatabaseInterface interface {
 	GetCommitmentIndex(tokenID common.Hash, commitment []byte, shardID byte) (*big.Int, error)
 	GetCommitmentLength(tokenID common.Hash, shardID byte) (*big.Int, error)
 	GetOutcoinsByPubkey(tokenID common.Hash, pubkey []byte, shardID byte) ([][]byte, error)
+	StoreOneTimeOutputCoins(tokenID common.Hash, outputCoinArr [][]byte, shardID byte) error
+	GetOneTimeOutputCoins(tokenID common.Hash, shardID byte, fromIndex uint64, limit int) ([][]byte, error)
+	GetOneTimeOutputCoinsLength(tokenID common.Hash, shardID byte) (uint64, error)
+	DeleteLastOneTimeOutputCoins(tokenID common.Hash, shardID byte, count uint64) error
 	BackupCommitmentsOfPubkey(tokenID common.Hash, shardID byte, pubkey []byte) error
 	RestoreCommitmentsOfPubkey(tokenID common.Hash, shardID byte, pubkey []byte, commitments [][]byte) error
 	DeleteOutputCoin(tokenID common.Hash, publicKey []byte, outputCoinArr [][]byte, shardID byte) error
@@ -122,6 +139,11 @@ type DatabaseInterface interface {
 	StoreTxByPublicKey(publicKey []byte, txID common.Hash, shardID byte) error
 	GetTxByPublicKey(publicKey []byte) (map[byte][]common.Hash, error)
 
+	// Ordered indexes of txs for paginated queries
+	StoreTxQueryIndex(index TxQueryIndex) error
+	DeleteTxQueryIndex(index TxQueryIndex) error
+	ListTxQueryIndex(filter TxQueryFilter, cursor []byte, limit int) ([]TxQueryIndex, []byte, error)
+
 	// Fee estimator
 	StoreFeeEstimator([]byte, byte) error
 	GetFeeEstimator(byte) ([]byte, error)
@@ -147,6 +169,14 @@ type DatabaseInterface interface {
 	ListPrivacyCustomToken() ([][]byte, error)                        // get list all custom token which issued in network
 	PrivacyCustomTokenTxs(tokenID common.Hash) ([]common.Hash, error) // from token id get all custom txs
 
+	// Privacy token registry
+	StorePrivacyTokenInfo(tokenID common.Hash, info []byte) error
+	GetPrivacyTokenInfo(tokenID common.Hash) ([]byte, error)
+	ListPrivacyTokenInfo() ([][]byte, error)
+	DeletePrivacyTokenInfo(tokenID common.Hash) error
+	UpdatePrivacyTokenSupply(tokenID common.Hash, amount uint64, updateType string) error
+	GetPrivacyTokenSupply(tokenID common.Hash) (uint64, error)
+
 	// Privacy token for Cross Shard
 	StorePrivacyCustomTokenCrossShard(tokenID common.Hash, tokenValue []byte) error // store custom token cross shard privacy
 	ListPrivacyCustomTokenCrossShard() ([][]byte, error)
@@ -184,4 +214,8 @@ type DatabaseInterface interface {
 	BackupCommitteeReward(committeeAddress []byte, tokenID common.Hash) error        //shard
 	RestoreShardRewardRequest(epoch uint64, shardID byte, tokenID common.Hash) error //beacon
 	RestoreCommitteeReward(committeeAddress []byte, tokenID common.Hash) error       //shard
+
+	// State snapshot
+	ExportStateSnapshot() (StateSnapshotReader, error)
+	ImportStateSnapshot(data []BatchData) error
 }
@@ -22,6 +22,7 @@ var (
 	blockKeyPrefix          = []byte("b-")
 	blockHeaderKeyPrefix    = []byte("bh-")
 	blockKeyIdxPrefix       = []byte("i-")
+	prunedHeightPrefix      = []byte("pruned-")
 	crossShardKeyPrefix     = []byte("csh-")
 	nextCrossShardKeyPrefix = []byte("ncsh-")
 	shardPrefix             = []byte("shd-")
@@ -32,6 +33,8 @@ var (
 	serialNumbersPrefix          = []byte("serinalnumbers-")
 	commitmentsPrefix            = []byte("commitments-")
 	outcoinsPrefix               = []byte("outcoins-")
+	oneTimeOutcoinsPrefix        = []byte("otcoins-")
+	oneTimeOutcoinsLenPrefix     = []byte("otcoinslen-")
 	snderivatorsPrefix           = []byte("snderivators-")
 	bestBlockKey                 = []byte("bestBlock")
 	feeEstimator                 = []byte("feeEstimator")
@@ -42,6 +45,9 @@ var (
 	TokenPaymentAddressPrefix    = []byte("token-paymentaddress-")
 	tokenInitPrefix              = []byte("token-init-")
 	privacyTokenInitPrefix       = []byte("privacy-token-init-")
+	privacyTokenInfoPrefix       = []byte("privacy-token-info-")
+	privacyTokenSupplyPrefix     = []byte("privacy-token-supply-")
+	SkippedTokenBurnPrefix       = []byte("skipped-token-burn-")
 	rewared                      = []byte("reward")
 
 	// multisigs
@@ -53,6 +59,13 @@ var (
 	decentralizedBridgePrefix = []byte("decentralizedbridge-")
 	ethTxHashIssued           = []byte("ethtxhashissued-")
 
+	// ordered tx indexes for paginated queries
+	txQueryShardPrefix    = []byte("txq-s-")
+	txQueryTypePrefix     = []byte("txq-t-")
+	txQueryMetadataPrefix = []byte("txq-m-")
+	txQueryTokenPrefix    = []byte("txq-k-")
+	txQueryReceiverPrefix = []byte("txq-r-")
+
 	// Incognito -> Ethereum relayer
 	burnConfirmPrefix = []byte("burnConfirm-")
 
@@ -129,6 +142,8 @@ func (db db) GetKey(keyType string, key common.Hash) []byte {
 	switch keyType {
 	case string(blockKeyPrefix):
 		dbkey = append(blockKeyPrefix, key[:]...)
+	case string(blockHeaderKeyPrefix):
+		dbkey = append(blockHeaderKeyPrefix, key[:]...)
 	case string(blockKeyIdxPrefix):
 		dbkey = append(blockKeyIdxPrefix, key[:]...)
 	case string(serialNumbersPrefix):
@@ -732,3 +732,67 @@ func TestDb_StoreAcceptedShardToBeacon(t *testing.T) {
 	err = db.HasAcceptedShardToBeacon(0, common.Hash{})
 	assert.NotEqual(t, nil, err)
 }
+
+func TestDb_ListTxQueryIndex(t *testing.T) {
+	if db == nil {
+		return
+	}
+	shardID := byte(7)
+	receiver := []byte{7, 7, 7}
+	tokenID := common.HashH([]byte("token"))
+	indexes := []database.TxQueryIndex{}
+	for height := uint64(1); height <= 5; height++ {
+		for i := 0; i < 2; i++ {
+			index := database.TxQueryIndex{
+				TxHash:       common.HashH([]byte(strconv.Itoa(int(height)*10 + i))),
+				ShardID:      shardID,
+				BlockHeight:  height,
+				IndexInBlock: i,
+				Type:         common.TxNormalType,
+				MetadataType: metadata.InvalidMeta,
+				TokenID:      common.PRVCoinID,
+			}
+			if i == 1 {
+				index.Type = common.TxCustomTokenPrivacyType
+				index.TokenID = tokenID
+				index.Receivers = [][]byte{receiver}
+			}
+			err := db.StoreTxQueryIndex(index)
+			assert.Equal(t, nil, err)
+			index.Receivers = nil
+			indexes = append(indexes, index)
+		}
+	}
+
+	// Pages of a height range
+	filter := database.TxQueryFilter{ShardID: shardID, FromHeight: 2, ToHeight: 4}
+	page, cursor, err := db.ListTxQueryIndex(filter, nil, 4)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, indexes[2:6], page)
+	assert.NotEqual(t, 0, len(cursor))
+	page, cursor, err = db.ListTxQueryIndex(filter, cursor, 4)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, indexes[6:8], page)
+	assert.Equal(t, 0, len(cursor))
+
+	// Token index and receiver index
+	page, _, err = db.ListTxQueryIndex(database.TxQueryFilter{ShardID: shardID, TokenID: &tokenID}, nil, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, len(page))
+	page, cursor, err = db.ListTxQueryIndex(database.TxQueryFilter{Receiver: receiver, FromHeight: 3}, nil, 2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []database.TxQueryIndex{indexes[5], indexes[7]}, page)
+	_, _, err = db.ListTxQueryIndex(filter, cursor, 2)
+	assert.NotEqual(t, nil, err)
+
+	// Deleted txs are not listed anymore
+	deleted := indexes[9]
+	deleted.Receivers = [][]byte{receiver}
+	assert.Equal(t, nil, db.DeleteTxQueryIndex(deleted))
+	page, _, err = db.ListTxQueryIndex(database.TxQueryFilter{ShardID: shardID, Type: common.TxCustomTokenPrivacyType}, nil, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 4, len(page))
+	page, _, err = db.ListTxQueryIndex(database.TxQueryFilter{Receiver: receiver}, nil, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 4, len(page))
+}
@@ -0,0 +1,91 @@
+package lvdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+Prune body of a stored block (shard or beacon), block index and block header are kept
+- Key: bh-{blockHash}
+- Value: {header}
+Record b-{blockHash} is deleted
+*/
+func (db *db) PruneBlockBody(hash common.Hash, header interface{}) error {
+	val, err := json.Marshal(header)
+	if err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "json.Marshal"))
+	}
+	batch := new(leveldb.Batch)
+	// key: bh-{blockhash}:header
+	batch.Put(db.GetKey(string(blockHeaderKeyPrefix), hash), val)
+	// key: b-{blockhash}:block
+	batch.Delete(db.GetKey(string(blockKeyPrefix), hash))
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	return nil
+}
+
+/*
+Query header of a block by hash, header of a pruned block is stored under bh-{blockHash},
+otherwise whole block is returned
+*/
+func (db *db) FetchBlockHeader(hash common.Hash) ([]byte, error) {
+	keyHeader := db.GetKey(string(blockHeaderKeyPrefix), hash)
+	if ok, _ := db.HasValue(keyHeader); ok {
+		return db.Get(keyHeader)
+	}
+	block, err := db.Get(db.GetKey(string(blockKeyPrefix), hash))
+	if err != nil {
+		return nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	return block, nil
+}
+
+/*
+Store height of the highest pruned block
+- Key: pruned-{beacon} or pruned-{shardID}
+- Value: {height}
+*/
+func (db *db) StorePrunedHeight(isBeacon bool, shardID byte, height uint64) error {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, height)
+	if err := db.Put(getPrunedHeightKey(isBeacon, shardID), buf); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.Put"))
+	}
+	return nil
+}
+
+/*
+Query height of the highest pruned block, return 0 if chain has never been pruned
+*/
+func (db *db) FetchPrunedHeight(isBeacon bool, shardID byte) (uint64, error) {
+	key := getPrunedHeightKey(isBeacon, shardID)
+	if ok, _ := db.HasValue(key); !ok {
+		return 0, nil
+	}
+	b, err := db.Get(key)
+	if err != nil {
+		return 0, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.Get"))
+	}
+	var height uint64
+	if err := binary.Read(bytes.NewReader(b[:8]), binary.LittleEndian, &height); err != nil {
+		return 0, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "binary.Read"))
+	}
+	return height, nil
+}
+
+func getPrunedHeightKey(isBeacon bool, shardID byte) []byte {
+	key := append([]byte{}, prunedHeightPrefix...)
+	if isBeacon {
+		return append(key, beaconPrefix...)
+	}
+	return append(key, shardID)
+}
@@ -0,0 +1,105 @@
+package lvdb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// snapshotPrefixes are prefixes of keys which make up chain state, blocks,
+// block indexes, tx indexes and backup data are not part of a state snapshot
+var snapshotPrefixes = [][]byte{
+	committeePrefix,
+	heightPrefix,
+	crossShardKeyPrefix,
+	nextCrossShardKeyPrefix,
+	shardToBeaconKeyPrefix,
+	serialNumbersPrefix,
+	commitmentsPrefix,
+	outcoinsPrefix,
+	oneTimeOutcoinsPrefix,
+	oneTimeOutcoinsLenPrefix,
+	snderivatorsPrefix,
+	TokenPrefix,
+	PrivacyTokenPrefix,
+	PrivacyTokenCrossShardPrefix,
+	bridgePrefix,
+	centralizedBridgePrefix,
+	decentralizedBridgePrefix,
+	ethTxHashIssued,
+	burnConfirmPrefix,
+	ShardRequestRewardPrefix,
+	BeaconBlockProposeCounterPrefix,
+	CommitteeRewardPrefix,
+}
+
+func isSnapshotKey(key []byte) bool {
+	for _, prefix := range snapshotPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type stateSnapshotReader struct {
+	snapshot *leveldb.Snapshot
+}
+
+// ExportStateSnapshot - return a reader of all key/value pairs of chain state: serial numbers,
+// commitments, snd derivators, tokens, bridge, rewards and committees by height,
+// it is cheap to create so caller can read state later without blocking writes
+func (db *db) ExportStateSnapshot() (database.StateSnapshotReader, error) {
+	snapshot, err := db.lvdb.GetSnapshot()
+	if err != nil {
+		return nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.GetSnapshot"))
+	}
+	return &stateSnapshotReader{snapshot: snapshot}, nil
+}
+
+func (reader *stateSnapshotReader) ReadAll() ([]database.BatchData, error) {
+	result := []database.BatchData{}
+	exported := make(map[string]struct{})
+	for _, prefix := range snapshotPrefixes {
+		iter := reader.snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+		for iter.Next() {
+			// prefixes may overlap (ex: token- and token-init-)
+			if _, ok := exported[string(iter.Key())]; ok {
+				continue
+			}
+			key := make([]byte, len(iter.Key()))
+			copy(key, iter.Key())
+			value := make([]byte, len(iter.Value()))
+			copy(value, iter.Value())
+			exported[string(key)] = struct{}{}
+			result = append(result, database.BatchData{Key: key, Value: value})
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "iter.Error"))
+		}
+	}
+	return result, nil
+}
+
+func (reader *stateSnapshotReader) Release() {
+	reader.snapshot.Release()
+}
+
+// ImportStateSnapshot - write key/value pairs exported by ExportStateSnapshot,
+// it rejects keys which are not part of chain state
+func (db *db) ImportStateSnapshot(data []database.BatchData) error {
+	for _, item := range data {
+		if !isSnapshotKey(item.Key) {
+			return database.NewDatabaseError(database.InvalidSnapshotKey, fmt.Errorf("key %x", item.Key))
+		}
+	}
+	if err := db.PutBatch(data); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.PutBatch"))
+	}
+	return nil
+}
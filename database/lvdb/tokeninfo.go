@@ -0,0 +1,113 @@
+package lvdb
+
+import (
+	"encoding/binary"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// PrivacyTokenInfo is the registry record of a privacy custom token, stored when beacon confirms its tx init token
+type PrivacyTokenInfo struct {
+	TokenID      *common.Hash `json:"tokenId"`
+	Name         string       `json:"name"`
+	Symbol       string       `json:"symbol"`
+	Decimals     uint8        `json:"decimals"`
+	Description  string       `json:"description"`
+	IssuerKey    []byte       `json:"issuerKey"`
+	SupplyPolicy byte         `json:"supplyPolicy"`
+	Mintable     bool         `json:"mintable"` // bridge token
+	InitAmount   uint64       `json:"initAmount"`
+	ShardID      byte         `json:"shardId"`
+	InitTxHash   *common.Hash `json:"initTxHash"`
+}
+
+// StorePrivacyTokenInfo - store registry record of privacy custom token
+// Key: privacy-token-info-{tokenID}
+// Value: json of PrivacyTokenInfo
+func (db *db) StorePrivacyTokenInfo(tokenID common.Hash, info []byte) error {
+	key := append(privacyTokenInfoPrefix, tokenID[:]...)
+	if err := db.Put(key, info); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+// GetPrivacyTokenInfo - get registry record of privacy custom token, return nil if token has no record
+func (db *db) GetPrivacyTokenInfo(tokenID common.Hash) ([]byte, error) {
+	key := append(privacyTokenInfoPrefix, tokenID[:]...)
+	info, err := db.lvdb.Get(key, nil)
+	if err != nil && err != lvdberr.ErrNotFound {
+		return nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	return info, nil
+}
+
+func (db *db) ListPrivacyTokenInfo() ([][]byte, error) {
+	result := make([][]byte, 0)
+	iter := db.lvdb.NewIterator(util.BytesPrefix(privacyTokenInfoPrefix), nil)
+	for iter.Next() {
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		result = append(result, value)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	return result, nil
+}
+
+func (db *db) DeletePrivacyTokenInfo(tokenID common.Hash) error {
+	key := append(privacyTokenInfoPrefix, tokenID[:]...)
+	if err := db.Delete(key); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.delete"))
+	}
+	return nil
+}
+
+// UpdatePrivacyTokenSupply - add ("+") or subtract ("-") amount to supply of privacy custom token,
+// supply is updated by beacon instructions so it is the same on all nodes whatever shards they store
+// Key: privacy-token-supply-{tokenID}
+// Value: supply
+func (db *db) UpdatePrivacyTokenSupply(tokenID common.Hash, amount uint64, updateType string) error {
+	supply, err := db.GetPrivacyTokenSupply(tokenID)
+	if err != nil {
+		return err
+	}
+	if updateType == "+" {
+		if supply+amount < supply {
+			return database.NewDatabaseError(database.UnexpectedError, errors.Errorf("supply of token %s overflows", tokenID.String()))
+		}
+		supply += amount
+	} else if amount > supply {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Errorf("supply %d of token %s is less than subtracted amount %d", supply, tokenID.String(), amount))
+	} else {
+		supply -= amount
+	}
+
+	key := append(privacyTokenSupplyPrefix, tokenID[:]...)
+	supplyBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(supplyBytes, supply)
+	if err := db.Put(key, supplyBytes); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+// GetPrivacyTokenSupply - get circulating supply of privacy custom token, issued minus burned units in all shards
+func (db *db) GetPrivacyTokenSupply(tokenID common.Hash) (uint64, error) {
+	key := append(privacyTokenSupplyPrefix, tokenID[:]...)
+	supplyBytes, err := db.lvdb.Get(key, nil)
+	if err != nil && err != lvdberr.ErrNotFound {
+		return 0, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	if len(supplyBytes) != 8 {
+		return 0, nil
+	}
+	return binary.LittleEndian.Uint64(supplyBytes), nil
+}
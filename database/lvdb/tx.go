@@ -1,6 +1,8 @@
 package lvdb
 
 import (
+	"encoding/binary"
+
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"strconv"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"math/big"
 
 	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -321,6 +324,104 @@ func (db *db) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []byte, shardID by
 	return arrDatabyPubkey, nil
 }
 
+func getOneTimeOutcoinsKey(prefix []byte, tokenID common.Hash, shardID byte) []byte {
+	key := append([]byte{}, prefix...)
+	key = append(key, tokenID[:]...)
+	return append(key, shardID)
+}
+
+// GetOneTimeOutputCoinsLength - number of output coins with one-time public key stored in shard
+func (db *db) GetOneTimeOutputCoinsLength(tokenID common.Hash, shardID byte) (uint64, error) {
+	key := getOneTimeOutcoinsKey(oneTimeOutcoinsLenPrefix, tokenID, shardID)
+	if ok, _ := db.HasValue(key); !ok {
+		return 0, nil
+	}
+	value, err := db.Get(key)
+	if err != nil {
+		return 0, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.Get"))
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// StoreOneTimeOutputCoins - store output coins with one-time public key of shard in the order they are added,
+// so a readonly key scans only coins added since its last scan
+// key: [oneTimeOutcoinsPrefix][tokenID][shardID][index]
+// value: output coin
+func (db *db) StoreOneTimeOutputCoins(tokenID common.Hash, outputCoinArr [][]byte, shardID byte) error {
+	if len(outputCoinArr) == 0 {
+		return nil
+	}
+	length, err := db.GetOneTimeOutputCoinsLength(tokenID, shardID)
+	if err != nil {
+		return err
+	}
+	key := getOneTimeOutcoinsKey(oneTimeOutcoinsPrefix, tokenID, shardID)
+	batch := new(leveldb.Batch)
+	for _, outputCoin := range outputCoinArr {
+		keyTemp := make([]byte, len(key), len(key)+8)
+		copy(keyTemp, key)
+		keyTemp = append(keyTemp, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(keyTemp[len(key):], length)
+		batch.Put(keyTemp, outputCoin)
+		length++
+	}
+	lengthBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(lengthBytes, length)
+	batch.Put(getOneTimeOutcoinsKey(oneTimeOutcoinsLenPrefix, tokenID, shardID), lengthBytes)
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	return nil
+}
+
+// DeleteLastOneTimeOutputCoins - delete the last count output coins with one-time public key of shard, used when a block is reverted
+func (db *db) DeleteLastOneTimeOutputCoins(tokenID common.Hash, shardID byte, count uint64) error {
+	length, err := db.GetOneTimeOutputCoinsLength(tokenID, shardID)
+	if err != nil {
+		return err
+	}
+	if count > length {
+		count = length
+	}
+	key := getOneTimeOutcoinsKey(oneTimeOutcoinsPrefix, tokenID, shardID)
+	batch := new(leveldb.Batch)
+	for index := length - count; index < length; index++ {
+		keyTemp := make([]byte, len(key)+8)
+		copy(keyTemp, key)
+		binary.BigEndian.PutUint64(keyTemp[len(key):], index)
+		batch.Delete(keyTemp)
+	}
+	lengthBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(lengthBytes, length-count)
+	batch.Put(getOneTimeOutcoinsKey(oneTimeOutcoinsLenPrefix, tokenID, shardID), lengthBytes)
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	return nil
+}
+
+// GetOneTimeOutputCoins - get at most limit output coins with one-time public key of shard from index fromIndex
+func (db *db) GetOneTimeOutputCoins(tokenID common.Hash, shardID byte, fromIndex uint64, limit int) ([][]byte, error) {
+	key := getOneTimeOutcoinsKey(oneTimeOutcoinsPrefix, tokenID, shardID)
+	start := make([]byte, len(key)+8)
+	copy(start, key)
+	binary.BigEndian.PutUint64(start[len(key):], fromIndex)
+	iterRange := util.BytesPrefix(key)
+	iterRange.Start = start
+	iter := db.lvdb.NewIterator(iterRange, nil)
+	defer iter.Release()
+	outputCoins := make([][]byte, 0)
+	for len(outputCoins) < limit && iter.Next() {
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		outputCoins = append(outputCoins, value)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "iter.Error"))
+	}
+	return outputCoins, nil
+}
+
 // CleanCommitments - clear all list commitments in DB
 func (db *db) CleanCommitments() error {
 	iter := db.lvdb.NewIterator(util.BytesPrefix(commitmentsPrefix), nil)
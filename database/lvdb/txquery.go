@@ -0,0 +1,178 @@
+package lvdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+/*
+Ordered indexes of txs, keys end with big endian {height}{index in block} so that iterating a prefix
+lists txs in chain order:
+- txq-s-{shardID}{height}{index}
+- txq-t-{shardID}{len(type)}{type}{height}{index}
+- txq-m-{shardID}{metadataType}{height}{index}
+- txq-k-{shardID}{tokenID}{height}{index} (only txs of custom tokens)
+- txq-r-{len(pubkey)}{pubkey}{shardID}{height}{index}
+- Value: {TxQueryIndex} as json
+A list query iterates the most selective index of its filter, other conditions of filter are checked on values
+*/
+
+// txQueryMaxScan is max number of index entries a list query scans, so a sparse filter does not walk a whole index
+var txQueryMaxScan = 10000
+
+// getTxQueryHeightKey returns key of a tx at given position in an index
+func getTxQueryHeightKey(prefix []byte, height uint64, indexInBlock int) []byte {
+	key := make([]byte, len(prefix)+12)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], height)
+	binary.BigEndian.PutUint32(key[len(prefix)+8:], uint32(indexInBlock))
+	return key
+}
+
+func getTxQueryShardPrefix(shardID byte) []byte {
+	return append(append([]byte{}, txQueryShardPrefix...), shardID)
+}
+
+func getTxQueryTypePrefix(shardID byte, txType string) []byte {
+	prefix := append(append([]byte{}, txQueryTypePrefix...), shardID, byte(len(txType)))
+	return append(prefix, []byte(txType)...)
+}
+
+func getTxQueryMetadataPrefix(shardID byte, metaType int) []byte {
+	prefix := append(append([]byte{}, txQueryMetadataPrefix...), shardID)
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(metaType))
+	return append(prefix, buf...)
+}
+
+func getTxQueryTokenPrefix(shardID byte, tokenID common.Hash) []byte {
+	prefix := append(append([]byte{}, txQueryTokenPrefix...), shardID)
+	return append(prefix, tokenID[:]...)
+}
+
+func getTxQueryReceiverPrefix(pubkey []byte) []byte {
+	prefix := append(append([]byte{}, txQueryReceiverPrefix...), byte(len(pubkey)))
+	return append(prefix, pubkey...)
+}
+
+// getTxQueryKeys returns keys of a tx in every index it belongs to
+func getTxQueryKeys(index database.TxQueryIndex) [][]byte {
+	keys := [][]byte{
+		getTxQueryHeightKey(getTxQueryShardPrefix(index.ShardID), index.BlockHeight, index.IndexInBlock),
+		getTxQueryHeightKey(getTxQueryTypePrefix(index.ShardID, index.Type), index.BlockHeight, index.IndexInBlock),
+		getTxQueryHeightKey(getTxQueryMetadataPrefix(index.ShardID, index.MetadataType), index.BlockHeight, index.IndexInBlock),
+	}
+	if !index.TokenID.IsEqual(&common.PRVCoinID) {
+		keys = append(keys, getTxQueryHeightKey(getTxQueryTokenPrefix(index.ShardID, index.TokenID), index.BlockHeight, index.IndexInBlock))
+	}
+	for _, receiver := range index.Receivers {
+		prefix := append(getTxQueryReceiverPrefix(receiver), index.ShardID)
+		keys = append(keys, getTxQueryHeightKey(prefix, index.BlockHeight, index.IndexInBlock))
+	}
+	return keys
+}
+
+// StoreTxQueryIndex stores a tx in every ordered index it belongs to
+func (db *db) StoreTxQueryIndex(index database.TxQueryIndex) error {
+	val, err := json.Marshal(index)
+	if err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "json.Marshal"))
+	}
+	batch := new(leveldb.Batch)
+	for _, key := range getTxQueryKeys(index) {
+		batch.Put(key, val)
+	}
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	return nil
+}
+
+// DeleteTxQueryIndex removes a tx from every ordered index, receivers of index must be the ones it was stored with
+func (db *db) DeleteTxQueryIndex(index database.TxQueryIndex) error {
+	batch := new(leveldb.Batch)
+	for _, key := range getTxQueryKeys(index) {
+		batch.Delete(key)
+	}
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	return nil
+}
+
+/*
+ListTxQueryIndex returns at most limit txs matching filter in chain order, starting after cursor (nil for the first page).
+The returned cursor is key of the last returned tx, it is nil when there is no more tx to list.
+A query stops after scanning txQueryMaxScan entries, then the page may hold less than limit txs (even none)
+and the cursor is key of the last scanned entry
+*/
+func (db *db) ListTxQueryIndex(filter database.TxQueryFilter, cursor []byte, limit int) ([]database.TxQueryIndex, []byte, error) {
+	var prefix []byte
+	switch {
+	case filter.Receiver != nil:
+		prefix = getTxQueryReceiverPrefix(filter.Receiver)
+	case filter.TokenID != nil && !filter.TokenID.IsEqual(&common.PRVCoinID):
+		prefix = getTxQueryTokenPrefix(filter.ShardID, *filter.TokenID)
+	case filter.MetadataType != 0:
+		prefix = getTxQueryMetadataPrefix(filter.ShardID, filter.MetadataType)
+	case filter.Type != "":
+		prefix = getTxQueryTypePrefix(filter.ShardID, filter.Type)
+	default:
+		prefix = getTxQueryShardPrefix(filter.ShardID)
+	}
+
+	iterRange := util.BytesPrefix(prefix)
+	if filter.Receiver == nil {
+		// Receiver index is ordered by shard first, height range is only checked on values
+		iterRange.Start = getTxQueryHeightKey(prefix, filter.FromHeight, 0)
+		if filter.ToHeight > 0 && filter.ToHeight < ^uint64(0) {
+			iterRange.Limit = getTxQueryHeightKey(prefix, filter.ToHeight+1, 0)
+		}
+	}
+	if cursor != nil {
+		if !bytes.HasPrefix(cursor, prefix) {
+			return nil, nil, database.NewDatabaseError(database.InvalidCursor, errors.Errorf("cursor %x", cursor))
+		}
+		// Smallest key after cursor
+		start := append(append([]byte{}, cursor...), 0)
+		if bytes.Compare(start, iterRange.Start) > 0 {
+			iterRange.Start = start
+		}
+	}
+
+	iter := db.lvdb.NewIterator(iterRange, nil)
+	defer iter.Release()
+	result := make([]database.TxQueryIndex, 0)
+	var lastKey []byte
+	scanned := 0
+	for iter.Next() {
+		index := database.TxQueryIndex{}
+		if err := json.Unmarshal(iter.Value(), &index); err != nil {
+			return nil, nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "json.Unmarshal"))
+		}
+		if filter.Match(index) {
+			if limit > 0 && len(result) == limit {
+				// A matching tx is left for the next page
+				return result, lastKey, nil
+			}
+			result = append(result, index)
+			lastKey = append([]byte{}, iter.Key()...)
+		}
+		scanned++
+		if scanned == txQueryMaxScan {
+			// Rest of index is left for the next page
+			return result, append([]byte{}, iter.Key()...), nil
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, nil, database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "iter.Error"))
+	}
+	return result, nil, nil
+}
@@ -0,0 +1,46 @@
+package lvdb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_db_ListTxQueryIndexMaxScan(t *testing.T) {
+	testDB, err := openTestDB("Test_db_ListTxQueryIndexMaxScan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	db := &db{lvdb: testDB}
+	defer func(maxScan int) { txQueryMaxScan = maxScan }(txQueryMaxScan)
+	txQueryMaxScan = 3
+
+	receiver := []byte{7, 7, 7}
+	indexes := []database.TxQueryIndex{}
+	for height := uint64(1); height <= 4; height++ {
+		index := database.TxQueryIndex{
+			TxHash:      common.HashH(common.Uint64ToBytes(height)),
+			BlockHeight: height,
+			Type:        common.TxNormalType,
+			TokenID:     common.PRVCoinID,
+			Receivers:   [][]byte{receiver},
+		}
+		assert.Nil(t, db.StoreTxQueryIndex(index))
+		index.Receivers = nil
+		indexes = append(indexes, index)
+	}
+
+	// Height range of receiver index is only checked on values, a page stops after scanning 3 entries
+	filter := database.TxQueryFilter{Receiver: receiver, FromHeight: 3}
+	page, cursor, err := db.ListTxQueryIndex(filter, nil, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []database.TxQueryIndex{indexes[2]}, page)
+	assert.NotEqual(t, 0, len(cursor))
+	page, cursor, err = db.ListTxQueryIndex(filter, cursor, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []database.TxQueryIndex{indexes[3]}, page)
+	assert.Equal(t, 0, len(cursor))
+}
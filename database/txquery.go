@@ -0,0 +1,46 @@
+package database
+
+import "github.com/incognitochain/incognito-chain/common"
+
+// TxQueryIndex locates a tx in the chain, it is stored in ordered indexes so that txs can be listed page by page
+type TxQueryIndex struct {
+	TxHash       common.Hash
+	ShardID      byte
+	BlockHeight  uint64
+	IndexInBlock int
+	Type         string
+	MetadataType int
+	TokenID      common.Hash
+	// Receivers are pubkeys of receivers of tx, they are keys of the receiver index and not stored in values
+	Receivers [][]byte `json:"-"`
+}
+
+// TxQueryFilter selects txs to list, zero value of a field means any value
+type TxQueryFilter struct {
+	ShardID    byte
+	FromHeight uint64
+	// ToHeight is inclusive, 0 means no upper bound
+	ToHeight     uint64
+	Type         string
+	MetadataType int
+	TokenID      *common.Hash
+	// Receiver lists txs sent to a pubkey from any shard, ShardID is ignored then
+	Receiver []byte
+}
+
+// Match tells if a tx satisfies every condition of filter
+func (filter TxQueryFilter) Match(index TxQueryIndex) bool {
+	if index.BlockHeight < filter.FromHeight || (filter.ToHeight > 0 && index.BlockHeight > filter.ToHeight) {
+		return false
+	}
+	if filter.Type != "" && index.Type != filter.Type {
+		return false
+	}
+	if filter.MetadataType != 0 && index.MetadataType != filter.MetadataType {
+		return false
+	}
+	if filter.TokenID != nil && !index.TokenID.IsEqual(filter.TokenID) {
+		return false
+	}
+	return true
+}
@@ -12,12 +12,14 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/connmanager"
+	"github.com/incognitochain/incognito-chain/consensus/instantseal"
 	"github.com/incognitochain/incognito-chain/consensus/mubft"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/netsync"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -46,6 +48,7 @@ var (
 	privacyLogger     = backendLog.Logger("Privacy log", false)
 	randomLogger      = backendLog.Logger("RandomAPI log", false)
 	bridgeLogger      = backendLog.Logger("DeBridge log", false)
+	pubsubLogger      = backendLog.Logger("PubSub log", false)
 )
 
 // logWriter implements an io.Writer that outputs to both standard output and
@@ -72,6 +75,7 @@ func init() {
 	wallet.Logger.Init(walletLogger)
 	blockchain.Logger.Init(blockchainLogger)
 	mubft.Logger.Init(consensusLogger)
+	instantseal.Logger.Init(consensusLogger)
 	mempool.Logger.Init(mempoolLogger)
 	main2.Logger.Init(randomLogger)
 	transaction.Logger.Init(transactionLogger)
@@ -79,6 +83,7 @@ func init() {
 	databasemp.Logger.Init(dbmpLogger)
 	blockchain.BLogger.Init(bridgeLogger)
 	rpcserver.BLogger.Init(bridgeLogger)
+	pubsub.Logger.Init(pubsubLogger)
 
 }
 
@@ -101,6 +106,7 @@ var subsystemLoggers = map[string]common.Logger{
 	"PRIV": privacyLogger,
 	"DBMP": dbmpLogger,
 	"DEBR": bridgeLogger,
+	"PUBS": pubsubLogger,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
@@ -5,7 +5,7 @@ const (
 	// contextual transaction information provided in a transaction store
 	// when it has not yet been mined into a block.
 	UnminedHeight = 0x7fffffffffffffff
-	MaxVersion    = 1
+	MaxVersion    = 7
 )
 
 // Beacon pool
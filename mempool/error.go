@@ -33,6 +33,8 @@ const (
 	WrongShardIDError
 	HashError
 	RejectReplacementTx
+	InvalidDumpFileNameError
+	RejectExpiredTx
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -63,6 +65,8 @@ var ErrCodeMessage = map[int]struct {
 	UnmarshalError:                    {-1020, "Unmarshal Error"},
 	HashError:                         {-1021, "Hash Error"},
 	RejectReplacementTx:               {-1022, "Replacement or Cancel Tx Error"},
+	InvalidDumpFileNameError:          {-1023, "Invalid Dump File Name Error"},
+	RejectExpiredTx:                   {-1024, "Reject Expired Tx"},
 }
 
 type MempoolTxError struct {
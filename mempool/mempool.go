@@ -45,6 +45,10 @@ type Config struct {
 	UserKeyset            *incognitokey.KeySet
 	PubSubManager         *pubsub.PubSubManager
 	RoleInCommitteesEvent pubsub.EventChannel
+	NewBeaconBlockEvent   pubsub.EventChannel // new beacon block, expired txs are evicted from pool on it
+	RevertedBlockEvent    pubsub.EventChannel // reverted shard block, its txs are returned to pool
+	VerificationCache     *VerificationCache  // cache of txs verified by itself, shared between mempool and temp pool for block validation
+	DataDir               string              // directory of node, files of DumpPool and LoadPool are in it
 }
 
 // TxDesc is transaction message in mempool
@@ -91,6 +95,11 @@ func (tp *TxPool) Init(cfg *Config) {
 	tp.DuplicateTxs = make(map[common.Hash]uint64)
 	_, subChanRole, _ := tp.config.PubSubManager.RegisterNewSubscriber(pubsub.ShardRoleTopic)
 	tp.config.RoleInCommitteesEvent = subChanRole
+	_, subChanBeaconBlock, _ := tp.config.PubSubManager.RegisterNewSubscriber(pubsub.NewBeaconBlockTopic)
+	tp.config.NewBeaconBlockEvent = subChanBeaconBlock
+	// reverted blocks are handled in order they are reverted
+	_, subChanShardBlockDisconnected, _ := tp.config.PubSubManager.RegisterNewOrderedSubscriber([]string{pubsub.ShardBlockDisconnectedTopic})
+	tp.config.RevertedBlockEvent = subChanShardBlockDisconnected
 	tp.ScanTime = defaultScanTime
 	tp.IsUnlockMempool = defaultIsUnlockMempool
 	tp.IsBlockGenStarted = defaultIsBlockGenStarted
@@ -154,9 +163,83 @@ func (tp *TxPool) Start(cQuit chan struct{}) {
 					tp.RoleInCommittees = shardID
 				}()
 			}
+		case <-tp.config.NewBeaconBlockEvent:
+			{
+				// beacon block may only be in beacon pool, use height of best state
+				go tp.removeExpiredTxs(tp.config.BlockChain.GetBeaconHeight())
+			}
+		case msg, ok := <-tp.config.RevertedBlockEvent:
+			{
+				if !ok {
+					// subscriber fell too far behind, txs of reverted blocks missed meanwhile are not returned
+					Logger.log.Error("Reverted shard blocks are missed by mempool, subscribe again")
+					_, tp.config.RevertedBlockEvent, _ = tp.config.PubSubManager.RegisterNewOrderedSubscriber([]string{pubsub.ShardBlockDisconnectedTopic})
+					continue
+				}
+				shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+				if !ok {
+					continue
+				}
+				tp.returnDisconnectedTxs(shardBlock.Body.Transactions)
+			}
 		}
 	}
 }
+
+// returnDisconnectedTxs puts txs of a reverted shard block back into pool,
+// txs which are no longer valid (ex: created by block producer, double spent by the replacing chain) are rejected
+func (tp *TxPool) returnDisconnectedTxs(txs []metadata.Transaction) {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	for _, tx := range txs {
+		if tx.GetType() == common.TxRewardType || tx.GetType() == common.TxReturnStakingType {
+			continue
+		}
+		if tp.isTxInPool(tx.Hash()) {
+			continue
+		}
+		_, _, err := tp.maybeAcceptTransaction(tx, tp.config.PersistMempool, true)
+		if err != nil {
+			Logger.log.Infof("Tx %+v of disconnected block is not returned to pool, error %+v", tx.Hash().String(), err)
+			tp.publishTxStatus(newTxRejectedEvent(*tx.Hash(), err))
+			continue
+		}
+		Logger.log.Infof("Return tx %+v of disconnected block to pool", tx.Hash().String())
+		tp.publishTxStatus(&TxStatusEvent{TxHash: *tx.Hash(), Status: TxStatusReturned})
+		if tp.IsBlockGenStarted && tp.IsUnlockMempool {
+			go func(tx metadata.Transaction) {
+				tp.CPendingTxs <- tx
+			}(tx)
+		}
+	}
+}
+
+// removeExpiredTxs remove txs which can not be included in a block at beacon height beaconHeight
+func (tp *TxPool) removeExpiredTxs(beaconHeight uint64) {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	txsToBeRemoved := []*TxDesc{}
+	for _, txDesc := range tp.pool {
+		if txDesc.Desc.Tx.IsExpired(beaconHeight) {
+			txsToBeRemoved = append(txsToBeRemoved, txDesc)
+		}
+	}
+	for _, txDesc := range txsToBeRemoved {
+		txHash := *txDesc.Desc.Tx.Hash()
+		tp.removeTx(txDesc.Desc.Tx)
+		tp.removeCandidateByTxHash(txHash)
+		tp.removeTokenIDByTxHash(txHash)
+		tp.config.DataBaseMempool.RemoveTransaction(txDesc.Desc.Tx.Hash())
+		Logger.log.Infof("Remove tx %+v expired at beacon height %+v from pool", txHash.String(), txDesc.Desc.Tx.GetExpiryHeight())
+		tp.publishTxStatus(&TxStatusEvent{TxHash: txHash, Status: TxStatusExpired})
+	}
+	if len(txsToBeRemoved) > 0 {
+		size := len(tp.pool)
+		go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+			metrics.Measurement:      metrics.PoolSize,
+			metrics.MeasurementValue: float64(size)})
+	}
+}
 func (tp *TxPool) monitorPool() {
 	if tp.config.TxLifeTime == 0 {
 		return
@@ -178,6 +261,7 @@ func (tp *TxPool) monitorPool() {
 			tp.removeCandidateByTxHash(txHash)
 			tp.removeTokenIDByTxHash(txHash)
 			tp.config.DataBaseMempool.RemoveTransaction(txDesc.Desc.Tx.Hash())
+			tp.publishTxStatus(&TxStatusEvent{TxHash: txHash, Status: TxStatusEvicted})
 			txSize := txDesc.Desc.Tx.GetTxActualSize()
 			go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 				metrics.Measurement:      metrics.TxPoolRemoveAfterLifeTime,
@@ -220,6 +304,7 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction) (*common.Hash,
 		senderShardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 		err := NewMempoolTxError(UnexpectedTransactionError, errors.New("Unexpected Transaction From Shard "+fmt.Sprintf("%d", senderShardID)))
 		Logger.log.Error(err)
+		tp.publishTxStatus(newTxRejectedEvent(*tx.Hash(), err))
 		return &common.Hash{}, &TxDesc{}, err
 	}
 	txType := tx.GetType()
@@ -239,7 +324,9 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction) (*common.Hash,
 		metrics.TagValue:         txType})
 	//==========
 	if uint64(len(tp.pool)) >= tp.config.MaxTx {
-		return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+		err := NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+		tp.publishTxStatus(newTxRejectedEvent(*tx.Hash(), err))
+		return nil, nil, err
 	}
 	startAdd := time.Now()
 	hash, txDesc, err := tp.maybeAcceptTransaction(tx, tp.config.PersistMempool, true)
@@ -276,7 +363,12 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction) (*common.Hash,
 	})
 	if err != nil {
 		Logger.log.Error(err)
+		// a duplicate tx is already in pool or in chain, its status is not changed
+		if mempoolErr, ok := err.(*MempoolTxError); !ok || mempoolErr.Code != ErrCodeMessage[RejectDuplicateTx].Code {
+			tp.publishTxStatus(newTxRejectedEvent(*tx.Hash(), err))
+		}
 	} else {
+		tp.publishTxStatus(&TxStatusEvent{TxHash: *tx.Hash(), Status: TxStatusAccepted})
 		if tp.IsBlockGenStarted {
 			if tp.IsUnlockMempool {
 				go func(tx metadata.Transaction) {
@@ -585,8 +677,14 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction) error {
 	if !validated {
 		return NewMempoolTxError(RejectInvalidTx, fmt.Errorf("Invalid tx - %+v", errValidateTxByItself))
 	}
+	tp.config.VerificationCache.Add(tx, shardID)
 
-	// Condition 7: validate tx with data of blockchain
+	// Condition 7: validate tx with data of blockchain,
+	// tx expired at current beacon height can not be included in next blocks
+	beaconHeight := tp.config.BlockChain.GetBeaconHeight()
+	if tx.IsExpired(beaconHeight) {
+		return NewMempoolTxError(RejectExpiredTx, transaction.NewTransactionErr(transaction.TxExpiredError, nil, beaconHeight))
+	}
 	now = time.Now()
 	err = tx.ValidateTxWithBlockChain(tp.config.BlockChain, shardID, tp.config.DataBase)
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
@@ -699,6 +797,7 @@ func (tp *TxPool) validateTransactionReplacement(tx metadata.Transaction) (error
 				tp.removeTx(txToBeReplaced)
 				tp.removeCandidateByTxHash(*txToBeReplaced.Hash())
 				tp.removeTokenIDByTxHash(*txToBeReplaced.Hash())
+				tp.publishTxStatus(&TxStatusEvent{TxHash: *txToBeReplaced.Hash(), Status: TxStatusReplaced, ReplacedBy: tx.Hash()})
 				if tp.IsBlockGenStarted {
 					go func(tx metadata.Transaction) {
 						tp.CRemoveTxs <- tx
@@ -1159,6 +1258,11 @@ func (tp *TxPool) EmptyPool() bool {
 	return false
 }
 
+// ResetVerificationCache - remove cached results of txs verified by itself
+func (tp *TxPool) ResetVerificationCache() {
+	tp.config.VerificationCache.Reset()
+}
+
 func (tp *TxPool) calPoolSize() uint64 {
 	var totalSize uint64
 	for _, txDesc := range tp.pool {
@@ -0,0 +1,233 @@
+package mempool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// PoolEntry describes a tx in pool with the data pool tracks about it
+type PoolEntry struct {
+	TxHash            common.Hash
+	Type              string
+	MetadataType      int
+	Size              uint64 // in kb
+	Fee               uint64
+	FeeToken          uint64
+	FeePerKB          uint64 // fee in PRV per kb of tx
+	FeeTokenPerKB     uint64 // fee in token per kb of tx
+	Height            uint64 // best shard height when tx enters pool
+	StartTime         time.Time
+	IsForwarded       bool
+	SerialNumbersHash common.Hash // txs spending the same coins have the same hash, they replace each other
+	Candidate         string      // staking public key of staking tx
+	InitTokenID       string      // token ID of custom token init tx
+}
+
+// dumpedTx is a tx of a pool dump, encoded the same way as in mempool database persistence
+type dumpedTx struct {
+	Type string
+	Tx   json.RawMessage
+	Desc json.RawMessage
+}
+
+// ListPoolEntries returns every tx in pool, sorted by fee per kb (PRV fee first, then token fee), highest first
+func (tp *TxPool) ListPoolEntries() []PoolEntry {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	tp.candidateMtx.RLock()
+	defer tp.candidateMtx.RUnlock()
+	tp.tokenIDMtx.RLock()
+	defer tp.tokenIDMtx.RUnlock()
+	entries := make([]PoolEntry, 0, len(tp.pool))
+	for txHash, txDesc := range tp.pool {
+		tx := txDesc.Desc.Tx
+		size := tx.GetTxActualSize()
+		if size == 0 {
+			size = 1
+		}
+		entries = append(entries, PoolEntry{
+			TxHash:            txHash,
+			Type:              tx.GetType(),
+			MetadataType:      tx.GetMetadataType(),
+			Size:              size,
+			Fee:               txDesc.Desc.Fee,
+			FeeToken:          txDesc.Desc.FeeToken,
+			FeePerKB:          txDesc.Desc.Fee / size,
+			FeeTokenPerKB:     txDesc.Desc.FeeToken / size,
+			Height:            txDesc.Desc.Height,
+			StartTime:         txDesc.StartTime,
+			IsForwarded:       txDesc.IsFowardMessage,
+			SerialNumbersHash: common.HashArrayOfHashArray(tp.poolSerialNumbersHashList[txHash]),
+			Candidate:         tp.PoolCandidate[txHash],
+			InitTokenID:       tp.poolTokenID[txHash],
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].FeePerKB != entries[j].FeePerKB {
+			return entries[i].FeePerKB > entries[j].FeePerKB
+		}
+		if entries[i].FeeTokenPerKB != entries[j].FeeTokenPerKB {
+			return entries[i].FeeTokenPerKB > entries[j].FeeTokenPerKB
+		}
+		return entries[i].StartTime.Before(entries[j].StartTime)
+	})
+	return entries
+}
+
+// ConflictingTxs returns, for each serial number, hash of the tx in pool spending it (nil if no tx in pool spends it)
+func (tp *TxPool) ConflictingTxs(serialNumbers [][]byte) []*common.Hash {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	spentBy := make(map[common.Hash]common.Hash)
+	for txHash, serialNumbersHashH := range tp.poolSerialNumbersHashList {
+		for _, serialNumberHashH := range serialNumbersHashH {
+			spentBy[serialNumberHashH] = txHash
+		}
+	}
+	result := make([]*common.Hash, len(serialNumbers))
+	for i, serialNumber := range serialNumbers {
+		if txHash, ok := spentBy[common.HashH(serialNumber)]; ok {
+			result[i] = &txHash
+		}
+	}
+	return result
+}
+
+// EvictTx removes a tx from pool and from mempool database persistence on request of operator
+func (tp *TxPool) EvictTx(txHash common.Hash) error {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	txDesc, ok := tp.pool[txHash]
+	if !ok {
+		return NewMempoolTxError(TransactionNotFoundError, fmt.Errorf("Transaction %+v Not Found!", txHash.String()))
+	}
+	tx := txDesc.Desc.Tx
+	tp.removeTx(tx)
+	tp.removeCandidateByTxHash(txHash)
+	tp.removeTokenIDByTxHash(txHash)
+	if tp.config.DataBaseMempool != nil {
+		if err := tp.RemoveTransactionFromDatabaseMP(&txHash); err != nil {
+			return NewMempoolTxError(DatabaseError, err)
+		}
+	}
+	if tp.IsBlockGenStarted {
+		go func(tx metadata.Transaction) {
+			tp.CRemoveTxs <- tx
+		}(tx)
+	}
+	Logger.log.Infof("Remove tx %+v from pool on request", txHash.String())
+	tp.publishTxStatus(&TxStatusEvent{TxHash: txHash, Status: TxStatusRemoved})
+	return nil
+}
+
+// dumpFilePath returns path of a dump file in data directory of node,
+// fileName must be a bare file name so that a dump never reads or writes outside data directory
+func (tp *TxPool) dumpFilePath(fileName string) (string, error) {
+	if tp.config.DataDir == "" {
+		return "", NewMempoolTxError(InvalidDumpFileNameError, errors.New("data directory is not set"))
+	}
+	if fileName == "" || fileName == "." || fileName == ".." || strings.ContainsAny(fileName, `/\`) || filepath.Base(fileName) != fileName {
+		return "", NewMempoolTxError(InvalidDumpFileNameError, fmt.Errorf("%+v is not a file name", fileName))
+	}
+	return filepath.Join(tp.config.DataDir, fileName), nil
+}
+
+// DumpPool writes every tx in pool into a file of data directory, which can be loaded by LoadPool of another node
+func (tp *TxPool) DumpPool(fileName string) (int, error) {
+	filePath, err := tp.dumpFilePath(fileName)
+	if err != nil {
+		return 0, err
+	}
+	tp.mtx.RLock()
+	dumpedTxs := make([]dumpedTx, 0, len(tp.pool))
+	for _, txDesc := range tp.pool {
+		txType, valueTx, valueDesc, err := MarshallTxDescForDatabase(*txDesc)
+		if err != nil {
+			tp.mtx.RUnlock()
+			return 0, NewMempoolTxError(MarshalError, err)
+		}
+		if valueTx == nil {
+			continue
+		}
+		dumpedTxs = append(dumpedTxs, dumpedTx{Type: txType, Tx: valueTx, Desc: valueDesc})
+	}
+	tp.mtx.RUnlock()
+	data, err := json.Marshal(dumpedTxs)
+	if err != nil {
+		return 0, NewMempoolTxError(MarshalError, err)
+	}
+	if err := ioutil.WriteFile(filePath, data, 0600); err != nil {
+		return 0, NewMempoolTxError(UnexpectedTransactionError, err)
+	}
+	return len(dumpedTxs), nil
+}
+
+// LoadPool adds txs dumped by DumpPool into pool from a file of data directory, every tx is validated against current chain and pool.
+// Pool is only locked while a tx is accepted, so other txs can enter pool during a long load.
+// It returns hashes of accepted txs and errors of rejected txs
+func (tp *TxPool) LoadPool(fileName string) ([]common.Hash, map[common.Hash]error, error) {
+	filePath, err := tp.dumpFilePath(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, NewMempoolTxError(UnexpectedTransactionError, err)
+	}
+	dumpedTxs := []dumpedTx{}
+	if err := json.Unmarshal(data, &dumpedTxs); err != nil {
+		return nil, nil, NewMempoolTxError(UnmarshalError, err)
+	}
+	accepted := make([]common.Hash, 0)
+	rejected := make(map[common.Hash]error)
+	for _, dumped := range dumpedTxs {
+		txDesc, err := UnMarshallTxDescFromDatabase(dumped.Type, dumped.Tx, dumped.Desc)
+		if err != nil {
+			return accepted, rejected, NewMempoolTxError(UnmarshalError, err)
+		}
+		if txDesc.Desc.Tx == nil {
+			continue
+		}
+		tx := txDesc.Desc.Tx
+		isAdded, err := tp.loadTx(tx)
+		if err != nil {
+			rejected[*tx.Hash()] = err
+			continue
+		}
+		if !isAdded {
+			continue
+		}
+		accepted = append(accepted, *tx.Hash())
+		if tp.IsBlockGenStarted && tp.IsUnlockMempool {
+			go func(tx metadata.Transaction) {
+				tp.CPendingTxs <- tx
+			}(tx)
+		}
+	}
+	return accepted, rejected, nil
+}
+
+// loadTx validates and accepts a tx of a pool dump, it returns false without error when tx is already in pool
+func (tp *TxPool) loadTx(tx metadata.Transaction) (bool, error) {
+	tp.mtx.Lock()
+	defer tp.mtx.Unlock()
+	if tp.isTxInPool(tx.Hash()) {
+		return false, nil
+	}
+	_, _, err := tp.maybeAcceptTransaction(tx, tp.config.PersistMempool, true)
+	if err != nil {
+		tp.publishTxStatus(newTxRejectedEvent(*tx.Hash(), err))
+		return false, err
+	}
+	tp.publishTxStatus(&TxStatusEvent{TxHash: *tx.Hash(), Status: TxStatusAccepted})
+	return true, nil
+}
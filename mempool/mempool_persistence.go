@@ -20,6 +20,19 @@ type TempDesc struct {
 
 // AddTransactionToDatabaseMempool - Add a transaction data into mempool database
 func (tp *TxPool) AddTransactionToDatabaseMempool(txHash *common.Hash, txDesc TxDesc) error {
+	txType, valueTx, valueDesc, err := MarshallTxDescForDatabase(txDesc)
+	if err != nil {
+		return err
+	}
+	if valueTx == nil {
+		return nil
+	}
+	return tp.config.DataBaseMempool.AddTransaction(txHash, txType, valueTx, valueDesc)
+}
+
+// MarshallTxDescForDatabase - convert TxDesc into tx type, tx data and description data of mempool database persistence,
+// tx data is nil if tx type can not be persisted
+func MarshallTxDescForDatabase(txDesc TxDesc) (string, []byte, []byte, error) {
 	tx := txDesc.Desc.Tx
 	tempDesc := TempDesc{
 		StartTime:     txDesc.StartTime,
@@ -28,59 +41,28 @@ func (tp *TxPool) AddTransactionToDatabaseMempool(txHash *common.Hash, txDesc Tx
 		Fee:           txDesc.Desc.Fee,
 		FeePerKB:      txDesc.Desc.FeePerKB,
 	}
+	var valueTx []byte
+	var err error
 	switch tx.GetType() {
 	//==================For PRV Transfer Only
 	case common.TxNormalType:
-		{
-			normalTx := tx.(*transaction.Tx)
-			valueTx, err := json.Marshal(normalTx)
-			if err != nil {
-				return err
-			}
-			valueDesc, err := json.Marshal(tempDesc)
-			if err != nil {
-				return err
-			}
-			err = tp.config.DataBaseMempool.AddTransaction(txHash, common.TxNormalType, valueTx, valueDesc)
-			if err != nil {
-				return err
-			}
-		}
+		valueTx, err = json.Marshal(tx.(*transaction.Tx))
 	//==================For PRV & TxCustomToken Transfer
 	case common.TxCustomTokenType:
-		{
-			customTokenTx := tx.(*transaction.TxCustomToken)
-			valueTx, err := json.Marshal(customTokenTx)
-			if err != nil {
-				return err
-			}
-			valueDesc, err := json.Marshal(tempDesc)
-			if err != nil {
-				return err
-			}
-			err = tp.config.DataBaseMempool.AddTransaction(txHash, common.TxCustomTokenType, valueTx, valueDesc)
-			if err != nil {
-				return err
-			}
-		}
+		valueTx, err = json.Marshal(tx.(*transaction.TxCustomToken))
 	case common.TxCustomTokenPrivacyType:
-		{
-			customTokenPrivacyTx := tx.(*transaction.TxCustomTokenPrivacy)
-			valueTx, err := json.Marshal(customTokenPrivacyTx)
-			if err != nil {
-				return err
-			}
-			valueDesc, err := json.Marshal(tempDesc)
-			if err != nil {
-				return err
-			}
-			err = tp.config.DataBaseMempool.AddTransaction(txHash, common.TxCustomTokenPrivacyType, valueTx, valueDesc)
-			if err != nil {
-				return err
-			}
-		}
+		valueTx, err = json.Marshal(tx.(*transaction.TxCustomTokenPrivacy))
+	default:
+		return tx.GetType(), nil, nil, nil
 	}
-	return nil
+	if err != nil {
+		return "", nil, nil, err
+	}
+	valueDesc, err := json.Marshal(tempDesc)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return tx.GetType(), valueTx, valueDesc, nil
 }
 
 // GetTransactionFromDatabaseMempool - get tx from mempool database
@@ -7,6 +7,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metrics"
 	"github.com/incognitochain/incognito-chain/transaction"
 )
 
@@ -23,10 +24,37 @@ func (tp *TxPool) ValidateTxList(txs []metadata.Transaction) error {
 	errCh = make(chan error)
 	validTxCount := 0
 	// salaryTxCount := 0
+	// txs verified by itself when entering mempool are not verified again
+	verifiedTxs := make([]bool, len(txs))
+	txsToVerify := make([]metadata.Transaction, 0, len(txs))
+	for index, tx := range txs {
+		if tx.IsSalaryTx() {
+			continue
+		}
+		if tx.GetType() == common.TxCustomTokenType && tx.(*transaction.TxCustomToken).TxTokenData.Type == transaction.CustomTokenCrossShard {
+			continue
+		}
+		shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+		if tp.config.VerificationCache.Lookup(tx, shardID) {
+			verifiedTxs[index] = true
+			continue
+		}
+		txsToVerify = append(txsToVerify, tx)
+	}
+	if hits, misses, _ := tp.config.VerificationCache.Stats(); hits+misses > 0 {
+		go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+			metrics.Measurement:      metrics.TxVerificationCacheHitRate,
+			metrics.MeasurementValue: float64(hits) / float64(hits+misses),
+		})
+	}
+	// verify range proofs and one out of many proofs of all txs at once
+	if err := transaction.VerifyProofsInBatch(txsToVerify, tp.config.BlockChain.GetDatabase()); err != nil {
+		return errors.New("tx in new block error:" + err.Error())
+	}
 	//validate individual tx
 	go func() {
-		for _, tx := range txs {
-			go func(tx metadata.Transaction) {
+		for index, tx := range txs {
+			go func(tx metadata.Transaction, isVerified bool) {
 				if tx.GetType() == common.TxCustomTokenType {
 					customTokenTx := tx.(*transaction.TxCustomToken)
 					if customTokenTx.TxTokenData.Type == transaction.CustomTokenCrossShard {
@@ -34,9 +62,9 @@ func (tp *TxPool) ValidateTxList(txs []metadata.Transaction) error {
 						return
 					}
 				}
-				err := tp.validateTxIndependentProperties(tx)
+				err := tp.validateTxIndependentProperties(tx, isVerified)
 				errCh <- err
-			}(tx)
+			}(tx, verifiedTxs[index])
 		}
 	}()
 
@@ -107,10 +135,10 @@ Verify Transaction with these condition:
 	2. Validate fee with tx size
 	3. Validate type of tx
 	4. Validate sanity data of tx
-	5. Validate By it self (data in tx): privacy proof, metadata,...
+	5. Validate By it self (data in tx): privacy proof, metadata,... (skipped if tx is verified already)
 	6. Validate tx with blockchain: douple spend, ...
 */
-func (tp *TxPool) validateTxIndependentProperties(tx metadata.Transaction) error {
+func (tp *TxPool) validateTxIndependentProperties(tx metadata.Transaction, isVerified bool) error {
 	var shardID byte
 	var err error
 	txHash := tx.Hash()
@@ -152,9 +180,11 @@ func (tp *TxPool) validateTxIndependentProperties(tx metadata.Transaction) error
 
 	// ValidateTransaction tx by it self
 	shardID = common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
-	validated, _ := tx.ValidateTxByItself(tx.IsPrivacy(), tp.config.BlockChain.GetDatabase(), tp.config.BlockChain, shardID)
-	if !validated {
-		return NewMempoolTxError(RejectInvalidTx, errors.New("invalid tx"))
+	if !isVerified {
+		validated, _ := tx.ValidateTxByItself(tx.IsPrivacy(), tp.config.BlockChain.GetDatabase(), tp.config.BlockChain, shardID)
+		if !validated {
+			return NewMempoolTxError(RejectInvalidTx, errors.New("invalid tx"))
+		}
 	}
 	// validate tx with data of blockchain
 	err = tx.ValidateTxWithBlockChain(tp.config.BlockChain, shardID, tp.config.BlockChain.GetDatabase())
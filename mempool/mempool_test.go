@@ -15,8 +15,10 @@ import (
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"log"
 	"math"
+	"os"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -823,7 +825,8 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 	txDesc1CustomTokenPrivacy := createTxDescMempool(txInitCustomTokenPrivacy, 1, txInitCustomTokenPrivacy.GetTxFee(), txInitCustomTokenPrivacy.GetTxFeeToken())
 	// Check condition 1: Sanity - Max version error
 	ResetMempoolTest()
-	tx1.(*transaction.Tx).Version = 2
+	tx1Version := tx1.(*transaction.Tx).Version
+	tx1.(*transaction.Tx).Version = MaxVersion + 1
 	err1 := tp.validateTransaction(tx1)
 	if err1 == nil {
 		t.Fatal("Expect max version error error but no error")
@@ -832,7 +835,7 @@ func TestTxPoolValidateTransaction(t *testing.T) {
 			t.Fatalf("Expect Error %+v but get %+v", ErrCodeMessage[RejectSansityTx], err1)
 		}
 	}
-	tx1.(*transaction.Tx).Version = 1
+	tx1.(*transaction.Tx).Version = tx1Version
 	// Check condition 1: Size - Invalid size error
 	ResetMempoolTest()
 	common.MaxTxSize = 0
@@ -1175,8 +1178,10 @@ func TestTxPoolmayBeAcceptTransaction(t *testing.T) {
 	max := tp.Size()
 	assert.NotEqual(t, 0, max)
 
+	// fee of privacy tx6 is its estimated size in kb (rounded up) * commonFee, estimated size of 1 input, 1 output
+	// grows from 3045 to 3079 bytes with the 34 bytes tx random of the one-time output, so it crosses 3kb
 	fee := tp.MaxFee()
-	assert.Equal(t, uint64(30), uint64(fee))
+	assert.Equal(t, uint64(40), uint64(fee))
 
 	tp.LockPool()
 	tp.UnlockPool()
@@ -1298,6 +1303,82 @@ func TestTxPoolRemoveTx(t *testing.T) {
 		t.Fatalf("Expect tx hash %+v NOT in database mempool but counter err", txStakingBeacon.Hash())
 	}
 }
+func TestTxPoolRemoveExpiredTxs(t *testing.T) {
+	ResetMempoolTest()
+	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, normalTranferAmount)
+	tx2 := CreateAndSaveTestNormalTransaction(privateKeyShard0[1], commonFee, false, normalTranferAmount)
+	tx3 := CreateAndSaveTestNormalTransaction(privateKeyShard0[2], commonFee, false, normalTranferAmount)
+	tx2.(*transaction.Tx).ExpiryHeight = 10
+	tx3.(*transaction.Tx).ExpiryHeight = 20
+	for _, tx := range []metadata.Transaction{tx1, tx2, tx3} {
+		tp.addTx(createTxDescMempool(tx, 1, tx.GetTxFee(), tx.GetTxFeeToken()), false)
+	}
+	tp.removeExpiredTxs(10)
+	if len(tp.pool) != 3 {
+		t.Fatalf("Expect 3 transaction from pool but get %+v", len(tp.pool))
+	}
+	tp.removeExpiredTxs(11)
+	if len(tp.pool) != 2 {
+		t.Fatalf("Expect 2 transaction from pool but get %+v", len(tp.pool))
+	}
+	if tp.isTxInPool(tx2.Hash()) {
+		t.Fatalf("Expect tx hash %+v NOT in pool", tx2.Hash())
+	}
+	tp.removeExpiredTxs(21)
+	if len(tp.pool) != 1 {
+		t.Fatalf("Expect 1 transaction from pool but get %+v", len(tp.pool))
+	}
+	if !tp.isTxInPool(tx1.Hash()) {
+		t.Fatalf("Expect tx hash %+v in pool", tx1.Hash())
+	}
+}
+func TestTxPoolReturnDisconnectedTxs(t *testing.T) {
+	ResetMempoolTest()
+	defer func(maxTx uint64) { tp.config.MaxTx = maxTx }(tp.config.MaxTx)
+	tp.config.MaxTx = 100
+	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, normalTranferAmount)
+	tx2 := CreateAndSaveTestNormalTransaction(privateKeyShard0[1], commonFee, false, normalTranferAmount)
+	// tx3 spends same coins as tx1 without paying higher fee
+	tx3 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, normalTranferAmount+1)
+	rewardTx := &transaction.Tx{Type: common.TxRewardType}
+	tp.addTx(createTxDescMempool(tx2, 1, tx2.GetTxFee(), tx2.GetTxFeeToken()), false)
+	subId, subChan, err := pbMempool.RegisterNewOrderedSubscriber([]string{pubsub.TransactionStatusTopic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pbMempool.UnsubscribeOrdered(subId)
+	cQuit := make(chan struct{})
+	defer close(cQuit)
+	go tp.Start(cQuit)
+
+	pbMempool.PublishMessage(pubsub.NewMessage(pubsub.ShardBlockDisconnectedTopic, &blockchain.ShardBlock{
+		Body: blockchain.ShardBody{Transactions: []metadata.Transaction{rewardTx, tx1, tx2, tx3}},
+	}))
+	// reward tx is dropped, tx already in pool is skipped
+	expected := []struct {
+		txHash *common.Hash
+		status string
+	}{
+		{tx1.Hash(), TxStatusReturned},
+		{tx3.Hash(), TxStatusRejected},
+	}
+	for _, item := range expected {
+		select {
+		case msg := <-subChan:
+			event := msg.Value.(*TxStatusEvent)
+			assert.Equal(t, *item.txHash, event.TxHash)
+			assert.Equal(t, item.status, event.Status)
+		case <-time.After(10 * time.Second):
+			t.Fatal("no tx status event")
+		}
+	}
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	assert.Equal(t, 2, len(tp.pool))
+	assert.True(t, tp.isTxInPool(tx1.Hash()))
+	assert.True(t, tp.isTxInPool(tx2.Hash()))
+	assert.False(t, tp.isTxInPool(rewardTx.Hash()))
+}
 func TestTxPoolMaybeAcceptTransaction(t *testing.T) {
 	ResetMempoolTest()
 	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], 10, false, normalTranferAmount)
@@ -1407,3 +1488,87 @@ func TestTxPoolEmptyPool(t *testing.T) {
 		t.Fatal("Can't empty token id pool")
 	}
 }
+func TestTxPoolInspectAndEvictTx(t *testing.T) {
+	ResetMempoolTest()
+	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, normalTranferAmount)
+	tx2 := CreateAndSaveTestNormalTransaction(privateKeyShard0[1], commonFee*3, false, normalTranferAmount)
+	tx3 := CreateAndSaveTestNormalTransaction(privateKeyShard0[2], commonFee*2, false, normalTranferAmount)
+	for _, tx := range []metadata.Transaction{tx1, tx2, tx3} {
+		tp.addTx(createTxDescMempool(tx, 1, tx.GetTxFee(), tx.GetTxFeeToken()), true)
+	}
+	entries := tp.ListPoolEntries()
+	if len(entries) != 3 {
+		t.Fatalf("Expect 3 pool entries but get %+v", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].FeePerKB < entries[i].FeePerKB {
+			t.Fatalf("Expect pool entries sorted by fee per kb but get %+v before %+v", entries[i-1].FeePerKB, entries[i].FeePerKB)
+		}
+	}
+
+	serialNumber := tx1.(*transaction.Tx).Proof.GetInputCoins()[0].CoinDetails.GetSerialNumber().Compress()
+	conflicts := tp.ConflictingTxs([][]byte{serialNumber, []byte("unknown")})
+	if conflicts[0] == nil || !conflicts[0].IsEqual(tx1.Hash()) {
+		t.Fatalf("Expect serial number spent by %+v but get %+v", tx1.Hash(), conflicts[0])
+	}
+	if conflicts[1] != nil {
+		t.Fatalf("Expect unknown serial number not spent but get %+v", conflicts[1])
+	}
+
+	if err := tp.EvictTx(*tx1.Hash()); err != nil {
+		t.Fatal("Expect no error but get ", err)
+	}
+	if tp.isTxInPool(tx1.Hash()) {
+		t.Fatalf("Expect tx hash %+v NOT in pool", tx1.Hash())
+	}
+	if isOk, err := tp.config.DataBaseMempool.HasTransaction(tx1.Hash()); isOk && err == nil {
+		t.Fatalf("Expect tx hash %+v NOT in database mempool", tx1.Hash())
+	}
+	if conflicts := tp.ConflictingTxs([][]byte{serialNumber}); conflicts[0] != nil {
+		t.Fatalf("Expect serial number of evicted tx not spent but get %+v", conflicts[0])
+	}
+	if err := tp.EvictTx(*tx1.Hash()); err == nil || err.(*MempoolTxError).Code != ErrCodeMessage[TransactionNotFoundError].Code {
+		t.Fatalf("Expect Error %+v but get %+v", ErrCodeMessage[TransactionNotFoundError], err)
+	}
+}
+func TestTxPoolDumpAndLoadPool(t *testing.T) {
+	ResetMempoolTest()
+	tx1 := CreateAndSaveTestNormalTransaction(privateKeyShard0[0], commonFee, false, normalTranferAmount)
+	tx2 := CreateAndSaveTestNormalTransaction(privateKeyShard0[1], commonFee, false, normalTranferAmount)
+	for _, tx := range []metadata.Transaction{tx1, tx2} {
+		tp.addTx(createTxDescMempool(tx, 1, tx.GetTxFee(), tx.GetTxFeeToken()), false)
+	}
+	dir, err := ioutil.TempDir(os.TempDir(), "test_dumppool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(dataDir string) { tp.config.DataDir = dataDir }(tp.config.DataDir)
+	tp.config.DataDir = dir
+	fileName := "mempool.json"
+	for _, invalidFileName := range []string{"", "..", filepath.Join(dir, fileName), "../" + fileName, `..\` + fileName} {
+		if _, err := tp.DumpPool(invalidFileName); err == nil || err.(*MempoolTxError).Code != ErrCodeMessage[InvalidDumpFileNameError].Code {
+			t.Fatalf("Expect Error %+v for file name %+v but get %+v", ErrCodeMessage[InvalidDumpFileNameError], invalidFileName, err)
+		}
+		if _, _, err := tp.LoadPool(invalidFileName); err == nil || err.(*MempoolTxError).Code != ErrCodeMessage[InvalidDumpFileNameError].Code {
+			t.Fatalf("Expect Error %+v for file name %+v but get %+v", ErrCodeMessage[InvalidDumpFileNameError], invalidFileName, err)
+		}
+	}
+	count, err := tp.DumpPool(fileName)
+	if err != nil || count != 2 {
+		t.Fatalf("Expect 2 dumped txs but get %+v, error %+v", count, err)
+	}
+
+	ResetMempoolTest()
+	tp.addTx(createTxDescMempool(tx1, 1, tx1.GetTxFee(), tx1.GetTxFeeToken()), false)
+	accepted, rejected, err := tp.LoadPool(fileName)
+	if err != nil {
+		t.Fatal("Expect no error but get ", err)
+	}
+	if len(accepted) != 1 || !accepted[0].IsEqual(tx2.Hash()) || len(rejected) != 0 {
+		t.Fatalf("Expect only %+v accepted but get %+v, rejected %+v", tx2.Hash(), accepted, rejected)
+	}
+	if !tp.isTxInPool(tx2.Hash()) {
+		t.Fatalf("Expect tx hash %+v in pool", tx2.Hash())
+	}
+}
@@ -0,0 +1,46 @@
+package mempool
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// status of a tx in pool, published with TxStatusEvent on pubsub.TransactionStatusTopic
+const (
+	TxStatusAccepted = "accepted" // tx is accepted into pool
+	TxStatusRejected = "rejected" // tx is rejected by pool, with code of MempoolTxError
+	TxStatusReplaced = "replaced" // tx is replaced by a tx spending the same coins with higher fee
+	TxStatusEvicted  = "evicted"  // tx is removed from pool after its life time
+	TxStatusExpired  = "expired"  // tx is removed from pool at its expiry beacon height
+	TxStatusReturned = "returned" // tx of a reverted shard block is returned to pool
+	TxStatusRemoved  = "removed"  // tx is removed from pool by operator
+)
+
+// TxStatusEvent is published when status of a tx in pool changes
+type TxStatusEvent struct {
+	TxHash       common.Hash
+	Status       string
+	ErrorCode    int          // code of MempoolTxError, only for rejected tx
+	ErrorMessage string       // only for rejected tx
+	ReplacedBy   *common.Hash // only for replaced tx
+}
+
+func newTxRejectedEvent(txHash common.Hash, err error) *TxStatusEvent {
+	event := &TxStatusEvent{
+		TxHash:       txHash,
+		Status:       TxStatusRejected,
+		ErrorMessage: err.Error(),
+	}
+	if mempoolErr, ok := err.(*MempoolTxError); ok {
+		event.ErrorCode = mempoolErr.Code
+	}
+	return event
+}
+
+// publishTxStatus publishes event synchronously, so subscribers get events of a tx in the order of its status changes
+func (tp *TxPool) publishTxStatus(event *TxStatusEvent) {
+	if tp.config.PubSubManager == nil {
+		return
+	}
+	tp.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionStatusTopic, event))
+}
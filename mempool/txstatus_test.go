@@ -0,0 +1,40 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+func TestPublishTxStatus(t *testing.T) {
+	pubSubManager := pubsub.NewPubSubManager()
+	go pubSubManager.Start()
+	_, subChan, err := pubSubManager.RegisterNewSubscriber(pubsub.TransactionStatusTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := &TxPool{config: Config{PubSubManager: pubSubManager}}
+	txHash := common.HashH([]byte("tx"))
+	tp.publishTxStatus(newTxRejectedEvent(txHash, NewMempoolTxError(RejectInvalidFee, errors.New("fee is too low"))))
+	select {
+	case msg := <-subChan:
+		event, ok := msg.Value.(*TxStatusEvent)
+		if !ok {
+			t.Fatalf("wrong message type %T", msg.Value)
+		}
+		if !event.TxHash.IsEqual(&txHash) || event.Status != TxStatusRejected {
+			t.Errorf("wrong event %+v", event)
+		}
+		if event.ErrorCode != ErrCodeMessage[RejectInvalidFee].Code {
+			t.Errorf("wrong error code %d", event.ErrorCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no tx status event")
+	}
+
+	// pool without pubsub manager does not publish
+	(&TxPool{}).publishTxStatus(&TxStatusEvent{TxHash: txHash, Status: TxStatusAccepted})
+}
@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// VerificationCache keeps hashes of txs which passed ValidateTxByItself (proof, signature, metadata by itself)
+// so a tx verified when it enters mempool is not verified again when the block containing it is validated.
+// Cache is bounded, the least recently used entry is evicted when it is full.
+// Results depend on commitments stored in database, so cache must be reset whenever chain state is reverted
+type VerificationCache struct {
+	mtx      sync.Mutex
+	capacity int
+	entries  map[common.Hash]*list.Element
+	order    *list.List
+	hits     uint64
+	misses   uint64
+}
+
+func NewVerificationCache(capacity int) *VerificationCache {
+	return &VerificationCache{
+		capacity: capacity,
+		entries:  make(map[common.Hash]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// verificationCacheKey - tx is verified against commitments of the shard of sender.
+// Key is hash of the whole serialized tx: tx hash doesn't cover signature, sig public key, info...
+// so a tx with same hash but modified signature must not hit the cache
+func verificationCacheKey(tx metadata.Transaction, shardID byte) (common.Hash, error) {
+	txBytes, err := json.Marshal(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HashH(append(txBytes, shardID)), nil
+}
+
+// Add records that tx is valid by itself in shard shardID
+func (cache *VerificationCache) Add(tx metadata.Transaction, shardID byte) {
+	if cache == nil || cache.capacity <= 0 {
+		return
+	}
+	key, err := verificationCacheKey(tx, shardID)
+	if err != nil {
+		return
+	}
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		cache.order.MoveToFront(element)
+		return
+	}
+	if cache.order.Len() >= cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(common.Hash))
+	}
+	cache.entries[key] = cache.order.PushFront(key)
+}
+
+// Lookup returns true if tx was verified by itself in shard shardID, hit and miss counters are updated
+func (cache *VerificationCache) Lookup(tx metadata.Transaction, shardID byte) bool {
+	if cache == nil || cache.capacity <= 0 {
+		return false
+	}
+	key, err := verificationCacheKey(tx, shardID)
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	element, ok := cache.entries[key]
+	if err != nil || !ok {
+		cache.misses++
+		return false
+	}
+	cache.hits++
+	cache.order.MoveToFront(element)
+	return true
+}
+
+// Reset removes all entries of cache, counters are kept
+func (cache *VerificationCache) Reset() {
+	if cache == nil {
+		return
+	}
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cache.entries = make(map[common.Hash]*list.Element)
+	cache.order.Init()
+}
+
+// Stats returns number of hits, misses and entries of cache, hit rate is reported to metrics when a block is validated
+func (cache *VerificationCache) Stats() (uint64, uint64, int) {
+	if cache == nil {
+		return 0, 0, 0
+	}
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	return cache.hits, cache.misses, cache.order.Len()
+}
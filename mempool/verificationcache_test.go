@@ -0,0 +1,61 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationCache(t *testing.T) {
+	cache := NewVerificationCache(2)
+	tx1 := &transaction.Tx{Fee: 1}
+	tx2 := &transaction.Tx{Fee: 2}
+	tx3 := &transaction.Tx{Fee: 3}
+
+	cache.Add(tx1, 0)
+	cache.Add(tx2, 0)
+	assert.Equal(t, true, cache.Lookup(tx1, 0))
+	// verified in another shard
+	assert.Equal(t, false, cache.Lookup(tx1, 1))
+
+	// tx2 is least recently used
+	cache.Add(tx3, 0)
+	assert.Equal(t, false, cache.Lookup(tx2, 0))
+	assert.Equal(t, true, cache.Lookup(tx1, 0))
+	assert.Equal(t, true, cache.Lookup(tx3, 0))
+
+	hits, misses, size := cache.Stats()
+	assert.Equal(t, uint64(3), hits)
+	assert.Equal(t, uint64(2), misses)
+	assert.Equal(t, 2, size)
+
+	cache.Reset()
+	assert.Equal(t, false, cache.Lookup(tx1, 0))
+	_, _, size = cache.Stats()
+	assert.Equal(t, 0, size)
+}
+
+func TestVerificationCacheDisabled(t *testing.T) {
+	tx := &transaction.Tx{Fee: 1}
+	var nilCache *VerificationCache
+	nilCache.Add(tx, 0)
+	assert.Equal(t, false, nilCache.Lookup(tx, 0))
+
+	cache := NewVerificationCache(0)
+	cache.Add(tx, 0)
+	assert.Equal(t, false, cache.Lookup(tx, 0))
+}
+
+func TestVerificationCacheKeyCoversSignature(t *testing.T) {
+	cache := NewVerificationCache(2)
+	tx := &transaction.Tx{Fee: 1, Sig: []byte{1}}
+	cache.Add(tx, 0)
+	// same tx hash but modified signature isn't verified
+	forged := &transaction.Tx{Fee: 1, Sig: []byte{2}}
+	assert.Equal(t, tx.Hash(), forged.Hash())
+	assert.Equal(t, false, cache.Lookup(forged, 0))
+	forged = &transaction.Tx{Fee: 1, Sig: []byte{1}, Info: []byte{1}}
+	assert.Equal(t, false, cache.Lookup(forged, 0))
+	assert.Equal(t, true, cache.Lookup(&transaction.Tx{Fee: 1, Sig: []byte{1}}, 0))
+}
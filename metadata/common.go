@@ -58,6 +58,11 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &WithDrawRewardRequest{}
 	case WithDrawRewardResponseMeta:
 		md = &WithDrawRewardResponse{}
+
+	case TokenMintRequestMeta:
+		md = &TokenMintRequest{}
+	case TokenBurnRequestMeta:
+		md = &TokenBurnRequest{}
 	default:
 		fmt.Printf("[db] parse meta err: %+v\n", meta)
 		return nil, errors.Errorf("Could not parse metadata with type: %d", int(mtTemp["Type"].(float64)))
@@ -30,6 +30,13 @@ const (
 	BeaconSwapConfirmMeta = 70
 	BridgeSwapConfirmMeta = 71
 	BurningConfirmMeta    = 72
+
+	// registry of privacy custom token, type of instruction confirming tx init token
+	PrivacyTokenInitMeta = 89
+
+	// issuer-controlled supply of privacy custom token
+	TokenMintRequestMeta = 90
+	TokenBurnRequestMeta = 91
 )
 
 var minerCreatedMetaTypes = []int{
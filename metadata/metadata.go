@@ -131,6 +131,7 @@ type BlockchainRetriever interface {
 	GetTxValue(txid string) (uint64, error)
 	GetShardIDFromTx(txid string) (byte, error)
 	GetRPCClient() *rpccaller.RPCClient
+	GetMinCommitmentRingSize() int
 }
 
 // Interface for all types of metadata in tx
@@ -156,6 +157,8 @@ type Transaction interface {
 	GetMetadataType() int
 	GetType() string
 	GetLockTime() int64
+	GetExpiryHeight() uint64
+	IsExpired(beaconHeight uint64) bool
 	GetTxActualSize() uint64
 	GetSenderAddrLastByte() byte
 	GetTxFee() uint64
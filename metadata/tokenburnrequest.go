@@ -0,0 +1,147 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/pkg/errors"
+)
+
+// TokenBurnRequest - holder of a privacy custom token burns units of the token by sending them to burning address,
+// burned units are subtracted from supply of the token. Bridge tokens are burned by BurningRequest instead
+type TokenBurnRequest struct {
+	TokenID common.Hash
+	Amount  uint64 // must be equal to value of coins sent to burning address
+	MetadataBase
+}
+
+func NewTokenBurnRequest(
+	tokenID common.Hash,
+	amount uint64,
+	metaType int,
+) (*TokenBurnRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	burnReq := &TokenBurnRequest{
+		TokenID: tokenID,
+		Amount:  amount,
+	}
+	burnReq.MetadataBase = metadataBase
+	return burnReq, nil
+}
+
+func (burnReq *TokenBurnRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	if !db.PrivacyCustomTokenIDExisted(burnReq.TokenID) && !db.PrivacyCustomTokenIDCrossShardExisted(burnReq.TokenID) {
+		return false, errors.Errorf("token %s is not existed", burnReq.TokenID.String())
+	}
+	// supply is tracked only for tokens in registry, burning others could not be subtracted by beacon
+	infoBytes, err := db.GetPrivacyTokenInfo(burnReq.TokenID)
+	if err != nil {
+		return false, err
+	}
+	if len(infoBytes) == 0 {
+		return false, errors.Errorf("token %s is not in registry", burnReq.TokenID.String())
+	}
+	info := new(lvdb.PrivacyTokenInfo)
+	err = json.Unmarshal(infoBytes, info)
+	if err != nil {
+		return false, err
+	}
+	if info.Mintable {
+		return false, errors.New("bridge token must be burned by burning request")
+	}
+	for _, isCentralized := range []bool{true, false} {
+		bridgeTokenExisted, err := db.IsBridgeTokenExistedByType(burnReq.TokenID, isCentralized)
+		if err != nil {
+			return false, err
+		}
+		if bridgeTokenExisted {
+			return false, errors.New("bridge token must be burned by burning request")
+		}
+	}
+	supply, err := db.GetPrivacyTokenSupply(burnReq.TokenID)
+	if err != nil {
+		return false, err
+	}
+	if burnReq.Amount > supply {
+		return false, errors.Errorf("burned amount %d is more than supply %d of token %s", burnReq.Amount, supply, burnReq.TokenID.String())
+	}
+	return true, nil
+}
+
+func (burnReq *TokenBurnRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if burnReq.Type != TokenBurnRequestMeta {
+		return false, false, errors.New("Wrong request info's meta type")
+	}
+	if burnReq.Amount == 0 {
+		return false, false, errors.New("Wrong request info's burned amount")
+	}
+	if txr.GetType() != common.TxCustomTokenPrivacyType {
+		return false, false, errors.New("Must burn with privacy custom token tx")
+	}
+	if !txr.GetTokenID().IsEqual(&burnReq.TokenID) {
+		return false, false, errors.New("TokenID incorrect")
+	}
+
+	keyWalletBurningAdd, err := wallet.Base58CheckDeserialize(common.BurningAddress)
+	if err != nil {
+		return false, false, err
+	}
+	burningPk := keyWalletBurningAdd.KeySet.PaymentAddress.Pk
+	burnedAmount := uint64(0)
+	pubkeys, amounts := txr.GetTokenReceivers()
+	for i, pk := range pubkeys {
+		if bytes.Equal(pk, burningPk[:]) {
+			burnedAmount += amounts[i]
+		}
+	}
+	if burnedAmount != burnReq.Amount {
+		return false, false, errors.New("Amount incorrect")
+	}
+	return true, true, nil
+}
+
+func (burnReq *TokenBurnRequest) ValidateMetadataByItself() bool {
+	return burnReq.Type == TokenBurnRequestMeta
+}
+
+func (burnReq *TokenBurnRequest) Hash() *common.Hash {
+	record := burnReq.MetadataBase.Hash().String()
+	record += burnReq.TokenID.String()
+	record += strconv.FormatUint(burnReq.Amount, 10)
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+// BuildReqActions - burned units are subtracted from supply of token when beacon confirms the action
+func (burnReq *TokenBurnRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := map[string]interface{}{
+		"meta":          *burnReq,
+		"RequestedTxID": tx.Hash(),
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(TokenBurnRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (burnReq *TokenBurnRequest) CalculateSize() uint64 {
+	return calculateSize(burnReq)
+}
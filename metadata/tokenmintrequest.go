@@ -0,0 +1,127 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/pkg/errors"
+)
+
+// TokenMintRequest - issuer of a privacy custom token with issuer-mintable supply policy mints more units of the token,
+// it is attached to a tx init token whose PRV part is paid and signed by issuer
+type TokenMintRequest struct {
+	TokenID  common.Hash
+	Amount   uint64 // must be equal to value of minted coin
+	Receiver privacy.PaymentAddress
+	MetadataBase
+}
+
+func NewTokenMintRequest(
+	tokenID common.Hash,
+	amount uint64,
+	receiver privacy.PaymentAddress,
+	metaType int,
+) (*TokenMintRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	mintReq := &TokenMintRequest{
+		TokenID:  tokenID,
+		Amount:   amount,
+		Receiver: receiver,
+	}
+	mintReq.MetadataBase = metadataBase
+	return mintReq, nil
+}
+
+func (mintReq *TokenMintRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	infoBytes, err := db.GetPrivacyTokenInfo(mintReq.TokenID)
+	if err != nil {
+		return false, err
+	}
+	if len(infoBytes) == 0 {
+		return false, errors.Errorf("token %s is not registered", mintReq.TokenID.String())
+	}
+	info := new(lvdb.PrivacyTokenInfo)
+	err = json.Unmarshal(infoBytes, info)
+	if err != nil {
+		return false, err
+	}
+	if info.Mintable || info.SupplyPolicy != common.TokenSupplyIssuerMintable {
+		return false, errors.New("token is not mintable by issuer")
+	}
+	if info.ShardID != shardID {
+		return false, errors.Errorf("token can only be minted in shard %d", info.ShardID)
+	}
+	if !bytes.Equal(txr.GetSender(), info.IssuerKey) {
+		return false, errors.New("sender is not issuer of token")
+	}
+	// registry and supply are updated by beacon instructions, so every node checks mint against the same supply
+	supply, err := db.GetPrivacyTokenSupply(mintReq.TokenID)
+	if err != nil {
+		return false, err
+	}
+	if supply+mintReq.Amount < supply {
+		return false, errors.New("supply of token overflows")
+	}
+	return true, nil
+}
+
+func (mintReq *TokenMintRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if mintReq.Type != TokenMintRequestMeta {
+		return false, false, errors.New("Wrong request info's meta type")
+	}
+	if mintReq.Amount == 0 {
+		return false, false, errors.New("Wrong request info's minted amount")
+	}
+	if len(mintReq.Receiver.Pk) != privacy.CompressedEllipticPointSize {
+		return false, false, errors.New("Wrong request info's receiver address")
+	}
+	if txr.GetType() != common.TxCustomTokenPrivacyType {
+		return false, false, errors.New("Must mint with privacy custom token tx")
+	}
+	if !txr.GetTokenID().IsEqual(&mintReq.TokenID) {
+		return false, false, errors.New("TokenID incorrect")
+	}
+	// issuer is identified by the input coins of PRV part, so they must be public
+	if txr.IsPrivacy() || len(txr.GetSender()) == 0 {
+		return false, false, errors.New("Must pay fee by non-privacy PRV coins of issuer")
+	}
+	isUnique, receiverPk, amount := txr.GetTokenUniqueReceiver()
+	if !isUnique || !bytes.Equal(receiverPk, mintReq.Receiver.Pk[:]) {
+		return false, false, errors.New("Receiver incorrect")
+	}
+	if amount != mintReq.Amount {
+		return false, false, errors.New("Amount incorrect")
+	}
+	return true, true, nil
+}
+
+func (mintReq *TokenMintRequest) ValidateMetadataByItself() bool {
+	return mintReq.Type == TokenMintRequestMeta
+}
+
+func (mintReq *TokenMintRequest) Hash() *common.Hash {
+	record := mintReq.MetadataBase.Hash().String()
+	record += mintReq.TokenID.String()
+	record += strconv.FormatUint(mintReq.Amount, 10)
+	record += mintReq.Receiver.String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (mintReq *TokenMintRequest) CalculateSize() uint64 {
+	return calculateSize(mintReq)
+}
@@ -29,6 +29,8 @@ const (
 	TxPoolRemovedTime                 = "TxPoolRemovedTime"
 	TxPoolRemovedTimeDetails          = "TxPoolRemovedTimeDetails"
 	TxPoolTxBeginEnter                = "TxPoolTxBeginEnter"
+	TxVerificationCacheHitRate        = "TxVerificationCacheHitRate"
+	RPCRejected                       = "RPCRejected"
 	
 	BeaconBlock = "BeaconBlock"
 	ShardBlock  = "ShardBlock"
@@ -47,6 +49,7 @@ const (
 	NodeIDTag            = "node"
 	TxHashTag               = "txhash"
 	FuncTag = "func"
+	RPCMethodTag = "rpcmethod"
 )
 
 //Tag value
@@ -413,6 +413,20 @@ func (_m *DatabaseInterface) DeleteIncomingCrossShard(shardID byte, crossShardID
 	return r0
 }
 
+// DeleteLastOneTimeOutputCoins provides a mock function with given fields: tokenID, shardID, count
+func (_m *DatabaseInterface) DeleteLastOneTimeOutputCoins(tokenID common.Hash, shardID byte, count uint64) error {
+	ret := _m.Called(tokenID, shardID, count)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, byte, uint64) error); ok {
+		r0 = rf(tokenID, shardID, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteOutputCoin provides a mock function with given fields: tokenID, publicKey, outputCoinArr, shardID
 func (_m *DatabaseInterface) DeleteOutputCoin(tokenID common.Hash, publicKey []byte, outputCoinArr [][]byte, shardID byte) error {
 	ret := _m.Called(tokenID, publicKey, outputCoinArr, shardID)
@@ -469,6 +483,20 @@ func (_m *DatabaseInterface) DeletePrivacyCustomTokenTx(tokenID common.Hash, txI
 	return r0
 }
 
+// DeletePrivacyTokenInfo provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) DeletePrivacyTokenInfo(tokenID common.Hash) error {
+	ret := _m.Called(tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash) error); ok {
+		r0 = rf(tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTransactionIndex provides a mock function with given fields: txId
 func (_m *DatabaseInterface) DeleteTransactionIndex(txId common.Hash) error {
 	ret := _m.Called(txId)
@@ -483,6 +511,43 @@ func (_m *DatabaseInterface) DeleteTransactionIndex(txId common.Hash) error {
 	return r0
 }
 
+// DeleteTxQueryIndex provides a mock function with given fields: index
+func (_m *DatabaseInterface) DeleteTxQueryIndex(index database.TxQueryIndex) error {
+	ret := _m.Called(index)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(database.TxQueryIndex) error); ok {
+		r0 = rf(index)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportStateSnapshot provides a mock function with given fields:
+func (_m *DatabaseInterface) ExportStateSnapshot() (database.StateSnapshotReader, error) {
+	ret := _m.Called()
+
+	var r0 database.StateSnapshotReader
+	if rf, ok := ret.Get(0).(func() database.StateSnapshotReader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.StateSnapshotReader)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchBeaconBestState provides a mock function with given fields:
 func (_m *DatabaseInterface) FetchBeaconBestState() ([]byte, error) {
 	ret := _m.Called()
@@ -575,6 +640,29 @@ func (_m *DatabaseInterface) FetchBlock(_a0 common.Hash) ([]byte, error) {
 	return r0, r1
 }
 
+// FetchBlockHeader provides a mock function with given fields: _a0
+func (_m *DatabaseInterface) FetchBlockHeader(_a0 common.Hash) ([]byte, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchCommitteeByHeight provides a mock function with given fields: _a0
 func (_m *DatabaseInterface) FetchCommitteeByHeight(_a0 uint64) ([]byte, error) {
 	ret := _m.Called(_a0)
@@ -665,6 +753,27 @@ func (_m *DatabaseInterface) FetchPrevBestState(_a0 bool, _a1 byte) ([]byte, err
 	return r0, r1
 }
 
+// FetchPrunedHeight provides a mock function with given fields: _a0, _a1
+func (_m *DatabaseInterface) FetchPrunedHeight(_a0 bool, _a1 byte) (uint64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(bool, byte) uint64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool, byte) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchShardBestState provides a mock function with given fields: _a0
 func (_m *DatabaseInterface) FetchShardBestState(_a0 byte) ([]byte, error) {
 	ret := _m.Called(_a0)
@@ -1095,6 +1204,94 @@ func (_m *DatabaseInterface) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []b
 	return r0, r1
 }
 
+// GetOneTimeOutputCoins provides a mock function with given fields: tokenID, shardID, fromIndex, limit
+func (_m *DatabaseInterface) GetOneTimeOutputCoins(tokenID common.Hash, shardID byte, fromIndex uint64, limit int) ([][]byte, error) {
+	ret := _m.Called(tokenID, shardID, fromIndex, limit)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(common.Hash, byte, uint64, int) [][]byte); ok {
+		r0 = rf(tokenID, shardID, fromIndex, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, byte, uint64, int) error); ok {
+		r1 = rf(tokenID, shardID, fromIndex, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOneTimeOutputCoinsLength provides a mock function with given fields: tokenID, shardID
+func (_m *DatabaseInterface) GetOneTimeOutputCoinsLength(tokenID common.Hash, shardID byte) (uint64, error) {
+	ret := _m.Called(tokenID, shardID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Hash, byte) uint64); ok {
+		r0 = rf(tokenID, shardID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, byte) error); ok {
+		r1 = rf(tokenID, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrivacyTokenInfo provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) GetPrivacyTokenInfo(tokenID common.Hash) ([]byte, error) {
+	ret := _m.Called(tokenID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrivacyTokenSupply provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) GetPrivacyTokenSupply(tokenID common.Hash) (uint64, error) {
+	ret := _m.Called(tokenID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
+		r0 = rf(tokenID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRewardOfShardByEpoch provides a mock function with given fields: epoch, shardID, tokenID
 func (_m *DatabaseInterface) GetRewardOfShardByEpoch(epoch uint64, shardID byte, tokenID common.Hash) (uint64, error) {
 	ret := _m.Called(epoch, shardID, tokenID)
@@ -1367,6 +1564,20 @@ func (_m *DatabaseInterface) HasValue(key []byte) (bool, error) {
 	return r0, r1
 }
 
+// ImportStateSnapshot provides a mock function with given fields: data
+func (_m *DatabaseInterface) ImportStateSnapshot(data []database.BatchData) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]database.BatchData) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertETHTxHashIssued provides a mock function with given fields: _a0
 func (_m *DatabaseInterface) InsertETHTxHashIssued(_a0 []byte) error {
 	ret := _m.Called(_a0)
@@ -1508,6 +1719,29 @@ func (_m *DatabaseInterface) ListPrivacyCustomTokenCrossShard() ([][]byte, error
 	return r0, r1
 }
 
+// ListPrivacyTokenInfo provides a mock function with given fields:
+func (_m *DatabaseInterface) ListPrivacyTokenInfo() ([][]byte, error) {
+	ret := _m.Called()
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListSerialNumber provides a mock function with given fields: tokenID, shardID
 func (_m *DatabaseInterface) ListSerialNumber(tokenID common.Hash, shardID byte) (map[string]uint64, error) {
 	ret := _m.Called(tokenID, shardID)
@@ -1531,6 +1765,38 @@ func (_m *DatabaseInterface) ListSerialNumber(tokenID common.Hash, shardID byte)
 	return r0, r1
 }
 
+// ListTxQueryIndex provides a mock function with given fields: filter, cursor, limit
+func (_m *DatabaseInterface) ListTxQueryIndex(filter database.TxQueryFilter, cursor []byte, limit int) ([]database.TxQueryIndex, []byte, error) {
+	ret := _m.Called(filter, cursor, limit)
+
+	var r0 []database.TxQueryIndex
+	if rf, ok := ret.Get(0).(func(database.TxQueryFilter, []byte, int) []database.TxQueryIndex); ok {
+		r0 = rf(filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.TxQueryIndex)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func(database.TxQueryFilter, []byte, int) []byte); ok {
+		r1 = rf(filter, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(database.TxQueryFilter, []byte, int) error); ok {
+		r2 = rf(filter, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PrivacyCustomTokenIDCrossShardExisted provides a mock function with given fields: tokenID
 func (_m *DatabaseInterface) PrivacyCustomTokenIDCrossShardExisted(tokenID common.Hash) bool {
 	ret := _m.Called(tokenID)
@@ -1582,6 +1848,20 @@ func (_m *DatabaseInterface) PrivacyCustomTokenTxs(tokenID common.Hash) ([]commo
 	return r0, r1
 }
 
+// PruneBlockBody provides a mock function with given fields: _a0, _a1
+func (_m *DatabaseInterface) PruneBlockBody(_a0 common.Hash, _a1 interface{}) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, interface{}) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Put provides a mock function with given fields: key, value
 func (_m *DatabaseInterface) Put(key []byte, value []byte) error {
 	ret := _m.Called(key, value)
@@ -1904,6 +2184,20 @@ func (_m *DatabaseInterface) StoreOutputCoins(tokenID common.Hash, publicKey []b
 	return r0
 }
 
+// StoreOneTimeOutputCoins provides a mock function with given fields: tokenID, outputCoinArr, shardID
+func (_m *DatabaseInterface) StoreOneTimeOutputCoins(tokenID common.Hash, outputCoinArr [][]byte, shardID byte) error {
+	ret := _m.Called(tokenID, outputCoinArr, shardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, [][]byte, byte) error); ok {
+		r0 = rf(tokenID, outputCoinArr, shardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StorePrevBestState provides a mock function with given fields: _a0, _a1, _a2
 func (_m *DatabaseInterface) StorePrevBestState(_a0 []byte, _a1 bool, _a2 byte) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -1918,6 +2212,34 @@ func (_m *DatabaseInterface) StorePrevBestState(_a0 []byte, _a1 bool, _a2 byte)
 	return r0
 }
 
+// StorePrivacyTokenInfo provides a mock function with given fields: tokenID, info
+func (_m *DatabaseInterface) StorePrivacyTokenInfo(tokenID common.Hash, info []byte) error {
+	ret := _m.Called(tokenID, info)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte) error); ok {
+		r0 = rf(tokenID, info)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorePrunedHeight provides a mock function with given fields: _a0, _a1, _a2
+func (_m *DatabaseInterface) StorePrunedHeight(_a0 bool, _a1 byte, _a2 uint64) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool, byte, uint64) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StorePrivacyCustomToken provides a mock function with given fields: tokenID, data
 func (_m *DatabaseInterface) StorePrivacyCustomToken(tokenID common.Hash, data []byte) error {
 	ret := _m.Called(tokenID, data)
@@ -2072,6 +2394,20 @@ func (_m *DatabaseInterface) StoreTxByPublicKey(publicKey []byte, txID common.Ha
 	return r0
 }
 
+// StoreTxQueryIndex provides a mock function with given fields: index
+func (_m *DatabaseInterface) StoreTxQueryIndex(index database.TxQueryIndex) error {
+	ret := _m.Called(index)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(database.TxQueryIndex) error); ok {
+		r0 = rf(index)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TrackBridgeReqWithStatus provides a mock function with given fields: txReqID, status
 func (_m *DatabaseInterface) TrackBridgeReqWithStatus(txReqID common.Hash, status byte) error {
 	ret := _m.Called(txReqID, status)
@@ -2099,3 +2435,17 @@ func (_m *DatabaseInterface) UpdateBridgeTokenInfo(_a0 common.Hash, _a1 []byte,
 
 	return r0
 }
+
+// UpdatePrivacyTokenSupply provides a mock function with given fields: tokenID, amount, updateType
+func (_m *DatabaseInterface) UpdatePrivacyTokenSupply(tokenID common.Hash, amount uint64, updateType string) error {
+	ret := _m.Called(tokenID, amount, updateType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, uint64, string) error); ok {
+		r0 = rf(tokenID, amount, updateType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
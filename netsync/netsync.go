@@ -176,6 +176,10 @@ out:
 						{
 							netSync.handleMessageBFTMsg(msg)
 						}
+					case *wire.MessageBFTViewChange:
+						{
+							netSync.handleMessageBFTMsg(msg)
+						}
 					case *wire.MessageBlockBeacon:
 						{
 							netSync.handleMessageBeaconBlock(msg)
@@ -308,7 +312,7 @@ func (netSync *NetSync) handleMessageTx(msg *wire.MessageTx) {
 	if isAdded := netSync.handleCacheTx(*msg.Transaction.Hash()); !isAdded {
 		hash, _, err := netSync.config.TxMemPool.MaybeAcceptTransaction(msg.Transaction)
 		if err != nil {
-			Logger.log.Error(err)
+			Logger.log.WithFields(common.LogFields{TxHash: msg.Transaction.Hash().String()}).Error(err)
 		} else {
 			// Broadcast to network
 			Logger.log.Debugf("there is hash of transaction %s", hash.String())
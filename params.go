@@ -12,20 +12,23 @@ var activeNetParams = &mainNetParams
 // network and test networks.
 type params struct {
 	*blockchain.Params
-	rpcPort string
-	wsPort  string
+	rpcPort  string
+	wsPort   string
+	grpcPort string
 }
 
 var mainNetParams = params{
-	Params:  &blockchain.ChainMainParam,
-	rpcPort: MainnetRpcServerPort,
-	wsPort:  MainnetWsServerPort,
+	Params:   &blockchain.ChainMainParam,
+	rpcPort:  MainnetRpcServerPort,
+	wsPort:   MainnetWsServerPort,
+	grpcPort: MainnetGrpcServerPort,
 }
 
 var testNetParams = params{
-	Params:  &blockchain.ChainTestParam,
-	rpcPort: TestnetRpcServerPort,
-	wsPort:  TestnetWsServerPort,
+	Params:   &blockchain.ChainTestParam,
+	rpcPort:  TestnetRpcServerPort,
+	wsPort:   TestnetWsServerPort,
+	grpcPort: TestnetGrpcServerPort,
 }
 
 // netName returns the name used when referring to a coin network.
@@ -134,6 +134,11 @@ func (p *PeerConn) SetVerValid(v bool) {
 
 // end GET/SET func
 
+// logger returns peer logger with remote peer ID attached to log messages
+func (peerConn *PeerConn) logger() common.Logger {
+	return Logger.log.WithFields(common.LogFields{PeerID: peerConn.remotePeerID.Pretty()})
+}
+
 // readString - read data from received message on stream
 // and convert to string format
 func (peerConn *PeerConn) readString(rw *bufio.ReadWriter, delim byte, maxReadBytes int) (string, error) {
@@ -175,15 +180,15 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 	hashMsgRaw := common.HashH(jsonDecodeBytesRaw).String()
 	if peerConn.listenerPeer != nil {
 		if err := peerConn.listenerPeer.HashToPool(hashMsgRaw); err != nil {
-			Logger.log.Error(err)
+			peerConn.logger().Error(err)
 			return NewPeerError(HashToPoolError, err, nil)
 		}
 	}
 	// unzip data before process
 	jsonDecodeBytes, err := common.GZipToBytes(jsonDecodeBytesRaw)
 	if err != nil {
-		Logger.log.Error("Can not unzip from message")
-		Logger.log.Error(err)
+		peerConn.logger().Error("Can not unzip from message")
+		peerConn.logger().Error(err)
 		return NewPeerError(UnzipMessageError, err, nil)
 	}
 
@@ -200,13 +205,13 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 	// convert to particular message from message cmd type
 	message, err := wire.MakeEmptyMessage(string(commandType))
 	if err != nil {
-		Logger.log.Error("Can not find particular message for message cmd type")
-		Logger.log.Error(err)
+		peerConn.logger().Error("Can not find particular message for message cmd type")
+		peerConn.logger().Error(err)
 		return NewPeerError(MessageTypeError, err, nil)
 	}
 
 	if len(jsonDecodeBytes) > message.MaxPayloadLength(wire.Version) {
-		Logger.log.Errorf("Msg size exceed MsgType %s max size, size %+v | max allow is %+v \n", commandType, len(jsonDecodeBytes), message.MaxPayloadLength(1))
+		peerConn.logger().Errorf("Msg size exceed MsgType %s max size, size %+v | max allow is %+v \n", commandType, len(jsonDecodeBytes), message.MaxPayloadLength(1))
 		return NewPeerError(MessageTypeError, err, nil)
 	}
 	// check forward
@@ -220,7 +225,7 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 					if peerConn.config.MessageListeners.PushRawBytesToShard != nil {
 						err1 := peerConn.config.MessageListeners.PushRawBytesToShard(peerConn, &jsonDecodeBytesRaw, *cShard)
 						if err1 != nil {
-							Logger.log.Error(err1)
+							peerConn.logger().Error(err1)
 						}
 					}
 					return NewPeerError(CheckForwardError, err, nil)
@@ -233,7 +238,7 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 				if peerConn.config.MessageListeners.PushRawBytesToBeacon != nil {
 					err1 := peerConn.config.MessageListeners.PushRawBytesToBeacon(peerConn, &jsonDecodeBytesRaw)
 					if err1 != nil {
-						Logger.log.Error(err1)
+						peerConn.logger().Error(err1)
 					}
 				}
 				return NewPeerError(CheckForwardError, err, nil)
@@ -243,8 +248,8 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 
 	err = json.Unmarshal(messageBody, &message)
 	if err != nil {
-		Logger.log.Error("Can not parse struct from json message")
-		Logger.log.Error(err)
+		peerConn.logger().Error("Can not parse struct from json message")
+		peerConn.logger().Error(err)
 		return NewPeerError(ParseJsonMessageError, err, nil)
 	}
 	realType := reflect.TypeOf(message)
@@ -254,7 +259,7 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 	if peerConn.listenerPeer != nil {
 		hashMsg := message.Hash()
 		if err := peerConn.listenerPeer.HashToPool(hashMsg); err != nil {
-			Logger.log.Error(err)
+			peerConn.logger().Error(err)
 			return NewPeerError(CacheMessageHashError, err, nil)
 		}
 	}
@@ -262,7 +267,7 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 	// process message for each of message type
 	errProcessMessage := peerConn.processMessageForEachType(realType, message)
 	if errProcessMessage != nil {
-		Logger.log.Error(errProcessMessage)
+		peerConn.logger().Error(errProcessMessage)
 	}
 
 	// MONITOR INBOUND MESSAGE
@@ -355,6 +360,10 @@ func (peerConn *PeerConn) processMessageForEachType(messageType reflect.Type, me
 		if peerConn.config.MessageListeners.OnBFTMsg != nil {
 			peerConn.config.MessageListeners.OnBFTMsg(peerConn, message.(*wire.MessageBFTReq))
 		}
+	case reflect.TypeOf(&wire.MessageBFTViewChange{}):
+		if peerConn.config.MessageListeners.OnBFTMsg != nil {
+			peerConn.config.MessageListeners.OnBFTMsg(peerConn, message.(*wire.MessageBFTViewChange))
+		}
 	case reflect.TypeOf(&wire.MessagePeerState{}):
 		if peerConn.config.MessageListeners.OnPeerState != nil {
 			peerConn.config.MessageListeners.OnPeerState(peerConn, message.(*wire.MessagePeerState))
@@ -391,11 +400,11 @@ func (peerConn *PeerConn) inMessageHandler(rw *bufio.ReadWriter) error {
 		if errR != nil {
 			// we has an error when read stream message an can not parse to string data
 			peerConn.setIsConnected(false)
-			Logger.log.Error("---------------------------------------------------------------------")
-			Logger.log.Errorf("InMessageHandler ERROR %s %s", peerConn.remotePeerID.Pretty(), peerConn.remotePeer.GetRawAddress())
-			Logger.log.Error(errR)
-			Logger.log.Errorf("InMessageHandler QUIT")
-			Logger.log.Error("---------------------------------------------------------------------")
+			peerConn.logger().Error("---------------------------------------------------------------------")
+			peerConn.logger().Errorf("InMessageHandler ERROR %s %s", peerConn.remotePeerID.Pretty(), peerConn.remotePeer.GetRawAddress())
+			peerConn.logger().Error(errR)
+			peerConn.logger().Errorf("InMessageHandler QUIT")
+			peerConn.logger().Error("---------------------------------------------------------------------")
 			close(peerConn.cWrite)
 			return errR
 		}
@@ -435,8 +444,8 @@ func (peerConn *PeerConn) outMessageHandler(rw *bufio.ReadWriter) {
 					// Create and send messageHex
 					messageBytes, err := outMsg.message.JsonSerialize()
 					if err != nil {
-						Logger.log.Error("Can not serialize json format for messageHex:" + outMsg.message.MessageType())
-						Logger.log.Error(err)
+						peerConn.logger().Error("Can not serialize json format for messageHex:" + outMsg.message.MessageType())
+						peerConn.logger().Error(err)
 						continue
 					}
 
@@ -445,8 +454,8 @@ func (peerConn *PeerConn) outMessageHandler(rw *bufio.ReadWriter) {
 					// add command type of message
 					cmdType, messageErr := wire.GetCmdType(reflect.TypeOf(outMsg.message))
 					if messageErr != nil {
-						Logger.log.Error("Can not get cmd type for " + outMsg.message.MessageType())
-						Logger.log.Error(messageErr)
+						peerConn.logger().Error("Can not get cmd type for " + outMsg.message.MessageType())
+						peerConn.logger().Error(messageErr)
 						continue
 					}
 					copy(headerBytes[:], []byte(cmdType))
@@ -462,8 +471,8 @@ func (peerConn *PeerConn) outMessageHandler(rw *bufio.ReadWriter) {
 					// zip data before send
 					messageBytes, err = common.GZipFromBytes(messageBytes)
 					if err != nil {
-						Logger.log.Error("Can not gzip for messageHex:" + outMsg.message.MessageType())
-						Logger.log.Error(err)
+						peerConn.logger().Error("Can not gzip for messageHex:" + outMsg.message.MessageType())
+						peerConn.logger().Error(err)
 						continue
 					}
 					messageHex := hex.EncodeToString(messageBytes)
@@ -482,12 +491,12 @@ func (peerConn *PeerConn) outMessageHandler(rw *bufio.ReadWriter) {
 
 				_, err := rw.Writer.WriteString(sendString)
 				if err != nil {
-					Logger.log.Critical("OutMessageHandler WriteString error", err)
+					peerConn.logger().Critical("OutMessageHandler WriteString error", err)
 					continue
 				}
 				err = rw.Writer.Flush()
 				if err != nil {
-					Logger.log.Critical("OutMessageHandler Flush error", err)
+					peerConn.logger().Critical("OutMessageHandler Flush error", err)
 					continue
 				}
 				continue
@@ -17,7 +17,8 @@ type Coin struct {
 	serialNumber   *EllipticPoint
 	randomness     *big.Int
 	value          uint64
-	info           []byte //256 bytes
+	info           []byte         //256 bytes
+	txRandom       *EllipticPoint // set if publicKey is a one-time public key
 }
 
 // Start GET/SET
@@ -73,10 +74,25 @@ func (coin Coin) GetInfo() []byte {
 	return coin.info
 }
 
+// SetInfo sets info of coin to a copy of v, empty v clears it.
+// Info used to be dropped here (copy into nil info), coins built from RPC params now keep it
 func (coin *Coin) SetInfo(v []byte) {
+	if len(v) == 0 {
+		coin.info = nil
+		return
+	}
+	coin.info = make([]byte, len(v))
 	copy(coin.info, v)
 }
 
+func (coin Coin) GetTxRandom() *EllipticPoint {
+	return coin.txRandom
+}
+
+func (coin *Coin) SetTxRandom(v *EllipticPoint) {
+	coin.txRandom = v
+}
+
 // END Get/Set
 
 // Init (Coin) initializes a coin
@@ -201,6 +217,14 @@ func (coin *Coin) Bytes() []byte {
 		coinBytes = append(coinBytes, byte(0))
 	}
 
+	// tx random is appended only to coins with one-time public key,
+	// so bytes of the other coins are the same as before
+	if coin.txRandom != nil {
+		txRandom := coin.txRandom.Compress()
+		coinBytes = append(coinBytes, byte(len(txRandom)))
+		coinBytes = append(coinBytes, txRandom...)
+	}
+
 	return coinBytes
 }
 
@@ -282,6 +306,23 @@ func (coin *Coin) SetBytes(coinBytes []byte) error {
 	if lenField != 0 {
 		coin.info = make([]byte, lenField)
 		copy(coin.info, coinBytes[offset:offset+int(lenField)])
+		offset += int(lenField)
+	}
+
+	// Parse TxRandom
+	if offset < len(coinBytes) {
+		lenField = coinBytes[offset]
+		offset++
+		if lenField != 0 {
+			if offset+int(lenField) > len(coinBytes) {
+				return errors.New("invalid tx random of coin")
+			}
+			coin.txRandom = new(EllipticPoint)
+			err = coin.txRandom.Decompress(coinBytes[offset : offset+int(lenField)])
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -332,6 +373,13 @@ func (outputCoin *OutputCoin) Init() *OutputCoin {
 func (outputCoin *OutputCoin) Bytes() []byte {
 	var outCoinBytes []byte
 
+	// coin with long info and tx random doesn't fit in 1 byte length
+	coinDetailBytes := outputCoin.CoinDetails.Bytes()
+	wide := IsWideLength(len(coinDetailBytes))
+	if wide {
+		outCoinBytes = append(outCoinBytes, WideLengthMarker)
+	}
+
 	if outputCoin.CoinDetailsEncrypted != nil {
 		coinDetailsEncryptedBytes := outputCoin.CoinDetailsEncrypted.Bytes()
 		outCoinBytes = append(outCoinBytes, byte(len(coinDetailsEncryptedBytes)))
@@ -340,8 +388,7 @@ func (outputCoin *OutputCoin) Bytes() []byte {
 		outCoinBytes = append(outCoinBytes, byte(0))
 	}
 
-	coinDetailBytes := outputCoin.CoinDetails.Bytes()
-	outCoinBytes = append(outCoinBytes, byte(len(coinDetailBytes)))
+	outCoinBytes = AppendLength(outCoinBytes, len(coinDetailBytes), wide)
 	outCoinBytes = append(outCoinBytes, coinDetailBytes...)
 	return outCoinBytes
 }
@@ -354,7 +401,14 @@ func (outputCoin *OutputCoin) SetBytes(bytes []byte) error {
 	}
 
 	offset := 0
-	lenCoinDetailEncrypted := int(bytes[0])
+	wide := bytes[0] == WideLengthMarker
+	if wide {
+		offset += 1
+	}
+	if offset >= len(bytes) {
+		return errors.New("coinBytes is invalid")
+	}
+	lenCoinDetailEncrypted := int(bytes[offset])
 	offset += 1
 
 	if lenCoinDetailEncrypted > 0 {
@@ -366,8 +420,10 @@ func (outputCoin *OutputCoin) SetBytes(bytes []byte) error {
 		offset += lenCoinDetailEncrypted
 	}
 
-	lenCoinDetail := int(bytes[offset])
-	offset += 1
+	lenCoinDetail, offset, err := ReadLength(bytes, offset, wide)
+	if err != nil {
+		return err
+	}
 
 	if lenCoinDetail > 0 {
 		outputCoin.CoinDetails = new(Coin)
@@ -350,6 +350,36 @@ func TestOutputCoinBytesSetBytes(t *testing.T) {
 	assert.Equal(t, coin, coin2)
 }
 
+func TestOutputCoinBytesSetBytesLengthFormat(t *testing.T) {
+	coin := new(OutputCoin).Init()
+	privateKey := GeneratePrivateKey([]byte{1, 2, 3})
+	coin.CoinDetails.publicKey.Decompress(GeneratePublicKey(privateKey))
+	coin.CoinDetails.snDerivator = RandScalar()
+	coin.CoinDetails.randomness = RandScalar()
+	coin.CoinDetails.value = uint64(100)
+	coin.CoinDetails.serialNumber = PedCom.G[0].Derive(new(big.Int).SetBytes(privateKey), coin.CoinDetails.snDerivator)
+	coin.CoinDetails.CommitAll()
+	coin.CoinDetailsEncrypted = nil
+
+	// coin details of exactly 255 bytes keep 1 byte length
+	coin.CoinDetails.info = []byte{}
+	coin.CoinDetails.info = make([]byte, 255-len(coin.CoinDetails.Bytes()))
+	assert.Equal(t, 255, len(coin.CoinDetails.Bytes()))
+	coinBytes := coin.Bytes()
+	assert.Equal(t, []byte{0, 255}, coinBytes[:2])
+	coin2 := new(OutputCoin)
+	assert.Equal(t, nil, coin2.SetBytes(coinBytes))
+	assert.Equal(t, coin.CoinDetails.Bytes(), coin2.CoinDetails.Bytes())
+
+	// longer coin details are marked and use 2 bytes length
+	coin.CoinDetails.info = make([]byte, 200)
+	coinBytes = coin.Bytes()
+	assert.Equal(t, byte(WideLengthMarker), coinBytes[0])
+	coin2 = new(OutputCoin)
+	assert.Equal(t, nil, coin2.SetBytes(coinBytes))
+	assert.Equal(t, coin.CoinDetails.Bytes(), coin2.CoinDetails.Bytes())
+}
+
 func TestOutputCoinBytesSetBytesWithMissingFields(t *testing.T) {
 	// init coin with fully fields
 	// init public key
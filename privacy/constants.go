@@ -11,4 +11,7 @@ const (
 
 	CommitmentRingSize    = 8
 	CommitmentRingSizeExp = 3
+
+	// ring size of one out of many proofs is 2^n with CommitmentRingSizeExp <= n <= MaxCommitmentRingSizeExp
+	MaxCommitmentRingSizeExp = 5
 )
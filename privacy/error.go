@@ -33,6 +33,12 @@ const (
 	SignMultiSigErr
 	InvalidLengthMultiSigErr
 	InvalidMultiSigErr
+	GenerateOneTimePublicKeyErr
+	InvalidCommitmentRingSizeErr
+	EncryptMemoErr
+	DecryptMemoErr
+	InvalidMultiSigAccountErr
+	VerifyMultiSigSpendingFailedErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -55,6 +61,11 @@ var ErrCodeMessage = map[int]struct {
 	SignMultiSigErr:                 {-9012, "Can not sign multi sig"},
 	InvalidLengthMultiSigErr:        {-9013, "Invalid length of multi sig signature"},
 	InvalidMultiSigErr:              {-9014, "invalid multiSig for converting to bytes array"},
+	GenerateOneTimePublicKeyErr:     {-9015, "Can not generate one-time public key for receiver"},
+	InvalidCommitmentRingSizeErr:    {-9016, "Invalid ring size of commitments"},
+	EncryptMemoErr:                  {-9017, "Can not encrypt memo of output coin"},
+	DecryptMemoErr:                  {-9018, "Can not decrypt memo of output coin"},
+	InvalidMultiSigAccountErr:       {-9019, "Invalid multisig account"},
 
 	ProveSerialNumberNoPrivacyErr: {-9100, "Proving serial number no privacy proof error"},
 	ProveOneOutOfManyErr:          {-9101, "Proving one out of many proof error"},
@@ -69,6 +80,7 @@ var ErrCodeMessage = map[int]struct {
 	VerifySerialNumberPrivacyProofFailedErr:   {-9206, "Verify serial number privacy proof failed"},
 	VerifyAggregatedProofFailedErr:            {-9207, "Verify aggregated proof failed"},
 	VerifyAmountPrivacyFailedErr:              {-9208, "Sum of input coins' amount is not equal sum of output coins' amount when creating private tx"},
+	VerifyMultiSigSpendingFailedErr:           {-9209, "Verify spending coins of multisig account failed"},
 }
 
 type PrivacyError struct {
@@ -32,10 +32,12 @@ type PaymentAddress struct {
 	Tk TransmissionKey // 33 bytes, use to encrypt pointByte
 }
 
-// PaymentInfo contains an address of a payee and a value of coins he/she will receive
+// PaymentInfo contains an address of a payee and a value of coins he/she will receive,
+// Memo (optional) is encrypted for the payee and attached to the output coin
 type PaymentInfo struct {
 	PaymentAddress PaymentAddress
 	Amount         uint64
+	Memo           []byte
 }
 
 // GeneratePrivateKey generates a random 32-byte spending key
@@ -0,0 +1,80 @@
+package privacy
+
+import (
+	"bytes"
+	"crypto/aes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	// MaxMemoSize is the max size of a memo, encrypted memo (ElGamal ciphertext of AES key + IV + memo + checksum)
+	// must fit in info of coin (255 bytes)
+	MaxMemoSize = 160
+
+	memoChecksumSize = 4
+)
+
+// memoChecksum is appended to memo before encrypting so a receiver can tell
+// whether info of a coin is a memo encrypted for it
+func memoChecksum(memo []byte) []byte {
+	return common.HashB(memo)[:memoChecksumSize]
+}
+
+// GetEncryptedMemoSize returns size of a memo with memoSize bytes after encrypting
+func GetEncryptedMemoSize(memoSize int) int {
+	return elGamalCiphertextSize + aes.BlockSize + memoSize + memoChecksumSize
+}
+
+// EncryptMemo encrypts memo for the receiver of coin with its transmission key,
+// the ciphertext is kept in info of coin so only the receiver can read it.
+// Info of coin is a memo only in txs whose version supports memos, see transaction.IsMemoSupported
+func (coin *Coin) EncryptMemo(memo []byte, recipientTK TransmissionKey) *PrivacyError {
+	if len(memo) == 0 || len(memo) > MaxMemoSize {
+		return NewPrivacyErr(EncryptMemoErr, fmt.Errorf("size of memo must be in range [1, %v]", MaxMemoSize))
+	}
+
+	transmissionKey := new(EllipticPoint)
+	err := transmissionKey.Decompress(recipientTK)
+	if err != nil {
+		return NewPrivacyErr(DecompressTransmissionKeyErr, err)
+	}
+
+	msg := append(append([]byte{}, memo...), memoChecksum(memo)...)
+	ciphertext, err := hybridEncrypt(msg, transmissionKey)
+	if err != nil {
+		return NewPrivacyErr(EncryptMemoErr, err)
+	}
+	coin.info = ciphertext.Bytes()
+	return nil
+}
+
+// DecryptMemo decrypts memo of coin with receiver's receiving key,
+// it returns an error if info of coin is not a memo encrypted for the viewing key
+func (coin Coin) DecryptMemo(viewingKey ViewingKey) ([]byte, *PrivacyError) {
+	if len(coin.info) <= GetEncryptedMemoSize(0) {
+		return nil, NewPrivacyErr(DecryptMemoErr, errors.New("invalid memo"))
+	}
+
+	ciphertext := new(hybridCipherText)
+	err := ciphertext.SetBytes(coin.info)
+	if err != nil {
+		return nil, NewPrivacyErr(DecryptMemoErr, err)
+	}
+	msg, err := hybridDecrypt(ciphertext, new(big.Int).SetBytes(viewingKey.Rk))
+	if err != nil {
+		return nil, NewPrivacyErr(DecryptMemoErr, err)
+	}
+	if len(msg) <= memoChecksumSize {
+		return nil, NewPrivacyErr(DecryptMemoErr, errors.New("invalid memo"))
+	}
+
+	memo := msg[:len(msg)-memoChecksumSize]
+	if !bytes.Equal(memoChecksum(memo), msg[len(msg)-memoChecksumSize:]) {
+		return nil, NewPrivacyErr(DecryptMemoErr, errors.New("memo is not encrypted for viewing key"))
+	}
+	return memo, nil
+}
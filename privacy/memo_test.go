@@ -0,0 +1,71 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemo(t *testing.T) {
+	privateKey := GeneratePrivateKey([]byte{1})
+	paymentAddress := GeneratePaymentAddress(privateKey)
+	viewingKey := GenerateViewingKey(privateKey)
+
+	coin := new(Coin).Init()
+	_, err := coin.DecryptMemo(viewingKey)
+	assert.NotEqual(t, (*PrivacyError)(nil), err)
+
+	memo := []byte("payment id 123456")
+	err = coin.EncryptMemo(memo, paymentAddress.Tk)
+	assert.Equal(t, (*PrivacyError)(nil), err)
+	assert.Equal(t, GetEncryptedMemoSize(len(memo)), len(coin.GetInfo()))
+	assert.NotEqual(t, memo, coin.GetInfo())
+
+	// memo is kept in bytes of coin
+	coin2 := new(Coin)
+	assert.Equal(t, nil, coin2.SetBytes(coin.Bytes()))
+	res, err := coin2.DecryptMemo(viewingKey)
+	assert.Equal(t, (*PrivacyError)(nil), err)
+	assert.Equal(t, memo, res)
+
+	// memo can not be read by other keys
+	otherViewingKey := GenerateViewingKey(GeneratePrivateKey([]byte{2}))
+	_, err = coin2.DecryptMemo(otherViewingKey)
+	assert.NotEqual(t, (*PrivacyError)(nil), err)
+
+	// memo size is limited
+	err = coin.EncryptMemo(make([]byte, MaxMemoSize+1), paymentAddress.Tk)
+	assert.NotEqual(t, (*PrivacyError)(nil), err)
+	err = coin.EncryptMemo(make([]byte, MaxMemoSize), paymentAddress.Tk)
+	assert.Equal(t, (*PrivacyError)(nil), err)
+	assert.Equal(t, nil, coin2.SetBytes(coin.Bytes()))
+}
+
+func TestMemoInvalidInfo(t *testing.T) {
+	viewingKey := GenerateViewingKey(GeneratePrivateKey([]byte{1}))
+
+	// info which is not an encrypted memo is not decrypted whatever its size
+	coin := new(Coin).Init()
+	coin.SetInfo(RandBytes(GetEncryptedMemoSize(MaxMemoSize)))
+	_, err := coin.DecryptMemo(viewingKey)
+	assert.NotEqual(t, (*PrivacyError)(nil), err)
+
+	// info which is too short to be a memo
+	coin.SetInfo([]byte{1, 2, 3})
+	_, err = coin.DecryptMemo(viewingKey)
+	assert.NotEqual(t, (*PrivacyError)(nil), err)
+}
+
+func TestCoinSetInfo(t *testing.T) {
+	coin := new(Coin).Init()
+	info := []byte{1, 2, 3}
+	coin.SetInfo(info)
+	assert.Equal(t, info, coin.GetInfo())
+
+	// info is copied
+	info[0] = 4
+	assert.Equal(t, []byte{1, 2, 3}, coin.GetInfo())
+
+	coin.SetInfo(nil)
+	assert.Equal(t, 0, len(coin.GetInfo()))
+}
@@ -0,0 +1,85 @@
+package privacy
+
+import (
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// MultiExp accumulates terms factor*point of a multi-scalar multiplication,
+// factors of the same point are summed up so every distinct point is multiplied only once
+type MultiExp struct {
+	points  []*EllipticPoint
+	factors []*big.Int
+	indexes map[string]int
+}
+
+func NewMultiExp() *MultiExp {
+	return &MultiExp{
+		points:  []*EllipticPoint{},
+		factors: []*big.Int{},
+		indexes: make(map[string]int),
+	}
+}
+
+// Add adds term factor*point to the multi-scalar multiplication
+func (multiExp *MultiExp) Add(point *EllipticPoint, factor *big.Int) {
+	key := string(point.Compress())
+	if i, ok := multiExp.indexes[key]; ok {
+		multiExp.factors[i].Add(multiExp.factors[i], factor)
+		multiExp.factors[i].Mod(multiExp.factors[i], Curve.Params().N)
+		return
+	}
+	multiExp.indexes[key] = len(multiExp.points)
+	multiExp.points = append(multiExp.points, point)
+	multiExp.factors = append(multiExp.factors, new(big.Int).Mod(factor, Curve.Params().N))
+}
+
+// Len returns number of distinct points in the multi-scalar multiplication
+func (multiExp MultiExp) Len() int {
+	return len(multiExp.points)
+}
+
+// Eval returns sum of all terms factor*point, scalar multiplications are run concurrently
+func (multiExp MultiExp) Eval() *EllipticPoint {
+	return MultiScalarMult(multiExp.points, multiExp.factors)
+}
+
+// IsZero returns true if sum of all terms is the point at infinity
+func (multiExp MultiExp) IsZero() bool {
+	res := multiExp.Eval()
+	return res.x.Sign() == 0 && res.y.Sign() == 0
+}
+
+// MultiScalarMult returns sum of factors[i]*points[i], points and factors must have the same length
+func MultiScalarMult(points []*EllipticPoint, factors []*big.Int) *EllipticPoint {
+	numWorker := runtime.NumCPU()
+	if numWorker > len(points) {
+		numWorker = len(points)
+	}
+	partialSums := make([]*EllipticPoint, numWorker)
+	var wg sync.WaitGroup
+	wg.Add(numWorker)
+	for w := 0; w < numWorker; w++ {
+		go func(w int) {
+			defer wg.Done()
+			sum := new(EllipticPoint)
+			sum.Zero()
+			for i := w; i < len(points); i += numWorker {
+				if factors[i].Sign() == 0 {
+					continue
+				}
+				sum = sum.Add(points[i].ScalarMult(factors[i]))
+			}
+			partialSums[w] = sum
+		}(w)
+	}
+	wg.Wait()
+
+	res := new(EllipticPoint)
+	res.Zero()
+	for _, sum := range partialSums {
+		res = res.Add(sum)
+	}
+	return res
+}
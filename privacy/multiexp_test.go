@@ -0,0 +1,36 @@
+package privacy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestMultiExpEval(t *testing.T) {
+	multiExp := NewMultiExp()
+	expected := new(EllipticPoint)
+	expected.Zero()
+	for i := 0; i < len(PedCom.G); i++ {
+		factor := RandScalar()
+		multiExp.Add(PedCom.G[i], factor)
+		expected = expected.Add(PedCom.G[i].ScalarMult(factor))
+	}
+	// factors of the same point are summed up
+	factor := RandScalar()
+	multiExp.Add(PedCom.G[0], factor)
+	expected = expected.Add(PedCom.G[0].ScalarMult(factor))
+
+	assert.Equal(t, len(PedCom.G), multiExp.Len())
+	assert.Equal(t, true, expected.IsEqual(multiExp.Eval()))
+	assert.Equal(t, false, multiExp.IsZero())
+}
+
+func TestMultiExpIsZero(t *testing.T) {
+	multiExp := NewMultiExp()
+	factor := RandScalar()
+	multiExp.Add(PedCom.G[PedersenValueIndex], factor)
+	multiExp.Add(PedCom.G[PedersenValueIndex], new(big.Int).Neg(factor))
+	multiExp.Add(PedCom.G[PedersenRandomnessIndex], big.NewInt(0))
+
+	assert.Equal(t, true, multiExp.IsZero())
+}
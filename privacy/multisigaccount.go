@@ -0,0 +1,297 @@
+package privacy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+const (
+	// MaxMultiSigPublicKeys is the max number of cosigners of a multisig account
+	MaxMultiSigPublicKeys = 16
+
+	// MultiSigSignersSize is the size of the bitmap of signers in a signature of multisig account
+	MultiSigSignersSize = MaxMultiSigPublicKeys / 8
+
+	// MultiSigSize is the size of a signature of multisig account (bitmap of signers + combined Schnorr multisig)
+	MultiSigSize = MultiSigSignersSize + CompressedEllipticPointSize + common.BigIntSize
+)
+
+var (
+	multiSigAccountDomain      = []byte("multisig-account")
+	multiSigSerialNumberDomain = []byte("multisig-serial-number")
+	multiSigNonceDomain        = []byte("multisig-nonce")
+)
+
+// MultiSigAccount is an M-of-N account whose coins are spent by txs signed by at least M of its N cosigners.
+// Public key of the account is hashed from M, public keys of cosigners and transmission key,
+// so nobody knows the spending key of the account and its coins can not be spent as normal coins.
+// Serial number of a coin of the account is hashed from public key and snd of the coin
+// instead of derived from a spending key
+type MultiSigAccount struct {
+	m               int
+	publicKeys      []PublicKey // sorted, so an account has only one representation
+	transmissionKey TransmissionKey
+}
+
+// NewMultiSigAccount creates an m-of-n account from public keys of n cosigners,
+// transmission key is used by senders to encrypt outputs for the account
+func NewMultiSigAccount(m int, publicKeys []PublicKey, transmissionKey TransmissionKey) (*MultiSigAccount, error) {
+	n := len(publicKeys)
+	if m < 1 || m > n || n > MaxMultiSigPublicKeys {
+		return nil, NewPrivacyErr(InvalidMultiSigAccountErr, fmt.Errorf("can not create %d-of-%d multisig account", m, n))
+	}
+
+	account := &MultiSigAccount{
+		m:          m,
+		publicKeys: make([]PublicKey, n),
+	}
+	for i, publicKey := range publicKeys {
+		point := new(EllipticPoint)
+		if len(publicKey) != CompressedEllipticPointSize || point.Decompress(publicKey) != nil {
+			return nil, NewPrivacyErr(InvalidMultiSigAccountErr, fmt.Errorf("invalid public key of cosigner %d", i))
+		}
+		account.publicKeys[i] = point.Compress()
+	}
+	sort.Slice(account.publicKeys, func(i, j int) bool {
+		return bytes.Compare(account.publicKeys[i], account.publicKeys[j]) < 0
+	})
+	for i := 1; i < n; i++ {
+		if bytes.Equal(account.publicKeys[i-1], account.publicKeys[i]) {
+			return nil, NewPrivacyErr(InvalidMultiSigAccountErr, errors.New("duplicated public key of cosigners"))
+		}
+	}
+
+	point := new(EllipticPoint)
+	if len(transmissionKey) != CompressedEllipticPointSize || point.Decompress(transmissionKey) != nil {
+		return nil, NewPrivacyErr(InvalidMultiSigAccountErr, errors.New("invalid transmission key"))
+	}
+	account.transmissionKey = point.Compress()
+	return account, nil
+}
+
+// GetM returns the number of cosigners required to spend coins of the account
+func (account MultiSigAccount) GetM() int {
+	return account.m
+}
+
+// GetPublicKeys returns sorted public keys of cosigners
+func (account MultiSigAccount) GetPublicKeys() []PublicKey {
+	return account.publicKeys
+}
+
+// GetTransmissionKey returns transmission key of the account
+func (account MultiSigAccount) GetTransmissionKey() TransmissionKey {
+	return account.transmissionKey
+}
+
+// IndexOfPublicKey returns index of a cosigner's public key in sorted public keys, or -1 if it is not a cosigner
+func (account MultiSigAccount) IndexOfPublicKey(publicKey PublicKey) int {
+	for i, pk := range account.publicKeys {
+		if bytes.Equal(pk, publicKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Bytes converts account to bytes array: m || n || public keys || transmission key
+func (account MultiSigAccount) Bytes() []byte {
+	res := []byte{byte(account.m), byte(len(account.publicKeys))}
+	for _, publicKey := range account.publicKeys {
+		res = append(res, publicKey...)
+	}
+	return append(res, account.transmissionKey...)
+}
+
+// SetBytes reverts bytes array to account, only the canonical encoding (sorted public keys) is accepted
+func (account *MultiSigAccount) SetBytes(data []byte) error {
+	if len(data) < 2 || len(data) != 2+(int(data[1])+1)*CompressedEllipticPointSize {
+		return NewPrivacyErr(InvalidMultiSigAccountErr, errors.New("invalid length of multisig account"))
+	}
+	n := int(data[1])
+	publicKeys := make([]PublicKey, n)
+	for i := 0; i < n; i++ {
+		offset := 2 + i*CompressedEllipticPointSize
+		publicKeys[i] = data[offset : offset+CompressedEllipticPointSize]
+	}
+	res, err := NewMultiSigAccount(int(data[0]), publicKeys, data[2+n*CompressedEllipticPointSize:])
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(res.Bytes(), data) {
+		return NewPrivacyErr(InvalidMultiSigAccountErr, errors.New("public keys of multisig account are not sorted"))
+	}
+	*account = *res
+	return nil
+}
+
+// GetPublicKey returns public key of the account, it is a point hashed from the account
+// so its discrete logarithm (spending key) is unknown
+func (account MultiSigAccount) GetPublicKey() *EllipticPoint {
+	return hashToPoint(append(append([]byte{}, multiSigAccountDomain...), account.Bytes()...))
+}
+
+// GetPaymentAddress returns payment address of the account, senders send coins to the account with it
+func (account MultiSigAccount) GetPaymentAddress() PaymentAddress {
+	return PaymentAddress{
+		Pk: account.GetPublicKey().Compress(),
+		Tk: account.transmissionKey,
+	}
+}
+
+// MultiSigSerialNumber returns serial number of a coin of multisig account from its public key and snd
+func MultiSigSerialNumber(publicKey *EllipticPoint, snd *big.Int) *EllipticPoint {
+	data := append([]byte{}, multiSigSerialNumberDomain...)
+	data = append(data, publicKey.Compress()...)
+	data = append(data, common.AddPaddingBigInt(snd, common.BigIntSize)...)
+	return hashToPoint(data)
+}
+
+// hashToPoint derives an elliptic point from data using hash function
+func hashToPoint(data []byte) *EllipticPoint {
+	res := new(EllipticPoint)
+	res.Zero()
+	tmp := data
+	for {
+		tmp = common.HashB(tmp)
+		res.x.SetBytes(tmp)
+		err := res.computeYCoord()
+
+		if (err == nil) && (res.IsSafe()) {
+			break
+		}
+	}
+	return res
+}
+
+// signerPublicKeys returns public keys of signers from their indices in sorted public keys,
+// indices must be increasing
+func (account MultiSigAccount) signerPublicKeys(signers []int) ([]*PublicKey, error) {
+	if len(signers) < account.m {
+		return nil, fmt.Errorf("need at least %d signers, got %d", account.m, len(signers))
+	}
+	res := make([]*PublicKey, len(signers))
+	for i, index := range signers {
+		if index < 0 || index >= len(account.publicKeys) || (i > 0 && index <= signers[i-1]) {
+			return nil, fmt.Errorf("invalid index of signer %d", index)
+		}
+		res[i] = &account.publicKeys[index]
+	}
+	return res, nil
+}
+
+// NonceCommitment returns the commitment of a signer to its public nonce for signing data,
+// signers exchange commitments before revealing nonces so that no signer can choose its nonce
+// after seeing nonces of the others
+func (account MultiSigAccount) NonceCommitment(data []byte, signer int, nonce *EllipticPoint) []byte {
+	res := append([]byte{}, multiSigNonceDomain...)
+	res = append(res, account.Bytes()...)
+	res = append(res, common.HashB(data)...)
+	res = append(res, byte(signer))
+	res = append(res, nonce.Compress()...)
+	return common.HashB(res)
+}
+
+// PartialSign signs data with private key of a cosigner,
+// signers are indices of cosigners joining the signing session, nonceCommitments and nonces are their commitments
+// (from NonceCommitment) and public nonces in the same order
+// and secretNonce is the secret nonce of the cosigner (from MultiSigScheme.GenerateRandom)
+func (account MultiSigAccount) PartialSign(data []byte, privateKey PrivateKey, signers []int, nonceCommitments [][]byte, nonces []*EllipticPoint, secretNonce *big.Int) (*SchnMultiSig, error) {
+	publicKeys, err := account.signerPublicKeys(signers)
+	if err != nil {
+		return nil, NewPrivacyErr(SignMultiSigErr, err)
+	}
+	if len(nonces) != len(signers) || len(nonceCommitments) != len(signers) {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("number of nonces and nonce commitments must be equal to number of signers"))
+	}
+	for i, signer := range signers {
+		if nonces[i] == nil || !bytes.Equal(nonceCommitments[i], account.NonceCommitment(data, signer, nonces[i])) {
+			return nil, NewPrivacyErr(SignMultiSigErr, fmt.Errorf("nonce of signer %d does not match its commitment", signer))
+		}
+	}
+	publicKey := GeneratePublicKey(privateKey)
+	index := account.IndexOfPublicKey(publicKey)
+	isSigner := false
+	for _, signer := range signers {
+		isSigner = isSigner || signer == index
+	}
+	if !isSigner {
+		return nil, NewPrivacyErr(SignMultiSigErr, errors.New("private key is not of a signer"))
+	}
+
+	keyset := new(MultiSigKeyset)
+	keyset.Set(&privateKey, &publicKey)
+	return keyset.SignMultiSig(data, publicKeys, nonces, secretNonce)
+}
+
+// VerifyPartialSig verifies a partial signature of the signer at position i of signers
+func (account MultiSigAccount) VerifyPartialSig(data []byte, signers []int, nonces []*EllipticPoint, i int, sig *SchnMultiSig) bool {
+	publicKeys, err := account.signerPublicKeys(signers)
+	if err != nil || len(nonces) != len(signers) || i < 0 || i >= len(signers) || sig == nil || sig.r == nil || sig.s == nil {
+		return false
+	}
+	combinedNonce := new(EllipticPoint)
+	combinedNonce.Zero()
+	for _, nonce := range nonces {
+		combinedNonce = combinedNonce.Add(nonce)
+	}
+	if !sig.r.IsEqual(nonces[i]) {
+		return false
+	}
+	res, err := sig.VerifyMultiSig(data, publicKeys, []*PublicKey{publicKeys[i]}, combinedNonce)
+	return err == nil && res
+}
+
+// CombineSigs combines partial signatures of signers into a signature of the account,
+// partial signatures are in the same order as signers
+func (account MultiSigAccount) CombineSigs(data []byte, signers []int, partialSigs []*SchnMultiSig) ([]byte, error) {
+	if _, err := account.signerPublicKeys(signers); err != nil {
+		return nil, NewPrivacyErr(InvalidMultiSigErr, err)
+	}
+	if len(partialSigs) != len(signers) {
+		return nil, NewPrivacyErr(InvalidMultiSigErr, errors.New("number of partial signatures must be equal to number of signers"))
+	}
+
+	bitmap := make([]byte, MultiSigSignersSize)
+	for _, index := range signers {
+		bitmap[index/8] |= 1 << uint(index%8)
+	}
+	sigBytes, err := MultiSigScheme{}.CombineMultiSig(partialSigs).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	res := append(bitmap, sigBytes...)
+	if !account.VerifySig(data, res) {
+		return nil, NewPrivacyErr(InvalidMultiSigErr, errors.New("combined signature is invalid"))
+	}
+	return res, nil
+}
+
+// VerifySig verifies a signature of the account, which must be signed by at least M cosigners
+func (account MultiSigAccount) VerifySig(data []byte, sig []byte) bool {
+	if len(sig) != MultiSigSize {
+		return false
+	}
+	signers := []int{}
+	for i := 0; i < MaxMultiSigPublicKeys; i++ {
+		if sig[i/8]&(1<<uint(i%8)) != 0 {
+			signers = append(signers, i)
+		}
+	}
+	publicKeys, err := account.signerPublicKeys(signers)
+	if err != nil {
+		return false
+	}
+
+	multiSig := new(SchnMultiSig)
+	if multiSig.SetBytes(sig[MultiSigSignersSize:]) != nil {
+		return false
+	}
+	res, err := multiSig.VerifyMultiSig(data, publicKeys, publicKeys, multiSig.r)
+	return err == nil && res
+}
@@ -0,0 +1,160 @@
+package privacy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMultiSigAccount(t *testing.T, m, n int) (*MultiSigAccount, []PrivateKey) {
+	privateKeys := make([]PrivateKey, n)
+	publicKeys := make([]PublicKey, n)
+	for i := 0; i < n; i++ {
+		privateKeys[i] = GeneratePrivateKey(big.NewInt(int64(i + 1)).Bytes())
+		publicKeys[i] = GeneratePublicKey(privateKeys[i])
+	}
+	transmissionKey := GenerateTransmissionKey(GenerateReceivingKey([]byte{0}))
+	account, err := NewMultiSigAccount(m, publicKeys, transmissionKey)
+	assert.Equal(t, nil, err)
+
+	// order private keys as sorted public keys of the account
+	res := make([]PrivateKey, n)
+	for i := 0; i < n; i++ {
+		res[account.IndexOfPublicKey(publicKeys[i])] = privateKeys[i]
+	}
+	return account, res
+}
+
+func newTestNonces(account *MultiSigAccount, signers []int, data []byte) ([]*EllipticPoint, [][]byte, []*big.Int) {
+	nonces := make([]*EllipticPoint, len(signers))
+	nonceCommitments := make([][]byte, len(signers))
+	secretNonces := make([]*big.Int, len(signers))
+	for i, index := range signers {
+		nonces[i], secretNonces[i] = MultiSigScheme{}.GenerateRandom()
+		nonceCommitments[i] = account.NonceCommitment(data, index, nonces[i])
+	}
+	return nonces, nonceCommitments, secretNonces
+}
+
+// signMultiSig runs both rounds of signing with private keys of signers
+func signMultiSig(t *testing.T, account *MultiSigAccount, privateKeys []PrivateKey, signers []int, data []byte) []byte {
+	nonces, nonceCommitments, secretNonces := newTestNonces(account, signers, data)
+
+	partialSigs := make([]*SchnMultiSig, len(signers))
+	for i, index := range signers {
+		var err error
+		partialSigs[i], err = account.PartialSign(data, privateKeys[index], signers, nonceCommitments, nonces, secretNonces[i])
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, account.VerifyPartialSig(data, signers, nonces, i, partialSigs[i]))
+	}
+
+	sig, err := account.CombineSigs(data, signers, partialSigs)
+	assert.Equal(t, nil, err)
+	return sig
+}
+
+func TestMultiSigAccountBytes(t *testing.T) {
+	account, _ := newTestMultiSigAccount(t, 2, 3)
+
+	res := new(MultiSigAccount)
+	err := res.SetBytes(account.Bytes())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, account.Bytes(), res.Bytes())
+	assert.Equal(t, account.GetPaymentAddress(), res.GetPaymentAddress())
+
+	// public keys must be sorted
+	data := account.Bytes()
+	swapped := append([]byte{}, data[:2]...)
+	swapped = append(swapped, data[2+CompressedEllipticPointSize:2+2*CompressedEllipticPointSize]...)
+	swapped = append(swapped, data[2:2+CompressedEllipticPointSize]...)
+	swapped = append(swapped, data[2+2*CompressedEllipticPointSize:]...)
+	err = res.SetBytes(swapped)
+	assert.NotEqual(t, nil, err)
+
+	err = res.SetBytes(data[:len(data)-1])
+	assert.NotEqual(t, nil, err)
+}
+
+func TestNewMultiSigAccountInvalid(t *testing.T) {
+	publicKey := GeneratePublicKey(GeneratePrivateKey([]byte{1}))
+	transmissionKey := GenerateTransmissionKey(GenerateReceivingKey([]byte{1}))
+
+	_, err := NewMultiSigAccount(0, []PublicKey{publicKey}, transmissionKey)
+	assert.NotEqual(t, nil, err)
+	_, err = NewMultiSigAccount(2, []PublicKey{publicKey}, transmissionKey)
+	assert.NotEqual(t, nil, err)
+	_, err = NewMultiSigAccount(1, []PublicKey{publicKey, publicKey}, transmissionKey)
+	assert.NotEqual(t, nil, err)
+	_, err = NewMultiSigAccount(1, []PublicKey{publicKey[:32]}, transmissionKey)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMultiSigAccountSign(t *testing.T) {
+	account, privateKeys := newTestMultiSigAccount(t, 3, 5)
+	data := []byte("multisig tx hash")
+
+	signers := []int{0, 2, 4}
+	sig := signMultiSig(t, account, privateKeys, signers, data)
+	assert.Equal(t, MultiSigSize, len(sig))
+	assert.Equal(t, true, account.VerifySig(data, sig))
+	assert.Equal(t, false, account.VerifySig([]byte("other data"), sig))
+
+	// all cosigners can sign too
+	sig = signMultiSig(t, account, privateKeys, []int{0, 1, 2, 3, 4}, data)
+	assert.Equal(t, true, account.VerifySig(data, sig))
+
+	// claiming another set of signers invalidates the signature
+	sig[0] = 0x07
+	assert.Equal(t, false, account.VerifySig(data, sig))
+}
+
+func TestMultiSigAccountNotEnoughSigners(t *testing.T) {
+	account, privateKeys := newTestMultiSigAccount(t, 3, 5)
+	data := []byte("multisig tx hash")
+
+	signers := []int{1, 3}
+	nonces, nonceCommitments, secretNonces := newTestNonces(account, signers, data)
+	_, err := account.PartialSign(data, privateKeys[1], signers, nonceCommitments, nonces, secretNonces[0])
+	assert.NotEqual(t, nil, err)
+
+	// a 2-of-5 signature is rejected by a 3-of-5 account
+	account2 := *account
+	account2.m = 2
+	sig := signMultiSig(t, &account2, privateKeys, signers, data)
+	assert.Equal(t, true, account2.VerifySig(data, sig))
+	assert.Equal(t, false, account.VerifySig(data, sig))
+}
+
+func TestMultiSigSerialNumber(t *testing.T) {
+	account, _ := newTestMultiSigAccount(t, 1, 2)
+	publicKey := account.GetPublicKey()
+	assert.Equal(t, true, publicKey.IsSafe())
+
+	snd := RandScalar()
+	sn1 := MultiSigSerialNumber(publicKey, snd)
+	sn2 := MultiSigSerialNumber(publicKey, snd)
+	assert.Equal(t, true, sn1.IsEqual(sn2))
+	assert.Equal(t, false, sn1.IsEqual(MultiSigSerialNumber(publicKey, RandScalar())))
+}
+
+func TestMultiSigAccountNonceCommitment(t *testing.T) {
+	account, privateKeys := newTestMultiSigAccount(t, 2, 3)
+	data := []byte("multisig tx hash")
+
+	signers := []int{0, 2}
+	nonces, nonceCommitments, secretNonces := newTestNonces(account, signers, data)
+	_, err := account.PartialSign(data, privateKeys[0], signers, nonceCommitments, nonces, secretNonces[0])
+	assert.Equal(t, nil, err)
+
+	// a nonce replaced after commitments were exchanged is rejected
+	rogueNonces := []*EllipticPoint{nonces[0], nonces[0].Add(nonces[1])}
+	_, err = account.PartialSign(data, privateKeys[0], signers, nonceCommitments, rogueNonces, secretNonces[0])
+	assert.NotEqual(t, nil, err)
+
+	// commitments are bound to data and signer
+	assert.NotEqual(t, nonceCommitments[0], account.NonceCommitment([]byte("other data"), 0, nonces[0]))
+	assert.NotEqual(t, nonceCommitments[0], account.NonceCommitment(data, 1, nonces[0]))
+	_, err = account.PartialSign(data, privateKeys[0], signers, nonceCommitments[:1], nonces, secretNonces[0])
+	assert.NotEqual(t, nil, err)
+}
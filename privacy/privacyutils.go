@@ -1,6 +1,7 @@
 package privacy
 
 import (
+	"errors"
 	"math/big"
 	rand2 "math/rand"
 	"time"
@@ -8,6 +9,49 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 )
 
+// WideLengthMarker is the first byte of a serialized object (payment proof, output coin) whose coin lengths are
+// encoded in 2 bytes, objects without it encode them in 1 byte as before, so existing data is parsed and hashed as it was.
+// An object in 1 byte format never starts with it: a payment proof starts with the number of one-of-many proofs
+// and an output coin with the length of its ciphertext, both are far below it
+const WideLengthMarker = 0xFF
+
+// maxNarrowLength is the max length encoded in 1 byte
+const maxNarrowLength = 0xFF
+
+// IsWideLength returns true if length must be encoded in 2 bytes, i.e object must be marked by WideLengthMarker
+func IsWideLength(length int) bool {
+	return length > maxNarrowLength
+}
+
+// AppendLength appends length of a field to bytes, in 2 bytes if wide is set or 1 byte otherwise
+func AppendLength(bytes []byte, length int, wide bool) []byte {
+	if wide {
+		return append(bytes, common.IntToBytes(length)...)
+	}
+	return append(bytes, byte(length))
+}
+
+// ReadLength reads length of the field at offset of bytes encoded by AppendLength,
+// it returns the length and offset of the field's body
+func ReadLength(bytes []byte, offset int, wide bool) (int, int, error) {
+	size := 1
+	if wide {
+		size = 2
+	}
+	if offset+size > len(bytes) {
+		return 0, 0, errors.New("invalid length of field")
+	}
+	length := int(bytes[offset])
+	if wide {
+		length = common.BytesToInt(bytes[offset : offset+size])
+	}
+	offset += size
+	if offset+length > len(bytes) {
+		return 0, 0, errors.New("invalid length of field")
+	}
+	return length, offset, nil
+}
+
 // RandBytes generates random bytes with length
 func RandBytes(length int) []byte {
 	seed := time.Now().UnixNano()
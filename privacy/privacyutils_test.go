@@ -104,3 +104,27 @@ func TestUtilsIntToByteArr(t *testing.T) {
 		assert.Equal(t, item.number, number)
 	}
 }
+
+func TestUtilsFieldLength(t *testing.T) {
+	for _, length := range []int{0, 1, 254, 255, 300} {
+		wide := IsWideLength(length)
+		assert.Equal(t, length > 255, wide)
+		bytes := AppendLength([]byte{}, length, wide)
+		bytes = append(bytes, make([]byte, length)...)
+
+		res, offset, err := ReadLength(bytes, 0, wide)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, length, res)
+		assert.Equal(t, len(bytes)-length, offset)
+	}
+
+	// length 255 is encoded in 1 byte as before
+	bytes := AppendLength([]byte{}, 255, false)
+	assert.Equal(t, []byte{255}, bytes)
+
+	// length exceeds bytes
+	_, _, err := ReadLength([]byte{10, 1, 2}, 0, false)
+	assert.NotEqual(t, nil, err)
+	_, _, err = ReadLength([]byte{1}, 0, true)
+	assert.NotEqual(t, nil, err)
+}
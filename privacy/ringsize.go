@@ -0,0 +1,12 @@
+package privacy
+
+// GetCommitmentRingSizeExp returns n where ringSize = 2^n,
+// it returns false if ringSize is not a supported ring size (8, 16 or 32)
+func GetCommitmentRingSizeExp(ringSize int) (int, bool) {
+	for n := CommitmentRingSizeExp; n <= MaxCommitmentRingSizeExp; n++ {
+		if ringSize == 1<<uint(n) {
+			return n, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,92 @@
+package privacy
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// maxOneTimeKeyAttempts bounds the number of tx randoms tried to get a one-time public key in receiver's shard
+const maxOneTimeKeyAttempts = 1000
+
+// One-time public key of an output is P = Pk + H(r*Tk)*G[Randomness],
+// in which Pk and Tk are public key and transmission key of the receiver,
+// r is a random scalar picked by the sender, tx random R = r*G is published along with the output.
+// The receiver computes the same offset H(rk*R) with receiving key rk to recognize outputs sent to it.
+// As the offset is put on randomness base, coin commitment of P is also a commitment of Pk with randomness
+// increased by the offset, so the coin is spent as a normal coin of Pk (serial number is derived from spending key as before)
+
+// oneTimeKeyOffset hashes the shared secret r*Tk = rk*R to a scalar
+func oneTimeKeyOffset(sharedSecret *EllipticPoint) *big.Int {
+	res := new(big.Int).SetBytes(common.HashB(sharedSecret.Compress()))
+	res.Mod(res, Curve.Params().N)
+	return res
+}
+
+// GenerateOneTimePublicKey returns a one-time public key for receiver and the tx random to publish with it,
+// one-time public key is in the same shard as receiver's public key
+func GenerateOneTimePublicKey(receiverAddr PaymentAddress) (*EllipticPoint, *EllipticPoint, error) {
+	publicKey := new(EllipticPoint)
+	if err := publicKey.Decompress(receiverAddr.Pk); err != nil {
+		return nil, nil, NewPrivacyErr(GenerateOneTimePublicKeyErr, err)
+	}
+	transmissionKey := new(EllipticPoint)
+	if err := transmissionKey.Decompress(receiverAddr.Tk); err != nil {
+		return nil, nil, NewPrivacyErr(GenerateOneTimePublicKeyErr, err)
+	}
+	shardID := common.GetShardIDFromLastByte(receiverAddr.Pk[len(receiverAddr.Pk)-1])
+
+	for i := 0; i < maxOneTimeKeyAttempts; i++ {
+		r := RandScalar()
+		offset := oneTimeKeyOffset(transmissionKey.ScalarMult(r))
+		oneTimePublicKey := publicKey.Add(PedCom.G[PedersenRandomnessIndex].ScalarMult(offset))
+		oneTimePublicKeyBytes := oneTimePublicKey.Compress()
+		if common.GetShardIDFromLastByte(oneTimePublicKeyBytes[len(oneTimePublicKeyBytes)-1]) == shardID {
+			return oneTimePublicKey, PedCom.G[PedersenPrivateKeyIndex].ScalarMult(r), nil
+		}
+	}
+	return nil, nil, NewPrivacyErr(GenerateOneTimePublicKeyErr, errors.New("can not find one-time public key in receiver's shard"))
+}
+
+// IsOneTimePublicKey returns true if public key of coin is a one-time public key
+func (coin Coin) IsOneTimePublicKey() bool {
+	return coin.txRandom != nil
+}
+
+// GetOneTimeKeyOffset returns offset of coin's one-time public key to public key of viewing key,
+// it returns false if coin does not belong to the viewing key
+func (coin Coin) GetOneTimeKeyOffset(viewingKey ViewingKey) (*big.Int, bool) {
+	if coin.txRandom == nil || coin.publicKey == nil || len(viewingKey.Rk) == 0 || len(viewingKey.Pk) == 0 {
+		return nil, false
+	}
+	publicKey := new(EllipticPoint)
+	if err := publicKey.Decompress(viewingKey.Pk); err != nil {
+		return nil, false
+	}
+	offset := oneTimeKeyOffset(coin.txRandom.ScalarMult(new(big.Int).SetBytes(viewingKey.Rk)))
+	if !publicKey.Add(PedCom.G[PedersenRandomnessIndex].ScalarMult(offset)).IsEqual(coin.publicKey) {
+		return nil, false
+	}
+	return offset, true
+}
+
+// ConvertToStaticPublicKey converts a coin with one-time public key to a coin of receiver's public key,
+// randomness of coin (decrypted already) is increased by the offset so coin commitment is unchanged
+func (coin *Coin) ConvertToStaticPublicKey(viewingKey ViewingKey) error {
+	offset, ok := coin.GetOneTimeKeyOffset(viewingKey)
+	if !ok {
+		return errors.New("coin does not belong to viewing key")
+	}
+	publicKey := new(EllipticPoint)
+	if err := publicKey.Decompress(viewingKey.Pk); err != nil {
+		return err
+	}
+	coin.publicKey = publicKey
+	if coin.randomness != nil {
+		coin.randomness = new(big.Int).Add(coin.randomness, offset)
+		coin.randomness.Mod(coin.randomness, Curve.Params().N)
+	}
+	coin.txRandom = nil
+	return nil
+}
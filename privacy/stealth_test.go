@@ -0,0 +1,95 @@
+package privacy
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestOneTimePublicKey(t *testing.T) {
+	privateKey := GeneratePrivateKey([]byte{1})
+	paymentAddress := GeneratePaymentAddress(privateKey)
+	viewingKey := GenerateViewingKey(privateKey)
+
+	oneTimePublicKey, txRandom, err := GenerateOneTimePublicKey(paymentAddress)
+	assert.Equal(t, nil, err)
+	oneTimePublicKeyBytes := oneTimePublicKey.Compress()
+	assert.NotEqual(t, paymentAddress.Pk, PublicKey(oneTimePublicKeyBytes))
+	assert.Equal(t, common.GetShardIDFromLastByte(paymentAddress.Pk[len(paymentAddress.Pk)-1]),
+		common.GetShardIDFromLastByte(oneTimePublicKeyBytes[len(oneTimePublicKeyBytes)-1]))
+
+	coin := new(Coin).Init()
+	coin.SetPublicKey(oneTimePublicKey)
+	coin.SetTxRandom(txRandom)
+	coin.SetValue(10)
+	coin.SetSNDerivator(RandScalar())
+	coin.SetRandomness(RandScalar())
+	err = coin.CommitAll()
+	assert.Equal(t, nil, err)
+
+	// bytes of coin keep tx random
+	coinBytes := coin.Bytes()
+	coin2 := new(Coin)
+	err = coin2.SetBytes(coinBytes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, coin2.IsOneTimePublicKey())
+	assert.Equal(t, true, coin2.GetTxRandom().IsEqual(txRandom))
+
+	// coin does not belong to other keys
+	otherViewingKey := GenerateViewingKey(GeneratePrivateKey([]byte{2}))
+	_, ok := coin.GetOneTimeKeyOffset(otherViewingKey)
+	assert.Equal(t, false, ok)
+	assert.NotEqual(t, nil, coin.ConvertToStaticPublicKey(otherViewingKey))
+
+	// coin commitment is a commitment of receiver's public key after converting
+	commitment := coin.GetCoinCommitment()
+	err = coin.ConvertToStaticPublicKey(viewingKey)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, coin.IsOneTimePublicKey())
+	assert.Equal(t, PublicKey(coin.GetPublicKey().Compress()), paymentAddress.Pk)
+	err = coin.CommitAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, commitment.IsEqual(coin.GetCoinCommitment()))
+}
+
+func TestCoinBytesWithoutTxRandom(t *testing.T) {
+	coin := new(Coin).Init()
+	coin.SetPublicKey(PedCom.G[0].ScalarMult(RandScalar()))
+	coin.SetValue(10)
+	coin.SetSNDerivator(RandScalar())
+	coin.SetRandomness(big.NewInt(1))
+
+	coin2 := new(Coin)
+	err := coin2.SetBytes(coin.Bytes())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, coin2.IsOneTimePublicKey())
+	assert.Equal(t, coin.Bytes(), coin2.Bytes())
+}
+
+func TestOutputCoinBytesWithTxRandom(t *testing.T) {
+	paymentAddress := GeneratePaymentAddress(GeneratePrivateKey([]byte{1}))
+	oneTimePublicKey, txRandom, err := GenerateOneTimePublicKey(paymentAddress)
+	assert.Equal(t, nil, err)
+
+	outputCoin := new(OutputCoin).Init()
+	outputCoin.CoinDetails.SetPublicKey(oneTimePublicKey)
+	outputCoin.CoinDetails.SetTxRandom(txRandom)
+	outputCoin.CoinDetails.SetValue(1000000000)
+	outputCoin.CoinDetails.SetSNDerivator(RandScalar())
+	outputCoin.CoinDetails.SetRandomness(RandScalar())
+	err = outputCoin.CoinDetails.CommitAll()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, (*PrivacyError)(nil), outputCoin.Encrypt(paymentAddress.Tk))
+	outputCoin.CoinDetails.SetSerialNumber(nil)
+	outputCoin.CoinDetails.SetValue(0)
+	outputCoin.CoinDetails.SetRandomness(nil)
+
+	// bytes of output coin with tx random is longer than 255 bytes
+	outputCoinBytes := outputCoin.Bytes()
+	outputCoin2 := new(OutputCoin)
+	err = outputCoin2.SetBytes(outputCoinBytes)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, outputCoinBytes, outputCoin2.Bytes())
+	assert.Equal(t, true, outputCoin2.CoinDetails.GetTxRandom().IsEqual(txRandom))
+}
@@ -94,9 +94,9 @@ func OneOutOfManyProve(args string) (string, error) {
 	commitmentStrs := temp["commitments"]
 	//fmt.Printf("commitmentStrs: %v\n", commitmentStrs)
 
-	if len(commitmentStrs) != privacy.CommitmentRingSize {
+	if _, ok := privacy.GetCommitmentRingSizeExp(len(commitmentStrs)); !ok {
 		println(err)
-		return "", errors.New("the number of Commitment list's elements must be equal to CMRingSize")
+		return "", errors.New("the number of Commitment list's elements must be a supported ring size")
 	}
 
 	commitmentPoints := make([]*privacy.EllipticPoint, len(commitmentStrs))
@@ -569,3 +569,83 @@ func (proof AggregatedRangeProof) Verify() (bool, error) {
 
 	return true, nil
 }
+
+// VerifyBatch verifies many aggregated range proofs at once: verification equations of all proofs
+// are multiplied by random weights and combined into a single multi-scalar multiplication,
+// so generators shared by proofs are multiplied only once.
+// It returns false if at least one proof is invalid, the invalid proof can be found by Verify
+func VerifyBatch(proofs []*AggregatedRangeProof) (bool, error) {
+	n := maxExp
+	oneNumber := big.NewInt(1)
+	twoNumber := big.NewInt(2)
+	oneVectorN := powerVector(oneNumber, n)
+	twoVectorN := powerVector(twoNumber, n)
+	// innerProduct2 = <1^n, 2^n>
+	innerProduct2, err := innerProduct(oneVectorN, twoVectorN)
+	if err != nil {
+		return false, privacy.NewPrivacyErr(privacy.CalInnerProductErr, err)
+	}
+
+	aggParams := make(map[int]*bulletproofParams)
+	multiExp := privacy.NewMultiExp()
+	for _, proof := range proofs {
+		numValue := len(proof.cmsValue)
+		numValuePad := pad(numValue)
+		AggParam, ok := aggParams[numValuePad]
+		if !ok {
+			AggParam = newBulletproofParams(numValuePad)
+			aggParams[numValuePad] = AggParam
+		}
+
+		// recalculate challenge y, z, x
+		y := generateChallengeForAggRange(AggParam, [][]byte{proof.a.Compress(), proof.s.Compress()})
+		z := generateChallengeForAggRange(AggParam, [][]byte{proof.a.Compress(), proof.s.Compress(), y.Bytes()})
+		zSquare := new(big.Int).Exp(z, twoNumber, privacy.Curve.Params().N)
+		x := generateChallengeForAggRange(AggParam, [][]byte{proof.a.Compress(), proof.s.Compress(), proof.t1.Compress(), proof.t2.Compress()})
+		xSquare := new(big.Int).Exp(x, twoNumber, privacy.Curve.Params().N)
+
+		// delta(y, z) = (z - z^2) * <1^(n*m), y^(n*m)> - sum_{j=1}^{m} z^(j+2) * <1^n, 2^n>
+		deltaYZ := new(big.Int).Sub(z, zSquare)
+		innerProduct1 := big.NewInt(0)
+		for _, yi := range powerVector(y, n*numValuePad) {
+			innerProduct1.Add(innerProduct1, yi)
+		}
+		innerProduct1.Mod(innerProduct1, privacy.Curve.Params().N)
+		deltaYZ.Mul(deltaYZ, innerProduct1)
+
+		sum := big.NewInt(0)
+		zTmp := new(big.Int).Set(zSquare)
+		for j := 0; j < numValuePad; j++ {
+			zTmp.Mul(zTmp, z)
+			zTmp.Mod(zTmp, privacy.Curve.Params().N)
+			sum.Add(sum, zTmp)
+		}
+		sum.Mul(sum, innerProduct2)
+		deltaYZ.Sub(deltaYZ, sum)
+		deltaYZ.Mod(deltaYZ, privacy.Curve.Params().N)
+
+		// statement 1: g^tHat * h^tauX = V^(z^2) * g^delta(y,z) * T1^x * T2^(x^2)
+		weight := privacy.RandScalar()
+		gFactor := new(big.Int).Sub(proof.tHat, deltaYZ)
+		multiExp.Add(privacy.PedCom.G[privacy.PedersenValueIndex], gFactor.Mul(gFactor, weight))
+		multiExp.Add(privacy.PedCom.G[privacy.PedersenRandomnessIndex], new(big.Int).Mul(proof.tauX, weight))
+		multiExp.Add(proof.t1, new(big.Int).Neg(new(big.Int).Mul(x, weight)))
+		multiExp.Add(proof.t2, new(big.Int).Neg(new(big.Int).Mul(xSquare, weight)))
+		expVector := vectorMulScalar(powerVector(z, numValuePad), zSquare)
+		for i, cm := range proof.cmsValue {
+			multiExp.Add(cm, new(big.Int).Neg(new(big.Int).Mul(expVector[i], weight)))
+		}
+
+		// statement 2: inner product argument
+		err := proof.innerProductProof.addToMultiExp(AggParam, privacy.RandScalar(), multiExp)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !multiExp.IsZero() {
+		privacy.Logger.Log.Errorf("batch verify aggregated range proofs failed")
+		return false, errors.New("batch verify aggregated range proofs failed")
+	}
+	return true, nil
+}
@@ -178,6 +178,38 @@ func TestAggregatedRangeProve(t *testing.T) {
 	assert.Equal(t, nil, err)
 }
 
+func TestAggregatedRangeVerifyBatch(t *testing.T) {
+	proofs := make([]*AggregatedRangeProof, 0)
+	for _, numValue := range []int{1, 2, 3} {
+		wit := new(AggregatedRangeWitness)
+		values := make([]*big.Int, numValue)
+		rands := make([]*big.Int, numValue)
+		for i := range values {
+			values[i] = new(big.Int).SetBytes(privacy.RandBytes(2))
+			rands[i] = privacy.RandScalar()
+		}
+		wit.Set(values, rands)
+
+		proof, err := wit.Prove()
+		assert.Equal(t, nil, err)
+		proofs = append(proofs, proof)
+	}
+
+	// verify the proofs in a batch
+	res, err := VerifyBatch(proofs)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	// batch fails if one of proofs is invalid
+	proofs[1].tHat = new(big.Int).Add(proofs[1].tHat, big.NewInt(1))
+	res, err = VerifyBatch(proofs)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	res, _ = proofs[1].Verify()
+	assert.Equal(t, false, res)
+}
+
 func TestPad(t *testing.T) {
 	data := []struct {
 		number       int
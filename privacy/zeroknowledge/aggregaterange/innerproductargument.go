@@ -293,3 +293,69 @@ func (proof InnerProductProof) Verify(AggParam *bulletproofParams) bool {
 
 	return res
 }
+
+// addToMultiExp adds verification equation of the inner product argument multiplied by weight to multiExp:
+// G'*a + H'*b + u*(a*b) - p' = 0 where G', H', p' are G[0], H[0], p folded by challenges of all rounds,
+// G' and H' are expressed as combinations of generators so generators of many proofs are multiplied only once
+func (proof InnerProductProof) addToMultiExp(AggParam *bulletproofParams, weight *big.Int, multiExp *privacy.MultiExp) error {
+	n := len(AggParam.g)
+	numRound := 0
+	for i := n; i > 1; i /= 2 {
+		numRound++
+	}
+	if len(proof.l) != numRound || len(proof.r) != numRound {
+		return errors.New("invalid number of rounds of inner product argument")
+	}
+
+	// s[k] is product of challenges (or their inverses) G[k] is multiplied by after folding
+	s := []*big.Int{big.NewInt(1)}
+	sInverse := []*big.Int{big.NewInt(1)}
+	p := new(privacy.EllipticPoint)
+	p.Set(proof.p.GetX(), proof.p.GetY())
+	for i := 0; i < numRound; i++ {
+		// calculate challenge x = hash(G || H || u || p ||  l || r)
+		x := generateChallengeForAggRange(AggParam, [][]byte{p.Compress(), proof.l[i].Compress(), proof.r[i].Compress()})
+		xInverse := new(big.Int).ModInverse(x, privacy.Curve.Params().N)
+		xSquare := new(big.Int).Mul(x, x)
+		xSquareInverse := new(big.Int).ModInverse(xSquare, privacy.Curve.Params().N)
+
+		// the first half of generators is multiplied by xInverse, the second half by x
+		sPrime := make([]*big.Int, 2*len(s))
+		sInversePrime := make([]*big.Int, 2*len(s))
+		for j := range s {
+			sPrime[2*j] = new(big.Int).Mul(s[j], xInverse)
+			sPrime[2*j].Mod(sPrime[2*j], privacy.Curve.Params().N)
+			sPrime[2*j+1] = new(big.Int).Mul(s[j], x)
+			sPrime[2*j+1].Mod(sPrime[2*j+1], privacy.Curve.Params().N)
+			sInversePrime[2*j] = new(big.Int).Mul(sInverse[j], x)
+			sInversePrime[2*j].Mod(sInversePrime[2*j], privacy.Curve.Params().N)
+			sInversePrime[2*j+1] = new(big.Int).Mul(sInverse[j], xInverse)
+			sInversePrime[2*j+1].Mod(sInversePrime[2*j+1], privacy.Curve.Params().N)
+		}
+		s = sPrime
+		sInverse = sInversePrime
+
+		// x^2 * l + P + xInverse^2 * r
+		if i < numRound-1 {
+			p = proof.l[i].ScalarMult(xSquare).Add(p).Add(proof.r[i].ScalarMult(xSquareInverse))
+		} else {
+			// folded p of the last round goes to multiExp directly
+			multiExp.Add(proof.l[i], new(big.Int).Neg(new(big.Int).Mul(weight, xSquare)))
+			multiExp.Add(proof.r[i], new(big.Int).Neg(new(big.Int).Mul(weight, xSquareInverse)))
+			multiExp.Add(p, new(big.Int).Neg(weight))
+		}
+	}
+
+	if numRound == 0 {
+		multiExp.Add(p, new(big.Int).Neg(weight))
+	}
+
+	wa := new(big.Int).Mul(weight, proof.a)
+	wb := new(big.Int).Mul(weight, proof.b)
+	for k := 0; k < n; k++ {
+		multiExp.Add(AggParam.g[k], new(big.Int).Mul(wa, s[k]))
+		multiExp.Add(AggParam.h[k], new(big.Int).Mul(wb, sInverse[k]))
+	}
+	multiExp.Add(AggParam.u, new(big.Int).Mul(wa, proof.b))
+	return nil
+}
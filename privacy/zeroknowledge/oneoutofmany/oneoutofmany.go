@@ -33,11 +33,32 @@ type OneOutOfManyProof struct {
 	zd             *big.Int
 }
 
+// GetRingSize returns size of the commitments ring the proof is made for,
+// ring size is carried in the proof by the number of bits of index (N = 2^n)
+func (proof OneOutOfManyProof) GetRingSize() int {
+	return 1 << uint(len(proof.cl))
+}
+
+// hasLength returns true if all arrays of proof have n elements
+func (proof OneOutOfManyProof) hasLength(n int) bool {
+	return len(proof.cl) == n && len(proof.ca) == n && len(proof.cb) == n && len(proof.cd) == n &&
+		len(proof.f) == n && len(proof.za) == n && len(proof.zb) == n
+}
+
+// verifyRingSize checks the commitments ring of statement against the proof, the minimum ring size of the network
+// is checked by the tx verifier as it depends on tx version, it returns n where ring size N = 2^n
+func (proof OneOutOfManyProof) verifyRingSize() (int, error) {
+	N := len(proof.Statement.Commitments)
+	n, ok := privacy.GetCommitmentRingSizeExp(N)
+	if !ok || !proof.hasLength(n) {
+		return 0, errors.New("Invalid length of commitments list in one out of many proof")
+	}
+	return n, nil
+}
+
 func (proof OneOutOfManyProof) ValidateSanity() bool {
-	if len(proof.cl) != privacy.CommitmentRingSizeExp || len(proof.ca) != privacy.CommitmentRingSizeExp ||
-		len(proof.cb) != privacy.CommitmentRingSizeExp || len(proof.cd) != privacy.CommitmentRingSizeExp ||
-		len(proof.f) != privacy.CommitmentRingSizeExp || len(proof.za) != privacy.CommitmentRingSizeExp ||
-		len(proof.zb) != privacy.CommitmentRingSizeExp {
+	if len(proof.cl) < privacy.CommitmentRingSizeExp || len(proof.cl) > privacy.MaxCommitmentRingSizeExp ||
+		!proof.hasLength(len(proof.cl)) {
 		return false
 	}
 
@@ -138,7 +159,7 @@ func (proof OneOutOfManyProof) Bytes() []byte {
 	}
 
 	// N = 2^n
-	n := privacy.CommitmentRingSizeExp
+	n := len(proof.cl)
 
 	var bytes []byte
 
@@ -190,7 +211,17 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 		return nil
 	}
 
-	n := privacy.CommitmentRingSizeExp
+	// ring size is carried by length of proof
+	n := 0
+	for exp := privacy.CommitmentRingSizeExp; exp <= privacy.MaxCommitmentRingSizeExp; exp++ {
+		if len(bytes) == utils.GetOneOfManyProofSize(exp) {
+			n = exp
+			break
+		}
+	}
+	if n == 0 {
+		return errors.New("Invalid length of one out of many proof bytes")
+	}
 
 	offset := 0
 
@@ -270,12 +301,11 @@ func (proof *OneOutOfManyProof) SetBytes(bytes []byte) error {
 func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 	// Check the number of Commitment list's elements
 	N := len(wit.stmt.Commitments)
-	if N != privacy.CommitmentRingSize {
-		return nil, errors.New("the number of Commitment list's elements must be equal to CMRingSize")
+	n, ok := privacy.GetCommitmentRingSizeExp(N)
+	if !ok {
+		return nil, errors.New("the number of Commitment list's elements must be a supported ring size")
 	}
 
-	n := privacy.CommitmentRingSizeExp
-
 	// Check indexIsZero
 	if wit.indexIsZero > uint64(N) {
 		return nil, errors.New("Index is zero must be Index in list of commitments")
@@ -391,11 +421,11 @@ func (wit OneOutOfManyWitness) Prove() (*OneOutOfManyProof, error) {
 func (proof OneOutOfManyProof) Verify() (bool, error) {
 	N := len(proof.Statement.Commitments)
 
-	// the number of Commitment list's elements must be equal to CMRingSize
-	if N != privacy.CommitmentRingSize {
-		return false, errors.New("Invalid length of commitments list in one out of many proof")
+	// the number of Commitment list's elements must be equal to ring size of proof
+	n, err := proof.verifyRingSize()
+	if err != nil {
+		return false, err
 	}
-	n := privacy.CommitmentRingSizeExp
 
 	//Calculate x
 	x := big.NewInt(0)
@@ -492,3 +522,79 @@ func getCoefficient(iBinary []byte, k int, n int, a []*big.Int, l []byte) *big.I
 	}
 	return res[k]
 }
+
+// VerifyBatch verifies many one out of many proofs at once: verification equations of all proofs
+// are multiplied by random weights and combined into a single multi-scalar multiplication,
+// so generators and commitments shared by proofs are multiplied only once.
+// It returns false if at least one proof is invalid, the invalid proof can be found by Verify
+func VerifyBatch(proofs []*OneOutOfManyProof) (bool, error) {
+	multiExp := privacy.NewMultiExp()
+	for _, proof := range proofs {
+		N := len(proof.Statement.Commitments)
+
+		// the number of Commitment list's elements must be equal to ring size of proof
+		n, err := proof.verifyRingSize()
+		if err != nil {
+			return false, err
+		}
+
+		//Calculate x
+		x := big.NewInt(0)
+		for j := 0; j < n; j++ {
+			x = utils.GenerateChallenge([][]byte{common.AddPaddingBigInt(x, common.BigIntSize), proof.cl[j].Compress(), proof.ca[j].Compress(), proof.cb[j].Compress(), proof.cd[j].Compress()})
+		}
+
+		hFactor := big.NewInt(0)
+		for i := 0; i < n; i++ {
+			//Check cl^x * ca = Com(f, za)
+			weight1 := privacy.RandScalar()
+			//Check cl^(x-f) * cb = Com(0, zb)
+			weight2 := privacy.RandScalar()
+
+			xSubF := new(big.Int).Sub(x, proof.f[i])
+			clFactor := new(big.Int).Mul(x, weight1)
+			clFactor.Add(clFactor, xSubF.Mul(xSubF, weight2))
+			multiExp.Add(proof.cl[i], clFactor)
+			multiExp.Add(proof.ca[i], weight1)
+			multiExp.Add(proof.cb[i], weight2)
+			multiExp.Add(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex], new(big.Int).Neg(new(big.Int).Mul(proof.f[i], weight1)))
+			hFactor.Add(hFactor, new(big.Int).Mul(proof.za[i], weight1))
+			hFactor.Add(hFactor, new(big.Int).Mul(proof.zb[i], weight2))
+		}
+
+		// Check prod_i c_i^(prod_j f_j,i) * prod_k cd_k^(-x^k) = Com(0, zd)
+		weight3 := privacy.RandScalar()
+		for i := 0; i < N; i++ {
+			iBinary := privacy.ConvertIntToBinary(i, n)
+
+			exp := new(big.Int).Set(weight3)
+			fji := big.NewInt(1)
+			for j := 0; j < n; j++ {
+				if iBinary[j] == 1 {
+					fji.Set(proof.f[j])
+				} else {
+					fji.Sub(x, proof.f[j])
+					fji.Mod(fji, privacy.Curve.Params().N)
+				}
+
+				exp.Mul(exp, fji)
+				exp.Mod(exp, privacy.Curve.Params().N)
+			}
+
+			multiExp.Add(proof.Statement.Commitments[i], exp)
+		}
+
+		for k := 0; k < n; k++ {
+			xk := big.NewInt(0).Exp(x, big.NewInt(int64(k)), privacy.Curve.Params().N)
+			multiExp.Add(proof.cd[k], xk.Neg(xk.Mul(xk, weight3)))
+		}
+		hFactor.Add(hFactor, new(big.Int).Mul(proof.zd, weight3))
+		multiExp.Add(privacy.PedCom.G[privacy.PedersenRandomnessIndex], hFactor.Neg(hFactor))
+	}
+
+	if !multiExp.IsZero() {
+		privacy.Logger.Log.Errorf("batch verify one out of many proofs failed")
+		return false, errors.New("batch verify one out of many proofs failed")
+	}
+	return true, nil
+}
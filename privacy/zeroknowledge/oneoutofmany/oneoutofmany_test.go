@@ -77,6 +77,98 @@ func TestPKOneOfMany(t *testing.T) {
 	assert.Equal(t, nil, err)
 }
 
+func TestOneOutOfManyVerifyBatch(t *testing.T) {
+	proofs := make([]*OneOutOfManyProof, 0)
+	for indexIsZero := 0; indexIsZero < 3; indexIsZero++ {
+		commitments := make([]*privacy.EllipticPoint, privacy.CommitmentRingSize)
+		randoms := make([]*big.Int, privacy.CommitmentRingSize)
+		for i := 0; i < privacy.CommitmentRingSize; i++ {
+			randoms[i] = privacy.RandScalar()
+			commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandScalar(), randoms[i], privacy.PedersenSndIndex)
+		}
+		commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(big.NewInt(0), randoms[indexIsZero], privacy.PedersenSndIndex)
+
+		witness := new(OneOutOfManyWitness)
+		witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+		proof, err := witness.Prove()
+		assert.Equal(t, nil, err)
+		proofs = append(proofs, proof)
+	}
+
+	// verify the proofs in a batch
+	res, err := VerifyBatch(proofs)
+	assert.Equal(t, true, res)
+	assert.Equal(t, nil, err)
+
+	// batch fails if one of proofs is invalid
+	proofs[2].zd = new(big.Int).Add(proofs[2].zd, big.NewInt(1))
+	res, err = VerifyBatch(proofs)
+	assert.Equal(t, false, res)
+	assert.NotEqual(t, nil, err)
+
+	res, _ = proofs[2].Verify()
+	assert.Equal(t, false, res)
+}
+
+func TestOneOutOfManyRingSize(t *testing.T) {
+	for _, ringSize := range []int{16, 32} {
+		indexIsZero := ringSize - 1
+		commitments := make([]*privacy.EllipticPoint, ringSize)
+		randoms := make([]*big.Int, ringSize)
+		for i := 0; i < ringSize; i++ {
+			randoms[i] = privacy.RandScalar()
+			commitments[i] = privacy.PedCom.CommitAtIndex(privacy.RandScalar(), randoms[i], privacy.PedersenSndIndex)
+		}
+		commitments[indexIsZero] = privacy.PedCom.CommitAtIndex(big.NewInt(0), randoms[indexIsZero], privacy.PedersenSndIndex)
+
+		witness := new(OneOutOfManyWitness)
+		witness.Set(commitments, randoms[indexIsZero], uint64(indexIsZero))
+		proof, err := witness.Prove()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, proof.ValidateSanity())
+		assert.Equal(t, ringSize, proof.GetRingSize())
+
+		// ring size is carried by the proof bytes
+		ringSizeExp, _ := privacy.GetCommitmentRingSizeExp(ringSize)
+		proofBytes := proof.Bytes()
+		assert.Equal(t, utils.GetOneOfManyProofSize(ringSizeExp), len(proofBytes))
+		proof2 := new(OneOutOfManyProof).Init()
+		err = proof2.SetBytes(proofBytes)
+		assert.Equal(t, nil, err)
+		proof2.Statement.Commitments = commitments
+		assert.Equal(t, proof, proof2)
+
+		res, err := proof2.Verify()
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+		res, err = VerifyBatch([]*OneOutOfManyProof{proof2})
+		assert.Equal(t, true, res)
+		assert.Equal(t, nil, err)
+
+		// statement must have the ring size of proof
+		proof2.Statement.Commitments = commitments[:ringSize/2]
+		res, _ = proof2.Verify()
+		assert.Equal(t, false, res)
+	}
+
+	// unsupported ring size
+	commitments := make([]*privacy.EllipticPoint, privacy.CommitmentRingSize)
+	randoms := make([]*big.Int, privacy.CommitmentRingSize)
+	for i := 0; i < privacy.CommitmentRingSize; i++ {
+		randoms[i] = privacy.RandScalar()
+		commitments[i] = privacy.PedCom.CommitAtIndex(big.NewInt(0), randoms[i], privacy.PedersenSndIndex)
+	}
+	witness := new(OneOutOfManyWitness)
+	witness.Set(commitments, randoms[0], 0)
+	proof, err := witness.Prove()
+	assert.Equal(t, nil, err)
+
+	_, ok := privacy.GetCommitmentRingSizeExp(12)
+	assert.Equal(t, false, ok)
+	err = new(OneOutOfManyProof).Init().SetBytes(proof.Bytes()[1:])
+	assert.NotEqual(t, nil, err)
+}
+
 func TestGetCoefficient(t *testing.T) {
 	a := make([]*big.Int, 3)
 
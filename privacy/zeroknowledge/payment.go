@@ -2,6 +2,7 @@ package zkp
 
 import (
 	"encoding/json"
+	"errors"
 	"math/big"
 
 	"github.com/incognitochain/incognito-chain/common"
@@ -40,6 +41,10 @@ type PaymentProof struct {
 	commitmentInputShardID   *privacy.EllipticPoint
 
 	commitmentIndices []uint64
+
+	// batchVerifiedRing is set when aggregated range proof and one out of many proofs are verified by VerifyBatch,
+	// Verify skips them if it is called with the same commitment ring (shardID, tokenID)
+	batchVerifiedRing *batchVerifiedRing
 }
 
 // GET/SET function
@@ -47,6 +52,14 @@ func (paymentProof PaymentProof) GetOneOfManyProof() []*oneoutofmany.OneOutOfMan
 	return paymentProof.oneOfManyProof
 }
 
+// GetRingSize returns size of commitments ring of each input coin, it is 0 for proof without privacy
+func (paymentProof PaymentProof) GetRingSize() int {
+	if len(paymentProof.oneOfManyProof) == 0 {
+		return 0
+	}
+	return paymentProof.oneOfManyProof[0].GetRingSize()
+}
+
 func (paymentProof PaymentProof) GetSerialNumberProof() []*serialnumberprivacy.SNPrivacyProof {
 	return paymentProof.serialNumberProof
 }
@@ -160,11 +173,27 @@ func (proof *PaymentProof) Bytes() []byte {
 	var bytes []byte
 	hasPrivacy := len(proof.oneOfManyProof) > 0
 
+	// lengths of coins are encoded in 2 bytes only if a coin doesn't fit in 1 byte length
+	inputCoinsBytes := make([][]byte, len(proof.inputCoins))
+	outputCoinsBytes := make([][]byte, len(proof.outputCoins))
+	wide := false
+	for i := 0; i < len(proof.inputCoins); i++ {
+		inputCoinsBytes[i] = proof.inputCoins[i].Bytes()
+		wide = wide || privacy.IsWideLength(len(inputCoinsBytes[i]))
+	}
+	for i := 0; i < len(proof.outputCoins); i++ {
+		outputCoinsBytes[i] = proof.outputCoins[i].Bytes()
+		wide = wide || privacy.IsWideLength(len(outputCoinsBytes[i]))
+	}
+	if wide {
+		bytes = append(bytes, privacy.WideLengthMarker)
+	}
+
 	// OneOfManyProofSize
 	bytes = append(bytes, byte(len(proof.oneOfManyProof)))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
 		oneOfManyProof := proof.oneOfManyProof[i].Bytes()
-		bytes = append(bytes, common.IntToBytes(utils.OneOfManyProofSize)...)
+		bytes = append(bytes, common.IntToBytes(len(oneOfManyProof))...)
 		bytes = append(bytes, oneOfManyProof...)
 	}
 
@@ -196,18 +225,15 @@ func (proof *PaymentProof) Bytes() []byte {
 	// InputCoins
 	bytes = append(bytes, byte(len(proof.inputCoins)))
 	for i := 0; i < len(proof.inputCoins); i++ {
-		inputCoins := proof.inputCoins[i].Bytes()
-		bytes = append(bytes, byte(len(inputCoins)))
-		bytes = append(bytes, inputCoins...)
+		bytes = privacy.AppendLength(bytes, len(inputCoinsBytes[i]), wide)
+		bytes = append(bytes, inputCoinsBytes[i]...)
 	}
 
 	// OutputCoins
 	bytes = append(bytes, byte(len(proof.outputCoins)))
 	for i := 0; i < len(proof.outputCoins); i++ {
-		outputCoins := proof.outputCoins[i].Bytes()
-		lenOutputCoins := len(outputCoins)
-		bytes = append(bytes, byte(lenOutputCoins))
-		bytes = append(bytes, outputCoins...)
+		bytes = privacy.AppendLength(bytes, len(outputCoinsBytes[i]), wide)
+		bytes = append(bytes, outputCoinsBytes[i]...)
 	}
 
 	// ComOutputValue
@@ -284,6 +310,13 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	}
 
 	offset := 0
+	wide := proofbytes[0] == privacy.WideLengthMarker
+	if wide {
+		offset += 1
+		if offset >= len(proofbytes) {
+			return privacy.NewPrivacyErr(privacy.InvalidInputToSetBytesErr, nil)
+		}
+	}
 
 	// Set OneOfManyProofSize
 	lenOneOfManyProofArray := int(proofbytes[offset])
@@ -349,10 +382,13 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	offset += 1
 	proof.inputCoins = make([]*privacy.InputCoin, lenInputCoinsArray)
 	for i := 0; i < lenInputCoinsArray; i++ {
-		lenInputCoin := int(proofbytes[offset])
-		offset += 1
+		lenInputCoin, newOffset, err := privacy.ReadLength(proofbytes, offset, wide)
+		if err != nil {
+			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, err)
+		}
+		offset = newOffset
 		proof.inputCoins[i] = new(privacy.InputCoin)
-		err := proof.inputCoins[i].SetBytes(proofbytes[offset : offset+lenInputCoin])
+		err = proof.inputCoins[i].SetBytes(proofbytes[offset : offset+lenInputCoin])
 		if err != nil {
 			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, err)
 		}
@@ -364,10 +400,13 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 	offset += 1
 	proof.outputCoins = make([]*privacy.OutputCoin, lenOutputCoinsArray)
 	for i := 0; i < lenOutputCoinsArray; i++ {
-		lenOutputCoin := int(proofbytes[offset])
-		offset += 1
+		lenOutputCoin, newOffset, err := privacy.ReadLength(proofbytes, offset, wide)
+		if err != nil {
+			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, err)
+		}
+		offset = newOffset
 		proof.outputCoins[i] = new(privacy.OutputCoin)
-		err := proof.outputCoins[i].SetBytes(proofbytes[offset : offset+lenOutputCoin])
+		err = proof.outputCoins[i].SetBytes(proofbytes[offset : offset+lenOutputCoin])
 		if err != nil {
 			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, err)
 		}
@@ -467,9 +506,15 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 		offset += lenComInputShardID
 	}
 
-	// get commitments list
-	proof.commitmentIndices = make([]uint64, len(proof.oneOfManyProof)*privacy.CommitmentRingSize)
-	for i := 0; i < len(proof.oneOfManyProof)*privacy.CommitmentRingSize; i++ {
+	// get commitments list, all input coins are hidden in rings of the same size
+	ringSize := proof.GetRingSize()
+	for i := 0; i < len(proof.oneOfManyProof); i++ {
+		if proof.oneOfManyProof[i].GetRingSize() != ringSize {
+			return privacy.NewPrivacyErr(privacy.SetBytesProofErr, errors.New("ring sizes of one out of many proofs are different"))
+		}
+	}
+	proof.commitmentIndices = make([]uint64, len(proof.oneOfManyProof)*ringSize)
+	for i := 0; i < len(proof.oneOfManyProof)*ringSize; i++ {
 		proof.commitmentIndices[i] = new(big.Int).SetBytes(proofbytes[offset : offset+common.Uint64Size]).Uint64()
 		offset = offset + common.Uint64Size
 	}
@@ -480,13 +525,10 @@ func (proof *PaymentProof) SetBytes(proofbytes []byte) *privacy.PrivacyError {
 }
 
 func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) (bool, error) {
-	var sumInputValue, sumOutputValue uint64
-	sumInputValue = 0
-	sumOutputValue = 0
-
-	pubKeyLastByteSender := pubKey[len(pubKey)-1]
-	senderShardID := common.GetShardIDFromLastByte(pubKeyLastByteSender)
-	cmShardIDSender := privacy.PedCom.G[privacy.PedersenShardIDIndex].ScalarMult(new(big.Int).SetBytes([]byte{senderShardID}))
+	if len(proof.serialNumberNoPrivacyProof) != len(proof.inputCoins) {
+		privacy.Logger.Log.Errorf("Number of serial number no privacy proofs is not equal number of input coins")
+		return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberNoPrivacyProofFailedErr, nil)
+	}
 
 	for i := 0; i < len(proof.inputCoins); i++ {
 		// Check input coins' Serial number is created from input coins' input and sender's spending key
@@ -495,7 +537,52 @@ func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64,
 			privacy.Logger.Log.Errorf("Verify serial number no privacy proof failed")
 			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberNoPrivacyProofFailedErr, err)
 		}
+	}
+
+	return proof.verifyCoinsNoPrivacy(pubKey, fee)
+}
+
+// VerifyMultiSigSpending verifies a proof spending coins of a multisig account (see privacy.MultiSigAccount),
+// proof has no privacy and no serial number proof: all input coins must belong to the account
+// and their serial numbers are hashed from the account's public key and their snds.
+// Spending is authorized by the signature of the account, which is verified with tx
+func (proof PaymentProof) VerifyMultiSigSpending(pubKey privacy.PublicKey, fee uint64) (bool, error) {
+	if len(proof.oneOfManyProof) > 0 || len(proof.serialNumberProof) > 0 || len(proof.serialNumberNoPrivacyProof) > 0 {
+		privacy.Logger.Log.Errorf("Proof spending coins of multisig account must not have privacy")
+		return false, privacy.NewPrivacyErr(privacy.VerifyMultiSigSpendingFailedErr, nil)
+	}
+
+	accountPublicKey := new(privacy.EllipticPoint)
+	if len(pubKey) != privacy.CompressedEllipticPointSize || accountPublicKey.Decompress(pubKey) != nil {
+		return false, privacy.NewPrivacyErr(privacy.VerifyMultiSigSpendingFailedErr, nil)
+	}
+
+	for i := 0; i < len(proof.inputCoins); i++ {
+		coin := proof.inputCoins[i].CoinDetails
+		if coin.GetPublicKey() == nil || !coin.GetPublicKey().IsEqual(accountPublicKey) {
+			privacy.Logger.Log.Errorf("Input coins %v does not belong to multisig account", i)
+			return false, privacy.NewPrivacyErr(privacy.VerifyMultiSigSpendingFailedErr, nil)
+		}
+		if coin.GetSNDerivator() == nil || coin.GetSerialNumber() == nil || !coin.GetSerialNumber().IsEqual(privacy.MultiSigSerialNumber(accountPublicKey, coin.GetSNDerivator())) {
+			privacy.Logger.Log.Errorf("Input coins %v serial number wrong", i)
+			return false, privacy.NewPrivacyErr(privacy.VerifyMultiSigSpendingFailedErr, nil)
+		}
+	}
+
+	return proof.verifyCoinsNoPrivacy(pubKey, fee)
+}
+
+// verifyCoinsNoPrivacy checks commitments of revealed input and output coins and that sum of inputs is equal sum of outputs plus fee
+func (proof PaymentProof) verifyCoinsNoPrivacy(pubKey privacy.PublicKey, fee uint64) (bool, error) {
+	var sumInputValue, sumOutputValue uint64
+	sumInputValue = 0
+	sumOutputValue = 0
+
+	pubKeyLastByteSender := pubKey[len(pubKey)-1]
+	senderShardID := common.GetShardIDFromLastByte(pubKeyLastByteSender)
+	cmShardIDSender := privacy.PedCom.G[privacy.PedersenShardIDIndex].ScalarMult(new(big.Int).SetBytes([]byte{senderShardID}))
 
+	for i := 0; i < len(proof.inputCoins); i++ {
 		// Check input coins' cm is calculated correctly
 		cmTmp := proof.inputCoins[i].CoinDetails.GetPublicKey()
 		cmTmp = cmTmp.Add(privacy.PedCom.G[privacy.PedersenValueIndex].ScalarMult(big.NewInt(int64(proof.inputCoins[i].CoinDetails.GetValue()))))
@@ -540,6 +627,9 @@ func (proof PaymentProof) verifyNoPrivacy(pubKey privacy.PublicKey, fee uint64,
 }
 
 func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) (bool, error) {
+	// aggregated range proof and one out of many proofs are verified already by VerifyBatch
+	isBatchVerified := proof.isBatchVerified(shardID, tokenID)
+
 	// verify for input coins
 	cmInputSum := make([]*privacy.EllipticPoint, len(proof.oneOfManyProof))
 	for i := 0; i < len(proof.oneOfManyProof); i++ {
@@ -550,39 +640,21 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 		cmInputSum[i] = cmInputSum[i].Add(proof.commitmentInputSND[i])
 		cmInputSum[i] = cmInputSum[i].Add(proof.commitmentInputShardID)
 
-		// get commitments list from CommitmentIndices
-		commitments := make([]*privacy.EllipticPoint, privacy.CommitmentRingSize)
-		for j := 0; j < privacy.CommitmentRingSize; j++ {
-			index := proof.commitmentIndices[i*privacy.CommitmentRingSize+j]
-			commitmentBytes, err := db.GetCommitmentByIndex(*tokenID, index, shardID)
-
+		if !isBatchVerified {
+			// get commitments list from CommitmentIndices
+			err := proof.setOneOfManyStatement(i, cmInputSum[i], db, shardID, tokenID)
 			if err != nil {
-				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Error when get commitment by index from database", index, err)
-				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
-			}
-			commitments[j] = new(privacy.EllipticPoint)
-			err = commitments[j].Decompress(commitmentBytes)
-			if err != nil {
-				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Cannot decompress commitment from database", index, err)
-				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+				return false, err
 			}
 
-			commitments[j], err = commitments[j].Sub(cmInputSum[i])
-			if err != nil {
-				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Cannot sub commitment to sum of commitment inputs", index, err)
+			valid, err := proof.oneOfManyProof[i].Verify()
+			if !valid {
+				privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
 				return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
 			}
 		}
-
-		proof.oneOfManyProof[i].Statement.Commitments = commitments
-
-		valid, err := proof.oneOfManyProof[i].Verify()
-		if !valid {
-			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
-			return false, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
-		}
 		// Verify for the Proof that input coins' serial number is derived from the committed derivator
-		valid, err = proof.serialNumberProof[i].Verify(nil)
+		valid, err := proof.serialNumberProof[i].Verify(nil)
 		if !valid {
 			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Serial number privacy failed")
 			return false, privacy.NewPrivacyErr(privacy.VerifySerialNumberPrivacyProofFailedErr, err)
@@ -602,10 +674,12 @@ func (proof PaymentProof) verifyHasPrivacy(pubKey privacy.PublicKey, fee uint64,
 	}
 
 	// Verify the proof that output values and sum of them do not exceed v_max
-	valid, err := proof.aggregatedRangeProof.Verify()
-	if !valid {
-		privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Multi-range failed")
-		return false, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+	if !isBatchVerified {
+		valid, err := proof.aggregatedRangeProof.Verify()
+		if !valid {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Multi-range failed")
+			return false, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+		}
 	}
 
 	// Verify the proof that sum of all input values is equal to sum of all output values
@@ -0,0 +1,132 @@
+package zkp
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/oneoutofmany"
+)
+
+// batchVerifiedRing is the commitment ring a payment proof was verified against by VerifyBatch
+type batchVerifiedRing struct {
+	shardID byte
+	tokenID common.Hash
+}
+
+// PaymentProofBatchItem is a payment proof (with privacy) to be verified by VerifyBatch,
+// along with the shard and the token its input coins' commitments are taken from
+type PaymentProofBatchItem struct {
+	Proof   *PaymentProof
+	ShardID byte
+	TokenID *common.Hash
+}
+
+func (proof PaymentProof) isBatchVerified(shardID byte, tokenID *common.Hash) bool {
+	if proof.batchVerifiedRing == nil || tokenID == nil {
+		return false
+	}
+	return proof.batchVerifiedRing.shardID == shardID && proof.batchVerifiedRing.tokenID.IsEqual(tokenID)
+}
+
+// setOneOfManyStatement gets commitments list of the i-th input coin from CommitmentIndices,
+// then subtracts cmInputSum from them so one of the commitments is a commitment to zero
+func (proof PaymentProof) setOneOfManyStatement(i int, cmInputSum *privacy.EllipticPoint, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) error {
+	ringSize := proof.GetRingSize()
+	if len(proof.commitmentIndices) < (i+1)*ringSize {
+		return privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, errors.New("invalid length of commitment indices"))
+	}
+	commitments := make([]*privacy.EllipticPoint, ringSize)
+	for j := 0; j < ringSize; j++ {
+		index := proof.commitmentIndices[i*ringSize+j]
+		commitmentBytes, err := db.GetCommitmentByIndex(*tokenID, index, shardID)
+
+		if err != nil {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Error when get commitment by index from database", index, err)
+			return privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+		}
+		commitments[j] = new(privacy.EllipticPoint)
+		err = commitments[j].Decompress(commitmentBytes)
+		if err != nil {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Cannot decompress commitment from database", index, err)
+			return privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+		}
+
+		commitments[j], err = commitments[j].Sub(cmInputSum)
+		if err != nil {
+			privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Cannot sub commitment to sum of commitment inputs", index, err)
+			return privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+		}
+	}
+
+	proof.oneOfManyProof[i].Statement.Commitments = commitments
+	return nil
+}
+
+// VerifyBatch verifies aggregated range proofs and one out of many proofs of many payment proofs at once,
+// verified proofs are marked so Verify skips these checks later (the other checks are still done by Verify).
+// When the batch fails, proofs are verified one by one to pinpoint the invalid one,
+// index of the invalid item is returned along with the error, -1 is returned if all proofs are valid
+func VerifyBatch(items []PaymentProofBatchItem, db database.DatabaseInterface) (int, error) {
+	rangeProofs := make([]*aggregaterange.AggregatedRangeProof, 0)
+	oneOfManyProofs := make([]*oneoutofmany.OneOutOfManyProof, 0)
+	batchItems := make([]int, 0)
+	for idx, item := range items {
+		proof := item.Proof
+		if proof == nil || len(proof.oneOfManyProof) == 0 || proof.aggregatedRangeProof == nil || proof.aggregatedRangeProof.IsNil() {
+			continue
+		}
+		if proof.commitmentInputSecretKey == nil || proof.commitmentInputShardID == nil ||
+			len(proof.commitmentInputValue) < len(proof.oneOfManyProof) || len(proof.commitmentInputSND) < len(proof.oneOfManyProof) {
+			continue
+		}
+		for i := 0; i < len(proof.oneOfManyProof); i++ {
+			// Calculate cm input sum
+			cmInputSum := proof.commitmentInputSecretKey.Add(proof.commitmentInputValue[i])
+			cmInputSum = cmInputSum.Add(proof.commitmentInputSND[i])
+			cmInputSum = cmInputSum.Add(proof.commitmentInputShardID)
+			if err := proof.setOneOfManyStatement(i, cmInputSum, db, item.ShardID, item.TokenID); err != nil {
+				return idx, err
+			}
+			oneOfManyProofs = append(oneOfManyProofs, proof.oneOfManyProof[i])
+		}
+		rangeProofs = append(rangeProofs, proof.aggregatedRangeProof)
+		batchItems = append(batchItems, idx)
+	}
+	if len(batchItems) == 0 {
+		return -1, nil
+	}
+
+	validRange, _ := aggregaterange.VerifyBatch(rangeProofs)
+	validOneOfMany, _ := oneoutofmany.VerifyBatch(oneOfManyProofs)
+	if !validRange || !validOneOfMany {
+		// pinpoint the invalid proof
+		for _, idx := range batchItems {
+			proof := items[idx].Proof
+			if !validRange {
+				if valid, err := proof.aggregatedRangeProof.Verify(); !valid {
+					privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: Multi-range failed")
+					return idx, privacy.NewPrivacyErr(privacy.VerifyAggregatedProofFailedErr, err)
+				}
+			}
+			if !validOneOfMany {
+				for i := 0; i < len(proof.oneOfManyProof); i++ {
+					if valid, err := proof.oneOfManyProof[i].Verify(); !valid {
+						privacy.Logger.Log.Errorf("VERIFICATION PAYMENT PROOF: One out of many failed")
+						return idx, privacy.NewPrivacyErr(privacy.VerifyOneOutOfManyProofFailedErr, err)
+					}
+				}
+			}
+		}
+	}
+
+	for _, idx := range batchItems {
+		items[idx].Proof.batchVerifiedRing = &batchVerifiedRing{
+			shardID: items[idx].ShardID,
+			tokenID: *items[idx].TokenID,
+		}
+	}
+	return -1, nil
+}
@@ -52,6 +52,9 @@ type PaymentWitnessParam struct {
 	CommitmentIndices       []uint64
 	MyCommitmentIndices     []uint64
 	Fee                     uint64
+
+	// MultiSig is true when spending coins of a multisig account, which have no serial number proof
+	MultiSig bool
 }
 
 // Build prepares witnesses for all protocol need to be proved when create tx
@@ -82,6 +85,11 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 		wit.inputCoins = inputCoins
 		wit.outputCoins = outputCoins
 
+		// serial numbers of coins of multisig account are not derived from a spending key
+		if PaymentWitnessParam.MultiSig {
+			return nil
+		}
+
 		publicKey := inputCoins[0].CoinDetails.GetPublicKey()
 
 		wit.serialNumberNoPrivacyWitness = make([]*serialnumbernoprivacy.SNNoPrivacyWitness, len(inputCoins))
@@ -103,6 +111,15 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 
 	numInputCoin := len(wit.inputCoins)
 
+	// each input coin is hidden in a ring of commitments, all rings have the same size
+	ringSize := 0
+	if numInputCoin > 0 {
+		ringSize = len(commitments) / numInputCoin
+		if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok || len(commitments) != numInputCoin*ringSize {
+			return privacy.NewPrivacyErr(privacy.InvalidCommitmentRingSizeErr, nil)
+		}
+	}
+
 	randInputSK := privacy.RandScalar()
 	// set rand sk for Schnorr signature
 	wit.randSecretKey = new(big.Int).Set(randInputSK)
@@ -171,13 +188,13 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 		randInputSumAll.Mod(randInputSumAll, privacy.Curve.Params().N)
 
 		// commitmentTemps is a list of commitments for protocol one-out-of-N
-		commitmentTemps[i] = make([]*privacy.EllipticPoint, privacy.CommitmentRingSize)
+		commitmentTemps[i] = make([]*privacy.EllipticPoint, ringSize)
 
 		randInputIsZero[i] = big.NewInt(0)
 		randInputIsZero[i].Sub(inputCoin.CoinDetails.GetRandomness(), randInputSum[i])
 		randInputIsZero[i].Mod(randInputIsZero[i], privacy.Curve.Params().N)
 		var err error
-		for j := 0; j < privacy.CommitmentRingSize; j++ {
+		for j := 0; j < ringSize; j++ {
 			commitmentTemps[i][j], err = commitments[preIndex+j].Sub(cmInputSum[i])
 			if err != nil {
 				return privacy.NewPrivacyErr(privacy.UnexpectedErr, err)
@@ -188,10 +205,10 @@ func (wit *PaymentWitness) Init(PaymentWitnessParam PaymentWitnessParam) *privac
 		if wit.oneOfManyWitness[i] == nil {
 			wit.oneOfManyWitness[i] = new(oneoutofmany.OneOutOfManyWitness)
 		}
-		indexIsZero := myCommitmentIndices[i] % privacy.CommitmentRingSize
+		indexIsZero := myCommitmentIndices[i] % uint64(ringSize)
 
 		wit.oneOfManyWitness[i].Set(commitmentTemps[i], randInputIsZero[i], indexIsZero)
-		preIndex = privacy.CommitmentRingSize * (i + 1)
+		preIndex = ringSize * (i + 1)
 		// ---------------------------------------------------
 
 		/***** Build witness for proving that serial number is derived from the committed derivator *****/
@@ -310,7 +327,7 @@ func (wit *PaymentWitness) Prove(hasPrivacy bool) (*PaymentProof, *privacy.Priva
 	// is proved by signing with spending key
 	if !hasPrivacy {
 		// Proving that serial number is derived from the committed derivator
-		for i := 0; i < len(wit.inputCoins); i++ {
+		for i := 0; i < len(wit.serialNumberNoPrivacyWitness); i++ {
 			snNoPrivacyProof, err := wit.serialNumberNoPrivacyWitness[i].Prove(nil)
 			if err != nil {
 				return nil, privacy.NewPrivacyErr(privacy.ProveSerialNumberNoPrivacyErr, err)
@@ -28,8 +28,15 @@ func GenerateChallenge(values [][]byte) *big.Int {
 	return res
 }
 
-// EstimateProofSize returns the estimated size of the proof in bytes
-func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
+// GetOneOfManyProofSize returns size in bytes of a one out of many proof with ring size 2^ringSizeExp:
+// cl, ca, cb, cd, f, za, zb for each bit of index and zd
+func GetOneOfManyProofSize(ringSizeExp int) int {
+	return ringSizeExp*(4*privacy.CompressedEllipticPointSize+3*common.BigIntSize) + common.BigIntSize
+}
+
+// EstimateProofSize returns the estimated size of the proof in bytes,
+// proof with privacy hides each input coin in a ring of ringSize commitments
+func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool, ringSize int) uint64 {
 	if !hasPrivacy {
 		FlagSize := 14 + 2*nInput + nOutput
 		sizeSNNoPrivacyProof := nInput * SnNoPrivacyProofSize
@@ -42,7 +49,12 @@ func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
 
 	FlagSize := 14 + 7*nInput + 4*nOutput
 
-	sizeOneOfManyProof := nInput * OneOfManyProofSize
+	ringSizeExp, ok := privacy.GetCommitmentRingSizeExp(ringSize)
+	if !ok {
+		ringSizeExp = privacy.CommitmentRingSizeExp
+	}
+
+	sizeOneOfManyProof := nInput * GetOneOfManyProofSize(ringSizeExp)
 	sizeSNPrivacyProof := nInput * SnPrivacyProofSize
 	sizeComOutputMultiRangeProof := int(aggregaterange.EstimateMultiRangeProofSize(nOutput))
 
@@ -58,7 +70,7 @@ func EstimateProofSize(nInput int, nOutput int, hasPrivacy bool) uint64 {
 	sizeComInputSND := nInput * privacy.CompressedEllipticPointSize
 	sizeComInputShardID := privacy.CompressedEllipticPointSize
 
-	sizeCommitmentIndices := nInput * privacy.CommitmentRingSize * common.Uint64Size
+	sizeCommitmentIndices := nInput * ringSize * common.Uint64Size
 
 	sizeProof := sizeOneOfManyProof + sizeSNPrivacyProof +
 		sizeComOutputMultiRangeProof + sizeInputCoins + sizeOutputCoins +
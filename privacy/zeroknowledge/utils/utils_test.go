@@ -3,9 +3,11 @@ package utils
 import (
 	"fmt"
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/privacy"
 )
 
 func TestEstimateProofSize(t *testing.T) {
-	testcase1 := EstimateProofSize(4, 2, false)
+	testcase1 := EstimateProofSize(4, 2, false, privacy.CommitmentRingSize)
 	fmt.Printf("testcase 1: %v\n", testcase1)
 }
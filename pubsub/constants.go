@@ -2,11 +2,18 @@ package pubsub
 
 const ChanWorkLoad = 100
 
+// MaxOrderedQueueSize is number of messages queued for an ordered subscriber,
+// a subscriber falling further behind is disconnected instead of losing messages silently
+const MaxOrderedQueueSize = 1000
+
 // TOPIC
 const (
 	NewShardblockTopic              = "newshardblocktopic"
 	NewBeaconBlockTopic             = "newbeaconblocktopic"
+	ShardBlockDisconnectedTopic     = "shardblockdisconnectedtopic"  // shard block is reverted from chain
+	BeaconBlockDisconnectedTopic    = "beaconblockdisconnectedtopic" // beacon block is reverted from chain
 	TransactionHashEnterNodeTopic   = "transactionhashenternodetopic"
+	TransactionStatusTopic          = "transactionstatustopic"
 	ShardRoleTopic                  = "shardroletopic"
 	BeaconRoleTopic                 = "beaconroletopic"
 	MempoolInfoTopic                = "mempoolinfotopic"
@@ -22,9 +29,12 @@ const (
 var Topics = []string{
 	NewShardblockTopic,
 	NewBeaconBlockTopic,
+	ShardBlockDisconnectedTopic,
+	BeaconBlockDisconnectedTopic,
 	MempoolInfoTopic,
 	TestTopic,
 	TransactionHashEnterNodeTopic,
+	TransactionStatusTopic,
 	ShardRoleTopic,
 	BeaconRoleTopic,
 	BeaconBeststateTopic,
@@ -20,6 +20,8 @@ type PubSubManager struct {
 	MessageBroker  map[string][]*Message            // Message pool
 	IdGenerator    uint                             // id generator for event
 	cond           *sync.Cond
+
+	orderedSubscribers map[uint]*orderedSubscriber // subscribers receiving messages in order of publishing
 }
 
 func NewPubSubManager() *PubSubManager {
@@ -29,6 +31,8 @@ func NewPubSubManager() *PubSubManager {
 		MessageBroker:  make(map[string][]*Message),
 		IdGenerator:    0,
 		cond:           sync.NewCond(&sync.Mutex{}),
+
+		orderedSubscribers: make(map[uint]*orderedSubscriber),
 	}
 	for _, topic := range pubSubManager.TopicList {
 		pubSubManager.SubscriberList[topic] = make(map[uint]EventChannel)
@@ -76,11 +80,53 @@ func (pubSubManager *PubSubManager) RegisterNewSubscriber(topic string) (uint, E
 	return id, cSubscribe, nil
 }
 
+// RegisterNewOrderedSubscriber registers a subscriber of several topics,
+// messages of these topics are sent to its Event in the order they are published, whatever their topic.
+// Subscriber must be unsubscribed by UnsubscribeOrdered.
+// When more than MaxOrderedQueueSize messages are waiting for it, subscriber is unsubscribed and its Event is closed
+func (pubSubManager *PubSubManager) RegisterNewOrderedSubscriber(topics []string) (uint, EventChannel, error) {
+	pubSubManager.cond.L.Lock()
+	defer pubSubManager.cond.L.Unlock()
+	cSubscribe := make(chan *Message, ChanWorkLoad)
+	for _, topic := range topics {
+		if !pubSubManager.HasTopic(topic) {
+			return 0, cSubscribe, NewPubSubError(UnregisteredTopicError, errors.New(topic))
+		}
+	}
+	subscriber := &orderedSubscriber{
+		topics: topics,
+		event:  cSubscribe,
+		signal: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	id := pubSubManager.IdGenerator
+	pubSubManager.orderedSubscribers[id] = subscriber
+	pubSubManager.IdGenerator = id + 1
+	go subscriber.run()
+	return id, cSubscribe, nil
+}
+
+func (pubSubManager *PubSubManager) UnsubscribeOrdered(subId uint) {
+	pubSubManager.cond.L.Lock()
+	defer pubSubManager.cond.L.Unlock()
+	if subscriber, ok := pubSubManager.orderedSubscribers[subId]; ok {
+		close(subscriber.done)
+		delete(pubSubManager.orderedSubscribers, subId)
+	}
+}
+
 // Publisher public message to EventChannel
 func (pubSubManager *PubSubManager) PublishMessage(message *Message) {
 	pubSubManager.cond.L.Lock()
 	defer pubSubManager.cond.L.Unlock()
 	pubSubManager.MessageBroker[message.Topic] = append(pubSubManager.MessageBroker[message.Topic], message)
+	for id, subscriber := range pubSubManager.orderedSubscribers {
+		if common.IndexOfStr(message.Topic, subscriber.topics) > -1 && !subscriber.push(message) {
+			Logger.log.Warnf("Disconnect ordered subscriber %d of topics %+v, %d messages are not received", id, subscriber.topics, MaxOrderedQueueSize)
+			subscriber.disconnect()
+			delete(pubSubManager.orderedSubscribers, id)
+		}
+	}
 	pubSubManager.cond.Signal()
 }
 
@@ -108,3 +154,72 @@ func (pubSubManager *PubSubManager) AddTopic(topic string) {
 		pubSubManager.TopicList = append(pubSubManager.TopicList, topic)
 	}
 }
+
+// orderedSubscriber queues messages of its topics and sends them to its event one by one,
+// so a slow subscriber never blocks publisher nor receives messages out of order
+type orderedSubscriber struct {
+	topics       []string
+	event        EventChannel
+	signal       chan struct{} // signals new message in queue
+	done         chan struct{} // closed when unsubscribed
+	mtx          sync.Mutex
+	queue        []*Message
+	disconnected bool // queue was full, event is closed when run returns
+}
+
+// push queues message, it returns false when queue is full
+func (subscriber *orderedSubscriber) push(message *Message) bool {
+	subscriber.mtx.Lock()
+	if len(subscriber.queue) >= MaxOrderedQueueSize {
+		subscriber.mtx.Unlock()
+		return false
+	}
+	subscriber.queue = append(subscriber.queue, message)
+	subscriber.mtx.Unlock()
+	select {
+	case subscriber.signal <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// disconnect drops queued messages and stops subscriber, its event is closed
+// so the reader knows it missed messages
+func (subscriber *orderedSubscriber) disconnect() {
+	subscriber.mtx.Lock()
+	subscriber.queue = nil
+	subscriber.disconnected = true
+	subscriber.mtx.Unlock()
+	close(subscriber.done)
+}
+
+func (subscriber *orderedSubscriber) run() {
+	defer func() {
+		subscriber.mtx.Lock()
+		defer subscriber.mtx.Unlock()
+		if subscriber.disconnected {
+			close(subscriber.event)
+		}
+	}()
+	for {
+		subscriber.mtx.Lock()
+		if len(subscriber.queue) == 0 {
+			subscriber.mtx.Unlock()
+			select {
+			case <-subscriber.signal:
+				continue
+			case <-subscriber.done:
+				return
+			}
+		}
+		message := subscriber.queue[0]
+		subscriber.queue[0] = nil
+		subscriber.queue = subscriber.queue[1:]
+		subscriber.mtx.Unlock()
+		select {
+		case subscriber.event <- message:
+		case <-subscriber.done:
+			return
+		}
+	}
+}
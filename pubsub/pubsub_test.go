@@ -4,8 +4,16 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
 )
 
+var _ = func() (_ struct{}) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
 func TestNewMessage(t *testing.T) {
 	msg := NewMessage(TestTopic, 1)
 	if msg.Topic != TestTopic {
@@ -137,3 +145,71 @@ func TestAddTopic(t *testing.T) {
 		t.Error("Pubsub manager should have this topic")
 	}
 }
+
+func TestOrderedSubscriber(t *testing.T) {
+	var pubsubManager = NewPubSubManager()
+	id, event, err := pubsubManager.RegisterNewOrderedSubscriber([]string{TestTopic, NewShardblockTopic})
+	if err != nil {
+		t.Fatalf("Error when subcription %+v", err)
+	}
+	// messages of both topics are received in order of publishing, messages of other topics are not received
+	for i := 0; i < 2*ChanWorkLoad; i++ {
+		topic := TestTopic
+		if i%3 == 0 {
+			topic = NewShardblockTopic
+		}
+		pubsubManager.PublishMessage(NewMessage(topic, i))
+		pubsubManager.PublishMessage(NewMessage(NewBeaconBlockTopic, -1))
+	}
+	for i := 0; i < 2*ChanWorkLoad; i++ {
+		msg := <-event
+		if value, ok := msg.Value.(int); !ok || value != i {
+			t.Fatalf("Wrong message %+v, expect %d", msg.Value, i)
+		}
+	}
+	pubsubManager.UnsubscribeOrdered(id)
+	pubsubManager.PublishMessage(NewMessage(TestTopic, 0))
+	if len(pubsubManager.orderedSubscribers) != 0 {
+		t.Error("Should have no ordered subscriber")
+	}
+
+	_, _, err = pubsubManager.RegisterNewOrderedSubscriber([]string{TestTopic, "ajsdkl;awjdkl"})
+	if pubsubErr, ok := err.(*PubSubError); !ok || pubsubErr.Code != -1002 {
+		t.Error("Wrong error type")
+	}
+}
+
+func TestOrderedSubscriberTooSlow(t *testing.T) {
+	var pubsubManager = NewPubSubManager()
+	_, event, err := pubsubManager.RegisterNewOrderedSubscriber([]string{TestTopic})
+	if err != nil {
+		t.Fatalf("Error when subcription %+v", err)
+	}
+	// subscriber reads nothing, its queue is full after event channel is
+	for i := 0; len(pubsubManager.orderedSubscribers) != 0; i++ {
+		if i > ChanWorkLoad+MaxOrderedQueueSize+1 {
+			t.Fatal("Slow ordered subscriber should be disconnected")
+		}
+		pubsubManager.PublishMessage(NewMessage(TestTopic, i))
+		for i == ChanWorkLoad && len(event) < ChanWorkLoad {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	// messages already in event are received in order, then event is closed
+	for i := 0; ; i++ {
+		select {
+		case msg, ok := <-event:
+			if !ok {
+				if i > ChanWorkLoad+1 {
+					t.Fatalf("Received %d messages, queued messages should be dropped", i)
+				}
+				return
+			}
+			if value, ok := msg.Value.(int); !ok || value != i {
+				t.Fatalf("Wrong message %+v, expect %d", msg.Value, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Event of disconnected subscriber should be closed")
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/pkg/errors"
+)
+
+/*
+API keys give named clients access to a set of RPC methods, every method call of a key is throttled by
+a token bucket. Keys are configured in a json file (--rpcapikeyfile), which is reloaded when it changes:
+{
+	"Public": {"Methods": ["getblockchaininfo", "listoutputcoins"], "RateLimits": {"*": {"Rate": 5, "Burst": 10}}},
+	"Keys": [
+		{
+			"Name": "explorer",
+			"Key": "secret",
+			"Methods": ["*"],
+			"RateLimits": {"*": {"Rate": 20, "Burst": 40}, "listoutputcoins": {"Rate": 0.5, "Burst": 2}}
+		}
+	]
+}
+- Methods: allowed methods, "*" allows every method
+- RateLimits: Rate is number of calls per second, Burst is number of calls which can be made at once,
+  limit "*" applies to allowed methods without their own limit, methods without any limit are not throttled
+- Public: optional policy of requests without api key when rpc auth is disabled (websocket has no rpc auth),
+  all its clients share its buckets
+A client sends its key in header X-API-Key, websocket clients can send it in query param apikey instead,
+gRPC clients send it in metadata x-api-key
+*/
+
+type contextKey string
+
+const apiKeyContextKey = contextKey("apikey")
+
+const (
+	apiKeyHeader         = "X-API-Key"
+	apiKeyQueryParam     = "apikey"
+	allMethods           = "*"
+	apiKeyReloadInterval = 5 * time.Second
+)
+
+// RateLimit configures a token bucket
+type RateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// APIKeyPolicy is the allowed methods and rate limits of an api key
+type APIKeyPolicy struct {
+	Methods    []string
+	RateLimits map[string]RateLimit
+}
+
+// APIKeyConfig is an api key entry of api key file
+type APIKeyConfig struct {
+	Name string
+	Key  string
+	APIKeyPolicy
+}
+
+// APIKeyFile is content of api key file
+type APIKeyFile struct {
+	Public *APIKeyPolicy
+	Keys   []APIKeyConfig
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate tokens per second, a call takes one token
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		limit:  limit,
+		tokens: limit.Burst,
+		last:   now,
+	}
+}
+
+func (bucket *tokenBucket) take(now time.Time) bool {
+	if now.After(bucket.last) {
+		bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.limit.Rate
+		if bucket.tokens > bucket.limit.Burst {
+			bucket.tokens = bucket.limit.Burst
+		}
+		bucket.last = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// apiKey is a loaded api key (or the public policy), safe for concurrent access
+type apiKey struct {
+	name       string
+	allMethods bool
+	methods    map[string]bool
+	rateLimits map[string]RateLimit
+	mtx        sync.Mutex
+	buckets    map[string]*tokenBucket // method -> bucket of method
+}
+
+func newAPIKey(name string, policy APIKeyPolicy) *apiKey {
+	key := &apiKey{
+		name:       name,
+		methods:    make(map[string]bool),
+		rateLimits: make(map[string]RateLimit),
+		buckets:    make(map[string]*tokenBucket),
+	}
+	for _, method := range policy.Methods {
+		if method == allMethods {
+			key.allMethods = true
+		}
+		key.methods[method] = true
+	}
+	for method, limit := range policy.RateLimits {
+		key.rateLimits[method] = limit
+	}
+	return key
+}
+
+func (key *apiKey) rateLimit(method string) (RateLimit, bool) {
+	if limit, ok := key.rateLimits[method]; ok {
+		return limit, true
+	}
+	limit, ok := key.rateLimits[allMethods]
+	return limit, ok
+}
+
+// keepBuckets takes buckets of old, whose limits are unchanged, so that reloading keys doesn't refill them
+func (key *apiKey) keepBuckets(old *apiKey) {
+	old.mtx.Lock()
+	defer old.mtx.Unlock()
+	for method, bucket := range old.buckets {
+		if limit, ok := key.rateLimit(method); ok && limit == bucket.limit {
+			key.buckets[method] = bucket
+		}
+	}
+}
+
+// access returns nil if key can call method now, or the error to reply
+func (key *apiKey) access(method string, now time.Time) *RPCError {
+	if !key.allMethods && !key.methods[method] {
+		return NewRPCError(ErrRPCInvalidMethodPermission, errors.Errorf("api key %s can not call method %s", key.name, method))
+	}
+	limit, ok := key.rateLimit(method)
+	if !ok {
+		return nil
+	}
+	key.mtx.Lock()
+	defer key.mtx.Unlock()
+	bucket, ok := key.buckets[method]
+	if !ok {
+		bucket = newTokenBucket(limit, now)
+		key.buckets[method] = bucket
+	}
+	if !bucket.take(now) {
+		return NewRPCError(ErrRPCRateLimitExceeded, errors.Errorf("api key %s exceeds rate limit of method %s", key.name, method))
+	}
+	return nil
+}
+
+// APIKeyManager keeps keys of api key file up to date with the file
+type APIKeyManager struct {
+	fileName string
+	mtx      sync.RWMutex
+	modTime  time.Time
+	size     int64
+	keys     map[string]*apiKey // key -> loaded key
+	public   *apiKey
+	cStop    chan struct{}
+}
+
+// NewAPIKeyManager loads api key file, a manager without file (empty fileName) accepts no api key and throttles nothing
+func NewAPIKeyManager(fileName string) (*APIKeyManager, error) {
+	manager := &APIKeyManager{
+		fileName: fileName,
+		keys:     make(map[string]*apiKey),
+		cStop:    make(chan struct{}),
+	}
+	if fileName == "" {
+		return manager, nil
+	}
+	if err := manager.Reload(); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// ParseAPIKeyFile parses and validates content of api key file
+func ParseAPIKeyFile(data []byte) (*APIKeyFile, error) {
+	file := &APIKeyFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	policies := []APIKeyPolicy{}
+	if file.Public != nil {
+		policies = append(policies, *file.Public)
+	}
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, keyConfig := range file.Keys {
+		if keyConfig.Name == "" || keyConfig.Key == "" {
+			return nil, errors.New("api key must have name and key")
+		}
+		if names[keyConfig.Name] || keys[keyConfig.Key] {
+			return nil, errors.Errorf("api key %s is duplicated", keyConfig.Name)
+		}
+		names[keyConfig.Name] = true
+		keys[keyConfig.Key] = true
+		policies = append(policies, keyConfig.APIKeyPolicy)
+	}
+	for _, policy := range policies {
+		for method, limit := range policy.RateLimits {
+			if limit.Rate < 0 || limit.Burst < 1 {
+				return nil, errors.Errorf("rate limit of method %s is invalid, rate must not be negative and burst must be at least 1", method)
+			}
+		}
+	}
+	return file, nil
+}
+
+// Reload reads api key file again, current keys are kept if the file is invalid
+func (manager *APIKeyManager) Reload() error {
+	info, err := os.Stat(manager.fileName)
+	if err != nil {
+		return errors.Wrap(err, "can not read api key file")
+	}
+	data, err := ioutil.ReadFile(manager.fileName)
+	if err != nil {
+		return errors.Wrap(err, "can not read api key file")
+	}
+	file, err := ParseAPIKeyFile(data)
+	if err != nil {
+		return errors.Wrapf(err, "api key file %s is invalid", manager.fileName)
+	}
+
+	manager.mtx.Lock()
+	defer manager.mtx.Unlock()
+	oldKeys := make(map[string]*apiKey)
+	for _, old := range manager.keys {
+		oldKeys[old.name] = old
+	}
+	keys := make(map[string]*apiKey)
+	for _, keyConfig := range file.Keys {
+		key := newAPIKey(keyConfig.Name, keyConfig.APIKeyPolicy)
+		if old, ok := oldKeys[key.name]; ok {
+			key.keepBuckets(old)
+		}
+		keys[keyConfig.Key] = key
+	}
+	var public *apiKey
+	if file.Public != nil {
+		public = newAPIKey("public", *file.Public)
+		if manager.public != nil {
+			public.keepBuckets(manager.public)
+		}
+	}
+	manager.keys = keys
+	manager.public = public
+	manager.modTime = info.ModTime()
+	manager.size = info.Size()
+	Logger.log.Infof("Loaded %d api keys from %s", len(keys), manager.fileName)
+	return nil
+}
+
+// isFileChanged checks whether api key file is modified since the last load
+func (manager *APIKeyManager) isFileChanged() bool {
+	info, err := os.Stat(manager.fileName)
+	if err != nil {
+		return false
+	}
+	manager.mtx.RLock()
+	defer manager.mtx.RUnlock()
+	return !info.ModTime().Equal(manager.modTime) || info.Size() != manager.size
+}
+
+// Start reloads api key file whenever it changes, until Stop is called
+func (manager *APIKeyManager) Start() {
+	if manager.fileName == "" {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(apiKeyReloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-manager.cStop:
+				return
+			case <-ticker.C:
+				if !manager.isFileChanged() {
+					continue
+				}
+				if err := manager.Reload(); err != nil {
+					Logger.log.Errorf("Keep current api keys, reload failed with err %+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops reloading api key file
+func (manager *APIKeyManager) Stop() {
+	if manager.fileName == "" {
+		return
+	}
+	close(manager.cStop)
+}
+
+// keyOfRequest returns api key sent with request, it is empty if there is none
+func keyOfRequest(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+	return r.URL.Query().Get(apiKeyQueryParam)
+}
+
+// getKey returns loaded key of key, or an error if key is unknown
+func (manager *APIKeyManager) getKey(key string) (*apiKey, error) {
+	manager.mtx.RLock()
+	defer manager.mtx.RUnlock()
+	loadedKey, ok := manager.keys[key]
+	if !ok {
+		return nil, NewRPCError(ErrAuthFail, errors.New("unknown api key"))
+	}
+	return loadedKey, nil
+}
+
+// getPublic returns the public policy, nil if there is none
+func (manager *APIKeyManager) getPublic() *apiKey {
+	manager.mtx.RLock()
+	defer manager.mtx.RUnlock()
+	return manager.public
+}
+
+// checkAccess returns nil if key (nil means no api key is used) can call method now, or the error to reply.
+// Every rejection is sent to metrics
+func checkAccess(key *apiKey, method string) *RPCError {
+	if key == nil {
+		return nil
+	}
+	rpcErr := key.access(method, time.Now())
+	if rpcErr != nil {
+		Logger.log.Warnf("Reject RPC method %s: %+v", method, rpcErr.GetErr())
+		go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+			metrics.Measurement:      metrics.RPCRejected,
+			metrics.MeasurementValue: float64(1),
+			metrics.Tag:              metrics.RPCMethodTag,
+			metrics.TagValue:         method,
+		})
+	}
+	return rpcErr
+}
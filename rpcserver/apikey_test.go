@@ -0,0 +1,139 @@
+package rpcserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAPIKeyFile = `{
+	"Public": {"Methods": ["getblockchaininfo"], "RateLimits": {"*": {"Rate": 1, "Burst": 1}}},
+	"Keys": [
+		{
+			"Name": "explorer",
+			"Key": "secret",
+			"Methods": ["*"],
+			"RateLimits": {"listoutputcoins": {"Rate": 1, "Burst": 2}}
+		},
+		{
+			"Name": "wallet",
+			"Key": "secret2",
+			"Methods": ["getbalance"]
+		}
+	]
+}`
+
+func writeTestAPIKeyFile(t *testing.T, dir string, content string) string {
+	fileName := filepath.Join(dir, "apikeys.json")
+	if err := ioutil.WriteFile(fileName, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return fileName
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(RateLimit{Rate: 2, Burst: 3}, now)
+	for i := 0; i < 3; i++ {
+		assert.True(t, bucket.take(now))
+	}
+	assert.False(t, bucket.take(now))
+	// 2 tokens per second
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, bucket.take(now))
+	assert.False(t, bucket.take(now))
+	// refill up to burst
+	now = now.Add(10 * time.Second)
+	for i := 0; i < 3; i++ {
+		assert.True(t, bucket.take(now))
+	}
+	assert.False(t, bucket.take(now))
+}
+
+func TestAPIKeyAccess(t *testing.T) {
+	file, err := ParseAPIKeyFile([]byte(testAPIKeyFile))
+	assert.Nil(t, err)
+	now := time.Now()
+
+	explorer := newAPIKey(file.Keys[0].Name, file.Keys[0].APIKeyPolicy)
+	assert.Nil(t, explorer.access(getBlockChainInfo, now))
+	assert.Nil(t, explorer.access(listOutputCoins, now))
+	assert.Nil(t, explorer.access(listOutputCoins, now))
+	rpcErr := explorer.access(listOutputCoins, now)
+	if assert.NotNil(t, rpcErr) {
+		assert.Equal(t, GetErrorCode(ErrRPCRateLimitExceeded), rpcErr.Code)
+	}
+	// other methods have their own buckets
+	assert.Nil(t, explorer.access(getBlockChainInfo, now))
+
+	wallet := newAPIKey(file.Keys[1].Name, file.Keys[1].APIKeyPolicy)
+	assert.Nil(t, wallet.access(getBalance, now))
+	rpcErr = wallet.access(listOutputCoins, now)
+	if assert.NotNil(t, rpcErr) {
+		assert.Equal(t, GetErrorCode(ErrRPCInvalidMethodPermission), rpcErr.Code)
+	}
+}
+
+func TestParseAPIKeyFile(t *testing.T) {
+	_, err := ParseAPIKeyFile([]byte(`{"Keys": [{"Name": "a", "Key": "k"}, {"Name": "b", "Key": "k"}]}`))
+	assert.NotNil(t, err)
+	_, err = ParseAPIKeyFile([]byte(`{"Keys": [{"Name": "a"}]}`))
+	assert.NotNil(t, err)
+	_, err = ParseAPIKeyFile([]byte(`{"Public": {"RateLimits": {"*": {"Rate": 1, "Burst": 0}}}}`))
+	assert.NotNil(t, err)
+	_, err = ParseAPIKeyFile([]byte(`{"Keys": [}`))
+	assert.NotNil(t, err)
+}
+
+func TestAPIKeyManagerReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := writeTestAPIKeyFile(t, dir, testAPIKeyFile)
+	manager, err := NewAPIKeyManager(fileName)
+	assert.Nil(t, err)
+
+	_, err = manager.getKey("unknown")
+	assert.NotNil(t, err)
+	explorer, err := manager.getKey("secret")
+	assert.Nil(t, err)
+	assert.Nil(t, checkAccess(explorer, listOutputCoins))
+	assert.Nil(t, checkAccess(explorer, listOutputCoins))
+	assert.NotNil(t, checkAccess(explorer, listOutputCoins))
+	public := manager.getPublic()
+	assert.Nil(t, checkAccess(public, getBlockChainInfo))
+	assert.NotNil(t, checkAccess(public, getBalance))
+
+	// an invalid file keeps current keys
+	writeTestAPIKeyFile(t, dir, `{"Keys": [`)
+	assert.NotNil(t, manager.Reload())
+	_, err = manager.getKey("secret")
+	assert.Nil(t, err)
+
+	// reloading keeps buckets of unchanged limits, removed keys are rejected
+	writeTestAPIKeyFile(t, dir, `{"Keys": [{"Name": "explorer", "Key": "newsecret", "Methods": ["*"], "RateLimits": {"listoutputcoins": {"Rate": 1, "Burst": 2}}}]}`)
+	assert.True(t, manager.isFileChanged())
+	assert.Nil(t, manager.Reload())
+	assert.False(t, manager.isFileChanged())
+	_, err = manager.getKey("secret")
+	assert.NotNil(t, err)
+	explorer, err = manager.getKey("newsecret")
+	assert.Nil(t, err)
+	assert.NotNil(t, checkAccess(explorer, listOutputCoins))
+	assert.Nil(t, manager.getPublic())
+	assert.Nil(t, checkAccess(manager.getPublic(), getBalance))
+
+	// a manager without file accepts no key
+	manager, err = NewAPIKeyManager("")
+	assert.Nil(t, err)
+	_, err = manager.getKey("secret")
+	assert.NotNil(t, err)
+	manager.Start()
+	manager.Stop()
+}
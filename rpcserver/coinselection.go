@@ -0,0 +1,280 @@
+package rpcserver
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/pkg/errors"
+)
+
+/*
+Coin selection chooses which unspent output coins of sender are spent by a tx, it is chosen per request by name:
+- default: smallest coins under the amount, else a single coin over the amount
+- largestfirst: largest coins first, a tx spends as few coins as possible
+- branchandbound: searches coins paying exactly amount and fee so tx has no change output, falls back to default
+- random: coins in random order, so chosen coins do not tell anything about the amount
+- consolidate: default, then adds dust coins of sender when fee is low so that they are merged into change
+Every strategy keeps tx under the limits of number of input coins, number of outputs and tx size
+*/
+
+const (
+	coinSelectionDefault        = "default"
+	coinSelectionLargestFirst   = "largestfirst"
+	coinSelectionBranchAndBound = "branchandbound"
+	coinSelectionRandom         = "random"
+	coinSelectionConsolidate    = "consolidate"
+
+	maxInputCoins            = 255 // see TxPrivacyInitParams
+	maxOutputCoins           = 254 // see TxPrivacyInitParams
+	maxBranchAndBoundTries   = 100000
+	maxDefaultSelectionTries = 10
+)
+
+var coinSelectors = map[string]coinSelector{
+	coinSelectionDefault:        defaultCoinSelector{},
+	coinSelectionLargestFirst:   largestFirstCoinSelector{},
+	coinSelectionBranchAndBound: branchAndBoundCoinSelector{},
+	coinSelectionRandom:         randomCoinSelector{},
+	coinSelectionConsolidate:    consolidateCoinSelector{},
+}
+
+// coinSelector chooses coins to spend among outCoins, chosen coins must pay amount and fee of target
+type coinSelector interface {
+	selectCoins(outCoins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error)
+}
+
+// getCoinSelectorParam returns coin selector named by an optional param of request, nil param means default
+func getCoinSelectorParam(param interface{}) (coinSelector, *RPCError) {
+	if param == nil {
+		return defaultCoinSelector{}, nil
+	}
+	name, ok := param.(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("coin selection strategy is invalid"))
+	}
+	if name == "" {
+		return defaultCoinSelector{}, nil
+	}
+	selector, ok := coinSelectors[name]
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.Errorf("coin selection strategy %s is not supported", name))
+	}
+	return selector, nil
+}
+
+// coinSelectionTarget is what chosen coins must pay: amount of receivers and fee of tx spending them
+type coinSelectionTarget struct {
+	amount uint64
+	// allowChange is false when receivers already take all outputs of tx
+	allowChange bool
+	// lowFee is true when fee per kb is not above limit fee of chain, it is cheap to spend more coins
+	lowFee bool
+	// estimateFee returns fee and size in kb of tx spending inputs, with or without change output
+	estimateFee func(inputs []*privacy.OutputCoin, hasChange bool) (uint64, uint64)
+}
+
+// payFee returns fee of tx spending inputs, false if inputs can not pay amount and fee.
+// When change is too small to be worth an output, it is paid as fee too
+func (target *coinSelectionTarget) payFee(inputs []*privacy.OutputCoin) (uint64, bool) {
+	if len(inputs) == 0 || target.tooLarge(inputs) {
+		return 0, false
+	}
+	sum := totalCoinValue(inputs)
+	feeNoChange, _ := target.estimateFee(inputs, false)
+	if sum < target.amount+feeNoChange {
+		return 0, false
+	}
+	feeWithChange, _ := target.estimateFee(inputs, true)
+	if sum < target.amount+feeWithChange {
+		return sum - target.amount, true
+	}
+	if !target.allowChange {
+		return 0, false
+	}
+	return feeWithChange, true
+}
+
+// isExact tells if inputs pay amount and fee without change output
+func (target *coinSelectionTarget) isExact(inputs []*privacy.OutputCoin, sum uint64) bool {
+	feeNoChange, _ := target.estimateFee(inputs, false)
+	feeWithChange, _ := target.estimateFee(inputs, true)
+	return sum >= target.amount+feeNoChange && sum < target.amount+feeWithChange
+}
+
+// tooLarge tells if tx spending inputs is over the limits of number of input coins or tx size
+func (target *coinSelectionTarget) tooLarge(inputs []*privacy.OutputCoin) bool {
+	if len(inputs) > maxInputCoins {
+		return true
+	}
+	_, sizeInKb := target.estimateFee(inputs, target.allowChange)
+	return sizeInKb >= common.MaxTxSize
+}
+
+func totalCoinValue(coins []*privacy.OutputCoin) uint64 {
+	sum := uint64(0)
+	for _, coin := range coins {
+		sum += coin.CoinDetails.GetValue()
+	}
+	return sum
+}
+
+// accumulateCoins spends coins in given order until they pay target
+func accumulateCoins(coins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error) {
+	selected := make([]*privacy.OutputCoin, 0)
+	for _, coin := range coins {
+		selected = append(selected, coin)
+		if target.tooLarge(selected) {
+			return nil, errors.New("tx spending enough coins is too large, defragment account first")
+		}
+		if _, ok := target.payFee(selected); ok {
+			return selected, nil
+		}
+	}
+	return nil, errors.New("Not enough coin")
+}
+
+type defaultCoinSelector struct{}
+
+// selectCoins chooses coins with chooseBestOutCoinsToSpent, the amount to choose grows with fee of chosen coins
+func (defaultCoinSelector) selectCoins(outCoins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error) {
+	amount := target.amount
+	for i := 0; i < maxDefaultSelectionTries; i++ {
+		selected, _, _, err := chooseBestOutCoinsToSpent(outCoins, amount)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := target.payFee(selected); ok {
+			return selected, nil
+		}
+		if target.tooLarge(selected) {
+			return nil, errors.New("tx spending enough coins is too large, defragment account first")
+		}
+		fee, _ := target.estimateFee(selected, target.allowChange)
+		if target.amount+fee <= amount {
+			break
+		}
+		amount = target.amount + fee
+	}
+	return nil, errors.New("Not enough coin to pay fee")
+}
+
+type largestFirstCoinSelector struct{}
+
+func (largestFirstCoinSelector) selectCoins(outCoins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error) {
+	coins := sortCoinsByValue(outCoins, true)
+	return accumulateCoins(coins, target)
+}
+
+type randomCoinSelector struct{}
+
+func (randomCoinSelector) selectCoins(outCoins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error) {
+	coins := make([]*privacy.OutputCoin, len(outCoins))
+	copy(coins, outCoins)
+	// Fisher-Yates shuffle with crypto random
+	for i := len(coins) - 1; i > 0; i-- {
+		j, err := common.RandBigIntMaxRange(big.NewInt(int64(i + 1)))
+		if err != nil {
+			return nil, err
+		}
+		coins[i], coins[j.Int64()] = coins[j.Int64()], coins[i]
+	}
+	return accumulateCoins(coins, target)
+}
+
+type branchAndBoundCoinSelector struct{}
+
+// selectCoins searches depth first, from the largest coin, a set of coins paying target without change output
+func (branchAndBoundCoinSelector) selectCoins(outCoins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error) {
+	coins := sortCoinsByValue(outCoins, true)
+	// remains[i] is total value of coins[i:]
+	remains := make([]uint64, len(coins)+1)
+	for i := len(coins) - 1; i >= 0; i-- {
+		remains[i] = remains[i+1] + coins[i].CoinDetails.GetValue()
+	}
+
+	var result []*privacy.OutputCoin
+	tries := 0
+	var search func(i int, selected []*privacy.OutputCoin, sum uint64) bool
+	search = func(i int, selected []*privacy.OutputCoin, sum uint64) bool {
+		tries++
+		if tries > maxBranchAndBoundTries {
+			return false
+		}
+		if len(selected) > 0 {
+			if target.isExact(selected, sum) {
+				result = make([]*privacy.OutputCoin, len(selected))
+				copy(result, selected)
+				return true
+			}
+			// Over amount and fee with change output, more coins only make change larger
+			if feeWithChange, _ := target.estimateFee(selected, true); sum >= target.amount+feeWithChange {
+				return false
+			}
+		}
+		if i == len(coins) || len(selected) == maxInputCoins {
+			return false
+		}
+		// Even all remaining coins can not pay amount and fee
+		if feeNoChange, _ := target.estimateFee(selected, false); sum+remains[i] < target.amount+feeNoChange {
+			return false
+		}
+		if search(i+1, append(selected, coins[i]), sum+coins[i].CoinDetails.GetValue()) {
+			return true
+		}
+		return search(i+1, selected, sum)
+	}
+	if search(0, make([]*privacy.OutputCoin, 0), 0) && !target.tooLarge(result) {
+		return result, nil
+	}
+	Logger.log.Debugf("No exact match in %d tries, fall back to default coin selection", tries)
+	return defaultCoinSelector{}.selectCoins(outCoins, target)
+}
+
+type consolidateCoinSelector struct{}
+
+// selectCoins chooses coins with default selection, then when fee is low it adds dust coins (smaller than amount of tx),
+// smallest first, which are worth more than the fee they add, while tx fits its limits
+func (consolidateCoinSelector) selectCoins(outCoins []*privacy.OutputCoin, target *coinSelectionTarget) ([]*privacy.OutputCoin, error) {
+	selected, err := defaultCoinSelector{}.selectCoins(outCoins, target)
+	if err != nil || !target.lowFee || !target.allowChange {
+		return selected, err
+	}
+	isSelected := make(map[*privacy.OutputCoin]bool)
+	for _, coin := range selected {
+		isSelected[coin] = true
+	}
+	fee, _ := target.estimateFee(selected, true)
+	for _, coin := range sortCoinsByValue(outCoins, false) {
+		if coin.CoinDetails.GetValue() >= target.amount {
+			break
+		}
+		if isSelected[coin] {
+			continue
+		}
+		candidate := append(append([]*privacy.OutputCoin{}, selected...), coin)
+		if target.tooLarge(candidate) {
+			break
+		}
+		newFee, _ := target.estimateFee(candidate, true)
+		if _, ok := target.payFee(candidate); !ok || coin.CoinDetails.GetValue() <= newFee-fee {
+			continue
+		}
+		selected, fee = candidate, newFee
+	}
+	return selected, nil
+}
+
+// sortCoinsByValue returns a sorted copy of coins
+func sortCoinsByValue(outCoins []*privacy.OutputCoin, descending bool) []*privacy.OutputCoin {
+	coins := make([]*privacy.OutputCoin, len(outCoins))
+	copy(coins, outCoins)
+	sort.SliceStable(coins, func(i, j int) bool {
+		if descending {
+			return coins[i].CoinDetails.GetValue() > coins[j].CoinDetails.GetValue()
+		}
+		return coins[i].CoinDetails.GetValue() < coins[j].CoinDetails.GetValue()
+	})
+	return coins
+}
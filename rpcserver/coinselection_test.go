@@ -0,0 +1,118 @@
+package rpcserver
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOutCoins(values ...uint64) []*privacy.OutputCoin {
+	outCoins := make([]*privacy.OutputCoin, len(values))
+	for i, value := range values {
+		outCoins[i] = new(privacy.OutputCoin)
+		outCoins[i].CoinDetails = new(privacy.Coin)
+		outCoins[i].CoinDetails.SetValue(value)
+	}
+	return outCoins
+}
+
+// newTestTarget returns a target whose tx costs 10 per input coin and 5 per output, 1 kb per input coin
+func newTestTarget(amount uint64) *coinSelectionTarget {
+	return &coinSelectionTarget{
+		amount:      amount,
+		allowChange: true,
+		lowFee:      true,
+		estimateFee: func(inputs []*privacy.OutputCoin, hasChange bool) (uint64, uint64) {
+			fee := uint64(10*len(inputs) + 5)
+			if hasChange {
+				fee += 5
+			}
+			return fee, uint64(len(inputs))
+		},
+	}
+}
+
+func TestCoinSelectors(t *testing.T) {
+	outCoins := newTestOutCoins(5, 100, 300, 1000, 40, 7)
+	for name, selector := range coinSelectors {
+		target := newTestTarget(350)
+		selected, err := selector.selectCoins(outCoins, target)
+		assert.Equal(t, nil, err, name)
+		fee, ok := target.payFee(selected)
+		assert.Equal(t, true, ok, name)
+		assert.Equal(t, true, totalCoinValue(selected) >= target.amount+fee, name)
+
+		_, err = selector.selectCoins(outCoins, newTestTarget(2000))
+		assert.NotEqual(t, nil, err, name)
+	}
+}
+
+func TestLargestFirstCoinSelector(t *testing.T) {
+	outCoins := newTestOutCoins(5, 100, 300, 1000, 40, 7)
+	selected, err := largestFirstCoinSelector{}.selectCoins(outCoins, newTestTarget(1100))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint64{1000, 300}, coinValues(selected))
+}
+
+func TestBranchAndBoundCoinSelector(t *testing.T) {
+	// 340 + fee of 2 inputs without change (25) is paid exactly by 300 and 65
+	outCoins := newTestOutCoins(1000, 300, 65, 90, 7)
+	target := newTestTarget(340)
+	selected, err := branchAndBoundCoinSelector{}.selectCoins(outCoins, target)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint64{300, 65}, coinValues(selected))
+	fee, ok := target.payFee(selected)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(25), fee)
+
+	// No exact match, it falls back to default selection
+	selected, err = branchAndBoundCoinSelector{}.selectCoins(newTestOutCoins(1000), target)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint64{1000}, coinValues(selected))
+}
+
+func TestConsolidateCoinSelector(t *testing.T) {
+	outCoins := newTestOutCoins(500, 3, 50, 20, 2000)
+	target := newTestTarget(400)
+	selected, err := consolidateCoinSelector{}.selectCoins(outCoins, target)
+	assert.Equal(t, nil, err)
+	// coin of 3 is not worth the fee of spending it, coin of 500 is not dust
+	assert.Equal(t, []uint64{2000, 20, 50}, coinValues(selected))
+
+	target.lowFee = false
+	selected, err = consolidateCoinSelector{}.selectCoins(outCoins, target)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []uint64{2000}, coinValues(selected))
+}
+
+func TestCoinSelectionTxSizeLimit(t *testing.T) {
+	values := make([]uint64, 150)
+	for i := range values {
+		values[i] = 100
+	}
+	// every input coin takes 1 kb, tx can not spend 100 of them
+	_, err := largestFirstCoinSelector{}.selectCoins(newTestOutCoins(values...), newTestTarget(10000))
+	assert.NotEqual(t, nil, err)
+	selected, err := largestFirstCoinSelector{}.selectCoins(newTestOutCoins(values...), newTestTarget(5000))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 56, len(selected))
+}
+
+func TestCoinSelectionNoChangeOutput(t *testing.T) {
+	target := newTestTarget(100)
+	target.allowChange = false
+	_, ok := target.payFee(newTestOutCoins(120))
+	assert.Equal(t, false, ok)
+	fee, ok := target.payFee(newTestOutCoins(117))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(17), fee)
+}
+
+func coinValues(coins []*privacy.OutputCoin) []uint64 {
+	values := make([]uint64, len(coins))
+	for i, coin := range coins {
+		values[i] = coin.CoinDetails.GetValue()
+	}
+	return values
+}
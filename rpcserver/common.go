@@ -21,6 +21,7 @@ func (rpcServer HttpServer) chooseOutsCoinByKeyset(paymentInfos []*privacy.Payme
 	metadataParam metadata.Metadata,
 	customTokenParams *transaction.CustomTokenParamTx,
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+	selector coinSelector,
 ) ([]*privacy.InputCoin, uint64, *RPCError) {
 	// estimate fee according to 8 recent block
 	if numBlock == 0 {
@@ -35,7 +36,7 @@ func (rpcServer HttpServer) chooseOutsCoinByKeyset(paymentInfos []*privacy.Payme
 	// get list outputcoins tx
 	prvCoinID := &common.Hash{}
 	prvCoinID.SetBytes(common.PRVCoinID[:])
-	outCoins, err := rpcServer.config.BlockChain.GetListOutputCoinsByKeyset(keyset, shardIDSender, prvCoinID)
+	outCoins, err := rpcServer.getOutputCoinsToSpend(keyset, shardIDSender, prvCoinID)
 	if err != nil {
 		return nil, 0, NewRPCError(ErrGetOutputCoin, err)
 	}
@@ -44,31 +45,36 @@ func (rpcServer HttpServer) chooseOutsCoinByKeyset(paymentInfos []*privacy.Payme
 	if err != nil {
 		return nil, 0, NewRPCError(ErrGetOutputCoin, err)
 	}
-	if len(outCoins) == 0 && totalAmmount > 0 {
-		return nil, 0, NewRPCError(ErrGetOutputCoin, errors.New("not enough output coin"))
+	return rpcServer.chooseOutsCoin(outCoins, paymentInfos, estimateFeeCoinPerKb, numBlock, keyset.PaymentAddress, shardIDSender, hasPrivacy, metadataParam, customTokenParams, privacyCustomTokenParams, selector)
+}
+
+// chooseOutsCoin chooses coins to spend among unspent output coins of sender with a coin selection strategy
+// (default one if selector is nil) and estimates fee of tx spending them
+func (rpcServer *HttpServer) chooseOutsCoin(outCoins []*privacy.OutputCoin, paymentInfos []*privacy.PaymentInfo,
+	estimateFeeCoinPerKb int64, numBlock uint64, senderAddress privacy.PaymentAddress, shardIDSender byte,
+	hasPrivacy bool,
+	metadataParam metadata.Metadata,
+	customTokenParams *transaction.CustomTokenParamTx,
+	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+	selector coinSelector,
+) ([]*privacy.InputCoin, uint64, *RPCError) {
+	if numBlock == 0 {
+		numBlock = 1000
 	}
-	// Use Knapsack to get candiate output coin
-	candidateOutputCoins, outCoins, candidateOutputCoinAmount, err := rpcServer.chooseBestOutCoinsToSpent(outCoins, totalAmmount)
-	if err != nil {
-		return nil, 0, NewRPCError(ErrGetOutputCoin, err)
+	if selector == nil {
+		selector = defaultCoinSelector{}
+	}
+	if len(paymentInfos) > maxOutputCoins {
+		return nil, 0, NewRPCError(ErrRPCInvalidParams, errors.Errorf("tx can not have more than %d receivers", maxOutputCoins))
 	}
-	// refund out put for sender
-	overBalanceAmount := candidateOutputCoinAmount - totalAmmount
-	if overBalanceAmount > 0 {
-		// add more into output for estimate fee
-		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
-			PaymentAddress: keyset.PaymentAddress,
-			Amount:         overBalanceAmount,
-		})
+	target := rpcServer.newCoinSelectionTarget(paymentInfos, estimateFeeCoinPerKb, numBlock, senderAddress, shardIDSender, hasPrivacy, metadataParam, customTokenParams, privacyCustomTokenParams)
+	if len(outCoins) == 0 && target.amount > 0 {
+		return nil, 0, NewRPCError(ErrGetOutputCoin, errors.New("not enough output coin"))
 	}
 
 	// check real fee(nano PRV) per tx
-	realFee, _, _ := rpcServer.estimateFee(estimateFeeCoinPerKb, candidateOutputCoins,
-		paymentInfos, shardIDSender, numBlock, hasPrivacy,
-		metadataParam, customTokenParams,
-		privacyCustomTokenParams)
-
-	if totalAmmount == 0 && realFee == 0 {
+	realFee, _ := target.estimateFee(nil, false)
+	if target.amount == 0 && realFee == 0 {
 		if metadataParam != nil {
 			metadataType := metadataParam.GetType()
 			switch metadataType {
@@ -77,30 +83,107 @@ func (rpcServer HttpServer) chooseOutsCoinByKeyset(paymentInfos []*privacy.Payme
 					return nil, realFee, nil
 				}
 			}
-			return nil, realFee, NewRPCError(ErrRejectInvalidFee, errors.New(fmt.Sprintf("totalAmmount: %+v, realFee: %+v", totalAmmount, realFee)))
+			return nil, realFee, NewRPCError(ErrRejectInvalidFee, errors.New(fmt.Sprintf("totalAmmount: %+v, realFee: %+v", target.amount, realFee)))
 		}
-		if privacyCustomTokenParams != nil {
+		if privacyCustomTokenParams != nil || len(outCoins) == 0 {
 			// for privacy token
 			return nil, 0, nil
 		}
 	}
 
-	needToPayFee := int64((totalAmmount + realFee) - candidateOutputCoinAmount)
-	// if not enough to pay fee
-	if needToPayFee > 0 {
-		if len(outCoins) > 0 {
-			candidateOutputCoinsForFee, _, _, err1 := rpcServer.chooseBestOutCoinsToSpent(outCoins, uint64(needToPayFee))
-			if err != nil {
-				return nil, 0, NewRPCError(ErrGetOutputCoin, err1)
-			}
-			candidateOutputCoins = append(candidateOutputCoins, candidateOutputCoinsForFee...)
-		}
+	candidateOutputCoins, err := selector.selectCoins(outCoins, target)
+	if err != nil {
+		return nil, 0, NewRPCError(ErrGetOutputCoin, err)
+	}
+	realFee, ok := target.payFee(candidateOutputCoins)
+	if !ok {
+		return nil, 0, NewRPCError(ErrGetOutputCoin, errors.New("Not enough coin"))
 	}
 	// convert to inputcoins
 	inputCoins := transaction.ConvertOutputCoinToInputCoin(candidateOutputCoins)
 	return inputCoins, realFee, nil
 }
 
+// newCoinSelectionTarget returns target of coin selection for a tx paying receivers,
+// its fee is estimated with fee per kb (from estimator if estimateFeeCoinPerKb is -1) and size of tx
+func (rpcServer *HttpServer) newCoinSelectionTarget(paymentInfos []*privacy.PaymentInfo,
+	estimateFeeCoinPerKb int64, numBlock uint64, senderAddress privacy.PaymentAddress, shardIDSender byte,
+	hasPrivacy bool,
+	metadataParam metadata.Metadata,
+	customTokenParams *transaction.CustomTokenParamTx,
+	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+) *coinSelectionTarget {
+	totalAmmount := uint64(0)
+	for _, receiver := range paymentInfos {
+		totalAmmount += receiver.Amount
+	}
+	_, feePerKb, _ := rpcServer.estimateFee(estimateFeeCoinPerKb, nil, paymentInfos, shardIDSender, numBlock, hasPrivacy, metadataParam, customTokenParams, privacyCustomTokenParams)
+	limitFee := uint64(0)
+	if feeEstimator, ok := rpcServer.config.FeeEstimator[shardIDSender]; ok {
+		limitFee = feeEstimator.GetLimitFee()
+	}
+	// refund output for sender, its amount does not change size of tx
+	paymentInfosWithChange := append(append([]*privacy.PaymentInfo{}, paymentInfos...), &privacy.PaymentInfo{
+		PaymentAddress: senderAddress,
+	})
+	return &coinSelectionTarget{
+		amount:      totalAmmount,
+		allowChange: len(paymentInfos) < maxOutputCoins,
+		lowFee:      feePerKb <= limitFee,
+		estimateFee: func(inputs []*privacy.OutputCoin, hasChange bool) (uint64, uint64) {
+			payments := paymentInfos
+			if hasChange {
+				payments = paymentInfosWithChange
+			}
+			sizeInKb := transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(inputs, payments, hasPrivacy, metadataParam, customTokenParams, privacyCustomTokenParams, limitFee).SetRingSize(rpcServer.config.BlockChain.GetMinCommitmentRingSize()))
+			return feePerKb * sizeInKb, sizeInKb
+		},
+	}
+}
+
+// buildPaymentInfo builds payment info of a receiver in list receivers param,
+// value of a receiver is an amount or an object {"Amount": amount, "Memo": "memo"},
+// memo is encrypted for the receiver
+func buildPaymentInfo(paymentAddressStr string, receiverParam interface{}) (*privacy.PaymentInfo, *RPCError) {
+	keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, err)
+	}
+	paymentInfo := &privacy.PaymentInfo{
+		PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+	}
+	switch receiver := receiverParam.(type) {
+	case float64:
+		paymentInfo.Amount = uint64(receiver)
+	case map[string]interface{}:
+		amount, ok := receiver["Amount"].(float64)
+		if !ok {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Amount of receiver is invalid"))
+		}
+		paymentInfo.Amount = uint64(amount)
+		if memoParam, ok := receiver["Memo"]; ok {
+			memo, ok := memoParam.(string)
+			if !ok || len(memo) > privacy.MaxMemoSize {
+				return nil, NewRPCError(ErrRPCInvalidParams, errors.Errorf("Memo of receiver must be a string of at most %d bytes", privacy.MaxMemoSize))
+			}
+			paymentInfo.Memo = []byte(memo)
+		}
+	default:
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Amount of receiver is invalid"))
+	}
+	return paymentInfo, nil
+}
+
+// decryptMemo returns memo of coin if it is encrypted for viewing key, otherwise it returns an empty string.
+// Version of tx is unknown for coins read from database, info of such coins is a memo when it decrypts with its checksum
+func decryptMemo(coin *privacy.Coin, viewingKey privacy.ViewingKey) string {
+	memo, err := coin.DecryptMemo(viewingKey)
+	if err != nil {
+		return ""
+	}
+	return string(memo)
+}
+
 func (rpcServer HttpServer) buildRawTransaction(params interface{}, meta metadata.Metadata) (*transaction.Tx, *RPCError) {
 	Logger.log.Infof("Params: \n%+v\n\n\n", params)
 
@@ -124,14 +207,10 @@ func (rpcServer HttpServer) buildRawTransaction(params interface{}, meta metadat
 		receiversPaymentAddressStrParam = arrayParams[1].(map[string]interface{})
 	}
 	paymentInfos := make([]*privacy.PaymentInfo, 0)
-	for paymentAddressStr, amount := range receiversPaymentAddressStrParam {
-		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	for paymentAddressStr, receiverParam := range receiversPaymentAddressStrParam {
+		paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
 		if err != nil {
-			return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, err)
-		}
-		paymentInfo := &privacy.PaymentInfo{
-			Amount:         uint64(amount.(float64)),
-			PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+			return nil, err
 		}
 		paymentInfos = append(paymentInfos, paymentInfo)
 	}
@@ -141,10 +220,30 @@ func (rpcServer HttpServer) buildRawTransaction(params interface{}, meta metadat
 
 	// param #4: hasPrivacyCoin flag: 1 or -1
 	hasPrivacyCoin := int(arrayParams[3].(float64)) > 0
+
+	// param #5: optional expiry beacon height, only for tx without metadata, 0 means tx never expires
+	expiryHeight := uint64(0)
+	if meta == nil && len(arrayParams) > 4 {
+		expiryHeightParam, ok := arrayParams[4].(float64)
+		if !ok || expiryHeightParam < 0 {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Expiry beacon height is invalid"))
+		}
+		expiryHeight = uint64(expiryHeightParam)
+	}
+
+	// param #6: optional coin selection strategy, only for tx without metadata, see coinselection.go
+	var selector coinSelector = defaultCoinSelector{}
+	if meta == nil && len(arrayParams) > 5 {
+		var rpcErr *RPCError
+		selector, rpcErr = getCoinSelectorParam(arrayParams[5])
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
 	/********* END Fetch all component to *******/
 
 	/******* START choose output native coins(PRV), which is used to create tx *****/
-	inputCoins, realFee, err1 := rpcServer.chooseOutsCoinByKeyset(paymentInfos, estimateFeeCoinPerKb, 0, senderKeySet, shardIDSender, hasPrivacyCoin, meta, nil, nil)
+	inputCoins, realFee, err1 := rpcServer.chooseOutsCoinByKeyset(paymentInfos, estimateFeeCoinPerKb, 0, senderKeySet, shardIDSender, hasPrivacyCoin, meta, nil, nil, selector)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -164,7 +263,7 @@ func (rpcServer HttpServer) buildRawTransaction(params interface{}, meta metadat
 			hasPrivacyCoin,
 			*rpcServer.config.Database,
 			nil, // use for prv coin -> nil is valid
-			meta))
+			meta).SetExpiryHeight(expiryHeight).SetRingSize(rpcServer.config.BlockChain.GetMinCommitmentRingSize()))
 	// END create tx
 
 	if err != nil {
@@ -268,14 +367,10 @@ func (rpcServer HttpServer) buildRawCustomTokenTransaction(
 		receiversPaymentAddressParam = arrayParams[1].(map[string]interface{})
 	}
 	paymentInfos := make([]*privacy.PaymentInfo, 0)
-	for paymentAddressStr, amount := range receiversPaymentAddressParam {
-		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	for paymentAddressStr, receiverParam := range receiversPaymentAddressParam {
+		paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
 		if err != nil {
-			return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, err)
-		}
-		paymentInfo := &privacy.PaymentInfo{
-			Amount:         uint64(amount.(float64)),
-			PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+			return nil, err
 		}
 		paymentInfos = append(paymentInfos, paymentInfo)
 	}
@@ -296,7 +391,7 @@ func (rpcServer HttpServer) buildRawCustomTokenTransaction(
 	/******* START choose output coins native coins(PRV), which is used to create tx *****/
 	inputCoins, realFee, err := rpcServer.chooseOutsCoinByKeyset(paymentInfos, estimateFeeCoinPerKb, 0,
 		senderKeySet, shardIDSender, hasPrivacyCoin,
-		metaData, tokenParams, nil)
+		metaData, tokenParams, nil, nil)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -350,11 +445,11 @@ func (rpcServer HttpServer) buildPrivacyCustomTokenParam(tokenParamsRaw map[stri
 			if !existed && !existedCrossShard {
 				return nil, nil, nil, NewRPCError(ErrRPCInvalidParams, errors.New("Invalid Token ID"))
 			}
-			outputTokens, err := rpcServer.config.BlockChain.GetListOutputCoinsByKeyset(senderKeySet, shardIDSender, tokenID)
+			outputTokens, err := rpcServer.getOutputCoinsToSpend(senderKeySet, shardIDSender, tokenID)
 			if err != nil {
 				return nil, nil, nil, NewRPCError(ErrGetOutputCoin, err)
 			}
-			candidateOutputTokens, _, _, err := rpcServer.chooseBestOutCoinsToSpent(outputTokens, uint64(voutsAmount))
+			candidateOutputTokens, _, _, err := chooseBestOutCoinsToSpent(outputTokens, uint64(voutsAmount))
 			if err != nil {
 				return nil, nil, nil, NewRPCError(ErrGetOutputCoin, err)
 			}
@@ -366,6 +461,29 @@ func (rpcServer HttpServer) buildPrivacyCustomTokenParam(tokenParamsRaw map[stri
 			if tokenParams.Receiver[0].Amount != tokenParams.Amount { // Init with wrong max amount of custom token
 				return nil, nil, nil, NewRPCError(ErrRPCInvalidParams, errors.New("Init with wrong max amount of property"))
 			}
+			// optional registry info of token
+			if decimals, ok := tokenParamsRaw["TokenDecimals"].(float64); ok {
+				if decimals < 0 || decimals > transaction.MaxTokenDecimals {
+					return nil, nil, nil, NewRPCError(ErrRPCInvalidParams, errors.New("Invalid token decimals"))
+				}
+				tokenParams.Decimals = uint8(decimals)
+			}
+			if description, ok := tokenParamsRaw["TokenDescription"].(string); ok {
+				tokenParams.Description = description
+			}
+			if supplyPolicy, ok := tokenParamsRaw["TokenSupplyPolicy"].(float64); ok {
+				if supplyPolicy != common.TokenSupplyFixed && supplyPolicy != common.TokenSupplyIssuerMintable {
+					return nil, nil, nil, NewRPCError(ErrRPCInvalidParams, errors.New("Invalid token supply policy"))
+				}
+				tokenParams.SupplyPolicy = byte(supplyPolicy)
+			}
+			if issuer, ok := tokenParamsRaw["TokenIssuer"].(string); ok && issuer != "" {
+				issuerKey, err := wallet.Base58CheckDeserialize(issuer)
+				if err != nil || len(issuerKey.KeySet.PaymentAddress.Pk) == 0 {
+					return nil, nil, nil, NewRPCError(ErrRPCInvalidParams, errors.New("Invalid payment address of token issuer"))
+				}
+				tokenParams.IssuerKey = issuerKey.KeySet.PaymentAddress.Pk
+			}
 		}
 	}
 	return tokenParams, nil, nil, nil
@@ -395,14 +513,10 @@ func (rpcServer HttpServer) buildRawPrivacyCustomTokenTransaction(
 		receiversPaymentAddressStrParam = arrayParams[1].(map[string]interface{})
 	}
 	paymentInfos := make([]*privacy.PaymentInfo, 0)
-	for paymentAddressStr, amount := range receiversPaymentAddressStrParam {
-		keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	for paymentAddressStr, receiverParam := range receiversPaymentAddressStrParam {
+		paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
 		if err != nil {
-			return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, err)
-		}
-		paymentInfo := &privacy.PaymentInfo{
-			Amount:         uint64(amount.(float64)),
-			PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+			return nil, err
 		}
 		paymentInfos = append(paymentInfos, paymentInfo)
 	}
@@ -437,7 +551,7 @@ func (rpcServer HttpServer) buildRawPrivacyCustomTokenTransaction(
 	inputCoins, realFeePrv, err = rpcServer.chooseOutsCoinByKeyset(paymentInfos,
 		estimateFeeCoinPerKb, 0, senderKeySet,
 		shardIDSender, hasPrivacyCoin, nil,
-		nil, tokenParams)
+		nil, tokenParams, nil)
 	if err.(*RPCError) != nil {
 		return nil, err.(*RPCError)
 	}
@@ -457,7 +571,7 @@ func (rpcServer HttpServer) buildRawPrivacyCustomTokenTransaction(
 			metaData,
 			hasPrivacyCoin,
 			hasPrivacyToken,
-			shardIDSender))
+			shardIDSender).SetRingSize(rpcServer.config.BlockChain.GetMinCommitmentRingSize()))
 
 	if err != nil {
 		return nil, NewRPCError(ErrCreateTxData, err)
@@ -517,12 +631,21 @@ func (rpcServer HttpServer) estimateFee(
 	if feeEstimator, ok := rpcServer.config.FeeEstimator[shardID]; ok {
 		limitFee = feeEstimator.GetLimitFee()
 	}
-	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(candidateOutputCoins, paymentInfos, hasPrivacy, metadata, customTokenParams, privacyCustomTokenParams, limitFee))
+	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(candidateOutputCoins, paymentInfos, hasPrivacy, metadata, customTokenParams, privacyCustomTokenParams, limitFee).SetRingSize(rpcServer.config.BlockChain.GetMinCommitmentRingSize()))
 
 	realFee = uint64(estimateFeeCoinPerKb) * uint64(estimateTxSizeInKb)
 	return realFee, estimateFeeCoinPerKb, estimateTxSizeInKb
 }
 
+// getOutputCoinsToSpend returns output coins of sender's keyset to choose input coins from,
+// keyset of a multisig account has no private key and its unspent coins are found with their multisig serial numbers
+func (rpcServer *HttpServer) getOutputCoinsToSpend(keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
+	if len(keyset.PrivateKey) == 0 {
+		return rpcServer.config.BlockChain.GetListUnspentMultiSigOutputCoins(keyset, shardID, tokenID)
+	}
+	return rpcServer.config.BlockChain.GetListOutputCoinsByKeyset(keyset, shardID, tokenID)
+}
+
 func (rpcServer HttpServer) filterMemPoolOutCoinsToSpent(outCoins []*privacy.OutputCoin) (remainOutputCoins []*privacy.OutputCoin, err error) {
 	remainOutputCoins = make([]*privacy.OutputCoin, 0)
 	for _, outCoin := range outCoins {
@@ -534,7 +657,7 @@ func (rpcServer HttpServer) filterMemPoolOutCoinsToSpent(outCoins []*privacy.Out
 }
 
 // chooseBestOutCoinsToSpent returns list of unspent coins for spending with amount
-func (rpcServer HttpServer) chooseBestOutCoinsToSpent(outCoins []*privacy.OutputCoin, amount uint64) (resultOutputCoins []*privacy.OutputCoin, remainOutputCoins []*privacy.OutputCoin, totalResultOutputCoinAmount uint64, err error) {
+func chooseBestOutCoinsToSpent(outCoins []*privacy.OutputCoin, amount uint64) (resultOutputCoins []*privacy.OutputCoin, remainOutputCoins []*privacy.OutputCoin, totalResultOutputCoinAmount uint64, err error) {
 	resultOutputCoins = make([]*privacy.OutputCoin, 0)
 	remainOutputCoins = make([]*privacy.OutputCoin, 0)
 	totalResultOutputCoinAmount = uint64(0)
@@ -20,11 +20,17 @@ const (
 
 	getActiveShards    = "getactiveshards"
 	getMaxShardsNumber = "getmaxshardsnumber"
+	getSyncStatus      = "getsyncstatus"
 
 	getMiningInfo                 = "getmininginfo"
 	getRawMempool                 = "getrawmempool"
 	getNumberOfTxsInMempool       = "getnumberoftxsinmempool"
 	getMempoolEntry               = "getmempoolentry"
+	listMempoolEntries            = "listmempoolentries"
+	getMempoolConflicts           = "getmempoolconflicts"
+	removeMempoolTx               = "removemempooltx"
+	dumpMempool                   = "dumpmempool"
+	loadMempool                   = "loadmempool"
 	getBeaconPoolState            = "getbeaconpoolstate"
 	getShardPoolState             = "getshardpoolstate"
 	getShardPoolLatestValidHeight = "getshardpoollatestvalidheight"
@@ -40,6 +46,7 @@ const (
 	getBestBlock        = "getbestblock"
 	getBestBlockHash    = "getbestblockhash"
 	getBlocks           = "getblocks"
+	listBlocks          = "listblocks"
 	retrieveBlock       = "retrieveblock"
 	retrieveBeaconBlock = "retrievebeaconblock"
 	getBlockChainInfo   = "getblockchaininfo"
@@ -49,6 +56,7 @@ const (
 	listOutputCoins                            = "listoutputcoins"
 	createRawTransaction                       = "createtransaction"
 	sendRawTransaction                         = "sendtransaction"
+	createUnsignedTransaction                  = "createunsignedtransaction"
 	createAndSendTransaction                   = "createandsendtransaction"
 	createAndSendCustomTokenTransaction        = "createandsendcustomtokentransaction"
 	sendRawCustomTokenTransaction              = "sendrawcustomtokentransaction"
@@ -66,6 +74,8 @@ const (
 	getBalanceCustomToken                      = "getbalancecustomtoken"
 	getTransactionByHash                       = "gettransactionbyhash"
 	gettransactionhashbyreceiver               = "gettransactionhashbyreceiver"
+	listTransactions                           = "listtransactions"
+	listTransactionsByReceiver                 = "listtransactionsbyreceiver"
 	listCustomToken                            = "listcustomtoken"
 	listPrivacyCustomToken                     = "listprivacycustomtoken"
 	getBalancePrivacyCustomToken               = "getbalanceprivacycustomtoken"
@@ -84,6 +94,22 @@ const (
 
 	createAndSendStakingTransaction = "createandsendstakingtransaction"
 
+	// multisig account
+	createMultiSigAddress                 = "createmultisigaddress"
+	createMultiSigTransaction             = "createmultisigtransaction"
+	createMultiSigPrivacyTokenTransaction = "createmultisigprivacytokentransaction"
+	createMultiSigNonces                  = "createmultisignonces"
+	signMultiSigTransaction               = "signmultisigtransaction"
+	combineMultiSigTransaction            = "combinemultisigtransaction"
+
+	// token registry
+	getTokenInfo                      = "gettokeninfo"
+	listTokens                        = "listtokens"
+	createRawTokenMintTransaction     = "createrawtokenminttransaction"
+	createAndSendTokenMintTransaction = "createandsendtokenminttransaction"
+	createRawTokenBurnTransaction     = "createrawtokenburntransaction"
+	createAndSendTokenBurnTransaction = "createandsendtokenburntransaction"
+
 	//===========For Testing and Benchmark==============
 	getAndSendTxsFromFile   = "getandsendtxsfromfile"
 	getAndSendTxsFromFileV2 = "getandsendtxsfromfilev2"
@@ -145,13 +171,23 @@ const (
 
 	enableMining         = "enablemining"
 	getChainMiningStatus = "getchainminingstatus"
+	generateBlocks       = "generateblocks"
+
+	setLogLevel  = "setloglevel"
+	getLogLevels = "getloglevels"
+
+	// OpenRPC document of all rpc methods
+	rpcDiscover = "rpc.discover"
 )
 
 const (
 	testSubcrice                                = "testsubcribe"
 	subcribeNewShardBlock                       = "subcribenewshardblock"
 	subcribeNewBeaconBlock                      = "subcribenewbeaconblock"
+	subcribeDisconnectedShardBlock              = "subcribedisconnectedshardblock"
+	subcribeDisconnectedBeaconBlock             = "subcribedisconnectedbeaconblock"
 	subcribePendingTransaction                  = "subcribependingtransaction"
+	subscribeTransactionStatus                  = "subscribetransactionstatus"
 	subcribeShardCandidateByPublickey           = "subcribeshardcandidatebypublickey"
 	subcribeShardPendingValidatorByPublickey    = "subcribeshardpendingvalidatorbypublickey"
 	subcribeShardCommitteeByPublickey           = "subcribeshardcommitteebypublickey"
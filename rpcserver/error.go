@@ -31,6 +31,12 @@ const (
 	ErrSubcribe
 	ErrNetwork
 	ErrTokenIsInvalid
+	ErrGenerateBlock
+	ErrSetLogLevel
+	ErrInvalidMultiSigKey
+	ErrCombineMultiSig
+	ErrTokenNotFound
+	ErrRPCRateLimitExceeded
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -62,10 +68,17 @@ var ErrCodeMessage = map[int]struct {
 	ErrRejectInvalidFee:              {-1016, "Reject invalid fee"},
 	ErrTxNotExistedInMemAndBLock:     {-1017, "Tx is not existed in mem and block"},
 	ErrTokenIsInvalid:                {-1018, "Token is invalid"},
+	ErrInvalidMultiSigKey:            {-1019, "Invalid multisig key"},
+	ErrTokenNotFound:                 {-1020, "Token is not found"},
+	ErrRPCRateLimitExceeded:          {-1021, "Rate limit exceeded"},
 
 	// processing -2xxx
-	ErrCreateTxData: {-2001, "Can not create tx"},
-	ErrSendTxData:   {-2002, "Can not send tx"},
+	ErrCreateTxData:  {-2001, "Can not create tx"},
+	ErrSendTxData:    {-2002, "Can not send tx"},
+	ErrGenerateBlock: {-2003, "Can not generate block"},
+	ErrSetLogLevel:   {-2004, "Can not set log level"},
+
+	ErrCombineMultiSig: {-2005, "Can not combine multisig signatures"},
 	// socket/subcribe -3xxx
 	ErrSubcribe:   {-3001, "Failed to subcribe"},
 	ErrUnsubcribe: {-2002, "Failed to unsubcribe"},
@@ -78,6 +91,8 @@ type RPCError struct {
 	Message    string `json:"Message,omitempty"`
 	err        error  `json:"Err"`
 	StackTrace string `json:"StackTrace"`
+	// Field is the malformed field of params, e.g. params[1].TokenID, set when params do not match schema of method
+	Field string `json:"Field,omitempty"`
 }
 
 func GetErrorCode(err int) int {
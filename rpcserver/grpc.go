@@ -0,0 +1,366 @@
+package rpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"path"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	rpcpb "github.com/incognitochain/incognito-chain/rpcserver/pb"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC metadata carrying credentials of a call
+const (
+	apiKeyMetadata        = "x-api-key"
+	authorizationMetadata = "authorization" // "Basic " + base64 of rpcuser:rpcpass, the same as JSON-RPC
+)
+
+/*
+GrpcServer serves the Incognito service of rpcserver/pb, its calls are authenticated the same way as JSON-RPC:
+by an api key, a method of api key is named by its gRPC method name (e.g. GetShardBlock),
+or by rpc user in basic auth metadata. Calls without credentials are only served when auth is disabled
+*/
+type GrpcServer struct {
+	started      int32
+	shutdown     int32
+	config       RpcServerConfig
+	server       *grpc.Server
+	authSHA      []byte
+	limitAuthSHA []byte
+}
+
+func (grpcServer *GrpcServer) Init(config *RpcServerConfig) {
+	grpcServer.config = *config
+	if config.RPCUser != "" && config.RPCPass != "" {
+		login := config.RPCUser + ":" + config.RPCPass
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+		grpcServer.authSHA = common.HashB([]byte(auth))
+	}
+	if config.RPCLimitUser != "" && config.RPCLimitPass != "" {
+		login := config.RPCLimitUser + ":" + config.RPCLimitPass
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+		grpcServer.limitAuthSHA = common.HashB([]byte(auth))
+	}
+}
+
+// Start is used by rpcserver.go to start the gRPC listener.
+func (grpcServer *GrpcServer) Start() error {
+	if atomic.AddInt32(&grpcServer.started, 1) != 1 {
+		return NewRPCError(ErrAlreadyStarted, nil)
+	}
+	grpcServer.server = grpc.NewServer(
+		grpc.UnaryInterceptor(grpcServer.unaryInterceptor),
+		grpc.StreamInterceptor(grpcServer.streamInterceptor),
+	)
+	rpcpb.RegisterIncognitoServer(grpcServer.server, grpcServer)
+	for _, listen := range grpcServer.config.GrpcListenters {
+		go func(listen net.Listener) {
+			Logger.log.Infof("RPC gRPC server listening on %s", listen.Addr())
+			if err := grpcServer.server.Serve(listen); err != nil {
+				Logger.log.Errorf("Close gRPC Listener %+v", err)
+			}
+		}(listen)
+	}
+	return nil
+}
+
+// Stop is used by rpcserver.go to stop the gRPC listener.
+func (grpcServer *GrpcServer) Stop() {
+	if atomic.AddInt32(&grpcServer.shutdown, 1) != 1 {
+		Logger.log.Info("gRPC server is already in the process of shutting down")
+		return
+	}
+	Logger.log.Info("gRPC server shutting down")
+	if grpcServer.server != nil {
+		grpcServer.server.Stop()
+	}
+	Logger.log.Warn("gRPC server shutdown complete")
+}
+
+// checkAuth checks credentials of a call to fullMethod. A call with api key is checked by the key,
+// other calls need rpc user in basic auth metadata, unless auth is disabled, then they use the public policy
+func (grpcServer *GrpcServer) checkAuth(ctx context.Context, fullMethod string) error {
+	var key *apiKey
+	md, _ := grpcmetadata.FromIncomingContext(ctx)
+	if keys := md.Get(apiKeyMetadata); len(keys) > 0 && keys[0] != "" && grpcServer.config.APIKeys != nil {
+		var err error
+		key, err = grpcServer.config.APIKeys.getKey(keys[0])
+		if err != nil {
+			Logger.log.Warnf("gRPC api key authentication failure for %s", fullMethod)
+			return toGrpcError(err.(*RPCError))
+		}
+	} else if grpcServer.config.DisableAuth {
+		if grpcServer.config.APIKeys != nil {
+			key = grpcServer.config.APIKeys.getPublic()
+		}
+	} else if !grpcServer.checkBasicAuth(md.Get(authorizationMetadata)) {
+		Logger.log.Warnf("gRPC authentication failure for %s", fullMethod)
+		return toGrpcError(NewRPCError(ErrAuthFail, errors.New("api key or rpc user is required")))
+	}
+	if rpcErr := checkAccess(key, path.Base(fullMethod)); rpcErr != nil {
+		return toGrpcError(rpcErr)
+	}
+	return nil
+}
+
+// checkBasicAuth returns true if authorization metadata of a call is basic auth of rpc user or rpc limit user
+func (grpcServer *GrpcServer) checkBasicAuth(auths []string) bool {
+	if len(auths) == 0 {
+		return false
+	}
+	authsha := common.HashB([]byte(auths[0]))
+	return subtle.ConstantTimeCompare(authsha, grpcServer.limitAuthSHA) == 1 || subtle.ConstantTimeCompare(authsha, grpcServer.authSHA) == 1
+}
+
+func (grpcServer *GrpcServer) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := grpcServer.checkAuth(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (grpcServer *GrpcServer) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := grpcServer.checkAuth(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// toGrpcError converts an RPC error into a gRPC status error with the closest code
+func toGrpcError(rpcErr *RPCError) error {
+	if rpcErr == nil {
+		return nil
+	}
+	code := codes.Internal
+	switch rpcErr.Code {
+	case GetErrorCode(ErrRPCInvalidParams), GetErrorCode(ErrRPCParse), GetErrorCode(ErrInvalidType), GetErrorCode(ErrTxTypeInvalid):
+		code = codes.InvalidArgument
+	case GetErrorCode(ErrTxNotExistedInMemAndBLock), GetErrorCode(ErrTokenNotFound):
+		code = codes.NotFound
+	case GetErrorCode(ErrAuthFail):
+		code = codes.Unauthenticated
+	case GetErrorCode(ErrRPCInvalidMethodPermission):
+		code = codes.PermissionDenied
+	case GetErrorCode(ErrRPCRateLimitExceeded):
+		code = codes.ResourceExhausted
+	case GetErrorCode(ErrRejectInvalidFee), GetErrorCode(ErrSendTxData):
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, rpcErr.Error())
+}
+
+// hashParam converts hash bytes of a request, name is used in error message
+func hashParam(hashBytes []byte, name string) (*common.Hash, error) {
+	hash, err := common.Hash{}.NewHash(hashBytes)
+	if err != nil {
+		return nil, toGrpcError(NewRPCError(ErrRPCInvalidParams, errors.New(name+" is invalid")))
+	}
+	return hash, nil
+}
+
+func (grpcServer *GrpcServer) checkShardID(shardID uint32) error {
+	if shardID >= uint32(grpcServer.config.ChainParams.ActiveShards) {
+		return toGrpcError(NewRPCError(ErrRPCInvalidParams, errors.New("shard ID is invalid")))
+	}
+	return nil
+}
+
+func (grpcServer *GrpcServer) GetBeaconBestState(ctx context.Context, req *rpcpb.GetBeaconBestStateRequest) (*rpcpb.BeaconBestState, error) {
+	bestState := grpcServer.config.BlockChain.BestState.Beacon
+	if bestState == nil {
+		return nil, toGrpcError(NewRPCError(ErrUnexpected, errors.New("Best State beacon not existed")))
+	}
+	return toPbBeaconBestState(bestState), nil
+}
+
+func (grpcServer *GrpcServer) GetShardBestState(ctx context.Context, req *rpcpb.GetShardBestStateRequest) (*rpcpb.ShardBestState, error) {
+	if err := grpcServer.checkShardID(req.ShardId); err != nil {
+		return nil, err
+	}
+	bestState, ok := grpcServer.config.BlockChain.BestState.Shard[byte(req.ShardId)]
+	if !ok || bestState == nil {
+		return nil, toGrpcError(NewRPCError(ErrUnexpected, errors.New("Best State shard not existed")))
+	}
+	return toPbShardBestState(bestState), nil
+}
+
+func (grpcServer *GrpcServer) GetShardBlock(ctx context.Context, req *rpcpb.GetShardBlockRequest) (*rpcpb.ShardBlock, error) {
+	var block *blockchain.ShardBlock
+	var err error
+	if len(req.Hash) > 0 {
+		hash, errH := hashParam(req.Hash, "block hash")
+		if errH != nil {
+			return nil, errH
+		}
+		block, _, err = grpcServer.config.BlockChain.GetShardBlockByHash(*hash)
+	} else {
+		if errS := grpcServer.checkShardID(req.ShardId); errS != nil {
+			return nil, errS
+		}
+		block, err = grpcServer.config.BlockChain.GetShardBlockByHeight(req.Height, byte(req.ShardId))
+	}
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toPbShardBlock(block, req.IncludeTransactions), nil
+}
+
+func (grpcServer *GrpcServer) GetBeaconBlock(ctx context.Context, req *rpcpb.GetBeaconBlockRequest) (*rpcpb.BeaconBlock, error) {
+	var block *blockchain.BeaconBlock
+	var err error
+	if len(req.Hash) > 0 {
+		hash, errH := hashParam(req.Hash, "block hash")
+		if errH != nil {
+			return nil, errH
+		}
+		block, _, err = grpcServer.config.BlockChain.GetBeaconBlockByHash(*hash)
+	} else {
+		block, err = grpcServer.config.BlockChain.GetBeaconBlockByHeight(req.Height)
+	}
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toPbBeaconBlock(block), nil
+}
+
+func (grpcServer *GrpcServer) GetTransaction(ctx context.Context, req *rpcpb.GetTransactionRequest) (*rpcpb.GetTransactionResponse, error) {
+	txHash, err := hashParam(req.Hash, "tx hash")
+	if err != nil {
+		return nil, err
+	}
+	shardID, blockHash, index, tx, err := grpcServer.config.BlockChain.GetTransactionByHash(*txHash)
+	if err != nil {
+		// maybe tx is still in tx mempool -> check mempool
+		tx, errM := grpcServer.config.TxMemPool.GetTx(txHash)
+		if errM != nil {
+			return nil, toGrpcError(NewRPCError(ErrTxNotExistedInMemAndBLock, errors.New("Tx is not existed in block or mempool")))
+		}
+		return &rpcpb.GetTransactionResponse{
+			Transaction: toPbTransaction(tx),
+			IsInMempool: true,
+		}, nil
+	}
+	blockHeight, _, err := (*grpcServer.config.Database).GetIndexOfBlock(blockHash)
+	if err != nil {
+		return nil, toGrpcError(NewRPCError(ErrUnexpected, err))
+	}
+	return &rpcpb.GetTransactionResponse{
+		Transaction: toPbTransaction(tx),
+		IsInBlock:   true,
+		ShardId:     uint32(shardID),
+		BlockHash:   blockHash[:],
+		BlockHeight: blockHeight,
+		Index:       int32(index),
+	}, nil
+}
+
+func (grpcServer *GrpcServer) SendTransaction(ctx context.Context, req *rpcpb.SendTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	var tx metadata.Transaction
+	msgCmd := wire.CmdTx
+	if req.PrivacyToken {
+		tx = &transaction.TxCustomTokenPrivacy{}
+		msgCmd = wire.CmdPrivacyCustomToken
+	} else {
+		tx = &transaction.Tx{}
+	}
+	if err := json.Unmarshal(req.RawTx, tx); err != nil {
+		return nil, toGrpcError(NewRPCError(ErrRPCInvalidParams, err))
+	}
+	if rpcErr := sendTx(&grpcServer.config, tx, msgCmd); rpcErr != nil {
+		return nil, toGrpcError(rpcErr)
+	}
+	return &rpcpb.SendTransactionResponse{
+		TxHash:  tx.Hash()[:],
+		ShardId: uint32(common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())),
+	}, nil
+}
+
+// subscribe registers to topic of pubsub and calls send with every message of topic until stream ends or send fails
+func (grpcServer *GrpcServer) subscribe(ctx context.Context, topic string, send func(msg *pubsub.Message) error) error {
+	subId, subChan, err := grpcServer.config.PubSubManager.RegisterNewSubscriber(topic)
+	if err != nil {
+		return toGrpcError(NewRPCError(ErrSubcribe, err))
+	}
+	defer grpcServer.config.PubSubManager.Unsubscribe(topic, subId)
+	for {
+		select {
+		case msg := <-subChan:
+			if err := send(msg); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (grpcServer *GrpcServer) SubscribeShardBlocks(req *rpcpb.SubscribeShardBlocksRequest, stream rpcpb.Incognito_SubscribeShardBlocksServer) error {
+	if err := grpcServer.checkShardID(req.ShardId); err != nil {
+		return err
+	}
+	return grpcServer.subscribe(stream.Context(), pubsub.NewShardblockTopic, func(msg *pubsub.Message) error {
+		shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+		if !ok {
+			Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+			return nil
+		}
+		if uint32(shardBlock.Header.ShardID) != req.ShardId {
+			return nil
+		}
+		return stream.Send(toPbShardBlock(shardBlock, req.IncludeTransactions))
+	})
+}
+
+func (grpcServer *GrpcServer) SubscribeBeaconBlocks(req *rpcpb.SubscribeBeaconBlocksRequest, stream rpcpb.Incognito_SubscribeBeaconBlocksServer) error {
+	return grpcServer.subscribe(stream.Context(), pubsub.NewBeaconBlockTopic, func(msg *pubsub.Message) error {
+		beaconBlock, ok := msg.Value.(*blockchain.BeaconBlock)
+		if !ok {
+			Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+			return nil
+		}
+		return stream.Send(toPbBeaconBlock(beaconBlock))
+	})
+}
+
+func (grpcServer *GrpcServer) SubscribeShardBestState(req *rpcpb.SubscribeShardBestStateRequest, stream rpcpb.Incognito_SubscribeShardBestStateServer) error {
+	if err := grpcServer.checkShardID(req.ShardId); err != nil {
+		return err
+	}
+	return grpcServer.subscribe(stream.Context(), pubsub.ShardBeststateTopic, func(msg *pubsub.Message) error {
+		bestState, ok := msg.Value.(*blockchain.ShardBestState)
+		if !ok {
+			Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBestState, have %+v", reflect.TypeOf(msg.Value))
+			return nil
+		}
+		if uint32(bestState.ShardID) != req.ShardId {
+			return nil
+		}
+		return stream.Send(toPbShardBestState(bestState))
+	})
+}
+
+func (grpcServer *GrpcServer) SubscribeBeaconBestState(req *rpcpb.SubscribeBeaconBestStateRequest, stream rpcpb.Incognito_SubscribeBeaconBestStateServer) error {
+	return grpcServer.subscribe(stream.Context(), pubsub.BeaconBeststateTopic, func(msg *pubsub.Message) error {
+		bestState, ok := msg.Value.(*blockchain.BeaconBestState)
+		if !ok {
+			Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBestState, have %+v", reflect.TypeOf(msg.Value))
+			return nil
+		}
+		return stream.Send(toPbBeaconBestState(bestState))
+	})
+}
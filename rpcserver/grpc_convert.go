@@ -0,0 +1,202 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	rpcpb "github.com/incognitochain/incognito-chain/rpcserver/pb"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// Conversions of chain data into messages of gRPC API
+
+func compressPoint(point *privacy.EllipticPoint) []byte {
+	if point == nil {
+		return nil
+	}
+	return point.Compress()
+}
+
+func toPbPaymentProof(proof *zkp.PaymentProof) *rpcpb.PaymentProof {
+	if proof == nil {
+		return nil
+	}
+	result := &rpcpb.PaymentProof{
+		CommitmentIndices: proof.GetCommitmentIndices(),
+		Data:              proof.Bytes(),
+	}
+	for _, inputCoin := range proof.GetInputCoins() {
+		if inputCoin == nil || inputCoin.CoinDetails == nil {
+			continue
+		}
+		result.InputSerialNumbers = append(result.InputSerialNumbers, compressPoint(inputCoin.CoinDetails.GetSerialNumber()))
+	}
+	for _, outputCoin := range proof.GetOutputCoins() {
+		if outputCoin == nil || outputCoin.CoinDetails == nil {
+			continue
+		}
+		coin := outputCoin.CoinDetails
+		pbCoin := &rpcpb.Coin{
+			PublicKey:      compressPoint(coin.GetPublicKey()),
+			CoinCommitment: compressPoint(coin.GetCoinCommitment()),
+			SerialNumber:   compressPoint(coin.GetSerialNumber()),
+			Value:          coin.GetValue(),
+			Info:           coin.GetInfo(),
+		}
+		if coin.GetSNDerivator() != nil {
+			pbCoin.Snd = coin.GetSNDerivator().Bytes()
+		}
+		result.OutputCoins = append(result.OutputCoins, pbCoin)
+	}
+	return result
+}
+
+// toPbTransaction converts a tx, fields of concrete tx types (version, signature, token) are set for known types
+func toPbTransaction(tx metadata.Transaction) *rpcpb.Transaction {
+	result := &rpcpb.Transaction{
+		Hash:                 tx.Hash()[:],
+		Type:                 tx.GetType(),
+		LockTime:             tx.GetLockTime(),
+		Fee:                  tx.GetTxFee(),
+		Info:                 tx.GetInfo(),
+		ExpiryHeight:         tx.GetExpiryHeight(),
+		SigPubKey:            tx.GetSigPubKey(),
+		Proof:                toPbPaymentProof(tx.GetProof()),
+		PubKeyLastByteSender: uint32(tx.GetSenderAddrLastByte()),
+		MetadataType:         int32(tx.GetMetadataType()),
+	}
+	if meta := tx.GetMetadata(); meta != nil {
+		if metaBytes, err := json.Marshal(meta); err == nil {
+			result.Metadata = metaBytes
+		}
+	}
+	switch tx := tx.(type) {
+	case *transaction.Tx:
+		result.Version = int32(tx.Version)
+		result.Sig = tx.Sig
+	case *transaction.TxCustomToken:
+		result.Version = int32(tx.Version)
+		result.Sig = tx.Sig
+		result.Token = &rpcpb.TokenData{
+			PropertyId:     tx.TxTokenData.PropertyID[:],
+			PropertyName:   tx.TxTokenData.PropertyName,
+			PropertySymbol: tx.TxTokenData.PropertySymbol,
+			Type:           int32(tx.TxTokenData.Type),
+			Mintable:       tx.TxTokenData.Mintable,
+			Amount:         tx.TxTokenData.Amount,
+		}
+	case *transaction.TxCustomTokenPrivacy:
+		result.Version = int32(tx.Version)
+		result.Sig = tx.Sig
+		tokenData := tx.TxTokenPrivacyData
+		result.Token = &rpcpb.TokenData{
+			PropertyId:     tokenData.PropertyID[:],
+			PropertyName:   tokenData.PropertyName,
+			PropertySymbol: tokenData.PropertySymbol,
+			Type:           int32(tokenData.Type),
+			Mintable:       tokenData.Mintable,
+			Amount:         tokenData.Amount,
+			Privacy:        true,
+			TxNormal:       toPbTransaction(&tokenData.TxNormal),
+		}
+	}
+	return result
+}
+
+func toPbShardBlock(block *blockchain.ShardBlock, includeTransactions bool) *rpcpb.ShardBlock {
+	header := block.Header
+	result := &rpcpb.ShardBlock{
+		Header: &rpcpb.ShardBlockHeader{
+			Hash:              block.Hash()[:],
+			ShardId:           uint32(header.ShardID),
+			Height:            header.Height,
+			Version:           int32(header.Version),
+			PreviousBlockHash: header.PreviousBlockHash[:],
+			Timestamp:         header.Timestamp,
+			Epoch:             header.Epoch,
+			Round:             int32(header.Round),
+			Producer:          header.ProducerAddress.String(),
+			TxRoot:            header.TxRoot[:],
+			InstructionsRoot:  header.InstructionsRoot[:],
+			BeaconHeight:      header.BeaconHeight,
+			BeaconHash:        header.BeaconHash[:],
+		},
+		ProducerSig:   block.ProducerSig,
+		AggregatedSig: block.AggregatedSig,
+	}
+	for _, shardID := range header.CrossShardBitMap {
+		result.Header.CrossShardBitMap = append(result.Header.CrossShardBitMap, uint32(shardID))
+	}
+	for _, tx := range block.Body.Transactions {
+		result.TxHashes = append(result.TxHashes, tx.Hash()[:])
+		if includeTransactions {
+			result.Transactions = append(result.Transactions, toPbTransaction(tx))
+		}
+	}
+	return result
+}
+
+func toPbBeaconBlock(block *blockchain.BeaconBlock) *rpcpb.BeaconBlock {
+	header := block.Header
+	result := &rpcpb.BeaconBlock{
+		Header: &rpcpb.BeaconBlockHeader{
+			Hash:              block.Hash()[:],
+			Height:            header.Height,
+			Version:           int32(header.Version),
+			PreviousBlockHash: header.PreviousBlockHash[:],
+			Timestamp:         header.Timestamp,
+			Epoch:             header.Epoch,
+			Round:             int32(header.Round),
+			Producer:          header.ProducerAddress.String(),
+			ShardStateHash:    header.ShardStateHash[:],
+			InstructionHash:   header.InstructionHash[:],
+		},
+		ProducerSig:   block.ProducerSig,
+		AggregatedSig: block.AggregatedSig,
+	}
+	for _, instruction := range block.Body.Instructions {
+		result.Instructions = append(result.Instructions, &rpcpb.Instruction{Values: instruction})
+	}
+	return result
+}
+
+func toPbShardBestState(bestState *blockchain.ShardBestState) *rpcpb.ShardBestState {
+	return &rpcpb.ShardBestState{
+		ShardId:               uint32(bestState.ShardID),
+		BestBlockHash:         bestState.BestBlockHash[:],
+		ShardHeight:           bestState.ShardHeight,
+		BestBeaconHash:        bestState.BestBeaconHash[:],
+		BeaconHeight:          bestState.BeaconHeight,
+		Epoch:                 bestState.Epoch,
+		NumTxns:               bestState.NumTxns,
+		TotalTxns:             bestState.TotalTxns,
+		ShardCommittee:        bestState.ShardCommittee,
+		ShardPendingValidator: bestState.ShardPendingValidator,
+	}
+}
+
+func toPbBeaconBestState(bestState *blockchain.BeaconBestState) *rpcpb.BeaconBestState {
+	result := &rpcpb.BeaconBestState{
+		BestBlockHash:          bestState.BestBlockHash[:],
+		BeaconHeight:           bestState.BeaconHeight,
+		Epoch:                  bestState.Epoch,
+		BeaconCommittee:        bestState.BeaconCommittee,
+		BeaconPendingValidator: bestState.BeaconPendingValidator,
+		ActiveShards:           int32(bestState.ActiveShards),
+	}
+	for shardID, height := range bestState.BestShardHeight {
+		shardState := &rpcpb.ShardState{ShardId: uint32(shardID), Height: height}
+		if hash, ok := bestState.BestShardHash[shardID]; ok {
+			shardState.Hash = hash[:]
+		}
+		result.BestShardStates = append(result.BestShardStates, shardState)
+	}
+	sort.Slice(result.BestShardStates, func(i, j int) bool {
+		return result.BestShardStates[i].ShardId < result.BestShardStates[j].ShardId
+	})
+	return result
+}
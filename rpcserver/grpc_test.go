@@ -0,0 +1,141 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	rpcpb "github.com/incognitochain/incognito-chain/rpcserver/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestToGrpcError(t *testing.T) {
+	assert.Nil(t, toGrpcError(nil))
+	cases := map[int]codes.Code{
+		ErrRPCInvalidParams:           codes.InvalidArgument,
+		ErrTxNotExistedInMemAndBLock:  codes.NotFound,
+		ErrAuthFail:                   codes.Unauthenticated,
+		ErrRPCInvalidMethodPermission: codes.PermissionDenied,
+		ErrRPCRateLimitExceeded:       codes.ResourceExhausted,
+		ErrRejectInvalidFee:           codes.FailedPrecondition,
+		ErrUnexpected:                 codes.Internal,
+	}
+	for errKey, code := range cases {
+		err := toGrpcError(NewRPCError(errKey, errors.New("test")))
+		assert.Equal(t, code, status.Code(err), ErrCodeMessage[errKey].message)
+	}
+}
+
+func TestGrpcServerAPIKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := writeTestAPIKeyFile(t, dir, `{
+		"Keys": [
+			{"Name": "explorer", "Key": "secret", "Methods": ["GetShardBestState"], "RateLimits": {"*": {"Rate": 0, "Burst": 2}}},
+			{"Name": "wallet", "Key": "secret2", "Methods": ["SendTransaction"]}
+		]
+	}`)
+	apiKeys, err := NewAPIKeyManager(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := &GrpcServer{}
+	grpcServer.Init(&RpcServerConfig{
+		GrpcListenters: []net.Listener{listener},
+		ChainParams:    &blockchain.Params{ActiveShards: 1},
+		APIKeys:        apiKeys,
+		RPCUser:        "user",
+		RPCPass:        "pass",
+	})
+	assert.Nil(t, grpcServer.Start())
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := rpcpb.NewIncognitoClient(conn)
+	call := func(key string) error {
+		ctx := context.Background()
+		if key != "" {
+			ctx = grpcmetadata.AppendToOutgoingContext(ctx, apiKeyMetadata, key)
+		}
+		return callGetShardBestState(ctx, client)
+	}
+	callWithAuth := func(login string) error {
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+		return callGetShardBestState(grpcmetadata.AppendToOutgoingContext(context.Background(), authorizationMetadata, auth), client)
+	}
+
+	// calls without key need rpc user as auth is not disabled
+	assert.Equal(t, codes.Unauthenticated, status.Code(call("")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(callWithAuth("user:wrong")))
+	assert.Equal(t, codes.InvalidArgument, status.Code(callWithAuth("user:pass")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(call("unknown")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call("secret2")))
+	assert.Equal(t, codes.InvalidArgument, status.Code(call("secret")))
+	assert.Equal(t, codes.InvalidArgument, status.Code(call("secret")))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("secret")))
+}
+
+// callGetShardBestState calls GetShardBestState of shard 1, which is not active,
+// so a call passing auth fails with invalid argument
+func callGetShardBestState(ctx context.Context, client rpcpb.IncognitoClient) error {
+	_, err := client.GetShardBestState(ctx, &rpcpb.GetShardBestStateRequest{ShardId: 1})
+	return err
+}
+
+func TestGrpcServerDisableAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileName := writeTestAPIKeyFile(t, dir, `{
+		"Public": {"Methods": ["GetShardBestState"], "RateLimits": {"*": {"Rate": 0, "Burst": 1}}}
+	}`)
+	apiKeys, err := NewAPIKeyManager(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := &GrpcServer{}
+	grpcServer.Init(&RpcServerConfig{
+		GrpcListenters: []net.Listener{listener},
+		ChainParams:    &blockchain.Params{ActiveShards: 1},
+		APIKeys:        apiKeys,
+		DisableAuth:    true,
+	})
+	assert.Nil(t, grpcServer.Start())
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := rpcpb.NewIncognitoClient(conn)
+	// calls without key use the public policy
+	assert.Equal(t, codes.InvalidArgument, status.Code(callGetShardBestState(context.Background(), client)))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(callGetShardBestState(context.Background(), client)))
+}
@@ -1,6 +1,7 @@
 package rpcserver
 
 import (
+	"context"
 	"crypto/subtle"
 	"encoding/base64"
 	"errors"
@@ -27,6 +28,9 @@ type HttpServer struct {
 	statusLines      map[int]string
 	authSHA          []byte
 	limitAuthSHA     []byte
+	// secret nonces of multisig cosigners by session
+	multiSigNonceLock     sync.Mutex
+	multiSigNonceSessions map[string]*multiSigNonceSession
 	// channel
 	cRequestProcessShutdown chan struct{}
 }
@@ -62,6 +66,9 @@ func (httpServer *HttpServer) Start() error {
 	httpServeMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		httpServer.handleRequest(w, r)
 	})
+	// probes of orchestrators, they don't need authentication
+	httpServeMux.HandleFunc(healthPath, httpServer.handleHealth)
+	httpServeMux.HandleFunc(readyPath, httpServer.handleReady)
 	for _, listen := range httpServer.config.HttpListenters {
 		go func(listen net.Listener) {
 			Logger.log.Infof("RPC Http server listening on %s", listen.Addr())
@@ -116,13 +123,27 @@ func (httpServer *HttpServer) handleRequest(w http.ResponseWriter, r *http.Reque
 	// Keep track of the number of connected clients.
 	httpServer.IncrementClients()
 	defer httpServer.DecrementClients()
-	// Check authentication for rpc user
-	ok, isLimitUser, err := httpServer.checkAuth(r, true)
-	if err != nil || !ok {
+	// Check api key, request with api key doesn't need authentication of rpc user
+	key, isLimitUser, err := httpServer.checkAPIKey(r)
+	if err != nil {
 		Logger.log.Error(err)
 		AuthFail(w)
 		return
 	}
+	if key == nil {
+		// Check authentication for rpc user
+		var ok bool
+		ok, isLimitUser, err = httpServer.checkAuth(r, true)
+		if err != nil || !ok {
+			Logger.log.Error(err)
+			AuthFail(w)
+			return
+		}
+		if httpServer.config.DisableAuth && httpServer.config.APIKeys != nil {
+			key = httpServer.config.APIKeys.getPublic()
+		}
+	}
+	r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey, key))
 
 	httpServer.ProcessRpcRequest(w, r, isLimitUser)
 }
@@ -135,6 +156,8 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 	if atomic.LoadInt32(&httpServer.shutdown) != 0 {
 		return
 	}
+	// api key of request, its allowed methods replace permission of rpc user
+	key, _ := r.Context().Value(apiKeyContextKey).(*apiKey)
 	// Read and close the JSON-RPC request body from the caller.
 	body, err := ioutil.ReadAll(r.Body)
 	r.Body.Close()
@@ -192,7 +215,11 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 		}()
 
 		// Check if the user is limited and set error if method unauthorized
-		if !isLimitedUser {
+		if key != nil {
+			if rpcErr := checkAccess(key, request.Method); rpcErr != nil {
+				jsonErr = rpcErr
+			}
+		} else if !isLimitedUser {
 			if function, ok := LimitedHttpHandler[request.Method]; ok {
 				_ = function
 				jsonErr = NewRPCError(ErrRPCInvalidMethodPermission, errors.New(""))
@@ -203,7 +230,7 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 			// command.
 			command := HttpHandler[request.Method]
 			if command == nil {
-				if isLimitedUser {
+				if isLimitedUser || key != nil {
 					command = LimitedHttpHandler[request.Method]
 				} else {
 					result = nil
@@ -211,7 +238,11 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 				}
 			}
 			if command != nil {
-				result, jsonErr = command(httpServer, request.Params, closeChan)
+				if rpcErr := validateParams(request.Method, request.Params); rpcErr != nil {
+					jsonErr = rpcErr
+				} else {
+					result, jsonErr = command(httpServer, request.Params, closeChan)
+				}
 			} else {
 				jsonErr = NewRPCError(ErrRPCMethodNotFound, nil)
 			}
@@ -298,6 +329,22 @@ func (httpServer *HttpServer) checkAuth(r *http.Request, require bool) (bool, bo
 	return false, false, NewRPCError(ErrAuthFail, nil)
 }
 
+// checkAPIKey returns api key sent with request r, it is nil if r has no api key.
+// An unknown api key is an auth failure. The second return value is always true
+// when there is an api key, because allowed methods of the key decide what it can call
+func (httpServer *HttpServer) checkAPIKey(r *http.Request) (*apiKey, bool, error) {
+	keyStr := keyOfRequest(r)
+	if keyStr == "" || httpServer.config.APIKeys == nil {
+		return nil, false, nil
+	}
+	key, err := httpServer.config.APIKeys.getKey(keyStr)
+	if err != nil {
+		Logger.log.Warnf("RPC api key authentication failure from %s", r.RemoteAddr)
+		return nil, false, err
+	}
+	return key, true, nil
+}
+
 // AuthFail sends a Message back to the client if the http auth is rejected.
 func AuthFail(w http.ResponseWriter) {
 	w.Header().Add("WWW-Authenticate", `Basic realm="RPC"`)
@@ -308,7 +355,7 @@ func NewCorsHeader(w http.ResponseWriter) {
 	w.Header().Set("Connection", "close")
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Device-Type, Device-Id, Authorization, Accept-Language, Access-Control-Allow-Headers, Access-Control-Allow-Credentials, Access-Control-Allow-Origin, Access-Control-Allow-Methods, *")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Device-Type, Device-Id, Authorization, X-API-Key, Accept-Language, Access-Control-Allow-Headers, Access-Control-Allow-Credentials, Access-Control-Allow-Origin, Access-Control-Allow-Methods, *")
 	w.Header().Set("Access-Control-Allow-Methods", "POST, PUT, GET, OPTIONS, DELETE")
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 }
@@ -332,6 +332,106 @@ func (httpServer *HttpServer) handleGetBlocks(params interface{}, closeChan <-ch
 	}
 }
 
+/*
+handleListBlocks - list blocks of a shard (or beacon) in a height range, page by page from the lowest height
+Parameter #1—shard ID, -1 for beacon
+Parameter #2—from height (optional, default 1)
+Parameter #3—to height (optional, default best height)
+Parameter #4—cursor (optional)
+Parameter #5—limit (optional)
+*/
+func (httpServer *HttpServer) handleListBlocks(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleListBlocks params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("shardID is required"))
+	}
+	shardIDTemp, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("shardID is invalid"))
+	}
+	shardID := int(shardIDTemp)
+	fromHeight, rpcErr := getHeightParam(arrayParams, 1, "fromHeight")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	toHeight, rpcErr := getHeightParam(arrayParams, 2, "toHeight")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	cursor, limit, rpcErr := getPageParams(arrayParams, 3)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	// cursor of block list is the next height to list
+	if cursor != "" {
+		nextHeight, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil || nextHeight < fromHeight {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("cursor is invalid"))
+		}
+		fromHeight = nextHeight
+	}
+
+	var bestHeight uint64
+	if shardID == -1 {
+		bestHeight = httpServer.config.BlockChain.BestState.Beacon.BestBlock.Header.Height
+	} else {
+		shardBestState, ok := httpServer.config.BlockChain.BestState.Shard[byte(shardID)]
+		if !ok || shardID < 0 {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("shardID is invalid"))
+		}
+		bestHeight = shardBestState.BestBlock.Header.Height
+	}
+	if toHeight == 0 || toHeight > bestHeight {
+		toHeight = bestHeight
+	}
+
+	result := jsonresult.ListBlocksResult{}
+	shardBlocks := make([]jsonresult.GetBlockResult, 0)
+	beaconBlocks := make([]jsonresult.GetBlocksBeaconResult, 0)
+	height := fromHeight
+	for ; height <= toHeight && len(shardBlocks)+len(beaconBlocks) < limit; height++ {
+		if shardID == -1 {
+			hash, err := httpServer.config.BlockChain.GetBeaconBlockHashByHeight(height)
+			if err != nil {
+				return nil, NewRPCError(ErrUnexpected, err)
+			}
+			block, size, err := httpServer.config.BlockChain.GetBeaconBlockByHash(hash)
+			if err != nil {
+				return nil, NewRPCError(ErrUnexpected, err)
+			}
+			blockResult := jsonresult.GetBlocksBeaconResult{}
+			blockResult.Init(block, size)
+			beaconBlocks = append(beaconBlocks, blockResult)
+		} else {
+			hash, err := httpServer.config.BlockChain.GetShardBlockHashByHeight(height, byte(shardID))
+			if err != nil {
+				return nil, NewRPCError(ErrUnexpected, err)
+			}
+			block, size, err := httpServer.config.BlockChain.GetShardBlockByHash(hash)
+			if err != nil {
+				return nil, NewRPCError(ErrUnexpected, err)
+			}
+			blockResult := jsonresult.GetBlockResult{}
+			blockResult.Init(block, size)
+			shardBlocks = append(shardBlocks, blockResult)
+		}
+	}
+	if shardID == -1 {
+		result.Blocks = beaconBlocks
+	} else {
+		result.Blocks = shardBlocks
+	}
+	if height <= toHeight {
+		result.NextCursor = strconv.FormatUint(height, 10)
+	}
+	Logger.log.Debugf("handleListBlocks result: %+v", result)
+	return result, nil
+}
+
 /*
 getblockchaininfo RPC return information fo blockchain node
 */
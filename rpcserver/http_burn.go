@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/bridgeproof"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -42,14 +43,14 @@ func (httpServer *HttpServer) handleGetBurnProof(params interface{}, closeChan <
 	}
 
 	// Get proof of instruction on beacon
-	beaconInstProof, err := getBurnProofOnBeacon(bridgeInstProof.inst, beaconBlocks, db)
+	beaconInstProof, err := getBurnProofOnBeacon(bridgeInstProof.Inst, beaconBlocks, db)
 	if err != nil {
 		return nil, NewRPCError(ErrUnexpected, err)
 	}
 
 	// Decode instruction to send to Ethereum without having to decode on client
-	decodedInst, bridgeHeight, beaconHeight := splitAndDecodeInst(bridgeInstProof.inst, beaconInstProof.inst)
-	//decodedInst := hex.EncodeToString(blockchain.DecodeInstruction(bridgeInstProof.inst))
+	decodedInst, bridgeHeight, beaconHeight := splitAndDecodeInst(bridgeInstProof.Inst, beaconInstProof.Inst)
+	//decodedInst := hex.EncodeToString(blockchain.DecodeInstruction(bridgeInstProof.Inst))
 
 	return buildProofResult(decodedInst, beaconInstProof, bridgeInstProof, beaconHeight, bridgeHeight), nil
 }
@@ -60,15 +61,15 @@ func getBurnProofOnBridge(
 	bridgeBlock *blockchain.ShardBlock,
 	bc *blockchain.BlockChain,
 	db database.DatabaseInterface,
-) (*swapProof, error) {
+) (*bridgeproof.InstProof, error) {
 	insts := bridgeBlock.Body.Instructions
 	_, instID := findBurnConfirmInst(insts, txID)
 	if instID < 0 {
 		return nil, fmt.Errorf("cannot find burning instruction in bridge block")
 	}
 
-	block := &shardBlock{ShardBlock: bridgeBlock}
-	proof, err := buildProofForBlock(block, insts, instID, db)
+	block := &bridgeproof.ShardBlock{ShardBlock: bridgeBlock}
+	proof, err := bridgeproof.BuildProofForBlock(block, insts, instID, db)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +81,7 @@ func getBurnProofOnBeacon(
 	inst []string,
 	beaconBlocks []*blockchain.BeaconBlock,
 	db database.DatabaseInterface,
-) (*swapProof, error) {
+) (*bridgeproof.InstProof, error) {
 	// Get beacon block and check if it contains beacon swap instruction
 	b, instID := findBeaconBlockWithBurnInst(beaconBlocks, inst)
 	if b == nil {
@@ -88,8 +89,8 @@ func getBurnProofOnBeacon(
 	}
 
 	insts := b.Body.Instructions
-	block := &beaconBlock{BeaconBlock: b}
-	return buildProofForBlock(block, insts, instID, db)
+	block := &bridgeproof.BeaconBlock{BeaconBlock: b}
+	return bridgeproof.BuildProofForBlock(block, insts, instID, db)
 }
 
 // findBeaconBlockWithBurnInst finds a beacon block with a specific burning instruction and the instruction's index; nil if not found
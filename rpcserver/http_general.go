@@ -6,10 +6,12 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/privacy"
@@ -284,6 +286,7 @@ func (httpServer *HttpServer) handleListUnspentOutputCoins(params interface{}, c
 				CoinCommitment: base58.Base58Check{}.Encode(outCoin.CoinDetails.GetCoinCommitment().Compress(), common.ZeroByte),
 				Randomness:     base58.Base58Check{}.Encode(outCoin.CoinDetails.GetRandomness().Bytes(), common.ZeroByte),
 				SNDerivator:    base58.Base58Check{}.Encode(outCoin.CoinDetails.GetSNDerivator().Bytes(), common.ZeroByte),
+				Memo:           decryptMemo(outCoin.CoinDetails, keyWallet.KeySet.ReadonlyKey),
 			})
 		}
 		result.Outputs[priKeyStr] = item
@@ -420,6 +423,182 @@ func (httpServer *HttpServer) handleMempoolEntry(params interface{}, closeChan <
 	return result, nil
 }
 
+/*
+handleListMempoolEntries - RPC lists txs in mempool sorted by fee per kb, highest first, with their size, fee and age
+Parameter #1—limit (optional, default all txs)
+*/
+func (httpServer *HttpServer) handleListMempoolEntries(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleListMempoolEntries params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	limit := 0
+	if len(arrayParams) > 0 && arrayParams[0] != nil {
+		limitParam, ok := arrayParams[0].(float64)
+		if !ok || limitParam < 0 {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("limit is invalid"))
+		}
+		limit = int(limitParam)
+	}
+	entries := httpServer.config.TxMemPool.ListPoolEntries()
+	result := jsonresult.ListMempoolEntriesResult{
+		Size:    len(entries),
+		Entries: make([]jsonresult.MempoolEntryDetail, 0, len(entries)),
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if limit > 0 && len(result.Entries) == limit {
+			break
+		}
+		result.Entries = append(result.Entries, jsonresult.MempoolEntryDetail{
+			TxID:              entry.TxHash.String(),
+			Type:              entry.Type,
+			MetadataType:      entry.MetadataType,
+			Size:              entry.Size,
+			Fee:               entry.Fee,
+			FeeToken:          entry.FeeToken,
+			FeePerKB:          entry.FeePerKB,
+			FeeTokenPerKB:     entry.FeeTokenPerKB,
+			Height:            entry.Height,
+			StartTime:         entry.StartTime.Unix(),
+			Age:               int64(now.Sub(entry.StartTime).Seconds()),
+			IsForwarded:       entry.IsForwarded,
+			SerialNumbersHash: entry.SerialNumbersHash.String(),
+			Candidate:         entry.Candidate,
+			InitTokenID:       entry.InitTokenID,
+		})
+	}
+	Logger.log.Debugf("handleListMempoolEntries result: %+v", result)
+	return result, nil
+}
+
+/*
+handleGetMempoolConflicts - RPC finds txs in mempool which spend given serial numbers
+Parameter #1—list of serial numbers in base58check encode string
+*/
+func (httpServer *HttpServer) handleGetMempoolConflicts(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleGetMempoolConflicts params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("serialNumbers is required"))
+	}
+	serialNumbersParam, ok := arrayParams[0].([]interface{})
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("serialNumbers is invalid"))
+	}
+	serialNumbers := make([][]byte, len(serialNumbersParam))
+	for i, item := range serialNumbersParam {
+		serialNumberStr, ok := item.(string)
+		if !ok {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("serialNumbers is invalid"))
+		}
+		serialNumber, _, err := base58.Base58Check{}.Decode(serialNumberStr)
+		if err != nil {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.Wrapf(err, "serial number %s is invalid", serialNumberStr))
+		}
+		serialNumbers[i] = serialNumber
+	}
+	result := jsonresult.GetMempoolConflictsResult{
+		Conflicts: make(map[string]string),
+	}
+	for i, txHash := range httpServer.config.TxMemPool.ConflictingTxs(serialNumbers) {
+		if txHash != nil {
+			result.Conflicts[serialNumbersParam[i].(string)] = txHash.String()
+		}
+	}
+	Logger.log.Debugf("handleGetMempoolConflicts result: %+v", result)
+	return result, nil
+}
+
+/*
+handleRemoveMempoolTx - RPC removes a tx from mempool and from mempool database persistence
+Parameter #1—tx id
+*/
+func (httpServer *HttpServer) handleRemoveMempoolTx(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleRemoveMempoolTx params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("tx id is required"))
+	}
+	txIDParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("tx id is invalid"))
+	}
+	txID, err := common.Hash{}.NewHashFromStr(txIDParam)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, err)
+	}
+	if err := httpServer.config.TxMemPool.EvictTx(*txID); err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	return true, nil
+}
+
+/*
+handleDumpMempool - RPC writes all txs in mempool into a file of node, to be loaded into mempool of another node by loadmempool
+Parameter #1—file name, without path separators, file is in data directory of node
+*/
+func (httpServer *HttpServer) handleDumpMempool(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleDumpMempool params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("file name is required"))
+	}
+	fileName, ok := arrayParams[0].(string)
+	if !ok || fileName == "" {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("file name is invalid"))
+	}
+	count, err := httpServer.config.TxMemPool.DumpPool(fileName)
+	if err != nil {
+		return nil, newDumpMempoolError(err)
+	}
+	result := jsonresult.DumpMempoolResult{
+		FileName: fileName,
+		Count:    count,
+	}
+	Logger.log.Debugf("handleDumpMempool result: %+v", result)
+	return result, nil
+}
+
+/*
+handleLoadMempool - RPC adds txs of a file written by dumpmempool into mempool, txs are validated as new txs
+Parameter #1—file name, without path separators, file is in data directory of node
+*/
+func (httpServer *HttpServer) handleLoadMempool(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleLoadMempool params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("file name is required"))
+	}
+	fileName, ok := arrayParams[0].(string)
+	if !ok || fileName == "" {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("file name is invalid"))
+	}
+	accepted, rejected, err := httpServer.config.TxMemPool.LoadPool(fileName)
+	if err != nil {
+		return nil, newDumpMempoolError(err)
+	}
+	result := jsonresult.LoadMempoolResult{
+		Accepted: make([]string, len(accepted)),
+		Rejected: make(map[string]string),
+	}
+	for i, txHash := range accepted {
+		result.Accepted[i] = txHash.String()
+	}
+	for txHash, err := range rejected {
+		result.Rejected[txHash.String()] = err.Error()
+	}
+	Logger.log.Debugf("handleLoadMempool result: %+v", result)
+	return result, nil
+}
+
+// newDumpMempoolError returns error of dumpmempool and loadmempool, a file name out of data directory is an invalid param
+func newDumpMempoolError(err error) *RPCError {
+	mempoolErr, ok := err.(*mempool.MempoolTxError)
+	if ok && mempoolErr.Code == mempool.ErrCodeMessage[mempool.InvalidDumpFileNameError].Code {
+		return NewRPCError(ErrRPCInvalidParams, mempoolErr)
+	}
+	return NewRPCError(ErrUnexpected, err)
+}
+
 /*
 handleEstimateFee - RPC estimates the transaction fee per kilobyte that needs to be paid for a transaction to be included within a certain number of blocks.
 */
@@ -478,14 +657,10 @@ func (httpServer *HttpServer) handleEstimateFee(params interface{}, closeChan <-
 			receiversPaymentAddressStrParam = arrayParams[1].(map[string]interface{})
 		}
 		paymentInfos := make([]*privacy.PaymentInfo, 0)
-		for paymentAddressStr, amount := range receiversPaymentAddressStrParam {
-			keyWalletReceiver, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+		for paymentAddressStr, receiverParam := range receiversPaymentAddressStrParam {
+			paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
 			if err != nil {
-				return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, err)
-			}
-			paymentInfo := &privacy.PaymentInfo{
-				Amount:         uint64(amount.(float64)),
-				PaymentAddress: keyWalletReceiver.KeySet.PaymentAddress,
+				return nil, err
 			}
 			paymentInfos = append(paymentInfos, paymentInfo)
 		}
@@ -0,0 +1,114 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+)
+
+const (
+	// healthPath answers 200 while rpc server is running, it is a liveness probe
+	healthPath = "/health"
+	// readyPath answers 200 when node is synced with its peers and 503 otherwise, body is result of getsyncstatus
+	readyPath = "/ready"
+)
+
+// newSyncStatusResult adds state of consensus of a node with role in shard shardID to its sync status
+func newSyncStatusResult(syncStatus blockchain.SyncStatus, isConsensusEnabled bool, role string, shardID byte) jsonresult.GetSyncStatusResult {
+	result := jsonresult.GetSyncStatusResult{
+		Beacon:             syncStatus.Beacon,
+		Shards:             syncStatus.Shards,
+		IsSynced:           syncStatus.IsSynced(),
+		IsConsensusEnabled: isConsensusEnabled,
+		Role:               role,
+	}
+	// consensus engine waits for beacon, a shard committee member waits for its shard too
+	caughtUp := syncStatus.Beacon.IsLatest
+	if role == common.SHARD_ROLE {
+		shardCaughtUp := false
+		for _, shard := range syncStatus.Shards {
+			if shard.ChainID == int(shardID) {
+				shardCaughtUp = shard.IsLatest
+			}
+		}
+		caughtUp = caughtUp && shardCaughtUp
+	}
+	result.IsConsensusCaughtUp = isConsensusEnabled && caughtUp
+	return result
+}
+
+func (httpServer *HttpServer) getSyncStatus() jsonresult.GetSyncStatusResult {
+	syncStatus := httpServer.config.BlockChain.GetSyncStatus()
+	if httpServer.config.MiningPubKeyB58 == "" {
+		return newSyncStatusResult(syncStatus, false, common.EmptyString, 0)
+	}
+	role, shardID := httpServer.config.BlockChain.BestState.Beacon.GetPubkeyRole(httpServer.config.MiningPubKeyB58, 0)
+	return newSyncStatusResult(syncStatus, httpServer.config.Server.IsEnableMining(), role, shardID)
+}
+
+/*
+handleGetSyncStatus - RPC returns for beacon and each shard synced by node: local height, the highest height reported
+by peers, sync speed in blocks per second and estimated seconds to catch up, with whether consensus is enabled and caught up
+*/
+func (httpServer *HttpServer) handleGetSyncStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleGetSyncStatus params: %+v", params)
+	result := httpServer.getSyncStatus()
+	Logger.log.Debugf("handleGetSyncStatus result: %+v", result)
+	return result, nil
+}
+
+// writeProbeResponse writes a json body with status code, HEAD requests get status code only
+func writeProbeResponse(w http.ResponseWriter, r *http.Request, statusCode int, body interface{}) {
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+// checkProbeMethod answers 405 to requests other than GET and HEAD
+func checkProbeMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	return false
+}
+
+// handleHealth answers liveness probes, node is alive while its rpc server is not shutting down
+func (httpServer *HttpServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	NewCorsHeader(w)
+	if !checkProbeMethod(w, r) {
+		return
+	}
+	if atomic.LoadInt32(&httpServer.shutdown) != 0 {
+		writeProbeResponse(w, r, http.StatusServiceUnavailable, map[string]string{"Status": "shutting down"})
+		return
+	}
+	writeProbeResponse(w, r, http.StatusOK, map[string]string{"Status": "OK"})
+}
+
+// handleReady answers readiness probes, node is ready when beacon and shards it syncs are synced with peers
+func (httpServer *HttpServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	NewCorsHeader(w)
+	if !checkProbeMethod(w, r) {
+		return
+	}
+	if atomic.LoadInt32(&httpServer.shutdown) != 0 {
+		writeProbeResponse(w, r, http.StatusServiceUnavailable, map[string]string{"Status": "shutting down"})
+		return
+	}
+	result := httpServer.getSyncStatus()
+	statusCode := http.StatusOK
+	if !result.IsSynced {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeProbeResponse(w, r, statusCode, result)
+}
@@ -0,0 +1,64 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSyncStatusResult(t *testing.T) {
+	syncStatus := blockchain.SyncStatus{
+		Beacon: blockchain.ChainSyncStatus{ChainID: blockchain.BeaconChainID, IsLatest: true, IsSynced: true},
+		Shards: []blockchain.ChainSyncStatus{
+			{ChainID: 0, IsLatest: false, IsSynced: true},
+			{ChainID: 1, IsLatest: true, IsSynced: true},
+		},
+	}
+	result := newSyncStatusResult(syncStatus, true, common.VALIDATOR_ROLE, 0)
+	assert.True(t, result.IsSynced)
+	assert.True(t, result.IsConsensusCaughtUp)
+	assert.False(t, newSyncStatusResult(syncStatus, true, common.SHARD_ROLE, 0).IsConsensusCaughtUp)
+	assert.True(t, newSyncStatusResult(syncStatus, true, common.SHARD_ROLE, 1).IsConsensusCaughtUp)
+	assert.False(t, newSyncStatusResult(syncStatus, false, common.SHARD_ROLE, 1).IsConsensusCaughtUp)
+
+	syncStatus.Shards[0].IsSynced = false
+	assert.False(t, newSyncStatusResult(syncStatus, true, common.VALIDATOR_ROLE, 0).IsSynced)
+}
+
+func TestHealthAndReadyProbes(t *testing.T) {
+	beacon := blockchain.NewBeaconBestState()
+	beacon.BeaconHeight = 10
+	server := &HttpServer{}
+	server.Init(&RpcServerConfig{
+		BlockChain: &blockchain.BlockChain{BestState: &blockchain.BestState{Beacon: beacon}},
+	})
+	probe := func(handler http.HandlerFunc, method string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(method, "/", nil))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, probe(server.handleHealth, http.MethodGet).Code)
+	assert.Equal(t, http.StatusOK, probe(server.handleHealth, http.MethodHead).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, probe(server.handleHealth, http.MethodPost).Code)
+
+	// no peer reported its height, so node is not ready
+	recorder := probe(server.handleReady, http.MethodGet)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	result := jsonresult.GetSyncStatusResult{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(10), result.Beacon.Height)
+	assert.False(t, result.IsSynced)
+	assert.False(t, result.IsConsensusEnabled)
+
+	server.shutdown = 1
+	assert.Equal(t, http.StatusServiceUnavailable, probe(server.handleHealth, http.MethodGet).Code)
+}
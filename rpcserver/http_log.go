@@ -0,0 +1,42 @@
+package rpcserver
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+)
+
+/*
+handleSetLogLevel - RPC changes logging level of subsystems on running node
+Parameter #1—logging level {trace, debug, info, warn, error, critical}
+Parameter #2—(optional) subsystem identifier, all subsystems are changed if it is omitted
+*/
+func (httpServer *HttpServer) handleSetLogLevel(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Infof("handleSetLogLevel params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Log level empty"))
+	}
+	logLevel, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Log level invalid"))
+	}
+	subsystemID := common.EmptyString
+	if len(arrayParams) > 1 {
+		subsystemID, ok = arrayParams[1].(string)
+		if !ok {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Subsystem invalid"))
+		}
+	}
+	err := httpServer.config.Server.SetLogLevel(subsystemID, logLevel)
+	if err != nil {
+		return nil, NewRPCError(ErrSetLogLevel, err)
+	}
+	return httpServer.config.Server.GetLogLevels(), nil
+}
+
+/*
+handleGetLogLevels - RPC returns current logging level of all subsystems
+*/
+func (httpServer *HttpServer) handleGetLogLevels(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	return httpServer.config.Server.GetLogLevels(), nil
+}
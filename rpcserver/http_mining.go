@@ -2,6 +2,7 @@ package rpcserver
 
 import (
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/pkg/errors"
 )
@@ -63,3 +64,35 @@ func (httpServer *HttpServer) handleGetChainMiningStatus(params interface{}, clo
 	}
 	return httpServer.config.Server.GetChainMiningStatus(int(chainIDParam)), nil
 }
+
+/*
+handleGenerateBlocks - RPC seals blocks on demand when node runs a dev consensus (instantseal)
+params: chainID (-1 for beacon, shardID otherwise), number of blocks
+*/
+func (httpServer *HttpServer) handleGenerateBlocks(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Chain ID or number of blocks empty"))
+	}
+	chainIDParam, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Chain ID component invalid"))
+	}
+	numBlocksParam, ok := arrayParams[1].(float64)
+	if !ok || numBlocksParam < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Number of blocks component invalid"))
+	}
+	sealer, ok := httpServer.config.ConsensusEngine.(consensus.BlockSealer)
+	if !ok {
+		return nil, NewRPCError(ErrGenerateBlock, errors.New("Consensus engine can't generate blocks on demand"))
+	}
+	blockHashes, err := sealer.GenerateBlocks(int(chainIDParam), int(numBlocksParam))
+	if err != nil {
+		return nil, NewRPCError(ErrGenerateBlock, err)
+	}
+	result := []string{}
+	for _, blockHash := range blockHashes {
+		result = append(result, blockHash.String())
+	}
+	return result, nil
+}
@@ -0,0 +1,626 @@
+package rpcserver
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+/*
+Coins of an M-of-N multisig account are spent in steps:
+1. createmultisigtransaction (or createmultisigprivacytokentransaction) creates an unsigned tx with the multisig key
+2. at least M cosigners call createmultisignonces with their private keys and share their nonce commitments,
+   public nonces are shared only after commitments of all signers are collected
+3. each of these cosigners calls signmultisigtransaction with nonce commitments and nonces of all signers
+   and the nonce session returned by createmultisignonces, it fails if a nonce does not match the commitment of its signer.
+   Secret nonces never leave the node: they are kept by session and deleted once the session is used
+4. combinemultisigtransaction combines partial signatures of the signers into a signed tx,
+   which is sent by sendtransaction (or sendrawprivacycustomtokentransaction)
+*/
+
+const (
+	multiSigNonceSessionTimeout = 30 * time.Minute
+	maxMultiSigNonceSessions    = 1000
+)
+
+// multiSigNonceSession keeps secret nonces of a cosigner between createmultisignonces and signmultisigtransaction
+type multiSigNonceSession struct {
+	signerIndex  int
+	partHashes   []common.Hash
+	nonces       []*privacy.EllipticPoint
+	secretNonces []*big.Int
+	createdAt    time.Time
+}
+
+// addMultiSigNonceSession keeps a nonce session and returns its id,
+// expired sessions are dropped first
+func (httpServer *HttpServer) addMultiSigNonceSession(session *multiSigNonceSession) (string, error) {
+	idBytes := make([]byte, common.HashSize)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", err
+	}
+	sessionID := hex.EncodeToString(idBytes)
+
+	httpServer.multiSigNonceLock.Lock()
+	defer httpServer.multiSigNonceLock.Unlock()
+	if httpServer.multiSigNonceSessions == nil {
+		httpServer.multiSigNonceSessions = make(map[string]*multiSigNonceSession)
+	}
+	for id, s := range httpServer.multiSigNonceSessions {
+		if time.Since(s.createdAt) > multiSigNonceSessionTimeout {
+			delete(httpServer.multiSigNonceSessions, id)
+		}
+	}
+	if len(httpServer.multiSigNonceSessions) >= maxMultiSigNonceSessions {
+		return "", errors.New("too many pending nonce sessions")
+	}
+	httpServer.multiSigNonceSessions[sessionID] = session
+	return sessionID, nil
+}
+
+// takeMultiSigNonceSession returns a nonce session and deletes it, so secret nonces are used at most once
+func (httpServer *HttpServer) takeMultiSigNonceSession(sessionID string) *multiSigNonceSession {
+	httpServer.multiSigNonceLock.Lock()
+	defer httpServer.multiSigNonceLock.Unlock()
+	session, ok := httpServer.multiSigNonceSessions[sessionID]
+	if !ok {
+		return nil
+	}
+	delete(httpServer.multiSigNonceSessions, sessionID)
+	if time.Since(session.createdAt) > multiSigNonceSessionTimeout {
+		return nil
+	}
+	return session
+}
+
+// multiSigTx is a tx spending coins of a multisig account
+type multiSigTx struct {
+	tx      metadata.Transaction
+	parts   []*transaction.Tx // parts of tx signed by cosigners: PRV part, then token part of privacy token tx
+	account *privacy.MultiSigAccount
+}
+
+// decodeMultiSigTx decodes a tx (created by createmultisigtransaction or createmultisigprivacytokentransaction)
+func decodeMultiSigTx(base58CheckData string) (*multiSigTx, *RPCError) {
+	rawTxBytes, _, err := base58.Base58Check{}.Decode(base58CheckData)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, err)
+	}
+	txType := struct{ Type string }{}
+	err = json.Unmarshal(rawTxBytes, &txType)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, err)
+	}
+
+	res := new(multiSigTx)
+	if txType.Type == common.TxCustomTokenPrivacyType {
+		tx := new(transaction.TxCustomTokenPrivacy)
+		err = json.Unmarshal(rawTxBytes, tx)
+		if err != nil {
+			return nil, NewRPCError(ErrRPCInvalidParams, err)
+		}
+		res.tx = tx
+		res.parts = []*transaction.Tx{&tx.Tx, &tx.TxTokenPrivacyData.TxNormal}
+	} else {
+		tx := new(transaction.Tx)
+		err = json.Unmarshal(rawTxBytes, tx)
+		if err != nil {
+			return nil, NewRPCError(ErrRPCInvalidParams, err)
+		}
+		res.tx = tx
+		res.parts = []*transaction.Tx{tx}
+	}
+
+	for _, part := range res.parts {
+		if !part.IsMultiSig() || !bytes.Equal(part.MultiSigAccount, res.parts[0].MultiSigAccount) {
+			return nil, NewRPCError(ErrTxTypeInvalid, errors.New("tx does not spend coins of a multisig account"))
+		}
+	}
+	res.account, err = res.parts[0].GetMultiSigAccount()
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidMultiSigKey, err)
+	}
+	return res, nil
+}
+
+func (multiSigTx multiSigTx) toResult() (interface{}, *RPCError) {
+	byteArrays, err := json.Marshal(multiSigTx.tx)
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            multiSigTx.tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, common.ZeroByte),
+		ShardID:         common.GetShardIDFromLastByte(multiSigTx.parts[0].PubKeyLastByteSender),
+	}
+	return result, nil
+}
+
+// getMultiSigKey deserializes a multisig key (created by createmultisigaddress)
+func getMultiSigKey(param interface{}) (*wallet.KeyWallet, *RPCError) {
+	multiSigKeyParam, ok := param.(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("multisig key is invalid"))
+	}
+	key, err := wallet.Base58CheckDeserialize(multiSigKeyParam)
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidMultiSigKey, err)
+	}
+	if key.MultiSigAccount == nil {
+		return nil, NewRPCError(ErrInvalidMultiSigKey, errors.New("key is not a multisig key"))
+	}
+	return key, nil
+}
+
+// parseMultiSigSignerValues parses an object mapping signer index to a list of base58 encoded values,
+// one value for each signed part of tx. Signer indices are returned in increasing order with their values
+func parseMultiSigSignerValues(param interface{}, numParts int) ([]int, [][][]byte, *RPCError) {
+	valuesParam, ok := param.(map[string]interface{})
+	if !ok || len(valuesParam) == 0 {
+		return nil, nil, NewRPCError(ErrRPCInvalidParams, errors.New("values of signers are invalid"))
+	}
+
+	type signerValues struct {
+		index  int
+		values [][]byte
+	}
+	signers := make([]signerValues, 0, len(valuesParam))
+	for key, valueParam := range valuesParam {
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, nil, NewRPCError(ErrRPCInvalidParams, fmt.Errorf("signer index %s is invalid", key))
+		}
+		list, ok := valueParam.([]interface{})
+		if !ok || len(list) != numParts {
+			return nil, nil, NewRPCError(ErrRPCInvalidParams, fmt.Errorf("signer %d must have %d values", index, numParts))
+		}
+		values := make([][]byte, numParts)
+		for i, item := range list {
+			str, ok := item.(string)
+			if !ok {
+				return nil, nil, NewRPCError(ErrRPCInvalidParams, fmt.Errorf("value of signer %d is invalid", index))
+			}
+			values[i], _, err = base58.Base58Check{}.Decode(str)
+			if err != nil {
+				return nil, nil, NewRPCError(ErrRPCInvalidParams, err)
+			}
+		}
+		signers = append(signers, signerValues{index: index, values: values})
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].index < signers[j].index
+	})
+
+	indices := make([]int, len(signers))
+	values := make([][][]byte, len(signers))
+	for i, signer := range signers {
+		indices[i] = signer.index
+		values[i] = signer.values
+	}
+	return indices, values, nil
+}
+
+/*
+handleCreateMultiSigAddress - RPC creates an M-of-N multisig account
+Parameter #1—M, the number of cosigners required to spend coins of the account
+Parameter #2—list of payment addresses of N cosigners
+Result—the multisig key, which is used to create txs and view balance of the account,
+and payment address of the account
+*/
+func (httpServer *HttpServer) handleCreateMultiSigAddress(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleCreateMultiSigAddress params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be M and list of payment addresses of cosigners"))
+	}
+	m, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("M is invalid"))
+	}
+	paymentAddressesParam, ok := arrayParams[1].([]interface{})
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment addresses of cosigners are invalid"))
+	}
+	publicKeys := make([]privacy.PublicKey, len(paymentAddressesParam))
+	for i, paymentAddressParam := range paymentAddressesParam {
+		paymentAddress, ok := paymentAddressParam.(string)
+		if !ok {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment address of cosigner is invalid"))
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(paymentAddress)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, err)
+		}
+		publicKeys[i] = keyWallet.KeySet.PaymentAddress.Pk
+	}
+
+	key, err := wallet.NewMultiSigKey(int(m), publicKeys)
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidMultiSigKey, err)
+	}
+	result := jsonresult.CreateMultiSigAddressResult{
+		MultiSigKey:    key.Base58CheckSerialize(wallet.MultiSigKeyType),
+		PaymentAddress: key.Base58CheckSerialize(wallet.PaymentAddressType),
+		M:              key.MultiSigAccount.GetM(),
+		PublicKeys:     make([]string, 0),
+		ShardID:        common.GetShardIDFromLastByte(key.KeySet.PaymentAddress.Pk[len(key.KeySet.PaymentAddress.Pk)-1]),
+	}
+	for _, publicKey := range key.MultiSigAccount.GetPublicKeys() {
+		result.PublicKeys = append(result.PublicKeys, base58.Base58Check{}.Encode(publicKey, common.ZeroByte))
+	}
+	Logger.log.Debugf("handleCreateMultiSigAddress result: %+v", result)
+	return result, nil
+}
+
+/*
+handleCreateMultiSigTransaction - RPC creates an unsigned tx spending PRV of a multisig account, tx has no privacy
+Parameter #1—the multisig key
+Parameter #2—list of receivers
+Parameter #3—estimation fee nano P per kb
+Parameter #4—optional expiry beacon height
+*/
+func (httpServer *HttpServer) handleCreateMultiSigTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleCreateMultiSigTransaction params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 3 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be multisig key, list of receivers and fee per kb"))
+	}
+	key, rpcErr := getMultiSigKey(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	keySet := &key.KeySet
+	shardIDSender := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+
+	receiversParam, ok := arrayParams[1].(map[string]interface{})
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("list of receivers is invalid"))
+	}
+	paymentInfos := make([]*privacy.PaymentInfo, 0)
+	for paymentAddressStr, receiverParam := range receiversParam {
+		paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
+		if err != nil {
+			return nil, err
+		}
+		paymentInfos = append(paymentInfos, paymentInfo)
+	}
+	estimateFeeCoinPerKb, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("fee per kb is invalid"))
+	}
+	expiryHeight := uint64(0)
+	if len(arrayParams) > 3 {
+		expiryHeightParam, ok := arrayParams[3].(float64)
+		if !ok || expiryHeightParam < 0 {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Expiry beacon height is invalid"))
+		}
+		expiryHeight = uint64(expiryHeightParam)
+	}
+
+	inputCoins, realFee, rpcErr := httpServer.chooseOutsCoinByKeyset(paymentInfos, int64(estimateFeeCoinPerKb), 0, keySet, shardIDSender, false, nil, nil, nil, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tx := new(transaction.Tx)
+	err := tx.Init(
+		transaction.NewTxPrivacyInitParams(nil,
+			paymentInfos,
+			inputCoins,
+			realFee,
+			false,
+			*httpServer.config.Database,
+			nil,
+			nil).SetExpiryHeight(expiryHeight).SetMultiSigAccount(key.MultiSigAccount))
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+
+	result, rpcErr := multiSigTx{tx: tx, parts: []*transaction.Tx{tx}, account: key.MultiSigAccount}.toResult()
+	Logger.log.Debugf("handleCreateMultiSigTransaction result: %+v", result)
+	return result, rpcErr
+}
+
+/*
+handleCreateMultiSigPrivacyTokenTransaction - RPC creates an unsigned tx transferring privacy token of a multisig account,
+fee is paid with PRV of the account, tx has no privacy
+Parameter #1—the multisig key
+Parameter #2—list of PRV receivers
+Parameter #3—estimation fee nano P per kb
+Parameter #4—token params, as in createrawprivacycustomtokentransaction, TokenTxType must be transfer
+*/
+func (httpServer *HttpServer) handleCreateMultiSigPrivacyTokenTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleCreateMultiSigPrivacyTokenTransaction params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 4 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be multisig key, list of receivers, fee per kb and token params"))
+	}
+	key, rpcErr := getMultiSigKey(arrayParams[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	keySet := &key.KeySet
+	shardIDSender := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+
+	receiversParam := make(map[string]interface{})
+	if arrayParams[1] != nil {
+		var ok bool
+		receiversParam, ok = arrayParams[1].(map[string]interface{})
+		if !ok {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("list of receivers is invalid"))
+		}
+	}
+	paymentInfos := make([]*privacy.PaymentInfo, 0)
+	for paymentAddressStr, receiverParam := range receiversParam {
+		paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
+		if err != nil {
+			return nil, err
+		}
+		paymentInfos = append(paymentInfos, paymentInfo)
+	}
+	estimateFeeCoinPerKb, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("fee per kb is invalid"))
+	}
+	tokenParamsRaw, ok := arrayParams[3].(map[string]interface{})
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("token params are invalid"))
+	}
+	tokenTxType, ok := tokenParamsRaw["TokenTxType"].(float64)
+	if !ok || int(tokenTxType) != transaction.CustomTokenTransfer {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("multisig account can only transfer privacy token"))
+	}
+	tokenParams, _, _, rpcErr := httpServer.buildPrivacyCustomTokenParam(tokenParamsRaw, keySet, shardIDSender)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	inputCoins, realFeePrv, rpcErr := httpServer.chooseOutsCoinByKeyset(paymentInfos, int64(estimateFeeCoinPerKb), 0, keySet, shardIDSender, false, nil, nil, tokenParams, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tx := new(transaction.TxCustomTokenPrivacy)
+	err := tx.Init(
+		transaction.NewTxPrivacyTokenInitParams(nil,
+			paymentInfos,
+			inputCoins,
+			realFeePrv,
+			tokenParams,
+			*httpServer.config.Database,
+			nil,
+			false,
+			false,
+			shardIDSender).SetMultiSigAccount(key.MultiSigAccount))
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+
+	byteArrays, err := json.Marshal(tx)
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+	result := jsonresult.CreateTransactionCustomTokenResult{
+		ShardID:         shardIDSender,
+		TxID:            tx.Hash().String(),
+		TokenID:         tx.TxTokenPrivacyData.PropertyID.String(),
+		TokenName:       tx.TxTokenPrivacyData.PropertyName,
+		TokenAmount:     tx.TxTokenPrivacyData.Amount,
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, common.ZeroByte),
+	}
+	Logger.log.Debugf("handleCreateMultiSigPrivacyTokenTransaction result: %+v", result)
+	return result, nil
+}
+
+/*
+handleCreateMultiSigNonces - RPC creates nonces of a cosigner for signing a multisig tx,
+nonce commitments are shared with other signers first, public nonces only after commitments of all signers are collected,
+secret nonces are kept by the node under the returned session for a single signmultisigtransaction
+Parameter #1—private key of the cosigner
+Parameter #2—the unsigned multisig tx
+*/
+func (httpServer *HttpServer) handleCreateMultiSigNonces(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be private key and multisig tx"))
+	}
+	privateKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrInvalidSenderPrivateKey, errors.New("private key is invalid"))
+	}
+	keySet, err := httpServer.GetKeySetFromPrivateKeyParams(privateKeyParam)
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidSenderPrivateKey, err)
+	}
+	txParam, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("multisig tx is invalid"))
+	}
+	multiSigTx, rpcErr := decodeMultiSigTx(txParam)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	signerIndex := multiSigTx.account.IndexOfPublicKey(keySet.PaymentAddress.Pk)
+	if signerIndex < 0 {
+		return nil, NewRPCError(ErrCanNotSign, errors.New("private key is not of a cosigner of multisig account"))
+	}
+
+	session := &multiSigNonceSession{
+		signerIndex:  signerIndex,
+		partHashes:   make([]common.Hash, len(multiSigTx.parts)),
+		nonces:       make([]*privacy.EllipticPoint, len(multiSigTx.parts)),
+		secretNonces: make([]*big.Int, len(multiSigTx.parts)),
+		createdAt:    time.Now(),
+	}
+	result := jsonresult.MultiSigNoncesResult{
+		SignerIndex:      signerIndex,
+		NonceCommitments: make([]string, len(multiSigTx.parts)),
+		Nonces:           make([]string, len(multiSigTx.parts)),
+	}
+	for i, part := range multiSigTx.parts {
+		session.partHashes[i] = *part.Hash()
+		session.nonces[i], session.secretNonces[i] = privacy.MultiSigScheme{}.GenerateRandom()
+		result.NonceCommitments[i] = base58.Base58Check{}.Encode(multiSigTx.account.NonceCommitment(session.partHashes[i][:], signerIndex, session.nonces[i]), common.ZeroByte)
+		result.Nonces[i] = base58.Base58Check{}.Encode(session.nonces[i].Compress(), common.ZeroByte)
+	}
+	result.Session, err = httpServer.addMultiSigNonceSession(session)
+	if err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	return result, nil
+}
+
+/*
+handleSignMultiSigTransaction - RPC creates partial signatures of a cosigner on a multisig tx
+Parameter #1—private key of the cosigner
+Parameter #2—the unsigned multisig tx
+Parameter #3—nonce commitments of all signers: {"signer index": [nonce commitments]}
+Parameter #4—public nonces of all signers: {"signer index": [nonces]}
+Parameter #5—nonce session of the cosigner returned by createmultisignonces, it can be used only once
+*/
+func (httpServer *HttpServer) handleSignMultiSigTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be private key, multisig tx, nonce commitments and nonces of signers and nonce session"))
+	}
+	privateKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrInvalidSenderPrivateKey, errors.New("private key is invalid"))
+	}
+	keySet, err := httpServer.GetKeySetFromPrivateKeyParams(privateKeyParam)
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidSenderPrivateKey, err)
+	}
+	txParam, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("multisig tx is invalid"))
+	}
+	multiSigTx, rpcErr := decodeMultiSigTx(txParam)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	numParts := len(multiSigTx.parts)
+	signers, nonceCommitments, rpcErr := parseMultiSigSignerValues(arrayParams[2], numParts)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	nonceSigners, nonceBytes, rpcErr := parseMultiSigSignerValues(arrayParams[3], numParts)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if !reflect.DeepEqual(signers, nonceSigners) {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("nonce commitments and nonces must be of the same signers"))
+	}
+	sessionParam, ok := arrayParams[4].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("nonce session is invalid"))
+	}
+
+	signerIndex := multiSigTx.account.IndexOfPublicKey(keySet.PaymentAddress.Pk)
+	position := sort.SearchInts(signers, signerIndex)
+	if signerIndex < 0 || position >= len(signers) || signers[position] != signerIndex {
+		return nil, NewRPCError(ErrCanNotSign, errors.New("private key is not of a signer"))
+	}
+	// session is deleted whatever happens next, its secret nonces are never used twice
+	session := httpServer.takeMultiSigNonceSession(sessionParam)
+	if session == nil {
+		return nil, NewRPCError(ErrCanNotSign, errors.New("nonce session is not found, already used or expired"))
+	}
+	if session.signerIndex != signerIndex || len(session.partHashes) != numParts {
+		return nil, NewRPCError(ErrCanNotSign, errors.New("nonce session is not of this signer and tx"))
+	}
+
+	result := jsonresult.MultiSigPartialSigsResult{
+		SignerIndex: signerIndex,
+		PartialSigs: make([]string, numParts),
+	}
+	for i, part := range multiSigTx.parts {
+		nonces := make([]*privacy.EllipticPoint, len(signers))
+		commitments := make([][]byte, len(signers))
+		for j := range signers {
+			commitments[j] = nonceCommitments[j][i]
+			nonces[j] = new(privacy.EllipticPoint)
+			if len(nonceBytes[j][i]) != privacy.CompressedEllipticPointSize || nonces[j].Decompress(nonceBytes[j][i]) != nil {
+				return nil, NewRPCError(ErrRPCInvalidParams, fmt.Errorf("nonce of signer %d is invalid", signers[j]))
+			}
+		}
+		if !part.Hash().IsEqual(&session.partHashes[i]) {
+			return nil, NewRPCError(ErrCanNotSign, errors.New("nonce session is not of this signer and tx"))
+		}
+		if !nonces[position].IsEqual(session.nonces[i]) {
+			return nil, NewRPCError(ErrCanNotSign, errors.New("nonce of signer does not match nonce session"))
+		}
+
+		data := part.Hash()[:]
+		partialSig, err := multiSigTx.account.PartialSign(data, keySet.PrivateKey, signers, commitments, nonces, session.secretNonces[i])
+		if err != nil {
+			return nil, NewRPCError(ErrCanNotSign, err)
+		}
+		if !multiSigTx.account.VerifyPartialSig(data, signers, nonces, position, partialSig) {
+			return nil, NewRPCError(ErrCanNotSign, errors.New("partial signature is invalid"))
+		}
+		partialSigBytes, err := partialSig.Bytes()
+		if err != nil {
+			return nil, NewRPCError(ErrCanNotSign, err)
+		}
+		result.PartialSigs[i] = base58.Base58Check{}.Encode(partialSigBytes, common.ZeroByte)
+	}
+	return result, nil
+}
+
+/*
+handleCombineMultiSigTransaction - RPC combines partial signatures of signers into a signed multisig tx
+Parameter #1—the unsigned multisig tx
+Parameter #2—partial signatures of all signers: {"signer index": [partial signatures]}
+Result—the signed tx, which is sent by sendtransaction or sendrawprivacycustomtokentransaction
+*/
+func (httpServer *HttpServer) handleCombineMultiSigTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be multisig tx and partial signatures of signers"))
+	}
+	txParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("multisig tx is invalid"))
+	}
+	multiSigTx, rpcErr := decodeMultiSigTx(txParam)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	signers, sigBytes, rpcErr := parseMultiSigSignerValues(arrayParams[1], len(multiSigTx.parts))
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	for i, part := range multiSigTx.parts {
+		partialSigs := make([]*privacy.SchnMultiSig, len(signers))
+		for j := range signers {
+			partialSigs[j] = new(privacy.SchnMultiSig)
+			if len(sigBytes[j][i]) != privacy.CompressedEllipticPointSize+common.BigIntSize || partialSigs[j].SetBytes(sigBytes[j][i]) != nil {
+				return nil, NewRPCError(ErrRPCInvalidParams, fmt.Errorf("partial signature of signer %d is invalid", signers[j]))
+			}
+		}
+		sig, err := multiSigTx.account.CombineSigs(part.Hash()[:], signers, partialSigs)
+		if err != nil {
+			return nil, NewRPCError(ErrCombineMultiSig, err)
+		}
+		part.Sig = sig
+	}
+	result, rpcErr := multiSigTx.toResult()
+	Logger.log.Debugf("handleCombineMultiSigTransaction result: %+v", result)
+	return result, rpcErr
+}
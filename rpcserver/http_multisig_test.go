@@ -0,0 +1,29 @@
+package rpcserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiSigNonceSession(t *testing.T) {
+	httpServer := new(HttpServer)
+	sessionID, err := httpServer.addMultiSigNonceSession(&multiSigNonceSession{signerIndex: 1, createdAt: time.Now()})
+	assert.Nil(t, err)
+	otherID, err := httpServer.addMultiSigNonceSession(&multiSigNonceSession{signerIndex: 1, createdAt: time.Now()})
+	assert.Nil(t, err)
+	assert.NotEqual(t, sessionID, otherID)
+
+	// secret nonces of a session are used only once
+	session := httpServer.takeMultiSigNonceSession(sessionID)
+	assert.NotNil(t, session)
+	assert.Equal(t, 1, session.signerIndex)
+	assert.Nil(t, httpServer.takeMultiSigNonceSession(sessionID))
+	assert.Nil(t, httpServer.takeMultiSigNonceSession("unknown"))
+
+	// expired sessions are not returned
+	expiredID, err := httpServer.addMultiSigNonceSession(&multiSigNonceSession{createdAt: time.Now().Add(-2 * multiSigNonceSessionTimeout)})
+	assert.Nil(t, err)
+	assert.Nil(t, httpServer.takeMultiSigNonceSession(expiredID))
+}
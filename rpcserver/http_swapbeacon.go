@@ -2,34 +2,17 @@ package rpcserver
 
 import (
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/bridgeproof"
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
-	"github.com/pkg/errors"
 )
 
-type swapProof struct {
-	inst []string
-
-	instPath       []string
-	instPathIsLeft []bool
-	instRoot       string
-	blkData        string
-	blkHash        string
-	signerSig      string
-	pubkeys        []string
-	sigIdxs        []int
-	rIdxs          []int
-	r              string
-}
-
 // handleGetBeaconSwapProof returns a proof of a new beacon committee (for a given bridge block height)
 func (httpServer *HttpServer) handleGetBeaconSwapProof(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
 	Logger.log.Infof("handleGetBeaconSwapProof params: %+v", params)
@@ -51,13 +34,13 @@ func (httpServer *HttpServer) handleGetBeaconSwapProof(params interface{}, close
 	}
 
 	// Get proof of instruction on beacon
-	beaconInstProof, err := getBeaconSwapProofOnBeacon(bridgeInstProof.inst, beaconBlocks, db)
+	beaconInstProof, err := getBeaconSwapProofOnBeacon(bridgeInstProof.Inst, beaconBlocks, db)
 	if err != nil {
 		return nil, NewRPCError(ErrUnexpected, err)
 	}
 
 	// Decode instruction to send to Ethereum without having to decode on client
-	decodedInst, err := blockchain.DecodeInstruction(bridgeInstProof.inst)
+	decodedInst, err := blockchain.DecodeInstruction(bridgeInstProof.Inst)
 	if err != nil {
 		return nil, NewRPCError(ErrUnexpected, err)
 	}
@@ -100,76 +83,15 @@ func getBeaconSwapProofOnBridge(
 	bridgeBlock *blockchain.ShardBlock,
 	bc *blockchain.BlockChain,
 	db database.DatabaseInterface,
-) (*swapProof, error) {
+) (*bridgeproof.InstProof, error) {
 	insts := bridgeBlock.Body.Instructions
 	_, instID := findCommSwapInst(insts, metadata.BeaconSwapConfirmMeta)
 	if instID < 0 {
 		return nil, fmt.Errorf("cannot find beacon swap instruction in bridge block")
 	}
 
-	block := &shardBlock{ShardBlock: bridgeBlock}
-	return buildProofForBlock(block, insts, instID, db)
-}
-
-type block interface {
-	SignerPubkeys(database.DatabaseInterface) ([][]byte, []int, error)
-	InstructionMerkleRoot() []byte
-	MetaHash() []byte
-	Hash() []byte
-	R() string
-	Sig() string
-	ValidatorsIdx(int) []int
-}
-
-// buildProofForBlock builds a swapProof for an instruction in a block (beacon or shard)
-func buildProofForBlock(
-	blk block,
-	insts [][]string,
-	id int,
-	db database.DatabaseInterface,
-) (*swapProof, error) {
-	// Get committee pubkey and signature
-	pubkeys, signerIdxs, err := blk.SignerPubkeys(db)
-	if err != nil {
-		return nil, err
-	}
-	signerPubkeys := make([]string, len(pubkeys))
-	for i, pk := range pubkeys {
-		signerPubkeys[i] = hex.EncodeToString(pk)
-	}
-
-	// Build merkle proof for instruction in bridge block
-	instProof := buildInstProof(insts, id)
-
-	// Get meta hash and block hash
-	instRoot := hex.EncodeToString(blk.InstructionMerkleRoot())
-	metaHash := blk.MetaHash()
-	blkHash := blk.Hash()
-
-	// Get sig data
-	r, _, err := base58.Base58Check{}.Decode(blk.R())
-	if err != nil {
-		return nil, err
-	}
-	sig, _, err := base58.Base58Check{}.Decode(blk.Sig())
-	if err != nil {
-		return nil, err
-	}
-	rIdxs := blk.ValidatorsIdx(0)
-
-	return &swapProof{
-		inst:           insts[id],
-		instPath:       instProof.getPath(),
-		instPathIsLeft: instProof.left,
-		instRoot:       instRoot,
-		blkData:        hex.EncodeToString(metaHash[:]),
-		blkHash:        hex.EncodeToString(blkHash[:]),
-		signerSig:      hex.EncodeToString(sig),
-		pubkeys:        signerPubkeys,
-		rIdxs:          rIdxs,
-		sigIdxs:        signerIdxs,
-		r:              hex.EncodeToString(r),
-	}, nil
+	block := &bridgeproof.ShardBlock{ShardBlock: bridgeBlock}
+	return bridgeproof.BuildProofForBlock(block, insts, instID, db)
 }
 
 // getBeaconSwapProofOnBeacon finds in given beacon blocks a beacon committee swap instruction and returns its proof
@@ -177,7 +99,7 @@ func getBeaconSwapProofOnBeacon(
 	inst []string,
 	beaconBlocks []*blockchain.BeaconBlock,
 	db database.DatabaseInterface,
-) (*swapProof, error) {
+) (*bridgeproof.InstProof, error) {
 	// Get beacon block and check if it contains beacon swap instruction
 	b, instID := findBeaconBlockWithInst(beaconBlocks, inst)
 	if b == nil {
@@ -185,8 +107,8 @@ func getBeaconSwapProofOnBeacon(
 	}
 
 	insts := b.Body.Instructions
-	block := &beaconBlock{BeaconBlock: b}
-	return buildProofForBlock(block, insts, instID, db)
+	block := &bridgeproof.BeaconBlock{BeaconBlock: b}
+	return bridgeproof.BuildProofForBlock(block, insts, instID, db)
 }
 
 // getIncludedBeaconBlocks retrieves all beacon blocks included in a shard block
@@ -245,169 +167,6 @@ func findCommSwapInst(insts [][]string, meta int) ([]string, int) {
 	return nil, -1
 }
 
-type keccak256MerkleProof struct {
-	path [][]byte
-	left []bool
-}
-
-// getPath encodes the path of merkle proof as string and returns
-func (p *keccak256MerkleProof) getPath() []string {
-	path := make([]string, len(p.path))
-	for i, h := range p.path {
-		path[i] = hex.EncodeToString(h)
-	}
-	return path
-}
-
-// buildProof builds a merkle proof for one element in a merkle tree
-func buildProofFromTree(merkles [][]byte, id int) *keccak256MerkleProof {
-	path, left := blockchain.GetKeccak256MerkleProofFromTree(merkles, id)
-	return &keccak256MerkleProof{path: path, left: left}
-}
-
-// buildProof receives a list of data (as bytes) and returns a merkle proof for one element in the list
-func buildProof(data [][]byte, id int) *keccak256MerkleProof {
-	merkles := blockchain.BuildKeccak256MerkleTree(data)
-	BLogger.log.Debugf("BuildProof: %x", merkles[id])
-	BLogger.log.Debugf("BuildProof merkles: %x", merkles)
-	return buildProofFromTree(merkles, id)
-}
-
-// buildInstProof receives a list of instructions (as string) and returns a merkle proof for one instruction in the list
-func buildInstProof(insts [][]string, id int) *keccak256MerkleProof {
-	flattenInsts, err := blockchain.FlattenAndConvertStringInst(insts)
-	if err != nil {
-		BLogger.log.Errorf("Cannot flatten instructions: %+v", err)
-		return nil
-	}
-	BLogger.log.Debugf("insts: %v", insts)
-	return buildProof(flattenInsts, id)
-}
-
-type beaconBlock struct {
-	*blockchain.BeaconBlock
-}
-
-func (bb *beaconBlock) InstructionMerkleRoot() []byte {
-	return bb.Header.InstructionMerkleRoot[:]
-}
-
-func (bb *beaconBlock) MetaHash() []byte {
-	h := bb.Header.MetaHash()
-	return h[:]
-}
-
-func (bb *beaconBlock) Hash() []byte {
-	h := bb.Header.Hash()
-	return h[:]
-}
-
-func (bb *beaconBlock) R() string {
-	return bb.BeaconBlock.R
-}
-
-func (bb *beaconBlock) Sig() string {
-	return bb.BeaconBlock.AggregatedSig
-}
-
-// SignerPubkeys finds the pubkeys of all signers of a beacon block
-func (bb *beaconBlock) SignerPubkeys(db database.DatabaseInterface) ([][]byte, []int, error) {
-	// Fetch with height-1 because BestStateBeacon is updated before saving committee to database => new committee is saved instead of the one signing this block
-	commsRaw, err := db.FetchBeaconCommitteeByHeight(bb.Header.Height - 1)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	comm := []string{}
-	err = json.Unmarshal(commsRaw, &comm)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	signerIdxs := bb.ValidatorsIdx(1) // List of signers
-	pubkeys := make([][]byte, len(comm))
-	for i, pkRaw := range comm {
-		pubkey, _, err := base58.Base58Check{}.Decode(pkRaw)
-		if err != nil {
-			return nil, nil, err
-		}
-		pubkeys[i] = pubkey
-	}
-	return pubkeys, signerIdxs, nil
-}
-
-func (bb *beaconBlock) ValidatorsIdx(idx int) []int {
-	return bb.BeaconBlock.ValidatorsIndex[idx]
-}
-
-type shardBlock struct {
-	*blockchain.ShardBlock
-}
-
-func (sb *shardBlock) InstructionMerkleRoot() []byte {
-	return sb.Header.InstructionMerkleRoot[:]
-}
-
-func (sb *shardBlock) MetaHash() []byte {
-	h := sb.Header.MetaHash()
-	return h[:]
-}
-
-func (sb *shardBlock) Hash() []byte {
-	h := sb.Header.Hash()
-	return h[:]
-}
-
-func (sb *shardBlock) R() string {
-	return sb.ShardBlock.R
-}
-
-func (sb *shardBlock) Sig() string {
-	return sb.ShardBlock.AggregatedSig
-}
-
-func (sb *shardBlock) ValidatorsIdx(idx int) []int {
-	return sb.ShardBlock.ValidatorsIndex[idx]
-}
-
-// SignerPubkeys finds the pubkeys of all signers of a shard block
-func (sb *shardBlock) SignerPubkeys(db database.DatabaseInterface) ([][]byte, []int, error) {
-	bridgeID := byte(common.BRIDGE_SHARD_ID)
-	commsRaw, err := db.FetchCommitteeFromShardBestState(bridgeID, sb.Header.Height)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	comm := []string{}
-	err = json.Unmarshal(commsRaw, &comm)
-	if err != nil {
-		return nil, nil, errors.WithStack(err)
-	}
-
-	signerIdxs := sb.ValidatorsIdx(1) // List of signers
-	pubkeys := make([][]byte, len(comm))
-	for i, pkRaw := range comm {
-		pubkey, _, err := base58.Base58Check{}.Decode(pkRaw)
-		if err != nil {
-			return nil, nil, err
-		}
-		pubkeys[i] = pubkey
-	}
-	return pubkeys, signerIdxs, nil
-}
-
-// buildSignersProof builds the merkle proofs for some elements in a list of pubkeys
-func buildSignersProof(pubkeys [][]byte, idxs []int) []*keccak256MerkleProof {
-	merkles := blockchain.BuildKeccak256MerkleTree(pubkeys)
-	BLogger.log.Debugf("pubkeys: %x", pubkeys)
-	BLogger.log.Debugf("merkles: %x", merkles)
-	proofs := make([]*keccak256MerkleProof, len(pubkeys))
-	for i, pid := range idxs {
-		proofs[i] = buildProofFromTree(merkles, pid)
-	}
-	return proofs
-}
-
 // findBeaconBlockWithInst finds a beacon block with a specific instruction and the instruction's index; nil if not found
 func findBeaconBlockWithInst(beaconBlocks []*blockchain.BeaconBlock, inst []string) (*blockchain.BeaconBlock, int) {
 	for _, b := range beaconBlocks {
@@ -429,8 +188,8 @@ func findBeaconBlockWithInst(beaconBlocks []*blockchain.BeaconBlock, inst []stri
 
 func buildProofResult(
 	decodedInst string,
-	beaconInstProof *swapProof,
-	bridgeInstProof *swapProof,
+	beaconInstProof *bridgeproof.InstProof,
+	bridgeInstProof *bridgeproof.InstProof,
 	beaconHeight string,
 	bridgeHeight string,
 ) jsonresult.GetInstructionProof {
@@ -439,26 +198,26 @@ func buildProofResult(
 		BeaconHeight: beaconHeight,
 		BridgeHeight: bridgeHeight,
 
-		BeaconInstPath:       beaconInstProof.instPath,
-		BeaconInstPathIsLeft: beaconInstProof.instPathIsLeft,
-		BeaconInstRoot:       beaconInstProof.instRoot,
-		BeaconBlkData:        beaconInstProof.blkData,
-		BeaconBlkHash:        beaconInstProof.blkHash,
-		BeaconSignerSig:      beaconInstProof.signerSig,
-		BeaconPubkeys:        beaconInstProof.pubkeys,
-		BeaconRIdxs:          beaconInstProof.rIdxs,
-		BeaconSigIdxs:        beaconInstProof.sigIdxs,
-		BeaconR:              beaconInstProof.r,
-
-		BridgeInstPath:       bridgeInstProof.instPath,
-		BridgeInstPathIsLeft: bridgeInstProof.instPathIsLeft,
-		BridgeInstRoot:       bridgeInstProof.instRoot,
-		BridgeBlkData:        bridgeInstProof.blkData,
-		BridgeBlkHash:        bridgeInstProof.blkHash,
-		BridgeSignerSig:      bridgeInstProof.signerSig,
-		BridgePubkeys:        bridgeInstProof.pubkeys,
-		BridgeRIdxs:          bridgeInstProof.rIdxs,
-		BridgeSigIdxs:        bridgeInstProof.sigIdxs,
-		BridgeR:              bridgeInstProof.r,
+		BeaconInstPath:       beaconInstProof.InstPath,
+		BeaconInstPathIsLeft: beaconInstProof.InstPathIsLeft,
+		BeaconInstRoot:       beaconInstProof.InstRoot,
+		BeaconBlkData:        beaconInstProof.BlkData,
+		BeaconBlkHash:        beaconInstProof.BlkHash,
+		BeaconSignerSig:      beaconInstProof.SignerSig,
+		BeaconPubkeys:        beaconInstProof.Pubkeys,
+		BeaconRIdxs:          beaconInstProof.RIdxs,
+		BeaconSigIdxs:        beaconInstProof.SigIdxs,
+		BeaconR:              beaconInstProof.R,
+
+		BridgeInstPath:       bridgeInstProof.InstPath,
+		BridgeInstPathIsLeft: bridgeInstProof.InstPathIsLeft,
+		BridgeInstRoot:       bridgeInstProof.InstRoot,
+		BridgeBlkData:        bridgeInstProof.BlkData,
+		BridgeBlkHash:        bridgeInstProof.BlkHash,
+		BridgeSignerSig:      bridgeInstProof.SignerSig,
+		BridgePubkeys:        bridgeInstProof.Pubkeys,
+		BridgeRIdxs:          bridgeInstProof.RIdxs,
+		BridgeSigIdxs:        bridgeInstProof.SigIdxs,
+		BridgeR:              bridgeInstProof.R,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/blockchain/bridgeproof"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -31,7 +32,7 @@ func (httpServer *HttpServer) handleGetBridgeSwapProof(params interface{}, close
 	}
 
 	// Decode instruction to send to Ethereum without having to decode on client
-	decodedInst, err := blockchain.DecodeInstruction(beaconInstProof.inst)
+	decodedInst, err := blockchain.DecodeInstruction(beaconInstProof.Inst)
 	if err != nil {
 		return nil, NewRPCError(ErrUnexpected, err)
 	}
@@ -45,22 +46,22 @@ func getBridgeSwapProofOnBridge(
 	beaconBlock *blockchain.BeaconBlock,
 	bc *blockchain.BlockChain,
 	db database.DatabaseInterface,
-) (*swapProof, error) {
+) (*bridgeproof.InstProof, error) {
 	// Get bridge block and check if it contains bridge swap instruction
 	b, instID, err := findBridgeBlockWithInst(beaconBlock, bc, db)
 	if err != nil {
 		return nil, err
 	}
 	insts := b.Body.Instructions
-	block := &shardBlock{ShardBlock: b}
-	return buildProofForBlock(block, insts, instID, db)
+	block := &bridgeproof.ShardBlock{ShardBlock: b}
+	return bridgeproof.BuildProofForBlock(block, insts, instID, db)
 }
 
 // getBridgeSwapProofOnBeacon finds in a given beacon block a bridge committee swap instruction and returns its proof
 func getBridgeSwapProofOnBeacon(
 	height uint64,
 	db database.DatabaseInterface,
-) (*swapProof, *blockchain.BeaconBlock, error) {
+) (*bridgeproof.InstProof, *blockchain.BeaconBlock, error) {
 	// Get beacon block
 	beaconBlocks, err := blockchain.FetchBeaconBlockFromHeight(db, height, height)
 	if len(beaconBlocks) == 0 {
@@ -74,8 +75,8 @@ func getBridgeSwapProofOnBeacon(
 	if instID < 0 {
 		return nil, nil, fmt.Errorf("cannot find bridge swap instruction in beacon block")
 	}
-	block := &beaconBlock{BeaconBlock: b}
-	proof, err := buildProofForBlock(block, insts, instID, db)
+	block := &bridgeproof.BeaconBlock{BeaconBlock: b}
+	proof, err := bridgeproof.BuildProofForBlock(block, insts, instID, db)
 	if err != nil {
 		return nil, nil, err
 	}
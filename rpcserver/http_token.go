@@ -0,0 +1,320 @@
+package rpcserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// getTokenInfos returns info of all privacy custom tokens, from registry records if tokens have,
+// otherwise from their tx init token or cross shard data
+func (httpServer *HttpServer) getTokenInfos() (map[common.Hash]*jsonresult.TokenInfo, *RPCError) {
+	registeredTokens, err := httpServer.config.BlockChain.ListPrivacyTokenInfo()
+	if err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	initTxs, crossShardTokens, err := httpServer.config.BlockChain.ListPrivacyCustomToken()
+	if err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+
+	result := make(map[common.Hash]*jsonresult.TokenInfo)
+	for tokenID, info := range registeredTokens {
+		supply, err := httpServer.config.BlockChain.GetPrivacyTokenSupply(tokenID)
+		if err != nil {
+			return nil, NewRPCError(ErrUnexpected, err)
+		}
+		item := new(jsonresult.TokenInfo)
+		item.Init(*info, supply)
+		result[tokenID] = item
+	}
+	for tokenID, tx := range initTxs {
+		if _, ok := result[tokenID]; ok {
+			continue
+		}
+		item := new(jsonresult.TokenInfo)
+		item.InitFromTx(tx)
+		result[tokenID] = item
+	}
+	for tokenID, token := range crossShardTokens {
+		if _, ok := result[tokenID]; ok {
+			continue
+		}
+		item := new(jsonresult.TokenInfo)
+		item.InitFromCrossShard(token)
+		result[tokenID] = item
+	}
+	return result, nil
+}
+
+/*
+handleGetTokenInfo - RPC returns info of a privacy custom token: name, symbol, decimals, description,
+issuer, supply policy and circulating supply
+Parameter #1—token id
+*/
+func (httpServer *HttpServer) handleGetTokenInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be token id"))
+	}
+	tokenIDParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("token id is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDParam)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, err)
+	}
+
+	tokenInfos, rpcErr := httpServer.getTokenInfos()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result, ok := tokenInfos[*tokenID]
+	if !ok {
+		return nil, NewRPCError(ErrTokenNotFound, errors.New("token is not found"))
+	}
+	return result, nil
+}
+
+/*
+handleListTokens - RPC returns info of all privacy custom tokens, sorted by token id
+*/
+func (httpServer *HttpServer) handleListTokens(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	tokenInfos, rpcErr := httpServer.getTokenInfos()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result := jsonresult.ListTokenInfo{Tokens: []jsonresult.TokenInfo{}}
+	for _, tokenInfo := range tokenInfos {
+		result.Tokens = append(result.Tokens, *tokenInfo)
+	}
+	sort.Slice(result.Tokens, func(i, j int) bool {
+		return result.Tokens[i].ID < result.Tokens[j].ID
+	})
+	return result, nil
+}
+
+/*
+handleCreateRawTokenMintTransaction - RPC creates a tx minting more units of a privacy custom token
+with issuer-mintable supply policy, tx must be sent by issuer of token and pays fee by PRV without privacy
+Parameter #1—private key of issuer
+Parameter #2—estimation fee coin per kb
+Parameter #3—token id
+Parameter #4—payment address of receiver, in shard of issuer or not
+Parameter #5—minted amount
+*/
+func (httpServer *HttpServer) handleCreateRawTokenMintTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("params must be issuer private key, fee per kb, token id, receiver and amount"))
+	}
+	senderKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("private key is invalid"))
+	}
+	senderKeySet, err := httpServer.GetKeySetFromPrivateKeyParams(senderKeyParam)
+	if err != nil {
+		return nil, NewRPCError(ErrInvalidSenderPrivateKey, err)
+	}
+	shardIDSender := common.GetShardIDFromLastByte(senderKeySet.PaymentAddress.Pk[len(senderKeySet.PaymentAddress.Pk)-1])
+	estimateFeeCoinPerKb, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("fee per kb is invalid"))
+	}
+	tokenIDParam, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("token id is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDParam)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, err)
+	}
+	receiverParam, ok := arrayParams[3].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("receiver is invalid"))
+	}
+	receiverKey, err := wallet.Base58CheckDeserialize(receiverParam)
+	if err != nil || len(receiverKey.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, NewRPCError(ErrInvalidReceiverPaymentAddress, errors.New("receiver is invalid"))
+	}
+	amount, ok := arrayParams[4].(float64)
+	if !ok || amount <= 0 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("amount is invalid"))
+	}
+
+	info, err := httpServer.config.BlockChain.GetPrivacyTokenInfo(*tokenID)
+	if err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	if info == nil {
+		return nil, NewRPCError(ErrTokenNotFound, errors.New("token is not registered"))
+	}
+	if info.Mintable || info.SupplyPolicy != common.TokenSupplyIssuerMintable {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("token is not mintable by issuer"))
+	}
+	if !bytes.Equal(info.IssuerKey, senderKeySet.PaymentAddress.Pk) {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("private key is not of token issuer"))
+	}
+
+	receiver := &privacy.PaymentInfo{
+		PaymentAddress: receiverKey.KeySet.PaymentAddress,
+		Amount:         uint64(amount),
+	}
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:     tokenID.String(),
+		PropertyName:   info.Name,
+		PropertySymbol: info.Symbol,
+		Amount:         receiver.Amount,
+		TokenTxType:    transaction.CustomTokenInit,
+		Receiver:       []*privacy.PaymentInfo{receiver},
+		Mintable:       true,
+	}
+	meta, _ := metadata.NewTokenMintRequest(*tokenID, receiver.Amount, receiver.PaymentAddress, metadata.TokenMintRequestMeta)
+
+	// issuer is identified by input coins of PRV part, so fee is paid without privacy
+	inputCoins, realFeePrv, rpcErr := httpServer.chooseOutsCoinByKeyset(nil, int64(estimateFeeCoinPerKb), 0, senderKeySet,
+		shardIDSender, false, meta, nil, tokenParams, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if len(inputCoins) == 0 {
+		return nil, NewRPCError(ErrGetOutputCoin, errors.New("tx minting token must pay fee by PRV"))
+	}
+	tx := &transaction.TxCustomTokenPrivacy{}
+	err = tx.Init(
+		transaction.NewTxPrivacyTokenInitParams(&senderKeySet.PrivateKey,
+			nil,
+			inputCoins,
+			realFeePrv,
+			tokenParams,
+			*httpServer.config.Database,
+			meta,
+			false,
+			false,
+			shardIDSender))
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+
+	byteArrays, err := json.Marshal(tx)
+	if err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+		ShardID:         shardIDSender,
+	}
+	return result, nil
+}
+
+/*
+handleCreateAndSendTokenMintTransaction - RPC creates and sends a tx minting more units of a privacy custom token,
+parameters are the same as createrawtokenminttransaction
+*/
+func (httpServer *HttpServer) handleCreateAndSendTokenMintTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	data, rpcErr := httpServer.handleCreateRawTokenMintTransaction(params, closeChan)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	newParam := []interface{}{tx.Base58CheckData}
+	return httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+}
+
+/*
+handleCreateRawTokenBurnTransaction - RPC creates a tx burning units of a privacy custom token,
+burned units are sent to burning address without privacy and subtracted from supply of token
+Parameter #1—private key of burner
+Parameter #2—estimation fee coin per kb
+Parameter #3—token id
+Parameter #4—burned amount
+*/
+func (httpServer *HttpServer) handleCreateRawTokenBurnTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 4 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("params must be private key, fee per kb, token id and amount"))
+	}
+	estimateFeeCoinPerKb, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("fee per kb is invalid"))
+	}
+	tokenIDParam, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("token id is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDParam)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, err)
+	}
+	amount, ok := arrayParams[3].(float64)
+	if !ok || amount <= 0 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("amount is invalid"))
+	}
+
+	tokenInfos, rpcErr := httpServer.getTokenInfos()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tokenInfo, ok := tokenInfos[*tokenID]
+	if !ok {
+		return nil, NewRPCError(ErrTokenNotFound, errors.New("token is not found"))
+	}
+	if tokenInfo.SupplyPolicy == jsonresult.TokenSupplyPolicyBridge {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("bridge token must be burned by burning request"))
+	}
+
+	tokenParamsRaw := map[string]interface{}{
+		"TokenID":     tokenID.String(),
+		"TokenName":   tokenInfo.Name,
+		"TokenSymbol": tokenInfo.Symbol,
+		"TokenTxType": float64(transaction.CustomTokenTransfer),
+		"TokenAmount": float64(0),
+		"TokenFee":    float64(0),
+		"TokenReceivers": map[string]interface{}{
+			common.BurningAddress: amount,
+		},
+	}
+	meta, _ := metadata.NewTokenBurnRequest(*tokenID, uint64(amount), metadata.TokenBurnRequestMeta)
+	// burned coins must be public to be proved
+	newParams := []interface{}{arrayParams[0], nil, estimateFeeCoinPerKb, float64(0), tokenParamsRaw, float64(0)}
+	tx, rpcErr := httpServer.buildRawPrivacyCustomTokenTransaction(newParams, meta)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	byteArrays, err := json.Marshal(tx)
+	if err != nil {
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+		ShardID:         common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte()),
+	}
+	return result, nil
+}
+
+/*
+handleCreateAndSendTokenBurnTransaction - RPC creates and sends a tx burning units of a privacy custom token,
+parameters are the same as createrawtokenburntransaction
+*/
+func (httpServer *HttpServer) handleCreateAndSendTokenBurnTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	data, rpcErr := httpServer.handleCreateRawTokenBurnTransaction(params, closeChan)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tx := data.(jsonresult.CreateTransactionResult)
+	newParam := []interface{}{tx.Base58CheckData}
+	return httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+}
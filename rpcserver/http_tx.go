@@ -11,11 +11,14 @@ import (
 
 	"github.com/incognitochain/incognito-chain/mempool"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -114,6 +117,7 @@ func (httpServer *HttpServer) handleListOutputCoins(params interface{}, closeCha
 				CoinCommitment: base58.Base58Check{}.Encode(outCoin.CoinDetails.GetCoinCommitment().Compress(), common.ZeroByte),
 				Randomness:     base58.Base58Check{}.Encode(outCoin.CoinDetails.GetRandomness().Bytes(), common.ZeroByte),
 				SNDerivator:    base58.Base58Check{}.Encode(outCoin.CoinDetails.GetSNDerivator().Bytes(), common.ZeroByte),
+				Memo:           decryptMemo(outCoin.CoinDetails, keySet.ReadonlyKey),
 			})
 		}
 		result.Outputs[readonlyKeyStr] = item
@@ -170,43 +174,51 @@ func (httpServer *HttpServer) handleSendRawTransaction(params interface{}, close
 		return nil, NewRPCError(ErrSendTxData, err)
 	}
 
-	hash, _, err := httpServer.config.TxMemPool.MaybeAcceptTransaction(&tx)
-	//httpServer.config.NetSync.HandleCacheTxHash(*tx.Hash())
+	if rpcErr := sendTx(&httpServer.config, &tx, wire.CmdTx); rpcErr != nil {
+		Logger.log.Errorf("handleSendRawTransaction result: %+v, err: %+v", nil, rpcErr)
+		return nil, rpcErr
+	}
+	Logger.log.Debugf("New transaction hash: %+v \n", *tx.Hash())
+
+	txID := tx.Hash().String()
+	result := jsonresult.CreateTransactionResult{
+		TxID:    txID,
+		ShardID: common.GetShardIDFromLastByte(tx.PubKeyLastByteSender),
+	}
+	Logger.log.Debugf("\n\n\n\n\n\nhandleSendRawTransaction result: %+v\n\n\n\n\n", result)
+	return result, nil
+}
+
+// sendTx adds tx to mempool then broadcasts it to all peers in a message of msgCmd,
+// tx is marked as forwarded by pool when it is sent to peers
+func sendTx(config *RpcServerConfig, tx metadata.Transaction, msgCmd string) *RPCError {
+	_, _, err := config.TxMemPool.MaybeAcceptTransaction(tx)
 	if err != nil {
 		mempoolErr, ok := err.(*mempool.MempoolTxError)
-		if ok {
-			if mempoolErr.Code == mempool.ErrCodeMessage[mempool.RejectInvalidFee].Code {
-				Logger.log.Errorf("handleSendRawTransaction result: %+v, err: %+v", nil, err)
-				return nil, NewRPCError(ErrRejectInvalidFee, mempoolErr)
-			}
+		if ok && mempoolErr.Code == mempool.ErrCodeMessage[mempool.RejectInvalidFee].Code {
+			return NewRPCError(ErrRejectInvalidFee, mempoolErr)
 		}
-		Logger.log.Errorf("handleSendRawTransaction result: %+v, err: %+v", nil, err)
-		return nil, NewRPCError(ErrSendTxData, err)
+		return NewRPCError(ErrSendTxData, err)
 	}
 
-	Logger.log.Debugf("New transaction hash: %+v \n", *hash)
-
 	// broadcast Message
-	txMsg, err := wire.MakeEmptyMessage(wire.CmdTx)
+	txMsg, err := wire.MakeEmptyMessage(msgCmd)
 	if err != nil {
-		Logger.log.Errorf("handleSendRawTransaction result: %+v, err: %+v", nil, err)
-		return nil, NewRPCError(ErrSendTxData, err)
+		return NewRPCError(ErrSendTxData, err)
 	}
-
-	txMsg.(*wire.MessageTx).Transaction = &tx
-	err = httpServer.config.Server.PushMessageToAll(txMsg)
-	if err == nil {
-		Logger.log.Infof("handleSendRawTransaction result: %+v, err: %+v", nil, err)
-		httpServer.config.TxMemPool.MarkForwardedTransaction(*tx.Hash())
+	switch msg := txMsg.(type) {
+	case *wire.MessageTx:
+		msg.Transaction = tx
+	case *wire.MessageTxPrivacyToken:
+		msg.Transaction = tx
+	default:
+		return NewRPCError(ErrSendTxData, errors.New("message "+msgCmd+" can not carry tx"))
 	}
-
-	txID := tx.Hash().String()
-	result := jsonresult.CreateTransactionResult{
-		TxID:    txID,
-		ShardID: common.GetShardIDFromLastByte(tx.PubKeyLastByteSender),
+	err = config.Server.PushMessageToAll(txMsg)
+	if err == nil {
+		config.TxMemPool.MarkForwardedTransaction(*tx.Hash())
 	}
-	Logger.log.Debugf("\n\n\n\n\n\nhandleSendRawTransaction result: %+v\n\n\n\n\n", result)
-	return result, nil
+	return nil
 }
 
 /*
@@ -251,8 +263,9 @@ func (httpServer *HttpServer) handleGetMempoolInfo(params interface{}, closeChan
 		result.ListTxs = make([]jsonresult.GetMempoolInfoTx, 0)
 		for _, tx := range listTxsDetail {
 			item := jsonresult.GetMempoolInfoTx{
-				LockTime: tx.GetLockTime(),
-				TxID:     tx.Hash().String(),
+				LockTime:     tx.GetLockTime(),
+				TxID:         tx.Hash().String(),
+				ExpiryHeight: tx.GetExpiryHeight(),
 			}
 			result.ListTxs = append(result.ListTxs, item)
 		}
@@ -365,6 +378,7 @@ func (httpServer *HttpServer) revertTxToResponseObject(tx metadata.Transaction,
 			return nil, NewRPCError(ErrTxTypeInvalid, errors.New("Tx type is invalid"))
 		}
 	}
+	result.ExpiryHeight = tx.GetExpiryHeight()
 	return result, nil
 }
 
@@ -397,7 +411,111 @@ func (httpServer *HttpServer) handleGetTransactionHashByReceiver(params interfac
 	return result, nil
 }
 
-// Get transaction by Hash
+/*
+handleListTransactions - list txs of a shard in chain order, page by page,
+txs of blocks stored before node supports tx indexes are not listed
+Parameter #1—filter: {"ShardID", "FromHeight", "ToHeight", "Type", "MetadataType", "TokenID"}, every field but ShardID is optional
+Parameter #2—cursor (optional)
+Parameter #3—limit (optional)
+*/
+func (httpServer *HttpServer) handleListTransactions(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleListTransactions params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("filter is required"))
+	}
+	filterParam, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("filter is invalid"))
+	}
+	filter := database.TxQueryFilter{}
+	shardID, ok := filterParam["ShardID"].(float64)
+	if !ok || shardID < 0 || int(shardID) >= common.MAX_SHARD_NUMBER {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("ShardID is invalid"))
+	}
+	filter.ShardID = byte(shardID)
+	if fromHeight, ok := filterParam["FromHeight"].(float64); ok {
+		filter.FromHeight = uint64(fromHeight)
+	}
+	if toHeight, ok := filterParam["ToHeight"].(float64); ok {
+		filter.ToHeight = uint64(toHeight)
+	}
+	if txType, ok := filterParam["Type"].(string); ok {
+		filter.Type = txType
+	}
+	if metaType, ok := filterParam["MetadataType"].(float64); ok {
+		filter.MetadataType = int(metaType)
+	}
+	if tokenIDParam, ok := filterParam["TokenID"].(string); ok && tokenIDParam != "" {
+		tokenID, err := common.Hash{}.NewHashFromStr(tokenIDParam)
+		if err != nil {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("TokenID is invalid"))
+		}
+		filter.TokenID = tokenID
+	}
+	return httpServer.listTransactions(filter, arrayParams, 1)
+}
+
+/*
+handleListTransactionsByReceiver - list txs sent to a payment address from any shard, page by page,
+txs of blocks stored before node supports tx indexes are not listed
+Parameter #1—payment address
+Parameter #2—cursor (optional)
+Parameter #3—limit (optional)
+*/
+func (httpServer *HttpServer) handleListTransactionsByReceiver(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleListTransactionsByReceiver params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment address is required"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment address is invalid"))
+	}
+	receiverKey, err := wallet.Base58CheckDeserialize(paymentAddress)
+	if err != nil {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment address is invalid"))
+	}
+	filter := database.TxQueryFilter{
+		Receiver: receiverKey.KeySet.PaymentAddress.Pk,
+	}
+	return httpServer.listTransactions(filter, arrayParams, 1)
+}
+
+// listTransactions returns a page of txs matching filter, cursor and limit are params of request at index and index+1
+func (httpServer *HttpServer) listTransactions(filter database.TxQueryFilter, arrayParams []interface{}, index int) (interface{}, *RPCError) {
+	cursorParam, limit, rpcErr := getPageParams(arrayParams, index)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var cursor []byte
+	if cursorParam != "" {
+		var err error
+		cursor, err = hex.DecodeString(cursorParam)
+		if err != nil {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("cursor is invalid"))
+		}
+	}
+	txs, nextCursor, err := httpServer.config.BlockChain.ListTransactions(filter, cursor, limit)
+	if err != nil {
+		blockchainErr, ok := err.(*blockchain.BlockChainError)
+		if ok && blockchainErr.Code == blockchain.ErrCodeMessage[blockchain.InvalidTxQueryCursorError].Code {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("cursor is invalid"))
+		}
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
+	result := jsonresult.ListTransactionsResult{}
+	result.Init(txs, nextCursor)
+	Logger.log.Debugf("listTransactions result: %+v", result)
+	return result, nil
+}
+
+/*
+handleGetTransactionByHash - get transaction by hash from chain or mempool
+Parameter #1—tx hash
+Parameter #2—readonly key (optional), memos of output coins encrypted for it are decrypted
+*/
 func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
 	Logger.log.Debugf("handleGetTransactionByHash params: %+v", params)
 	arrayParams := common.InterfaceSlice(params)
@@ -410,6 +528,19 @@ func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, clo
 	if !ok {
 		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Tx hash is invalid"))
 	}
+	// param #2: readonly key to decrypt memos
+	var viewingKey *privacy.ViewingKey
+	if len(arrayParams) > 1 && arrayParams[1] != nil {
+		readonlyKeyStr, ok := arrayParams[1].(string)
+		if !ok {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("readonly key is invalid"))
+		}
+		readonlyKey, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+		if err != nil || len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("readonly key is invalid"))
+		}
+		viewingKey = &readonlyKey.KeySet.ReadonlyKey
+	}
 	txHash, _ := common.Hash{}.NewHashFromStr(txHashTemp)
 	Logger.log.Infof("Get Transaction By Hash %+v", *txHash)
 	db := *(httpServer.config.Database)
@@ -425,6 +556,9 @@ func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, clo
 			return nil, errM.(*RPCError)
 		}
 		result.IsInMempool = true
+		if viewingKey != nil {
+			decryptTxMemos(result, tx, *viewingKey)
+		}
 		return result, nil
 	}
 
@@ -437,10 +571,37 @@ func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, clo
 		return nil, err.(*RPCError)
 	}
 	result.IsInBlock = true
+	if viewingKey != nil {
+		decryptTxMemos(result, tx, *viewingKey)
+	}
 	Logger.log.Debugf("handleGetTransactionByHash result: %+v", result)
 	return result, nil
 }
 
+// decryptTxMemos sets memos of output coins of tx which are encrypted for viewing key in the response of tx,
+// output coins of privacy token are added to the response with their memos
+func decryptTxMemos(result *jsonresult.TransactionDetail, tx metadata.Transaction, viewingKey privacy.ViewingKey) {
+	decryptProofMemos := func(proofDetail *jsonresult.ProofDetail, proof *zkp.PaymentProof, version int8) {
+		if !transaction.IsMemoSupported(version) {
+			return
+		}
+		for i, outCoin := range proof.GetOutputCoins() {
+			if outCoin.CoinDetails != nil && i < len(proofDetail.OutputCoins) {
+				proofDetail.OutputCoins[i].Memo = decryptMemo(outCoin.CoinDetails, viewingKey)
+			}
+		}
+	}
+	if result.Proof != nil {
+		decryptProofMemos(&result.ProofDetail, result.Proof, result.Version)
+	}
+	if tokenTx, ok := tx.(*transaction.TxCustomTokenPrivacy); ok && tokenTx.TxTokenPrivacyData.TxNormal.Proof != nil {
+		tokenProof := tokenTx.TxTokenPrivacyData.TxNormal.Proof
+		result.PrivacyCustomTokenProofDetail = &jsonresult.ProofDetail{}
+		result.PrivacyCustomTokenProofDetail.ConvertFromProof(tokenProof)
+		decryptProofMemos(result.PrivacyCustomTokenProofDetail, tokenProof, tokenTx.TxTokenPrivacyData.TxNormal.Version)
+	}
+}
+
 func (self HttpServer) handleGetBlockProducerList(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
 	result := make(map[string]string)
 	// for shardID, bestState := range self.config.BlockChain.BestState {
@@ -1032,6 +1193,8 @@ func (httpServer *HttpServer) handleRandomCommitments(params interface{}, closeC
 		_ = PublicKey.Decompress(PublicKeyBytes)
 		i.CoinDetails.SetPublicKey(PublicKey)
 
+		// info (e.g. an encrypted memo) is kept in coin, it is not a part of coin commitment
+		// so it does not change the commitments chosen for the ring
 		InfoBytes, _, _ := base58.Base58Check{}.Decode(out.Info)
 		i.CoinDetails.SetInfo(InfoBytes)
 
@@ -1054,7 +1217,12 @@ func (httpServer *HttpServer) handleRandomCommitments(params interface{}, closeC
 			return nil, NewRPCError(ErrListCustomTokenNotFound, err)
 		}
 	}
-	commitmentIndexs, myCommitmentIndexs, commitments := httpServer.config.BlockChain.RandomCommitmentsProcess(usableInputCoins, 0, shardIDSender, tokenID)
+	ringSize := httpServer.config.BlockChain.GetMinCommitmentRingSize()
+	commitmentIndexs, myCommitmentIndexs, commitments, err := httpServer.config.BlockChain.RandomCommitmentsProcess(usableInputCoins, ringSize, shardIDSender, tokenID)
+	if err != nil {
+		Logger.log.Debugf("handleRandomCommitments result: %+v, err: %+v", nil, err)
+		return nil, NewRPCError(ErrUnexpected, err)
+	}
 	result := make(map[string]interface{})
 	result["CommitmentIndices"] = commitmentIndexs
 	result["MyCommitmentIndexs"] = myCommitmentIndexs
@@ -1270,27 +1438,12 @@ func (httpServer *HttpServer) handleSendRawPrivacyCustomTokenTransaction(params
 		return nil, NewRPCError(ErrSendTxData, err)
 	}
 
-	hash, _, err := httpServer.config.TxMemPool.MaybeAcceptTransaction(&tx)
-	//httpServer.config.NetSync.HandleCacheTxHash(*tx.Hash())
-	if err != nil {
-		Logger.log.Debugf("handleSendRawPrivacyCustomTokenTransaction result: %+v, err: %+v", nil, err)
-		return nil, NewRPCError(ErrSendTxData, err)
+	if rpcErr := sendTx(&httpServer.config, &tx, wire.CmdPrivacyCustomToken); rpcErr != nil {
+		Logger.log.Debugf("handleSendRawPrivacyCustomTokenTransaction result: %+v, err: %+v", nil, rpcErr)
+		return nil, rpcErr
 	}
+	Logger.log.Debugf("there is hash of transaction: %s\n", tx.Hash().String())
 
-	Logger.log.Debugf("there is hash of transaction: %s\n", hash.String())
-
-	txMsg, err := wire.MakeEmptyMessage(wire.CmdPrivacyCustomToken)
-	if err != nil {
-		Logger.log.Debugf("handleSendRawPrivacyCustomTokenTransaction result: %+v, err: %+v", nil, err)
-		return nil, NewRPCError(ErrSendTxData, err)
-	}
-
-	txMsg.(*wire.MessageTxPrivacyToken).Transaction = &tx
-	err = httpServer.config.Server.PushMessageToAll(txMsg)
-	//Mark forwarded message
-	if err == nil {
-		httpServer.config.TxMemPool.MarkForwardedTransaction(*tx.Hash())
-	}
 	result := jsonresult.CreateTransactionCustomTokenResult{
 		TxID:        tx.Hash().String(),
 		TokenID:     tx.TxTokenPrivacyData.PropertyID.String(),
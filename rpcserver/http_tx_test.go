@@ -0,0 +1,96 @@
+package rpcserver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleRandomCommitmentsKeepsInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "randomcommitments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	server := &HttpServer{config: RpcServerConfig{BlockChain: blockchain.NewBlockChain(&blockchain.Config{DataBase: db}, true)}}
+
+	keySet := new(incognitokey.KeySet).GenerateKey([]byte{1})
+	shardID := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+	decoys := make([][]byte, 0)
+	for i := 0; i < 2*privacy.CommitmentRingSize; i++ {
+		decoys = append(decoys, privacy.PedCom.G[0].ScalarMult(privacy.RandScalar()).Compress())
+	}
+	assert.Nil(t, db.StoreCommitments(common.PRVCoinID, []byte("decoys"), decoys, shardID))
+
+	coin := new(privacy.Coin).Init()
+	coin.SetPublicKey(privacy.PedCom.G[0].ScalarMult(privacy.RandScalar()))
+	coin.SetValue(10)
+	coin.SetSNDerivator(privacy.RandScalar())
+	coin.SetRandomness(privacy.RandScalar())
+	assert.Nil(t, coin.CommitAll())
+	commitment := coin.GetCoinCommitment().Compress()
+	assert.Nil(t, db.StoreCommitments(common.PRVCoinID, coin.GetPublicKey().Compress(), [][]byte{commitment}, shardID))
+	assert.Nil(t, coin.EncryptMemo([]byte("payment id"), keySet.PaymentAddress.Tk))
+
+	paymentAddress := (&wallet.KeyWallet{KeySet: *keySet}).Base58CheckSerialize(wallet.PaymentAddressType)
+	for _, info := range [][]byte{nil, coin.GetInfo()} {
+		outputs := []interface{}{map[string]interface{}{
+			"PublicKey":      base58.Base58Check{}.Encode(coin.GetPublicKey().Compress(), common.ZeroByte),
+			"CoinCommitment": base58.Base58Check{}.Encode(commitment, common.ZeroByte),
+			"SNDerivator":    base58.Base58Check{}.Encode(coin.GetSNDerivator().Bytes(), common.ZeroByte),
+			"Randomness":     base58.Base58Check{}.Encode(coin.GetRandomness().Bytes(), common.ZeroByte),
+			"Value":          "10",
+			"Info":           base58.Base58Check{}.Encode(info, common.ZeroByte),
+		}}
+		res, rpcErr := server.handleRandomCommitments([]interface{}{paymentAddress, outputs}, nil)
+		if !assert.Nil(t, rpcErr) {
+			continue
+		}
+		// info of coin is kept but the ring still hides the commitment of coin
+		result := res.(map[string]interface{})
+		commitments := result["Commitments"].([]string)
+		myIndices := result["MyCommitmentIndexs"].([]uint64)
+		assert.Equal(t, privacy.CommitmentRingSize, len(commitments))
+		if assert.Equal(t, 1, len(myIndices)) {
+			assert.Equal(t, base58.Base58Check{}.Encode(commitment, common.ZeroByte), commitments[myIndices[0]])
+		}
+	}
+}
+
+func TestHandleListTransactionsInvalidCursor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "listtransactions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	server := &HttpServer{config: RpcServerConfig{BlockChain: blockchain.NewBlockChain(&blockchain.Config{DataBase: db}, false)}}
+
+	filter := map[string]interface{}{"ShardID": float64(0)}
+	_, rpcErr := server.handleListTransactions([]interface{}{filter}, nil)
+	assert.Nil(t, rpcErr)
+	// cursor which is not of filter is an invalid param, not an unexpected error
+	_, rpcErr = server.handleListTransactions([]interface{}{filter, "00"}, nil)
+	if assert.NotNil(t, rpcErr) {
+		assert.Equal(t, ErrCodeMessage[ErrRPCInvalidParams].code, rpcErr.Code)
+	}
+}
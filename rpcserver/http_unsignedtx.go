@@ -0,0 +1,179 @@
+package rpcserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+/*
+PRV of a cold wallet is spent without giving its private key to the node:
+1. createunsignedtransaction builds an unsigned tx template with payment address and readonly key of sender
+2. the template is signed on an offline device holding the private key (see signtransaction command of incognitoctl)
+3. the signed tx is sent by sendtransaction, which verifies it and broadcasts it
+*/
+
+/*
+handleCreateUnsignedTransaction - RPC creates an unsigned tx template sending PRV
+Parameter #1—payment address of sender
+Parameter #2—readonly key of sender
+Parameter #3—list of receivers
+Parameter #4—estimation fee nano P per kb
+Parameter #5—hasPrivacy flag for PRV
+Parameter #6—optional expiry beacon height
+Parameter #7—optional list of base58 encoded commitments of coins already spent by sender.
+Node can not detect spent coins without private key, a template spending one of them is rejected when it is sent
+Parameter #8—optional coin selection strategy (see coinselection.go)
+*/
+func (httpServer *HttpServer) handleCreateUnsignedTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	Logger.log.Debugf("handleCreateUnsignedTransaction params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("param must be payment address, readonly key, list of receivers, fee per kb and hasPrivacy flag"))
+	}
+	keySet, rpcErr := getUnsignedTxSenderKeySet(arrayParams[0], arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	shardIDSender := common.GetShardIDFromLastByte(keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1])
+
+	receiversParam, ok := arrayParams[2].(map[string]interface{})
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("list of receivers is invalid"))
+	}
+	paymentInfos := make([]*privacy.PaymentInfo, 0)
+	for paymentAddressStr, receiverParam := range receiversParam {
+		paymentInfo, err := buildPaymentInfo(paymentAddressStr, receiverParam)
+		if err != nil {
+			return nil, err
+		}
+		paymentInfos = append(paymentInfos, paymentInfo)
+	}
+	estimateFeeCoinPerKb, ok := arrayParams[3].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("fee per kb is invalid"))
+	}
+	hasPrivacy, ok := arrayParams[4].(float64)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("hasPrivacy flag is invalid"))
+	}
+	expiryHeight := uint64(0)
+	if len(arrayParams) > 5 && arrayParams[5] != nil {
+		expiryHeightParam, ok := arrayParams[5].(float64)
+		if !ok || expiryHeightParam < 0 {
+			return nil, NewRPCError(ErrRPCInvalidParams, errors.New("Expiry beacon height is invalid"))
+		}
+		expiryHeight = uint64(expiryHeightParam)
+	}
+	spentCommitments := make([][]byte, 0)
+	if len(arrayParams) > 6 && arrayParams[6] != nil {
+		for _, commitmentParam := range common.InterfaceSlice(arrayParams[6]) {
+			commitmentStr, ok := commitmentParam.(string)
+			if !ok {
+				return nil, NewRPCError(ErrRPCInvalidParams, errors.New("spent commitment is invalid"))
+			}
+			commitment, _, err := base58.Base58Check{}.Decode(commitmentStr)
+			if err != nil {
+				return nil, NewRPCError(ErrRPCInvalidParams, err)
+			}
+			spentCommitments = append(spentCommitments, commitment)
+		}
+	}
+	var selector coinSelector = defaultCoinSelector{}
+	if len(arrayParams) > 7 {
+		selector, rpcErr = getCoinSelectorParam(arrayParams[7])
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	outCoins, err := httpServer.config.BlockChain.GetListOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID)
+	if err != nil {
+		return nil, NewRPCError(ErrGetOutputCoin, err)
+	}
+	outCoins = filterSpentCommitmentOutCoins(outCoins, spentCommitments)
+	inputCoins, realFee, rpcErr := httpServer.chooseOutsCoin(outCoins, paymentInfos, int64(estimateFeeCoinPerKb), 0, keySet.PaymentAddress, shardIDSender, hasPrivacy > 0, nil, nil, nil, selector)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	unsignedTx, err := transaction.NewUnsignedTx(keySet.PaymentAddress, paymentInfos, inputCoins, realFee, hasPrivacy > 0, httpServer.config.BlockChain.GetMinCommitmentRingSize(), *httpServer.config.Database)
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+	unsignedTx.ExpiryHeight = expiryHeight
+
+	byteArrays, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return nil, NewRPCError(ErrCreateTxData, err)
+	}
+	result := jsonresult.CreateUnsignedTransactionResult{
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+		Fee:             unsignedTx.Fee,
+		ShardID:         shardIDSender,
+	}
+	for _, coin := range inputCoins {
+		result.InputCommitments = append(result.InputCommitments, base58.Base58Check{}.Encode(coin.CoinDetails.GetCoinCommitment().Compress(), common.ZeroByte))
+	}
+	Logger.log.Debugf("handleCreateUnsignedTransaction result: %+v", result)
+	return result, nil
+}
+
+// getUnsignedTxSenderKeySet builds keyset of sender from its payment address and readonly key,
+// readonly key decrypts output coins of sender and payment address receives change of tx
+func getUnsignedTxSenderKeySet(paymentAddressParam interface{}, readonlyKeyParam interface{}) (*incognitokey.KeySet, *RPCError) {
+	paymentAddressStr, ok := paymentAddressParam.(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment address is invalid"))
+	}
+	paymentAddressKey, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil || len(paymentAddressKey.KeySet.PaymentAddress.Pk) != privacy.CompressedEllipticPointSize {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("payment address is invalid"))
+	}
+	readonlyKeyStr, ok := readonlyKeyParam.(string)
+	if !ok {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("readonly key is invalid"))
+	}
+	readonlyKey, err := wallet.Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil || len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("readonly key is invalid"))
+	}
+	if !bytes.Equal(readonlyKey.KeySet.ReadonlyKey.Pk, paymentAddressKey.KeySet.PaymentAddress.Pk) {
+		return nil, NewRPCError(ErrRPCInvalidParams, errors.New("readonly key does not belong to payment address"))
+	}
+	return &incognitokey.KeySet{
+		PaymentAddress: paymentAddressKey.KeySet.PaymentAddress,
+		ReadonlyKey:    readonlyKey.KeySet.ReadonlyKey,
+	}, nil
+}
+
+// filterSpentCommitmentOutCoins removes output coins whose commitments are in list of spent commitments
+func filterSpentCommitmentOutCoins(outCoins []*privacy.OutputCoin, spentCommitments [][]byte) []*privacy.OutputCoin {
+	if len(spentCommitments) == 0 {
+		return outCoins
+	}
+	remainOutputCoins := make([]*privacy.OutputCoin, 0)
+	for _, outCoin := range outCoins {
+		commitment := outCoin.CoinDetails.GetCoinCommitment().Compress()
+		isSpent := false
+		for _, spentCommitment := range spentCommitments {
+			if bytes.Equal(commitment, spentCommitment) {
+				isSpent = true
+				break
+			}
+		}
+		if !isSpent {
+			remainOutputCoins = append(remainOutputCoins, outCoin)
+		}
+	}
+	return remainOutputCoins
+}
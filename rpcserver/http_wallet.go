@@ -626,7 +626,7 @@ func (httpServer *HttpServer) buildRawDefragmentAccountTransaction(params interf
 			hasPrivacyCoin,
 			*httpServer.config.Database,
 			nil, // use for prv coin -> nil is valid
-			meta))
+			meta).SetRingSize(httpServer.config.BlockChain.GetMinCommitmentRingSize()))
 	// END create tx
 
 	if err != nil {
@@ -14,3 +14,10 @@ type CreateTransactionCustomTokenResult struct {
 	TokenName       string `json:"TokenName"`
 	TokenAmount     uint64 `json:"TokenAmount"`
 }
+
+type CreateUnsignedTransactionResult struct {
+	Base58CheckData  string // base58 encoded template, signed offline with private key of sender
+	Fee              uint64
+	ShardID          byte
+	InputCommitments []string // commitments of coins spent by template, kept by wallet to exclude them from next templates
+}
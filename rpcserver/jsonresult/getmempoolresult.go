@@ -13,8 +13,9 @@ type GetMempoolInfo struct {
 }
 
 type GetMempoolInfoTx struct {
-	TxID     string `json:"TxID"`
-	LockTime int64  `json:"LockTime"`
+	TxID         string `json:"TxID"`
+	LockTime     int64  `json:"LockTime"`
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
 }
 
 type GetRawMempoolResult struct {
@@ -24,3 +25,40 @@ type GetRawMempoolResult struct {
 type GetMempoolEntryResult struct {
 	Tx metadata.Transaction
 }
+
+type MempoolEntryDetail struct {
+	TxID              string `json:"TxID"`
+	Type              string `json:"Type"`
+	MetadataType      int    `json:"MetadataType"`
+	Size              uint64 `json:"Size"`
+	Fee               uint64 `json:"Fee"`
+	FeeToken          uint64 `json:"FeeToken"`
+	FeePerKB          uint64 `json:"FeePerKB"`
+	FeeTokenPerKB     uint64 `json:"FeeTokenPerKB"`
+	Height            uint64 `json:"Height"`
+	StartTime         int64  `json:"StartTime"`
+	Age               int64  `json:"Age"` // seconds since tx entered pool
+	IsForwarded       bool   `json:"IsForwarded"`
+	SerialNumbersHash string `json:"SerialNumbersHash"`
+	Candidate         string `json:"Candidate,omitempty"`
+	InitTokenID       string `json:"InitTokenID,omitempty"`
+}
+
+type ListMempoolEntriesResult struct {
+	Size    int                  `json:"Size"` // number of txs in pool
+	Entries []MempoolEntryDetail `json:"Entries"`
+}
+
+type GetMempoolConflictsResult struct {
+	Conflicts map[string]string `json:"Conflicts"` // serial number -> tx id in pool spending it
+}
+
+type DumpMempoolResult struct {
+	FileName string `json:"FileName"`
+	Count    int    `json:"Count"`
+}
+
+type LoadMempoolResult struct {
+	Accepted []string          `json:"Accepted"`
+	Rejected map[string]string `json:"Rejected"` // tx id -> reason
+}
@@ -0,0 +1,16 @@
+package jsonresult
+
+import "github.com/incognitochain/incognito-chain/blockchain"
+
+// GetSyncStatusResult is sync progress of beacon and shards synced by node, with state of consensus of node
+type GetSyncStatusResult struct {
+	Beacon blockchain.ChainSyncStatus   `json:"Beacon"`
+	Shards []blockchain.ChainSyncStatus `json:"Shards"`
+	// IsSynced is set if beacon and all shards synced by node are synced, node is ready to serve requests
+	IsSynced bool `json:"IsSynced"`
+	// IsConsensusEnabled is set if node has a mining key and mining is enabled
+	IsConsensusEnabled bool `json:"IsConsensusEnabled"`
+	// IsConsensusCaughtUp is set if chains which node takes part in consensus of are caught up
+	IsConsensusCaughtUp bool   `json:"IsConsensusCaughtUp"`
+	Role                string `json:"Role"`
+}
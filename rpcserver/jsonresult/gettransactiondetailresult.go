@@ -28,10 +28,15 @@ type TransactionDetail struct {
 	Metadata               string `json:"Metadata"`
 	CustomTokenData        string `json:"CustomTokenData"`
 	PrivacyCustomTokenData string `json:"PrivacyCustomTokenData"`
+	// output coins of privacy token with decrypted memos, set only when viewing key is supplied
+	PrivacyCustomTokenProofDetail *ProofDetail `json:"PrivacyCustomTokenProofDetail,omitempty"`
 
 	IsInMempool bool `json:"IsInMempool"`
 	IsInBlock   bool `json:"IsInBlock"`
 
+	// last beacon height at which tx can be included in a block, tx in mempool is abandoned after it
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
+
 	Info string `json:"Info"`
 }
 
@@ -101,6 +106,7 @@ func (proofDetail *ProofDetail) ConvertFromProof(proof *zkp.PaymentProof) {
 type CoinDetail struct {
 	CoinDetails          Coin
 	CoinDetailsEncrypted string
+	Memo                 string `json:",omitempty"` // decrypted memo, set only when viewing key is supplied
 }
 
 type Coin struct {
@@ -0,0 +1,47 @@
+package jsonresult
+
+import (
+	"encoding/hex"
+
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+// ListBlocksResult is a page of shard blocks ([]GetBlockResult) or beacon blocks ([]GetBlocksBeaconResult),
+// NextCursor is passed back to get the next page, it is empty on the last page
+type ListBlocksResult struct {
+	Blocks     interface{} `json:"Blocks"`
+	NextCursor string      `json:"NextCursor"`
+}
+
+// ListTransactionsResult is a page of txs in chain order,
+// NextCursor is passed back to get the next page, it is empty on the last page
+type ListTransactionsResult struct {
+	Txs        []ListTransactionResult `json:"Txs"`
+	NextCursor string                  `json:"NextCursor"`
+}
+
+type ListTransactionResult struct {
+	Hash         string `json:"Hash"`
+	ShardID      byte   `json:"ShardID"`
+	BlockHeight  uint64 `json:"BlockHeight"`
+	Index        int    `json:"Index"`
+	Type         string `json:"Type"`
+	MetadataType int    `json:"MetadataType"`
+	TokenID      string `json:"TokenID"`
+}
+
+func (result *ListTransactionsResult) Init(txs []database.TxQueryIndex, nextCursor []byte) {
+	result.Txs = make([]ListTransactionResult, len(txs))
+	result.NextCursor = hex.EncodeToString(nextCursor)
+	for i, tx := range txs {
+		result.Txs[i] = ListTransactionResult{
+			Hash:         tx.TxHash.String(),
+			ShardID:      tx.ShardID,
+			BlockHeight:  tx.BlockHeight,
+			Index:        tx.IndexInBlock,
+			Type:         tx.Type,
+			MetadataType: tx.MetadataType,
+			TokenID:      tx.TokenID.String(),
+		}
+	}
+}
@@ -17,6 +17,7 @@ type OutCoin struct {
 	Randomness     string
 	Value          string
 	Info           string
+	Memo           string `json:",omitempty"` // decrypted memo, set only when viewing key is supplied
 }
 
 func (outcoin *OutCoin) Init(data interface{}) error {
@@ -0,0 +1,21 @@
+package jsonresult
+
+type CreateMultiSigAddressResult struct {
+	MultiSigKey    string
+	PaymentAddress string
+	M              int
+	PublicKeys     []string // public keys of cosigners, signer index of a cosigner is index of its public key
+	ShardID        byte
+}
+
+type MultiSigNoncesResult struct {
+	SignerIndex      int
+	NonceCommitments []string // commitments to nonces, shared with other signers before nonces
+	Nonces           []string // one public nonce per signed part of tx, shared after commitments of all signers are collected
+	Session          string   // id of secret nonces kept by the node for a single signmultisigtransaction
+}
+
+type MultiSigPartialSigsResult struct {
+	SignerIndex int
+	PartialSigs []string // one partial signature per signed part of tx
+}
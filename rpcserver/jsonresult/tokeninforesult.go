@@ -0,0 +1,87 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const (
+	TokenSupplyPolicyFixed          = "fixed"
+	TokenSupplyPolicyIssuerMintable = "issuer-mintable"
+	TokenSupplyPolicyBridge         = "bridge"
+)
+
+type TokenInfo struct {
+	ID           string `json:"ID"`
+	Name         string `json:"Name"`
+	Symbol       string `json:"Symbol"`
+	Image        string `json:"Image"`
+	Decimals     uint8  `json:"Decimals"`
+	Description  string `json:"Description"`
+	IssuerKey    string `json:"IssuerKey"` // base58 check encoded public key of issuer
+	SupplyPolicy string `json:"SupplyPolicy"`
+	InitAmount   uint64 `json:"InitAmount"`
+	Supply       uint64 `json:"Supply"` // circulating supply, issued minus burned units
+	ShardID      byte   `json:"ShardID"`
+	InitTx       string `json:"InitTx"`
+	IsRegistered bool   `json:"IsRegistered"` // false if token has no registry record, info is from its tx init token
+}
+
+func getTokenSupplyPolicy(supplyPolicy byte, mintable bool) string {
+	if mintable {
+		return TokenSupplyPolicyBridge
+	}
+	if supplyPolicy == common.TokenSupplyIssuerMintable {
+		return TokenSupplyPolicyIssuerMintable
+	}
+	return TokenSupplyPolicyFixed
+}
+
+func (tokenInfo *TokenInfo) Init(info lvdb.PrivacyTokenInfo, supply uint64) {
+	tokenInfo.ID = info.TokenID.String()
+	tokenInfo.Name = info.Name
+	tokenInfo.Symbol = info.Symbol
+	tokenInfo.Image = common.Render(info.TokenID[:])
+	tokenInfo.Decimals = info.Decimals
+	tokenInfo.Description = info.Description
+	if len(info.IssuerKey) > 0 {
+		tokenInfo.IssuerKey = base58.Base58Check{}.Encode(info.IssuerKey, common.ZeroByte)
+	}
+	tokenInfo.SupplyPolicy = getTokenSupplyPolicy(info.SupplyPolicy, info.Mintable)
+	tokenInfo.InitAmount = info.InitAmount
+	tokenInfo.Supply = supply
+	tokenInfo.ShardID = info.ShardID
+	if info.InitTxHash != nil {
+		tokenInfo.InitTx = info.InitTxHash.String()
+	}
+	tokenInfo.IsRegistered = true
+}
+
+func (tokenInfo *TokenInfo) InitFromTx(obj transaction.TxCustomTokenPrivacy) {
+	tokenInfo.ID = obj.TxTokenPrivacyData.PropertyID.String()
+	tokenInfo.Name = obj.TxTokenPrivacyData.PropertyName
+	tokenInfo.Symbol = obj.TxTokenPrivacyData.PropertySymbol
+	tokenInfo.Image = common.Render(obj.TxTokenPrivacyData.PropertyID[:])
+	tokenInfo.SupplyPolicy = getTokenSupplyPolicy(common.TokenSupplyFixed, obj.TxTokenPrivacyData.Mintable)
+	tokenInfo.InitAmount = obj.TxTokenPrivacyData.Amount
+	tokenInfo.Supply = obj.TxTokenPrivacyData.Amount
+	tokenInfo.ShardID = common.GetShardIDFromLastByte(obj.PubKeyLastByteSender)
+	tokenInfo.InitTx = obj.Hash().String()
+}
+
+func (tokenInfo *TokenInfo) InitFromCrossShard(obj blockchain.CrossShardTokenPrivacyMetaData) {
+	tokenInfo.ID = obj.TokenID.String()
+	tokenInfo.Name = obj.PropertyName
+	tokenInfo.Symbol = obj.PropertySymbol
+	tokenInfo.Image = common.Render(obj.TokenID[:])
+	tokenInfo.SupplyPolicy = getTokenSupplyPolicy(common.TokenSupplyFixed, obj.Mintable)
+	tokenInfo.InitAmount = obj.Amount
+	tokenInfo.Supply = obj.Amount
+}
+
+type ListTokenInfo struct {
+	Tokens []TokenInfo `json:"Tokens"`
+}
@@ -6,3 +6,31 @@ type ClosedConnectionResult struct {
 type UnsubcribeResult struct {
 	Message string `json:"Message"`
 }
+
+type TransactionStatusResult struct {
+	TxID         string
+	Status       string
+	ErrorCode    int    `json:",omitempty"` // code of mempool error of rejected tx
+	ErrorMessage string `json:",omitempty"`
+	ReplacedBy   string `json:",omitempty"` // tx which replaces a replaced tx
+	BlockHash    string `json:",omitempty"` // shard block including tx
+	BlockHeight  uint64 `json:",omitempty"`
+	ShardID      byte
+}
+
+// DisconnectedShardBlockResult - shard block reverted from (status disconnected) or inserted to (status connected) chain,
+// txs of a reverted block are returned to mempool or rejected, as reported by subscribetransactionstatus
+type DisconnectedShardBlockResult struct {
+	Hash    string
+	Height  uint64
+	ShardID byte
+	Status  string
+	TxIDs   []string
+}
+
+// DisconnectedBeaconBlockResult - beacon block reverted from (status disconnected) or inserted to (status connected) chain
+type DisconnectedBeaconBlockResult struct {
+	Hash   string
+	Height uint64
+	Status string
+}
@@ -0,0 +1,52 @@
+package rpcserver
+
+import (
+	"github.com/pkg/errors"
+)
+
+/*
+List RPCs (listblocks, listtransactions, listtransactionsbyreceiver) return one page of results at a time.
+A page is requested by optional params cursor and limit: an empty cursor starts from the first result,
+NextCursor of a result is passed as cursor to get the next page and it is empty on the last page
+*/
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// getPageParams returns cursor and limit of a list request, from its params at index and index+1
+func getPageParams(arrayParams []interface{}, index int) (string, int, *RPCError) {
+	cursor := ""
+	if len(arrayParams) > index && arrayParams[index] != nil {
+		cursorParam, ok := arrayParams[index].(string)
+		if !ok {
+			return "", 0, NewRPCError(ErrRPCInvalidParams, errors.New("cursor is invalid"))
+		}
+		cursor = cursorParam
+	}
+	limit := defaultPageLimit
+	if len(arrayParams) > index+1 && arrayParams[index+1] != nil {
+		limitParam, ok := arrayParams[index+1].(float64)
+		if !ok || limitParam < 1 {
+			return "", 0, NewRPCError(ErrRPCInvalidParams, errors.New("limit is invalid"))
+		}
+		limit = int(limitParam)
+		if limit > maxPageLimit {
+			limit = maxPageLimit
+		}
+	}
+	return cursor, limit, nil
+}
+
+// getHeightParam returns an optional block height param at index, 0 when it is absent
+func getHeightParam(arrayParams []interface{}, index int, name string) (uint64, *RPCError) {
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return 0, nil
+	}
+	height, ok := arrayParams[index].(float64)
+	if !ok || height < 0 {
+		return 0, NewRPCError(ErrRPCInvalidParams, errors.Errorf("%s is invalid", name))
+	}
+	return uint64(height), nil
+}
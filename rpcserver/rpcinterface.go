@@ -22,11 +22,17 @@ var HttpHandler = map[string]httpHandler{
 	estimateFeeWithEstimator: (*HttpServer).handleEstimateFeeWithEstimator,
 	getActiveShards:          (*HttpServer).handleGetActiveShards,
 	getMaxShardsNumber:       (*HttpServer).handleGetMaxShardsNumber,
+	getSyncStatus:            (*HttpServer).handleGetSyncStatus,
 	//pool
 	getMiningInfo:               (*HttpServer).handleGetMiningInfo,
 	getRawMempool:               (*HttpServer).handleGetRawMempool,
 	getNumberOfTxsInMempool:     (*HttpServer).handleGetNumberOfTxsInMempool,
 	getMempoolEntry:             (*HttpServer).handleMempoolEntry,
+	listMempoolEntries:          (*HttpServer).handleListMempoolEntries,
+	getMempoolConflicts:         (*HttpServer).handleGetMempoolConflicts,
+	removeMempoolTx:             (*HttpServer).handleRemoveMempoolTx,
+	dumpMempool:                 (*HttpServer).handleDumpMempool,
+	loadMempool:                 (*HttpServer).handleLoadMempool,
 	getShardToBeaconPoolStateV2: (*HttpServer).handleGetShardToBeaconPoolStateV2,
 	getCrossShardPoolStateV2:    (*HttpServer).handleGetCrossShardPoolStateV2,
 	getShardPoolStateV2:         (*HttpServer).handleGetShardPoolStateV2,
@@ -41,6 +47,7 @@ var HttpHandler = map[string]httpHandler{
 	retrieveBlock:       (*HttpServer).handleRetrieveBlock,
 	retrieveBeaconBlock: (*HttpServer).handleRetrieveBeaconBlock,
 	getBlocks:           (*HttpServer).handleGetBlocks,
+	listBlocks:          (*HttpServer).handleListBlocks,
 	getBlockChainInfo:   (*HttpServer).handleGetBlockChainInfo,
 	getBlockCount:       (*HttpServer).handleGetBlockCount,
 	getBlockHash:        (*HttpServer).handleGetBlockHash,
@@ -51,15 +58,25 @@ var HttpHandler = map[string]httpHandler{
 	listOutputCoins:                 (*HttpServer).handleListOutputCoins,
 	createRawTransaction:            (*HttpServer).handleCreateRawTransaction,
 	sendRawTransaction:              (*HttpServer).handleSendRawTransaction,
+	createUnsignedTransaction:       (*HttpServer).handleCreateUnsignedTransaction,
 	createAndSendTransaction:        (*HttpServer).handleCreateAndSendTx,
 	getMempoolInfo:                  (*HttpServer).handleGetMempoolInfo,
 	getTransactionByHash:            (*HttpServer).handleGetTransactionByHash,
 	gettransactionhashbyreceiver:    (*HttpServer).handleGetTransactionHashByReceiver,
+	listTransactions:                (*HttpServer).handleListTransactions,
+	listTransactionsByReceiver:      (*HttpServer).handleListTransactionsByReceiver,
 	createAndSendStakingTransaction: (*HttpServer).handleCreateAndSendStakingTx,
 	randomCommitments:               (*HttpServer).handleRandomCommitments,
 	hasSerialNumbers:                (*HttpServer).handleHasSerialNumbers,
 	hasSnDerivators:                 (*HttpServer).handleHasSnDerivators,
 	listSerialNumbers:               (*HttpServer).handleListSerialNumbers,
+	// multisig account
+	createMultiSigAddress:                 (*HttpServer).handleCreateMultiSigAddress,
+	createMultiSigTransaction:             (*HttpServer).handleCreateMultiSigTransaction,
+	createMultiSigPrivacyTokenTransaction: (*HttpServer).handleCreateMultiSigPrivacyTokenTransaction,
+	createMultiSigNonces:                  (*HttpServer).handleCreateMultiSigNonces,
+	signMultiSigTransaction:               (*HttpServer).handleSignMultiSigTransaction,
+	combineMultiSigTransaction:            (*HttpServer).handleCombineMultiSigTransaction,
 	//======Testing and Benchmark======
 	getAndSendTxsFromFile:   (*HttpServer).handleGetAndSendTxsFromFile,
 	getAndSendTxsFromFileV2: (*HttpServer).handleGetAndSendTxsFromFileV2,
@@ -94,6 +111,13 @@ var HttpHandler = map[string]httpHandler{
 	privacyCustomTokenTxs:                      (*HttpServer).handlePrivacyCustomTokenDetail,
 	getListPrivacyCustomTokenBalance:           (*HttpServer).handleGetListPrivacyCustomTokenBalance,
 	getBalancePrivacyCustomToken:               (*HttpServer).handleGetBalancePrivacyCustomToken,
+	// token registry
+	getTokenInfo:                      (*HttpServer).handleGetTokenInfo,
+	listTokens:                        (*HttpServer).handleListTokens,
+	createRawTokenMintTransaction:     (*HttpServer).handleCreateRawTokenMintTransaction,
+	createAndSendTokenMintTransaction: (*HttpServer).handleCreateAndSendTokenMintTransaction,
+	createRawTokenBurnTransaction:     (*HttpServer).handleCreateRawTokenBurnTransaction,
+	createAndSendTokenBurnTransaction: (*HttpServer).handleCreateAndSendTokenBurnTransaction,
 	// Bridge
 	createIssuingRequest:            (*HttpServer).handleCreateIssuingRequest,
 	sendIssuingRequest:              (*HttpServer).handleSendIssuingRequest,
@@ -127,6 +151,11 @@ var HttpHandler = map[string]httpHandler{
 	//GetNodeStatus
 	enableMining:         (*HttpServer).handleEnableMining,
 	getChainMiningStatus: (*HttpServer).handleGetChainMiningStatus,
+	generateBlocks:       (*HttpServer).handleGenerateBlocks,
+
+	// log
+	setLogLevel:  (*HttpServer).handleSetLogLevel,
+	getLogLevels: (*HttpServer).handleGetLogLevels,
 }
 
 // Commands that are available to a limited user
@@ -151,7 +180,10 @@ var WsHandler = map[string]wsHandler{
 	testSubcrice:                                (*WsServer).handleTestSubcribe,
 	subcribeNewShardBlock:                       (*WsServer).handleSubscribeNewShardBlock,
 	subcribeNewBeaconBlock:                      (*WsServer).handleSubscribeNewBeaconBlock,
+	subcribeDisconnectedShardBlock:              (*WsServer).handleSubscribeDisconnectedShardBlock,
+	subcribeDisconnectedBeaconBlock:             (*WsServer).handleSubscribeDisconnectedBeaconBlock,
 	subcribePendingTransaction:                  (*WsServer).handleSubscribePendingTransaction,
+	subscribeTransactionStatus:                  (*WsServer).handleSubscribeTransactionStatus,
 	subcribeShardCandidateByPublickey:           (*WsServer).handleSubcribeShardCandidateByPublickey,
 	subcribeShardCommitteeByPublickey:           (*WsServer).handleSubcribeShardCommitteeByPublickey,
 	subcribeShardPendingValidatorByPublickey:    (*WsServer).handleSubcribeShardPendingValidatorByPublickey,
@@ -0,0 +1,523 @@
+package rpcserver
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/pkg/errors"
+)
+
+/*
+Every RPC method is registered in rpcMethodSchemas (rpcschema_methods.go) with a JSON Schema for each of its params,
+which are passed by position, and the Go type of its result. Params of a request are checked against the schemas
+before the handler runs, a malformed param is rejected with ErrRPCInvalidParams naming the field in error.
+The schemas are served as an OpenRPC document by method rpc.discover
+*/
+
+const (
+	schemaTypeNull    = "null"
+	schemaTypeBoolean = "boolean"
+	schemaTypeNumber  = "number"
+	schemaTypeInteger = "integer"
+	schemaTypeString  = "string"
+	schemaTypeArray   = "array"
+	schemaTypeObject  = "object"
+
+	openRPCVersion = "1.2.6"
+	// componentsRef is the prefix of references to schemas of named result types in components of OpenRPC document
+	componentsRef = "#/components/schemas/"
+)
+
+// schemaTypes is type keyword of a schema, it is marshalled as a string when there is only one type
+type schemaTypes []string
+
+func (types schemaTypes) MarshalJSON() ([]byte, error) {
+	if len(types) == 1 {
+		return json.Marshal(types[0])
+	}
+	return json.Marshal([]string(types))
+}
+
+// Schema is a JSON Schema of a param or a result, only keywords used by RPC methods are supported
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 schemaTypes        `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+}
+
+func newSchema(schemaType string, description string) *Schema {
+	return &Schema{Type: schemaTypes{schemaType}, Description: description}
+}
+
+func schemaAny(description string) *Schema {
+	return &Schema{Description: description}
+}
+
+func schemaBoolean(description string) *Schema {
+	return newSchema(schemaTypeBoolean, description)
+}
+
+func schemaNumber(description string) *Schema {
+	return newSchema(schemaTypeNumber, description)
+}
+
+func schemaInteger(description string) *Schema {
+	return newSchema(schemaTypeInteger, description)
+}
+
+func schemaString(description string) *Schema {
+	return newSchema(schemaTypeString, description)
+}
+
+func schemaArray(description string, items *Schema) *Schema {
+	schema := newSchema(schemaTypeArray, description)
+	schema.Items = items
+	return schema
+}
+
+// schemaObject describes an object, properties not listed in required are optional
+func schemaObject(description string, properties map[string]*Schema, required ...string) *Schema {
+	schema := newSchema(schemaTypeObject, description)
+	schema.Properties = properties
+	schema.Required = required
+	return schema
+}
+
+// schemaMap describes an object whose keys are chosen by client, every value matches values
+func schemaMap(description string, values *Schema) *Schema {
+	schema := newSchema(schemaTypeObject, description)
+	schema.AdditionalProperties = values
+	return schema
+}
+
+// orNull allows null in place of schema, handlers take null as an absent value
+func (schema *Schema) orNull() *Schema {
+	schema.Type = append(schema.Type, schemaTypeNull)
+	return schema
+}
+
+// or allows values of another type, keywords of the other type are merged into schema
+func (schema *Schema) or(other *Schema) *Schema {
+	schema.Type = append(schema.Type, other.Type...)
+	if other.Properties != nil {
+		schema.Properties = other.Properties
+		schema.Required = other.Required
+	}
+	if other.AdditionalProperties != nil {
+		schema.AdditionalProperties = other.AdditionalProperties
+	}
+	if other.Items != nil {
+		schema.Items = other.Items
+	}
+	return schema
+}
+
+func (schema *Schema) withMinimum(minimum float64) *Schema {
+	schema.Minimum = &minimum
+	return schema
+}
+
+func (schema *Schema) withEnum(values ...interface{}) *Schema {
+	schema.Enum = values
+	return schema
+}
+
+// typeOfValue returns json type of a value decoded by encoding/json
+func typeOfValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return schemaTypeNull
+	case bool:
+		return schemaTypeBoolean
+	case float64:
+		return schemaTypeNumber
+	case string:
+		return schemaTypeString
+	case []interface{}:
+		return schemaTypeArray
+	case map[string]interface{}:
+		return schemaTypeObject
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func (schema *Schema) allowsType(value interface{}) bool {
+	if len(schema.Type) == 0 {
+		return true
+	}
+	valueType := typeOfValue(value)
+	for _, schemaType := range schema.Type {
+		if schemaType == valueType {
+			return true
+		}
+		if schemaType == schemaTypeInteger && valueType == schemaTypeNumber {
+			number := value.(float64)
+			if number == math.Trunc(number) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newParamError is the error of a malformed field of params
+func newParamError(field string, format string, args ...interface{}) *RPCError {
+	rpcErr := NewRPCError(ErrRPCInvalidParams, errors.Errorf("%s %s", field, fmt.Sprintf(format, args...)))
+	rpcErr.Field = field
+	return rpcErr
+}
+
+// validate checks value against schema, field is path of value in params of request, e.g. params[1].TokenID
+func (schema *Schema) validate(value interface{}, field string) *RPCError {
+	if !schema.allowsType(value) {
+		return newParamError(field, "must be %s, not %s", strings.Join(schema.Type, " or "), typeOfValue(value))
+	}
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, enumValue := range schema.Enum {
+			if reflect.DeepEqual(enumValue, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return newParamError(field, "must be one of %v", schema.Enum)
+		}
+	}
+	switch value := value.(type) {
+	case float64:
+		if schema.Minimum != nil && value < *schema.Minimum {
+			return newParamError(field, "must be at least %v", *schema.Minimum)
+		}
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range value {
+				if rpcErr := schema.Items.validate(item, fmt.Sprintf("%s[%d]", field, i)); rpcErr != nil {
+					return rpcErr
+				}
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			propertyValue, ok := value[name]
+			propertySchema := schema.Properties[name]
+			if !ok || propertyValue == nil && propertySchema != nil && !propertySchema.allowsType(nil) {
+				return newParamError(field+"."+name, "is required")
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propertySchema, ok := schema.Properties[key]
+			if !ok {
+				propertySchema = schema.AdditionalProperties
+			}
+			// null of an optional property is taken as absent
+			if propertySchema == nil || value[key] == nil && !propertySchema.allowsType(nil) && !schema.isRequired(key) {
+				continue
+			}
+			if rpcErr := propertySchema.validate(value[key], field+"."+key); rpcErr != nil {
+				return rpcErr
+			}
+		}
+	}
+	return nil
+}
+
+func (schema *Schema) isRequired(name string) bool {
+	for _, required := range schema.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcParam is a param of an RPC method at its position in params of request
+type rpcParam struct {
+	Name     string
+	Schema   *Schema
+	Optional bool
+}
+
+func param(name string, schema *Schema) rpcParam {
+	return rpcParam{Name: name, Schema: schema}
+}
+
+func optionalParam(name string, schema *Schema) rpcParam {
+	return rpcParam{Name: name, Schema: schema, Optional: true}
+}
+
+// rpcMethodSchema describes params and result of an RPC method
+type rpcMethodSchema struct {
+	Summary string
+	Params  []rpcParam
+	// ParamsByValue is set for the few methods taking a single param as params itself instead of an array
+	ParamsByValue bool
+	// Result is a value of the type of result, nil if the type depends on request
+	Result interface{}
+}
+
+// validateParams checks params of a request to method against its schema, methods without schema are not checked
+func validateParams(method string, params interface{}) *RPCError {
+	methodSchema, ok := rpcMethodSchemas[method]
+	if !ok || len(methodSchema.Params) == 0 {
+		return nil
+	}
+	if methodSchema.ParamsByValue {
+		if params == nil && methodSchema.Params[0].Optional {
+			return nil
+		}
+		return methodSchema.Params[0].Schema.validate(params, "params")
+	}
+	// handlers take params which are not an array as no params
+	arrayParams, _ := params.([]interface{})
+	for i, param := range methodSchema.Params {
+		field := fmt.Sprintf("params[%d]", i)
+		if i >= len(arrayParams) {
+			if !param.Optional {
+				return newParamError(field, "(%s) is required", param.Name)
+			}
+			continue
+		}
+		if arrayParams[i] == nil && param.Optional && !param.Schema.allowsType(nil) {
+			continue
+		}
+		if rpcErr := param.Schema.validate(arrayParams[i], field); rpcErr != nil {
+			return rpcErr
+		}
+	}
+	return nil
+}
+
+// schemaGenerator builds schemas of result types, named struct types are put in components and referenced
+type schemaGenerator struct {
+	components map[string]*Schema
+}
+
+var (
+	typeJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	typeBigInt        = reflect.TypeOf(big.Int{})
+	typeRawMessage    = reflect.TypeOf(json.RawMessage{})
+	// types marshalled as base58 check encoded strings by their MarshalJSON
+	base58CheckTypes = map[reflect.Type]bool{
+		reflect.TypeOf(privacy.Coin{}):          true,
+		reflect.TypeOf(privacy.EllipticPoint{}): true,
+	}
+)
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+// schemaOf returns schema of values of type t as encoding/json marshals them, nil t means any value
+func (generator *schemaGenerator) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return schemaAny("")
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == typeBigInt:
+		return schemaInteger("")
+	case t == typeRawMessage:
+		return schemaAny("")
+	case base58CheckTypes[t]:
+		return schemaString("base58 check encoded")
+	case implements(t, typeTextMarshaler):
+		return schemaString("")
+	case implements(t, typeJSONMarshaler) && t.Kind() != reflect.Struct:
+		return schemaAny("")
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return schemaBoolean("")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return schemaInteger("")
+	case reflect.Float32, reflect.Float64:
+		return schemaNumber("")
+	case reflect.String:
+		return schemaString("")
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return schemaString("base64 encoded")
+		}
+		return schemaArray("", generator.schemaOf(t.Elem()))
+	case reflect.Array:
+		return schemaArray("", generator.schemaOf(t.Elem()))
+	case reflect.Map:
+		return schemaMap("", generator.schemaOf(t.Elem()))
+	case reflect.Struct:
+		if t.Name() == "" {
+			return generator.structSchema(t)
+		}
+		name := strings.Replace(t.String(), "*", "", -1)
+		if _, ok := generator.components[name]; !ok {
+			// set before building, so that recursive types refer to it
+			generator.components[name] = nil
+			generator.components[name] = generator.structSchema(t)
+		}
+		return &Schema{Ref: componentsRef + name}
+	default:
+		return schemaAny("")
+	}
+}
+
+// structSchema follows encoding/json: exported fields are properties named by json tag, embedded structs are inlined
+func (generator *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	schema := schemaObject("", map[string]*Schema{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded := generator.structSchema(fieldType)
+			for propertyName, property := range embedded.Properties {
+				if _, ok := schema.Properties[propertyName]; !ok {
+					schema.Properties[propertyName] = property
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = generator.schemaOf(field.Type)
+	}
+	return schema
+}
+
+// openRPCDocument is the OpenRPC document of RPC methods, served by rpc.discover
+type openRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       openRPCInfo       `json:"info"`
+	Methods    []openRPCMethod   `json:"methods"`
+	Components openRPCComponents `json:"components"`
+}
+
+type openRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openRPCMethod struct {
+	Name           string       `json:"name"`
+	Summary        string       `json:"summary,omitempty"`
+	Tags           []openRPCTag `json:"tags,omitempty"`
+	ParamStructure string       `json:"paramStructure"`
+	// ParamsByValue marks methods taking their only param as params itself, not in an array
+	ParamsByValue bool                       `json:"x-params-by-value,omitempty"`
+	Params        []openRPCContentDescriptor `json:"params"`
+	Result        openRPCContentDescriptor   `json:"result"`
+}
+
+type openRPCTag struct {
+	Name string `json:"name"`
+}
+
+type openRPCContentDescriptor struct {
+	Name     string  `json:"name"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type openRPCComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// methodTags returns names of handler tables serving method: http, limited (http, for limited user) and websocket
+func methodTags(method string) []openRPCTag {
+	tags := []openRPCTag{}
+	if _, ok := HttpHandler[method]; ok {
+		tags = append(tags, openRPCTag{Name: "http"})
+	}
+	if _, ok := LimitedHttpHandler[method]; ok {
+		tags = append(tags, openRPCTag{Name: "limited"})
+	}
+	if _, ok := WsHandler[method]; ok {
+		tags = append(tags, openRPCTag{Name: "websocket"})
+	}
+	return tags
+}
+
+// buildOpenRPCDocument describes every registered method, sorted by name
+func buildOpenRPCDocument() openRPCDocument {
+	generator := &schemaGenerator{components: make(map[string]*Schema)}
+	document := openRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info: openRPCInfo{
+			Title:   "Incognito RPC",
+			Version: RpcServerVersion,
+		},
+		Methods: make([]openRPCMethod, 0, len(rpcMethodSchemas)),
+	}
+	for name, methodSchema := range rpcMethodSchemas {
+		method := openRPCMethod{
+			Name:           name,
+			Summary:        methodSchema.Summary,
+			Tags:           methodTags(name),
+			ParamStructure: "by-position",
+			ParamsByValue:  methodSchema.ParamsByValue,
+			Params:         make([]openRPCContentDescriptor, 0, len(methodSchema.Params)),
+			Result: openRPCContentDescriptor{
+				Name:   "result",
+				Schema: generator.schemaOf(reflect.TypeOf(methodSchema.Result)),
+			},
+		}
+		for _, param := range methodSchema.Params {
+			method.Params = append(method.Params, openRPCContentDescriptor{
+				Name:     param.Name,
+				Required: !param.Optional,
+				Schema:   param.Schema,
+			})
+		}
+		document.Methods = append(document.Methods, method)
+	}
+	sort.Slice(document.Methods, func(i, j int) bool {
+		return document.Methods[i].Name < document.Methods[j].Name
+	})
+	document.Components.Schemas = generator.components
+	return document
+}
+
+// rpc.discover is registered here, HttpHandler can not refer to it in its initializer as document is built from HttpHandler
+func init() {
+	HttpHandler[rpcDiscover] = (*HttpServer).handleDiscover
+}
+
+/*
+handleDiscover - RPC returns the OpenRPC document describing params and results of all RPC methods
+*/
+func (httpServer *HttpServer) handleDiscover(params interface{}, closeChan <-chan struct{}) (interface{}, *RPCError) {
+	return buildOpenRPCDocument(), nil
+}
@@ -0,0 +1,1052 @@
+package rpcserver
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Schemas of params shared by several methods, they are functions because modifiers of Schema change it in place
+
+func shardIDParam() rpcParam {
+	return param("shardID", schemaInteger("ID of shard"))
+}
+
+func privateKeyParam() rpcParam {
+	return param("privateKey", schemaString("base58 check encoded private key of sender"))
+}
+
+func paymentAddressParam() rpcParam {
+	return param("paymentAddress", schemaString("base58 check encoded payment address"))
+}
+
+func tokenIDParam() rpcParam {
+	return param("tokenID", schemaString("hex encoded token id"))
+}
+
+func base58TxParam() rpcParam {
+	return param("base58CheckData", schemaString("base58 check encoded signed transaction"))
+}
+
+func receiversSchema() *Schema {
+	receiver := schemaNumber("amount").or(schemaObject("", map[string]*Schema{
+		"Amount": schemaNumber("amount"),
+		"Memo":   schemaString("memo of output coin"),
+	}, "Amount"))
+	return schemaMap("amounts by payment address of receivers", receiver).orNull()
+}
+
+// txParams are the leading params of methods building a PRV transaction
+func txParams() []rpcParam {
+	return []rpcParam{
+		privateKeyParam(),
+		param("receivers", receiversSchema()),
+		param("fee", schemaNumber("fee per kb, -1 means estimated fee")),
+		param("hasPrivacy", schemaNumber("positive for a transaction with privacy")),
+	}
+}
+
+// txParamsWith returns txParams followed by more params of a method
+func txParamsWith(params ...rpcParam) []rpcParam {
+	return append(txParams(), params...)
+}
+
+func createTxParams() []rpcParam {
+	return txParamsWith(
+		optionalParam("expiryHeight", schemaNumber("beacon height after which tx is rejected, 0 means never").withMinimum(0)),
+		optionalParam("coinSelection", schemaString("coin selection strategy").orNull()),
+	)
+}
+
+func tokenReceiversSchema() *Schema {
+	return schemaAny("token amounts by payment address of receivers")
+}
+
+func customTokenParamsSchema(privacy bool) *Schema {
+	properties := map[string]*Schema{
+		"TokenID":           schemaString("hex encoded token id, empty to init a token"),
+		"TokenName":         schemaString(""),
+		"TokenSymbol":       schemaString(""),
+		"TokenTxType":       schemaNumber("0 to init a token, 1 to transfer"),
+		"TokenAmount":       schemaNumber(""),
+		"TokenReceivers":    tokenReceiversSchema(),
+		"TokenDecimals":     schemaNumber("").orNull(),
+		"TokenDescription":  schemaString("").orNull(),
+		"TokenSupplyPolicy": schemaNumber("").orNull(),
+		"TokenIssuer":       schemaAny(""),
+	}
+	required := []string{"TokenID", "TokenName", "TokenSymbol", "TokenTxType", "TokenAmount"}
+	if privacy {
+		properties["TokenFee"] = schemaNumber("fee in token")
+		required = append(required, "TokenFee")
+	}
+	return schemaObject("params of token transaction", properties, required...)
+}
+
+func customTokenTxParams(privacy bool) []rpcParam {
+	params := txParamsWith(param("tokenParams", customTokenParamsSchema(privacy)))
+	if privacy {
+		params = append(params, optionalParam("hasPrivacyToken", schemaNumber("positive for a token transaction with privacy")))
+	}
+	return params
+}
+
+// bridgeTokenTxParams are params of burning and contracting requests, whose token params are filled as privacy token transfer
+func bridgeTokenTxParams(extraProperties map[string]*Schema, extraRequired ...string) []rpcParam {
+	tokenParams := customTokenParamsSchema(true)
+	tokenParams.Properties["TokenReceivers"] = tokenReceiversSchema()
+	for name, property := range extraProperties {
+		tokenParams.Properties[name] = property
+	}
+	tokenParams.Required = append(tokenParams.Required, extraRequired...)
+	return txParamsWith(
+		param("tokenParams", tokenParams),
+		optionalParam("hasPrivacyToken", schemaNumber("privacy mode must be disabled")),
+	)
+}
+
+func metadataParam() rpcParam {
+	return param("metadata", schemaObject("fields of metadata", nil))
+}
+
+func publicKeyParam() rpcParam {
+	return param("publicKey", schemaString("base58 check encoded public key"))
+}
+
+func txHashParam() rpcParam {
+	return param("txHash", schemaString("hex encoded transaction hash"))
+}
+
+func paginationParams() []rpcParam {
+	return []rpcParam{
+		optionalParam("cursor", schemaString("cursor returned by previous page, null for the first page").orNull()),
+		optionalParam("limit", schemaInteger("max number of items of page").withMinimum(1)),
+	}
+}
+
+func accountParams() []rpcParam {
+	return []rpcParam{
+		param("accountName", schemaString("")),
+		param("minConfirmations", schemaNumber("")),
+		param("passPhrase", schemaString("")),
+	}
+}
+
+// rpcMethodSchemas has an entry for every method of HttpHandler, LimitedHttpHandler and WsHandler
+var rpcMethodSchemas = map[string]rpcMethodSchema{
+	rpcDiscover: {
+		Summary: "returns the OpenRPC document of all rpc methods",
+		Result:  openRPCDocument{},
+	},
+
+	// test, profiling
+	testHttpServer: {Summary: "checks rpc server is running"},
+	startProfiling: {Summary: "starts cpu profiling"},
+	stopProfiling:  {Summary: "stops cpu profiling"},
+
+	// node
+	getNodeRole: {
+		Summary: "returns role of node",
+		Result:  "",
+	},
+	getNetworkInfo: {
+		Summary: "returns info of p2p network of node",
+		Result:  jsonresult.GetNetworkInfoResult{},
+	},
+	getConnectionCount: {
+		Summary: "returns number of connected peers",
+		Result:  0,
+	},
+	getAllConnectedPeers: {
+		Summary: "returns connected peers",
+		Result:  struct{ Peers []map[string]string }{},
+	},
+	getInOutMessages: {
+		Summary: "returns inbound and outbound messages of a peer or of all peers",
+		Params:  []rpcParam{optionalParam("peerID", schemaAny("peer id, all peers if it is not a string"))},
+	},
+	getInOutMessageCount: {
+		Summary: "returns number of inbound and outbound messages of a peer or of all peers",
+		Params:  []rpcParam{optionalParam("peerID", schemaAny("peer id, all peers if it is not a string"))},
+	},
+	getAllPeers: {
+		Summary: "returns all known peers",
+		Result:  jsonresult.GetAllPeersResult{},
+	},
+	estimateFee: {
+		Summary: "estimates fee of a transaction",
+		Params: txParamsWith(optionalParam("tokenParams", schemaObject("params of token transaction, fee is estimated for a PRV transaction if it is missing", map[string]*Schema{
+			"Privacy": schemaBoolean("true for a privacy token transaction"),
+		}))),
+		Result: jsonresult.EstimateFeeResult{},
+	},
+	estimateFeeWithEstimator: {
+		Summary: "estimates fee per kb with the fee estimator of a shard",
+		Params: []rpcParam{
+			param("defaultFee", schemaNumber("fee per kb returned if estimator has no data")),
+			param("key", schemaString("payment address or private key deciding the shard")),
+			optionalParam("numBlock", schemaInteger("number of blocks in which tx should be confirmed")),
+			optionalParam("tokenID", schemaString("hex encoded token id, fee is paid in this token").orNull()),
+		},
+		Result: jsonresult.EstimateFeeResult{},
+	},
+	getActiveShards: {
+		Summary: "returns number of active shards",
+		Result:  0,
+	},
+	getMaxShardsNumber: {
+		Summary: "returns max number of shards",
+		Result:  0,
+	},
+	getSyncStatus: {
+		Summary: "returns sync progress of beacon and shards synced by node and whether consensus is caught up",
+		Result:  jsonresult.GetSyncStatusResult{},
+	},
+
+	// pool
+	getMiningInfo: {
+		Summary: "returns mining info of node",
+		Result:  jsonresult.GetMiningInfoResult{},
+	},
+	getRawMempool: {
+		Summary: "returns hashes of transactions in mempool",
+		Result:  jsonresult.GetRawMempoolResult{},
+	},
+	getNumberOfTxsInMempool: {
+		Summary: "returns number of transactions in mempool",
+		Result:  0,
+	},
+	getMempoolEntry: {
+		Summary:       "returns a transaction in mempool",
+		Params:        []rpcParam{param("txHash", schemaString("hex encoded transaction hash"))},
+		ParamsByValue: true,
+		Result:        jsonresult.GetMempoolEntryResult{},
+	},
+	listMempoolEntries: {
+		Summary: "returns transactions in mempool sorted by fee per kb",
+		Params:  []rpcParam{optionalParam("limit", schemaInteger("max number of entries"))},
+		Result:  jsonresult.ListMempoolEntriesResult{},
+	},
+	getMempoolConflicts: {
+		Summary: "returns transactions in mempool spending serial numbers",
+		Params:  []rpcParam{param("serialNumbers", schemaArray("base58 check encoded serial numbers", schemaString("")))},
+		Result:  jsonresult.GetMempoolConflictsResult{},
+	},
+	removeMempoolTx: {
+		Summary: "removes a transaction from mempool",
+		Params:  []rpcParam{txHashParam()},
+		Result:  true,
+	},
+	dumpMempool: {
+		Summary: "writes transactions of mempool to a file",
+		Params:  []rpcParam{param("fileName", schemaString("name of a file in data directory of node, without path separators"))},
+		Result:  jsonresult.DumpMempoolResult{},
+	},
+	loadMempool: {
+		Summary: "adds transactions of a file written by dumpmempool to mempool",
+		Params:  []rpcParam{param("fileName", schemaString("name of a file in data directory of node, without path separators"))},
+		Result:  jsonresult.LoadMempoolResult{},
+	},
+	getShardToBeaconPoolStateV2: {
+		Summary: "returns heights of blocks in shard to beacon pool",
+		Result:  jsonresult.ShardToBeaconPoolResult{},
+	},
+	getCrossShardPoolStateV2: {
+		Summary: "returns heights of blocks in cross shard pool of a shard",
+		Params:  []rpcParam{shardIDParam()},
+		Result:  jsonresult.CrossShardPoolResult{},
+	},
+	getShardPoolStateV2: {
+		Summary: "returns heights of blocks in pool of a shard",
+		Params:  []rpcParam{shardIDParam()},
+	},
+	getBeaconPoolStateV2: {
+		Summary: "returns heights of blocks in beacon pool",
+		Result:  Blocks{},
+	},
+	getShardToBeaconPoolState: {Summary: "returns heights of blocks in shard to beacon pool"},
+	getCrossShardPoolState: {
+		Summary: "returns heights of blocks in cross shard pool of a shard",
+		Params:  []rpcParam{shardIDParam()},
+	},
+	getNextCrossShard: {
+		Summary: "returns height of next cross shard block from a shard to another",
+		Params: []rpcParam{
+			param("fromShardID", schemaInteger("")),
+			param("toShardID", schemaInteger("")),
+			param("startHeight", schemaInteger("")),
+		},
+	},
+	getFeeEstimator: {Summary: "returns fee estimators of shards"},
+
+	// block
+	getBestBlock: {
+		Summary: "returns best blocks of beacon and shards",
+		Result:  jsonresult.GetBestBlockResult{},
+	},
+	getBestBlockHash: {
+		Summary: "returns hashes of best blocks of beacon and shards",
+		Result:  jsonresult.GetBestBlockHashResult{},
+	},
+	retrieveBlock: {
+		Summary: "returns a shard block",
+		Params: []rpcParam{
+			param("hash", schemaString("hex encoded block hash")),
+			param("verbosity", schemaString("0 for raw block, 1 for block with tx hashes, 2 for block with txs")),
+		},
+		Result: jsonresult.GetBlockResult{},
+	},
+	retrieveBeaconBlock: {
+		Summary: "returns a beacon block",
+		Params: []rpcParam{
+			param("hash", schemaString("hex encoded block hash")),
+			param("verbosity", schemaString("")),
+		},
+		Result: jsonresult.GetBlocksBeaconResult{},
+	},
+	getBlocks: {
+		Summary: "returns latest blocks of a shard or beacon",
+		Params: []rpcParam{
+			optionalParam("numBlock", schemaInteger("number of blocks")),
+			optionalParam("shardID", schemaInteger("ID of shard, -1 for beacon")),
+		},
+	},
+	listBlocks: {
+		Summary: "returns a page of blocks of a shard or beacon in a range of heights",
+		Params: append([]rpcParam{
+			param("shardID", schemaInteger("ID of shard, -1 for beacon")),
+			optionalParam("fromHeight", schemaInteger("")),
+			optionalParam("toHeight", schemaInteger("")),
+		}, paginationParams()...),
+		Result: jsonresult.ListBlocksResult{},
+	},
+	getBlockChainInfo: {
+		Summary: "returns info of chain",
+		Result:  jsonresult.GetBlockChainInfoResult{},
+	},
+	getBlockCount: {
+		Summary: "returns number of blocks of a shard or beacon",
+		Params:  []rpcParam{param("chainID", schemaInteger("ID of shard, -1 for beacon"))},
+		Result:  uint64(0),
+	},
+	getBlockHash: {
+		Summary: "returns hash of block of a shard or beacon at a height",
+		Params: []rpcParam{
+			optionalParam("shardID", schemaInteger("ID of shard, -1 for beacon")),
+			optionalParam("height", schemaInteger("")),
+		},
+		Result: "",
+	},
+	checkHashValue: {
+		Summary: "returns whether a hash is of a block, beacon block or transaction",
+		Params:  []rpcParam{param("hash", schemaString("hex encoded hash"))},
+		Result:  jsonresult.HashValueDetail{},
+	},
+	getBlockHeader: {
+		Summary: "returns header of a shard block",
+		Params: []rpcParam{
+			optionalParam("getBy", schemaString("blockhash or blocknum")),
+			optionalParam("block", schemaString("block hash or height")),
+			optionalParam("shardID", schemaInteger("")),
+		},
+		Result: jsonresult.GetHeaderResult{},
+	},
+	getCrossShardBlock: {
+		Summary: "returns cross shard outputs of a shard block",
+		Params: []rpcParam{
+			shardIDParam(),
+			param("blockHeight", schemaInteger("")),
+		},
+		Result: jsonresult.CrossShardDataResult{},
+	},
+
+	// transaction
+	listOutputCoins: {
+		Summary: "returns output coins of keys",
+		Params: []rpcParam{
+			param("min", schemaNumber("unused")),
+			param("max", schemaNumber("unused")),
+			param("keys", schemaArray("", schemaObject("", map[string]*Schema{
+				"ReadonlyKey":    schemaString("base58 check encoded readonly key"),
+				"PaymentAddress": schemaString("base58 check encoded payment address"),
+			}, "ReadonlyKey", "PaymentAddress"))),
+			optionalParam("tokenID", schemaString("hex encoded token id, PRV if it is missing")),
+		},
+		Result: jsonresult.ListOutputCoins{},
+	},
+	createRawTransaction: {
+		Summary: "creates a signed PRV transaction",
+		Params:  createTxParams(),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	sendRawTransaction: {
+		Summary: "sends a transaction created by createtransaction",
+		Params:  []rpcParam{base58TxParam()},
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createUnsignedTransaction: {
+		Summary: "creates an unsigned PRV transaction for an offline signer",
+		Params: []rpcParam{
+			paymentAddressParam(),
+			param("readonlyKey", schemaString("base58 check encoded readonly key of payment address")),
+			param("receivers", receiversSchema()),
+			param("fee", schemaNumber("fee per kb")),
+			param("hasPrivacy", schemaNumber("positive for a transaction with privacy")),
+			optionalParam("expiryHeight", schemaNumber("beacon height after which tx is rejected").withMinimum(0).orNull()),
+			optionalParam("spentCommitments", schemaArray("base58 check encoded commitments of coins already spent", schemaString("")).orNull()),
+			optionalParam("coinSelection", schemaString("coin selection strategy").orNull()),
+		},
+		Result: jsonresult.CreateUnsignedTransactionResult{},
+	},
+	createAndSendTransaction: {
+		Summary: "creates and sends a PRV transaction",
+		Params:  createTxParams(),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	getMempoolInfo: {
+		Summary: "returns info of mempool",
+		Result:  jsonresult.GetMempoolInfo{},
+	},
+	getTransactionByHash: {
+		Summary: "returns a transaction in chain or mempool",
+		Params: []rpcParam{
+			txHashParam(),
+			optionalParam("readonlyKey", schemaString("base58 check encoded readonly key, memos of output coins encrypted for it are decrypted").orNull()),
+		},
+		Result: jsonresult.TransactionDetail{},
+	},
+	gettransactionhashbyreceiver: {
+		Summary: "returns hashes of transactions sent to a payment address",
+		Params:  []rpcParam{paymentAddressParam()},
+	},
+	listTransactions: {
+		Summary: "returns a page of transactions of a shard matching a filter, transactions of blocks stored before node supports transaction indexes are not listed",
+		Params: append([]rpcParam{
+			param("filter", schemaObject("", map[string]*Schema{
+				"ShardID":      schemaInteger(""),
+				"FromHeight":   schemaInteger(""),
+				"ToHeight":     schemaInteger(""),
+				"Type":         schemaString("type of transaction"),
+				"MetadataType": schemaInteger(""),
+				"TokenID":      schemaString("hex encoded token id"),
+			}, "ShardID")),
+		}, paginationParams()...),
+		Result: jsonresult.ListTransactionsResult{},
+	},
+	listTransactionsByReceiver: {
+		Summary: "returns a page of transactions sent to a payment address, transactions of blocks stored before node supports transaction indexes are not listed",
+		Params:  append([]rpcParam{paymentAddressParam()}, paginationParams()...),
+		Result:  jsonresult.ListTransactionsResult{},
+	},
+	createAndSendStakingTransaction: {
+		Summary: "creates and sends a staking transaction",
+		Params:  txParamsWith(param("stakingType", schemaNumber("63 to stake for shard, 64 to stake for beacon"))),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	randomCommitments: {
+		Summary: "returns random commitments to use with output coins of a payment address",
+		Params: []rpcParam{
+			paymentAddressParam(),
+			param("outputs", schemaArray("output coins", schemaAny(""))),
+			optionalParam("tokenID", schemaString("hex encoded token id")),
+		},
+	},
+	hasSerialNumbers: {
+		Summary: "returns whether serial numbers are spent in shard of a payment address",
+		Params: []rpcParam{
+			paymentAddressParam(),
+			param("serialNumbers", schemaArray("base58 check encoded serial numbers", schemaString(""))),
+			optionalParam("tokenID", schemaString("hex encoded token id")),
+		},
+		Result: []bool{},
+	},
+	hasSnDerivators: {
+		Summary: "returns whether serial number derivators are used in shard of a payment address",
+		Params: []rpcParam{
+			paymentAddressParam(),
+			param("snDerivators", schemaArray("base58 check encoded serial number derivators", schemaString(""))),
+			optionalParam("tokenID", schemaString("hex encoded token id")),
+		},
+		Result: []bool{},
+	},
+	listSerialNumbers: {
+		Summary: "returns serial numbers of a token in a shard",
+		Params: []rpcParam{
+			optionalParam("tokenID", schemaString("hex encoded token id")),
+			optionalParam("shardID", schemaInteger("")),
+		},
+	},
+
+	// multisig account
+	createMultiSigAddress: {
+		Summary: "creates an M of N multisig address",
+		Params: []rpcParam{
+			param("m", schemaNumber("number of signatures required")),
+			param("addresses", schemaArray("payment addresses of signers", schemaString(""))),
+		},
+		Result: jsonresult.CreateMultiSigAddressResult{},
+	},
+	createMultiSigTransaction: {
+		Summary: "creates an unsigned PRV transaction of a multisig address",
+		Params: []rpcParam{
+			param("multiSigKey", schemaString("")),
+			param("receivers", receiversSchema()),
+			param("fee", schemaNumber("fee per kb")),
+			optionalParam("expiryHeight", schemaNumber("")),
+			optionalParam("tokenParams", schemaAny("")),
+		},
+	},
+	createMultiSigPrivacyTokenTransaction: {
+		Summary: "creates an unsigned privacy token transaction of a multisig address",
+		Params: []rpcParam{
+			param("multiSigKey", schemaString("")),
+			param("receivers", receiversSchema()),
+			param("fee", schemaNumber("fee per kb")),
+			param("tokenParams", schemaObject("params of token transaction", nil)),
+		},
+		Result: jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	createMultiSigNonces: {
+		Summary: "creates nonces of a signer of a multisig transaction",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("tx", schemaString("multisig transaction")),
+		},
+		Result: jsonresult.MultiSigNoncesResult{},
+	},
+	signMultiSigTransaction: {
+		Summary: "creates partial signatures of a signer of a multisig transaction",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("tx", schemaString("multisig transaction")),
+			param("nonceCommitments", schemaMap("nonce commitments by signer", schemaArray("", schemaString("")))),
+			param("nonces", schemaMap("public nonces by signer", schemaArray("", schemaString("")))),
+			param("session", schemaString("nonce session returned by createmultisignonces, used only once")),
+		},
+		Result: jsonresult.MultiSigPartialSigsResult{},
+	},
+	combineMultiSigTransaction: {
+		Summary: "combines partial signatures into a signed multisig transaction",
+		Params: []rpcParam{
+			param("tx", schemaString("multisig transaction")),
+			param("partialSigs", schemaObject("partial signatures by signer", nil)),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+
+	// testing and benchmark
+	getAndSendTxsFromFile: {
+		Summary: "sends transactions of a test file",
+		Params: []rpcParam{
+			shardIDParam(),
+			param("txType", schemaString("")),
+			param("isSent", schemaBoolean("")),
+			param("interval", schemaNumber("")),
+		},
+		Result: CountResult{},
+	},
+	getAndSendTxsFromFileV2: {
+		Summary: "sends transactions of a test file",
+		Params: []rpcParam{
+			shardIDParam(),
+			param("txType", schemaString("")),
+			param("isSent", schemaBoolean("")),
+			param("interval", schemaNumber("")),
+		},
+		Result: CountResult{},
+	},
+	unlockMempool: {Summary: "unlocks mempool"},
+
+	// best state
+	getCandidateList: {
+		Summary: "returns candidates of beacon and shards",
+		Result:  jsonresult.CandidateListsResult{},
+	},
+	getCommitteeList: {
+		Summary: "returns committees of beacon and shards",
+		Result:  jsonresult.CommitteeListsResult{},
+	},
+	getBlockProducerList: {Summary: "returns block producers of beacon and shards"},
+	getShardBestState: {
+		Summary: "returns best state of a shard",
+		Params:  []rpcParam{shardIDParam()},
+		Result:  blockchain.ShardBestState{},
+	},
+	getBeaconBestState: {
+		Summary: "returns best state of beacon",
+		Result:  blockchain.BeaconBestState{},
+	},
+	getBeaconPoolState: {Summary: "returns heights of blocks in beacon pool"},
+	getShardPoolState: {
+		Summary: "returns heights of blocks in pool of a shard",
+		Params:  []rpcParam{shardIDParam()},
+	},
+	getShardPoolLatestValidHeight: {
+		Summary: "returns height of latest valid block in pool of a shard",
+		Params:  []rpcParam{shardIDParam()},
+		Result:  uint64(0),
+	},
+	canPubkeyStake: {
+		Summary: "returns whether a public key can stake",
+		Params:  []rpcParam{publicKeyParam()},
+		Result:  jsonresult.StakeResult{},
+	},
+	getTotalTransaction: {
+		Summary: "returns number of transactions of a shard",
+		Params:  []rpcParam{shardIDParam()},
+		Result:  jsonresult.TotalTransactionInShard{},
+	},
+
+	// custom token
+	createRawCustomTokenTransaction: {
+		Summary: "creates a signed custom token transaction",
+		Params:  customTokenTxParams(false),
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	sendRawCustomTokenTransaction: {
+		Summary: "sends a transaction created by createrawcustomtokentransaction",
+		Params:  []rpcParam{base58TxParam()},
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	createAndSendCustomTokenTransaction: {
+		Summary: "creates and sends a custom token transaction",
+		Params:  customTokenTxParams(false),
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	listUnspentCustomToken: {
+		Summary: "returns unspent outputs of a custom token of a payment address",
+		Params:  []rpcParam{paymentAddressParam(), tokenIDParam()},
+		Result:  []jsonresult.UnspentCustomToken{},
+	},
+	getBalanceCustomToken: {
+		Summary: "returns balance of a custom token of a payment address",
+		Params:  []rpcParam{paymentAddressParam(), tokenIDParam()},
+		Result:  uint64(0),
+	},
+	listCustomToken: {
+		Summary: "returns custom tokens",
+		Result:  jsonresult.ListCustomToken{},
+	},
+	customTokenTxs: {
+		Summary: "returns transactions of a custom token",
+		Params:  []rpcParam{tokenIDParam()},
+		Result:  jsonresult.CustomToken{},
+	},
+	listCustomTokenHolders: {
+		Summary: "returns holders of a custom token",
+		Params:  []rpcParam{tokenIDParam()},
+	},
+	getListCustomTokenBalance: {
+		Summary: "returns balances of custom tokens of a payment address",
+		Params:  []rpcParam{paymentAddressParam()},
+		Result:  jsonresult.ListCustomTokenBalance{},
+	},
+
+	// privacy custom token
+	createRawPrivacyCustomTokenTransaction: {
+		Summary: "creates a signed privacy custom token transaction",
+		Params:  customTokenTxParams(true),
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	sendRawPrivacyCustomTokenTransaction: {
+		Summary: "sends a transaction created by createrawprivacycustomtokentransaction",
+		Params:  []rpcParam{base58TxParam()},
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	createAndSendPrivacyCustomTokenTransaction: {
+		Summary: "creates and sends a privacy custom token transaction",
+		Params:  customTokenTxParams(true),
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	listPrivacyCustomToken: {
+		Summary: "returns privacy custom tokens",
+		Result:  jsonresult.ListCustomToken{},
+	},
+	privacyCustomTokenTxs: {
+		Summary: "returns transactions of a privacy custom token",
+		Params:  []rpcParam{tokenIDParam()},
+		Result:  jsonresult.CustomToken{},
+	},
+	getListPrivacyCustomTokenBalance: {
+		Summary: "returns balances of privacy custom tokens of a private key",
+		Params:  []rpcParam{privateKeyParam()},
+		Result:  jsonresult.ListCustomTokenBalance{},
+	},
+	getBalancePrivacyCustomToken: {
+		Summary: "returns balance of a privacy custom token of a private key",
+		Params:  []rpcParam{privateKeyParam(), tokenIDParam()},
+		Result:  uint64(0),
+	},
+
+	// token registry
+	getTokenInfo: {
+		Summary: "returns info of a privacy custom token",
+		Params:  []rpcParam{tokenIDParam()},
+		Result:  jsonresult.TokenInfo{},
+	},
+	listTokens: {
+		Summary: "returns info of privacy custom tokens",
+		Result:  jsonresult.ListTokenInfo{},
+	},
+	createRawTokenMintTransaction: {
+		Summary: "creates a signed transaction minting a mintable token, signed by its issuer",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("fee", schemaNumber("fee per kb")),
+			tokenIDParam(),
+			param("receiver", schemaString("payment address of receiver")),
+			param("amount", schemaNumber("")),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTokenMintTransaction: {
+		Summary: "creates and sends a transaction minting a mintable token",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("fee", schemaNumber("fee per kb")),
+			tokenIDParam(),
+			param("receiver", schemaString("payment address of receiver")),
+			param("amount", schemaNumber("")),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createRawTokenBurnTransaction: {
+		Summary: "creates a signed transaction burning a token",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("fee", schemaNumber("fee per kb")),
+			tokenIDParam(),
+			param("amount", schemaNumber("")),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	createAndSendTokenBurnTransaction: {
+		Summary: "creates and sends a transaction burning a token",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("fee", schemaNumber("fee per kb")),
+			tokenIDParam(),
+			param("amount", schemaNumber("")),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+
+	// bridge
+	createIssuingRequest: {
+		Summary: "creates a signed issuing request",
+		Params:  txParamsWith(metadataParam()),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	sendIssuingRequest: {
+		Summary: "sends an issuing request created by createissuingrequest",
+		Params:  []rpcParam{base58TxParam()},
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendIssuingRequest: {
+		Summary: "creates and sends an issuing request",
+		Params:  txParamsWith(metadataParam()),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	createAndSendContractingRequest: {
+		Summary: "creates and sends a contracting request",
+		Params:  bridgeTokenTxParams(nil),
+		Result:  jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	createAndSendBurningRequest: {
+		Summary: "creates and sends a burning request to withdraw a bridge token",
+		Params: bridgeTokenTxParams(map[string]*Schema{
+			"RemoteAddress": schemaString("address on remote chain"),
+		}, "RemoteAddress"),
+		Result: jsonresult.CreateTransactionCustomTokenResult{},
+	},
+	createAndSendTxWithIssuingETHReq: {
+		Summary: "creates and sends an issuing request with a proof of deposit on Ethereum",
+		Params:  txParamsWith(metadataParam()),
+		Result:  jsonresult.CreateTransactionResult{},
+	},
+	checkETHHashIssued: {
+		Summary: "returns whether a deposit on Ethereum is issued",
+		Params: []rpcParam{param("deposit", schemaObject("", map[string]*Schema{
+			"BlockHash": schemaString("hex encoded Ethereum block hash"),
+			"TxIndex":   schemaNumber("index of tx in block"),
+		}, "BlockHash", "TxIndex"))},
+		Result: true,
+	},
+	getAllBridgeTokens: {Summary: "returns bridge tokens"},
+	getETHHeaderByHash: {
+		Summary: "returns header of an Ethereum block",
+		Params:  []rpcParam{param("hash", schemaString("hex encoded Ethereum block hash"))},
+	},
+	getBridgeReqWithStatus: {
+		Summary: "returns status of a bridge request",
+		Params: []rpcParam{param("request", schemaObject("", map[string]*Schema{
+			"TxReqID": schemaString("hex encoded hash of request tx"),
+		}, "TxReqID"))},
+	},
+
+	// wallet
+	getPublicKeyFromPaymentAddress: {
+		Summary: "returns public key of a payment address",
+		Params:  []rpcParam{paymentAddressParam()},
+		Result:  jsonresult.GetPublicKeyFromPaymentAddress{},
+	},
+	defragmentAccount: {
+		Summary: "merges small output coins of an account into one",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("maxValue", schemaNumber("max value of coins to merge")),
+			param("fee", schemaNumber("fee per kb")),
+			param("hasPrivacy", schemaNumber("positive for a transaction with privacy")),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	getStackingAmount: {
+		Summary: "returns amount to stake",
+		Params:  []rpcParam{param("stakingType", schemaNumber("0 for shard, 1 for beacon"))},
+		Result:  uint64(0),
+	},
+	hashToIdenticon: {
+		Summary: "returns identicons of hashes",
+		Params:  []rpcParam{optionalParam("hash", schemaString("hex encoded hash, more hashes may follow"))},
+		Result:  []string{},
+	},
+
+	// Incognito -> Ethereum bridge
+	getBeaconSwapProof: {
+		Summary: "returns proof of swap of beacon committee at a beacon height",
+		Params:  []rpcParam{param("height", schemaNumber("beacon height"))},
+	},
+	getBridgeSwapProof: {
+		Summary: "returns proof of swap of bridge committee at a beacon height",
+		Params:  []rpcParam{param("height", schemaNumber("beacon height"))},
+	},
+	getBurnProof: {
+		Summary: "returns proof of a burning request",
+		Params:  []rpcParam{txHashParam()},
+	},
+
+	// reward
+	CreateRawWithDrawTransaction: {
+		Summary: "creates and sends a transaction withdrawing reward",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("receivers", schemaAny("ignored")),
+			param("fee", schemaNumber("fee per kb")),
+			param("hasPrivacy", schemaNumber("positive for a transaction with privacy")),
+			param("reward", schemaObject("", map[string]*Schema{
+				"TokenID": schemaString("hex encoded id of token of reward"),
+			}, "TokenID")),
+		},
+		Result: jsonresult.CreateTransactionResult{},
+	},
+	getRewardAmount: {
+		Summary: "returns reward amounts of a payment address",
+		Params:  []rpcParam{paymentAddressParam()},
+	},
+	listRewardAmount: {Summary: "returns reward amounts of committee members"},
+
+	// revert
+	revertbeaconchain: {Summary: "reverts best state of beacon to previous block"},
+	revertshardchain: {
+		Summary: "reverts best state of a shard to previous block",
+		Params:  []rpcParam{shardIDParam()},
+	},
+
+	// mining
+	enableMining: {
+		Summary: "enables or disables mining",
+		Params:  []rpcParam{param("enable", schemaBoolean(""))},
+		Result:  true,
+	},
+	getChainMiningStatus: {
+		Summary: "returns mining status of a shard or beacon",
+		Params:  []rpcParam{param("chainID", schemaInteger("ID of shard, -1 for beacon"))},
+		Result:  "",
+	},
+	generateBlocks: {
+		Summary: "generates blocks of a shard or beacon, for test networks",
+		Params: []rpcParam{
+			param("chainID", schemaInteger("ID of shard, -1 for beacon")),
+			param("numBlocks", schemaInteger("")),
+		},
+		Result: []string{},
+	},
+
+	// log
+	setLogLevel: {
+		Summary: "sets log level of a subsystem or all subsystems",
+		Params: []rpcParam{
+			param("level", schemaString("")),
+			optionalParam("subsystem", schemaString("all subsystems if it is missing")),
+		},
+	},
+	getLogLevels: {Summary: "returns log levels of subsystems"},
+
+	// local wallet
+	listAccounts: {
+		Summary: "returns accounts of wallet",
+		Result:  jsonresult.ListAccounts{},
+	},
+	getAccount: {
+		Summary:       "returns account of a payment address",
+		Params:        []rpcParam{paymentAddressParam()},
+		ParamsByValue: true,
+		Result:        "",
+	},
+	getAddressesByAccount: {
+		Summary:       "returns addresses of an account",
+		Params:        []rpcParam{param("accountName", schemaString(""))},
+		ParamsByValue: true,
+		Result:        jsonresult.GetAddressesByAccount{},
+	},
+	getAccountAddress: {
+		Summary:       "returns address of an account, it is created if it does not exist",
+		Params:        []rpcParam{param("accountName", schemaString(""))},
+		ParamsByValue: true,
+		Result:        wallet.KeySerializedData{},
+	},
+	dumpPrivkey: {
+		Summary:       "returns private key of a payment address",
+		Params:        []rpcParam{paymentAddressParam()},
+		ParamsByValue: true,
+		Result:        wallet.KeySerializedData{},
+	},
+	importAccount: {
+		Summary: "imports an account into wallet",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("accountName", schemaString("")),
+			param("passPhrase", schemaString("")),
+		},
+		Result: wallet.KeySerializedData{},
+	},
+	removeAccount: {
+		Summary: "removes an account from wallet",
+		Params: []rpcParam{
+			privateKeyParam(),
+			param("accountName", schemaString("")),
+			param("passPhrase", schemaString("")),
+		},
+		Result: true,
+	},
+	listUnspentOutputCoins: {
+		Summary: "returns unspent output coins of private keys",
+		Params: []rpcParam{
+			param("min", schemaNumber("unused").orNull()),
+			param("max", schemaNumber("unused").orNull()),
+			param("keys", schemaArray("", schemaObject("", map[string]*Schema{
+				"PrivateKey": schemaString("base58 check encoded private key"),
+			}, "PrivateKey"))),
+		},
+		Result: jsonresult.ListOutputCoins{},
+	},
+	getBalance: {
+		Summary: "returns balance of an account, * for all accounts",
+		Params:  accountParams(),
+		Result:  uint64(0),
+	},
+	getBalanceByPrivatekey: {
+		Summary: "returns PRV balance of a private key",
+		Params:  []rpcParam{privateKeyParam()},
+		Result:  uint64(0),
+	},
+	getBalanceByPaymentAddress: {
+		Summary: "returns PRV balance of a payment address",
+		Params:  []rpcParam{paymentAddressParam()},
+		Result:  uint64(0),
+	},
+	getReceivedByAccount: {
+		Summary: "returns amount received by an account",
+		Params:  accountParams(),
+		Result:  uint64(0),
+	},
+	setTxFee: {
+		Summary:       "sets default fee per kb of wallet",
+		Params:        []rpcParam{param("fee", schemaNumber("fee per kb"))},
+		ParamsByValue: true,
+		Result:        true,
+	},
+
+	// websocket subscriptions
+	testSubcrice: {Summary: "test subscription"},
+	subcribeNewShardBlock: {
+		Summary: "notifies new blocks of a shard",
+		Params:  []rpcParam{shardIDParam()},
+	},
+	subcribeNewBeaconBlock: {Summary: "notifies new beacon blocks"},
+	subcribeDisconnectedShardBlock: {
+		Summary: "notifies blocks of a shard removed from chain, and blocks inserted after them in chain order",
+		Params:  []rpcParam{shardIDParam()},
+		Result:  jsonresult.DisconnectedShardBlockResult{},
+	},
+	subcribeDisconnectedBeaconBlock: {
+		Summary: "notifies beacon blocks removed from chain, and blocks inserted after them in chain order",
+		Result:  jsonresult.DisconnectedBeaconBlockResult{},
+	},
+	subcribePendingTransaction: {
+		Summary: "notifies when a transaction is included in a block",
+		Params:  []rpcParam{txHashParam()},
+	},
+	subscribeTransactionStatus: {
+		Summary: "notifies status changes of a transaction",
+		Params:  []rpcParam{txHashParam()},
+		Result:  jsonresult.TransactionStatusResult{},
+	},
+	subcribeShardCandidateByPublickey: {
+		Summary: "notifies when a public key becomes shard candidate",
+		Params:  []rpcParam{publicKeyParam()},
+	},
+	subcribeShardCommitteeByPublickey: {
+		Summary: "notifies when a public key becomes shard committee member",
+		Params:  []rpcParam{publicKeyParam()},
+	},
+	subcribeShardPendingValidatorByPublickey: {
+		Summary: "notifies when a public key becomes shard pending validator",
+		Params:  []rpcParam{publicKeyParam()},
+	},
+	subcribeBeaconCandidateByPublickey: {
+		Summary: "notifies when a public key becomes beacon candidate",
+		Params:  []rpcParam{publicKeyParam()},
+	},
+	subcribeBeaconPendingValidatorByPublickey: {
+		Summary: "notifies when a public key becomes beacon pending validator",
+		Params:  []rpcParam{publicKeyParam()},
+	},
+	subcribeBeaconCommitteeByPublickey: {
+		Summary: "notifies when a public key becomes beacon committee member",
+		Params:  []rpcParam{publicKeyParam()},
+	},
+	subcribeMempoolInfo: {
+		Summary: "notifies info of mempool",
+		Result:  jsonresult.GetMempoolInfo{},
+	},
+	subcribeCrossOutputCoinByPrivateKey: {
+		Summary: "notifies cross shard PRV outputs sent to a private key",
+		Params:  []rpcParam{privateKeyParam()},
+		Result:  jsonresult.CrossOutputCoinResult{},
+	},
+	subcribeCrossCustomTokenByPrivateKey: {
+		Summary: "notifies cross shard custom token outputs sent to a private key",
+		Params:  []rpcParam{privateKeyParam()},
+		Result:  jsonresult.CrossCustomTokenResult{},
+	},
+	subcribeCrossCustomTokenPrivacyByPrivateKey: {
+		Summary: "notifies cross shard privacy custom token outputs sent to a private key",
+		Params:  []rpcParam{privateKeyParam()},
+		Result:  jsonresult.CrossCustomTokenPrivacyResult{},
+	},
+	subcribeShardBestState: {
+		Summary: "notifies best state of a shard",
+		Params:  []rpcParam{shardIDParam()},
+		Result:  blockchain.ShardBestState{},
+	},
+	subcribeBeaconBestState: {
+		Summary: "notifies best state of beacon",
+		Result:  blockchain.BeaconBestState{},
+	},
+	subcribeBeaconPoolBeststate: {Summary: "notifies heights of blocks in beacon pool"},
+	subcribeShardPoolBeststate: {
+		Summary: "notifies heights of blocks in pool of a shard",
+		Params:  []rpcParam{shardIDParam()},
+	},
+}
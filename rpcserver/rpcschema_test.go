@@ -0,0 +1,157 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCMethodSchemasComplete(t *testing.T) {
+	for _, handlers := range []map[string]httpHandler{HttpHandler, LimitedHttpHandler} {
+		for method := range handlers {
+			_, ok := rpcMethodSchemas[method]
+			assert.True(t, ok, "method %s has no schema", method)
+		}
+	}
+	for method := range WsHandler {
+		_, ok := rpcMethodSchemas[method]
+		assert.True(t, ok, "method %s has no schema", method)
+	}
+	for method, methodSchema := range rpcMethodSchemas {
+		assert.NotEmpty(t, methodTags(method), "schema of method %s has no handler", method)
+		if methodSchema.ParamsByValue {
+			assert.Len(t, methodSchema.Params, 1, method)
+		}
+		optional := false
+		for _, param := range methodSchema.Params {
+			assert.False(t, optional && !param.Optional, "required param %s of method %s follows an optional param", param.Name, method)
+			optional = optional || param.Optional
+		}
+	}
+}
+
+func decodeParams(t *testing.T, params string) interface{} {
+	var result interface{}
+	if err := json.Unmarshal([]byte(params), &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestValidateParams(t *testing.T) {
+	tokenParams := `{"TokenID": "", "TokenName": "T", "TokenSymbol": "T", "TokenTxType": 0, "TokenAmount": 100, "TokenFee": 0, "TokenReceivers": {}}`
+	cases := []struct {
+		method string
+		params string
+		field  string
+	}{
+		// methods without params or without schema are not checked
+		{getBlockChainInfo, `""`, ""},
+		{"unknownmethod", `[1]`, ""},
+		{getShardBestState, `[0]`, ""},
+		{getShardBestState, `[]`, "params[0]"},
+		{getShardBestState, `"0"`, "params[0]"},
+		{getShardBestState, `["0"]`, "params[0]"},
+		{getShardBestState, `[0.5]`, "params[0]"},
+		{getShardBestState, `[1, "extra params are allowed"]`, ""},
+		{createRawTransaction, `["key", {"addr": 10}, -1, 0]`, ""},
+		{createRawTransaction, `["key", null, -1, 0, 0, null]`, ""},
+		{createRawTransaction, `["key", {"addr": {"Amount": 10, "Memo": "hi"}}, -1, 0]`, ""},
+		{createRawTransaction, `["key", {"addr": {"Memo": "hi"}}, -1, 0]`, "params[1].addr.Amount"},
+		{createRawTransaction, `["key", {"addr": "10"}, -1, 0]`, "params[1].addr"},
+		{createRawTransaction, `["key", {}, "-1", 0]`, "params[2]"},
+		{createRawTransaction, `["key", {}, -1]`, "params[3]"},
+		{createRawTransaction, `["key", {}, -1, 0, -5]`, "params[4]"},
+		{createRawPrivacyCustomTokenTransaction, `["key", null, -1, 0, ` + tokenParams + `]`, ""},
+		{createRawPrivacyCustomTokenTransaction, `["key", null, -1, 0, ` + strings.Replace(tokenParams, `"TokenID": ""`, `"TokenID": 1`, 1) + `]`, "params[4].TokenID"},
+		{createRawPrivacyCustomTokenTransaction, `["key", null, -1, 0, ` + strings.Replace(tokenParams, `"TokenFee": 0, `, "", 1) + `]`, "params[4].TokenFee"},
+		{createRawPrivacyCustomTokenTransaction, `["key", null, -1, 0, ` + strings.Replace(tokenParams, `"TokenSymbol": "T"`, `"TokenSymbol": null`, 1) + `]`, "params[4].TokenSymbol"},
+		{listBlocks, `[0, 1, 10, null, 5]`, ""},
+		{listBlocks, `[0, 1, 10, null, 0]`, "params[4]"},
+		{getMempoolConflicts, `[["a", 1]]`, "params[0][1]"},
+		{getMempoolEntry, `"hash"`, ""},
+		{getMempoolEntry, `["hash"]`, "params"},
+		{subcribeNewShardBlock, `["0"]`, "params[0]"},
+	}
+	for _, testCase := range cases {
+		rpcErr := validateParams(testCase.method, decodeParams(t, testCase.params))
+		if testCase.field == "" {
+			assert.Nil(t, rpcErr, "%s %s", testCase.method, testCase.params)
+			continue
+		}
+		if assert.NotNil(t, rpcErr, "%s %s", testCase.method, testCase.params) {
+			assert.Equal(t, ErrCodeMessage[ErrRPCInvalidParams].code, rpcErr.Code)
+			assert.Equal(t, testCase.field, rpcErr.Field, "%s %s", testCase.method, testCase.params)
+		}
+	}
+}
+
+// collectRefs returns values of $ref keywords in a decoded json document
+func collectRefs(value interface{}, refs []string) []string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = collectRefs(item, refs)
+		}
+	case []interface{}:
+		for _, item := range value {
+			refs = collectRefs(item, refs)
+		}
+	}
+	return refs
+}
+
+func TestBuildOpenRPCDocument(t *testing.T) {
+	result, rpcErr := (&HttpServer{}).handleDiscover(nil, nil)
+	assert.Nil(t, rpcErr)
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		OpenRPC string `json:"openrpc"`
+		Methods []struct {
+			Name   string
+			Params []struct {
+				Name     string
+				Required bool
+			}
+			Result struct {
+				Schema map[string]interface{}
+			}
+		}
+		Components struct {
+			Schemas map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, openRPCVersion, document.OpenRPC)
+	assert.Len(t, document.Methods, len(rpcMethodSchemas))
+
+	methods := make(map[string]int)
+	for i, method := range document.Methods {
+		methods[method.Name] = i
+	}
+	createTx := document.Methods[methods[createRawTransaction]]
+	assert.Equal(t, "privateKey", createTx.Params[0].Name)
+	assert.True(t, createTx.Params[0].Required)
+	assert.False(t, createTx.Params[4].Required)
+	assert.Equal(t, componentsRef+"jsonresult.CreateTransactionResult", createTx.Result.Schema["$ref"])
+	assert.Contains(t, document.Components.Schemas, "blockchain.ShardBestState")
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range collectRefs(decoded, nil) {
+		assert.Contains(t, document.Components.Schemas, strings.TrimPrefix(ref, componentsRef))
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/incognitochain/incognito-chain/addrmanager"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/connmanager"
+	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/wallet"
@@ -37,6 +38,7 @@ type UsageFlag uint32
 type RpcServer struct {
 	HttpServer *HttpServer
 	WsServer   *WsServer
+	GrpcServer *GrpcServer
 
 	started          int32
 	shutdown         int32
@@ -58,6 +60,7 @@ type RpcServer struct {
 type RpcServerConfig struct {
 	HttpListenters  []net.Listener
 	WsListenters    []net.Listener
+	GrpcListenters  []net.Listener
 	ProtocolVersion string
 	ChainParams     *blockchain.Params
 	BlockChain      *blockchain.BlockChain
@@ -76,6 +79,8 @@ type RpcServerConfig struct {
 		EnableMining(enable bool) error
 		IsEnableMining() bool
 		GetChainMiningStatus(chain int) string
+		SetLogLevel(subsystemID string, logLevel string) error
+		GetLogLevels() map[string]string
 	}
 	TxMemPool         *mempool.TxPool
 	ShardToBeaconPool *mempool.ShardToBeaconPool
@@ -89,15 +94,19 @@ type RpcServerConfig struct {
 	RPCLimitUser string
 	RPCLimitPass string
 	DisableAuth  bool
+	// API keys with their allowed methods and rate limits
+	APIKeys *APIKeyManager
 	// The fee estimator keeps track of how long transactions are left in
 	// the mempool before they are mined into blocks.
 	FeeEstimator map[byte]*mempool.FeeEstimator
 	// IsMiningNode    bool   // flag mining node. True: mining, False: not mining
 	MiningPubKeyB58 string // base58check encode of mining pubkey
 	PubSubManager   *pubsub.PubSubManager
+	ConsensusEngine consensus.ConsensusEngine
 }
 
 func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
+	rpcServer.config = *config
 	if len(config.HttpListenters) > 0 {
 		rpcServer.HttpServer = &HttpServer{}
 		rpcServer.HttpServer.Init(config)
@@ -106,8 +115,15 @@ func (rpcServer *RpcServer) Init(config *RpcServerConfig) {
 		rpcServer.WsServer = &WsServer{}
 		rpcServer.WsServer.Init(config)
 	}
+	if len(config.GrpcListenters) > 0 {
+		rpcServer.GrpcServer = &GrpcServer{}
+		rpcServer.GrpcServer.Init(config)
+	}
 }
 func (rpcServer *RpcServer) Start() {
+	if rpcServer.config.APIKeys != nil {
+		rpcServer.config.APIKeys.Start()
+	}
 	if rpcServer.WsServer != nil {
 		err := rpcServer.WsServer.Start()
 		if err != nil {
@@ -120,14 +136,26 @@ func (rpcServer *RpcServer) Start() {
 			Logger.log.Error(err)
 		}
 	}
+	if rpcServer.GrpcServer != nil {
+		err := rpcServer.GrpcServer.Start()
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
 }
 func (rpcServer *RpcServer) Stop() {
+	if rpcServer.config.APIKeys != nil {
+		rpcServer.config.APIKeys.Stop()
+	}
 	if rpcServer.WsServer != nil {
 		rpcServer.WsServer.Stop()
 	}
 	if rpcServer.HttpServer != nil {
 		rpcServer.HttpServer.Stop()
 	}
+	if rpcServer.GrpcServer != nil {
+		rpcServer.GrpcServer.Stop()
+	}
 }
 
 // RequestedProcessShutdown returns a channel that is sent to when an authorized
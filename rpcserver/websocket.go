@@ -34,6 +34,7 @@ type SubcriptionManager struct {
 	subMtx         sync.RWMutex
 	subRequestList map[string]map[common.Hash]chan struct{} // String: Subcription Method, Hash: hash from Subcription Params
 	ws             *websocket.Conn
+	apiKey         *apiKey // api key of connection, nil if connection is not throttled
 }
 
 var upgrader = websocket.Upgrader{
@@ -98,11 +99,25 @@ func (wsServer *WsServer) Stop() {
 /*
 Handle all ws request to rpcserver
 */
-// @NOTICE: no auth for this version yet
+// @NOTICE: no auth for this version yet, connection is only checked for api key
 func (wsServer *WsServer) handleWsRequest(w http.ResponseWriter, r *http.Request) {
 	if wsServer.limitWsConnections(w, r.RemoteAddr) {
 		return
 	}
+	var key *apiKey
+	if wsServer.config.APIKeys != nil {
+		if keyStr := keyOfRequest(r); keyStr != "" {
+			var err error
+			key, err = wsServer.config.APIKeys.getKey(keyStr)
+			if err != nil {
+				Logger.log.Warnf("RPC Websocket api key authentication failure from %s", r.RemoteAddr)
+				AuthFail(w)
+				return
+			}
+		} else {
+			key = wsServer.config.APIKeys.getPublic()
+		}
+	}
 	// Keep track of the number of connected clients.
 	wsServer.IncrementWsClients()
 	defer wsServer.DecrementWsClients()
@@ -114,7 +129,7 @@ func (wsServer *WsServer) handleWsRequest(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		return
 	}
-	wsServer.ProcessRpcWsRequest(ws)
+	wsServer.ProcessRpcWsRequest(ws, key)
 }
 
 func (wsServer *WsServer) limitWsConnections(w http.ResponseWriter, remoteAddr string) bool {
@@ -137,13 +152,14 @@ func (wsServer *WsServer) DecrementWsClients() {
 	atomic.AddInt32(&wsServer.numWsClients, -1)
 }
 
-func (wsServer *WsServer) ProcessRpcWsRequest(ws *websocket.Conn) {
+func (wsServer *WsServer) ProcessRpcWsRequest(ws *websocket.Conn, key *apiKey) {
 	if atomic.LoadInt32(&wsServer.shutdown) != 0 {
 		return
 	}
 	defer ws.Close()
 	// one sub manager will manage connection and subcription with one client (one websocket connection)
 	subManager := NewSubscriptionManager(ws)
+	subManager.apiKey = key
 	for {
 		msgType, msg, err := ws.ReadMessage()
 		if err != nil {
@@ -186,10 +202,18 @@ func (wsServer *WsServer) subscribe(subManager *SubcriptionManager, subRequest *
 	}
 	// Attempt to parse the JSON-RPC request into a known concrete command.
 	command := WsHandler[request.Method]
-	if command == nil {
+	if rpcErr := checkAccess(subManager.apiKey, request.Method); rpcErr != nil {
+		jsonErr = rpcErr
+		command = nil
+	} else if command == nil {
 		jsonErr = NewRPCError(ErrRPCMethodNotFound, errors.New("Method"+request.Method+"Not found"))
+	} else if rpcErr := validateParams(request.Method, request.Params); rpcErr != nil {
+		jsonErr = rpcErr
+		command = nil
+	}
+	if command == nil {
 		Logger.log.Errorf("RPC from client %+v error %+v", subManager.ws.RemoteAddr(), jsonErr)
-		//Notify user, method not found
+		//Notify user, method not found or not allowed
 		res, err := createMarshalledSubResponse(subRequest, nil, jsonErr)
 		if err != nil {
 			Logger.log.Errorf("Failed to marshal reply: %s", err.Error())
@@ -104,4 +104,136 @@ func (wsServer *WsServer) handleSubscribeNewBeaconBlock(params interface{}, subc
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+// status of a block pushed by subcribedisconnectedshardblock and subcribedisconnectedbeaconblock
+const (
+	blockStatusDisconnected = "disconnected" // block is reverted from chain
+	blockStatusConnected    = "connected"    // block is inserted to chain, after blocks it replaces are reverted
+)
+
+/*
+handleSubscribeDisconnectedShardBlock - push shard blocks of a shard reverted from chain and blocks inserted to chain,
+both are pushed in the order chain changes, so a reverted block is always pushed before the block replacing it
+Parameter #1—shard ID
+*/
+func (wsServer *WsServer) handleSubscribeDisconnectedShardBlock(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Disconnected Shard Block", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := NewRPCError(ErrRPCInvalidParams, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardIDParam, ok := arrayParams[0].(float64)
+	if !ok {
+		err := NewRPCError(ErrRPCInvalidParams, errors.New("Invalid Shard ID"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	shardID := byte(shardIDParam)
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewOrderedSubscriber([]string{pubsub.ShardBlockDisconnectedTopic, pubsub.NewShardblockTopic})
+	if err != nil {
+		err := NewRPCError(ErrSubcribe, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Disconnected Shard Block ShardID ", shardID)
+		wsServer.config.PubSubManager.UnsubscribeOrdered(subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg, ok := <-subChan:
+			{
+				if !ok {
+					cResult <- RpcSubResult{Error: NewRPCError(ErrSubcribe, errors.New("subscription is too slow to receive all events"))}
+					return
+				}
+				shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				if shardBlock.Header.ShardID != shardID {
+					continue
+				}
+				result := jsonresult.DisconnectedShardBlockResult{
+					Hash:    shardBlock.Hash().String(),
+					Height:  shardBlock.Header.Height,
+					ShardID: shardBlock.Header.ShardID,
+					Status:  blockStatusConnected,
+					TxIDs:   []string{},
+				}
+				if msg.Topic == pubsub.ShardBlockDisconnectedTopic {
+					result.Status = blockStatusDisconnected
+				}
+				for _, tx := range shardBlock.Body.Transactions {
+					result.TxIDs = append(result.TxIDs, tx.Hash().String())
+				}
+				cResult <- RpcSubResult{Result: result, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Disconnected Shard Block"}}
+				return
+			}
+		}
+	}
+}
+
+/*
+handleSubscribeDisconnectedBeaconBlock - push beacon blocks reverted from chain and blocks inserted to chain,
+both are pushed in the order chain changes, so a reverted block is always pushed before the block replacing it
+*/
+func (wsServer *WsServer) handleSubscribeDisconnectedBeaconBlock(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Disconnected Beacon Block", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 0 {
+		err := NewRPCError(ErrRPCInvalidParams, errors.New("Methods should only contain NO params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewOrderedSubscriber([]string{pubsub.BeaconBlockDisconnectedTopic, pubsub.NewBeaconBlockTopic})
+	if err != nil {
+		err := NewRPCError(ErrSubcribe, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Disconnected Beacon Block")
+		wsServer.config.PubSubManager.UnsubscribeOrdered(subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg, ok := <-subChan:
+			{
+				if !ok {
+					cResult <- RpcSubResult{Error: NewRPCError(ErrSubcribe, errors.New("subscription is too slow to receive all events"))}
+					return
+				}
+				beaconBlock, ok := msg.Value.(*blockchain.BeaconBlock)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.BeaconBlock, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				result := jsonresult.DisconnectedBeaconBlockResult{
+					Hash:   beaconBlock.Hash().String(),
+					Height: beaconBlock.Header.Height,
+					Status: blockStatusConnected,
+				}
+				if msg.Topic == pubsub.BeaconBlockDisconnectedTopic {
+					result.Status = blockStatusDisconnected
+				}
+				cResult <- RpcSubResult{Result: result, Error: nil}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Disconnected Beacon Block"}}
+				return
+			}
+		}
+	}
+}
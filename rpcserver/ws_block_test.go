@@ -0,0 +1,61 @@
+package rpcserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/stretchr/testify/assert"
+)
+
+func nextDisconnectedShardBlock(t *testing.T, cResult chan RpcSubResult) jsonresult.DisconnectedShardBlockResult {
+	select {
+	case res := <-cResult:
+		assert.Nil(t, res.Error)
+		return res.Result.(jsonresult.DisconnectedShardBlockResult)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no disconnected shard block")
+	}
+	return jsonresult.DisconnectedShardBlockResult{}
+}
+
+func TestHandleSubscribeDisconnectedShardBlock(t *testing.T) {
+	pubSubManager := pubsub.NewPubSubManager()
+	wsServer := &WsServer{config: RpcServerConfig{PubSubManager: pubSubManager}}
+	cResult := make(chan RpcSubResult)
+	closeChan := make(chan struct{})
+	go wsServer.handleSubscribeDisconnectedShardBlock([]interface{}{float64(0)}, "", cResult, closeChan)
+	// wait for subscription to be registered, blocks of height 1 are only published to probe it
+	for {
+		pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, newTestShardBlock(1)))
+		select {
+		case <-cResult:
+		case <-time.After(10 * time.Millisecond):
+			continue
+		}
+		break
+	}
+
+	revertedBlock := newTestShardBlock(2)
+	otherShardBlock := newTestShardBlock(2)
+	otherShardBlock.Header.ShardID = 1
+	replacingBlock := newTestShardBlock(2)
+	replacingBlock.Header.Round = 2
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBlockDisconnectedTopic, revertedBlock))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, otherShardBlock))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, replacingBlock))
+	expected := []jsonresult.DisconnectedShardBlockResult{
+		{Hash: revertedBlock.Hash().String(), Height: 2, Status: blockStatusDisconnected, TxIDs: []string{}},
+		{Hash: replacingBlock.Hash().String(), Height: 2, Status: blockStatusConnected, TxIDs: []string{}},
+	}
+	for _, item := range expected {
+		result := nextDisconnectedShardBlock(t, cResult)
+		for result.Height == 1 {
+			result = nextDisconnectedShardBlock(t, cResult)
+		}
+		assert.Equal(t, item, result)
+	}
+	close(closeChan)
+	<-cResult
+}
@@ -4,11 +4,20 @@ import (
 	"errors"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"reflect"
 )
 
+// status of a tx out of pool, other statuses are statuses of mempool.TxStatusEvent
+const (
+	txStatusPending      = "pending"      // tx is already in pool when subscribing
+	txStatusIncluded     = "included"     // tx is included in a shard block
+	txStatusFinalized    = "finalized"    // shard block including tx is confirmed by beacon chain
+	txStatusDisconnected = "disconnected" // shard block including tx is reverted, tx is then returned to pool or rejected
+)
+
 func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
 	Logger.log.Info("Handle Subcribe Pending Transaction", params, subcription)
 	arrayParams := common.InterfaceSlice(params)
@@ -71,3 +80,164 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 		}
 	}
 }
+
+/*
+handleSubscribeTransactionStatus - follow a tx through its lifecycle, an event is pushed when tx is:
+accepted or rejected by pool, replaced by fee, evicted by life time, expired, included in a shard block,
+disconnected when the shard block is reverted and finalized when the shard block is confirmed by beacon chain.
+Subscription ends after tx is finalized or removed from pool without being included in a block
+Parameter #1—tx hash
+*/
+func (wsServer *WsServer) handleSubscribeTransactionStatus(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Transaction Status", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := NewRPCError(ErrRPCInvalidParams, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHashTemp, ok := arrayParams[0].(string)
+	if !ok {
+		err := NewRPCError(ErrRPCInvalidParams, errors.New("Invalid Tx Hash"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHash, err := common.Hash{}.NewHashFromStr(txHashTemp)
+	if err != nil {
+		cResult <- RpcSubResult{Error: NewRPCError(ErrRPCInvalidParams, err)}
+		return
+	}
+	// subscribe before looking up tx so no event is missed,
+	// events of all topics come through one channel in order they are published
+	topics := []string{pubsub.TransactionStatusTopic, pubsub.NewShardblockTopic, pubsub.NewBeaconBlockTopic, pubsub.ShardBlockDisconnectedTopic}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewOrderedSubscriber(topics)
+	if err != nil {
+		cResult <- RpcSubResult{Error: NewRPCError(ErrSubcribe, err)}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Transaction Status ", txHashTemp)
+		wsServer.config.PubSubManager.UnsubscribeOrdered(subId)
+		close(cResult)
+	}()
+
+	// included is set when tx is in a shard block, then subscription waits for beacon chain to confirm the block
+	var included *jsonresult.TransactionStatusResult
+	isFinalized := func() bool {
+		return wsServer.config.BlockChain.BestState.Beacon.GetBestHeightOfShard(included.ShardID) >= included.BlockHeight
+	}
+	_, blockHash, _, _, err := wsServer.config.BlockChain.GetTransactionByHash(*txHash)
+	if err == nil {
+		shardBlock, _, err := wsServer.config.BlockChain.GetShardBlockByHash(blockHash)
+		if err == nil {
+			included = newTxIncludedResult(txHash, shardBlock)
+		}
+	}
+	if included != nil {
+		cResult <- RpcSubResult{Result: *included}
+		if isFinalized() {
+			cResult <- RpcSubResult{Result: newTxFinalizedResult(included)}
+			return
+		}
+	} else if wsServer.config.TxMemPool != nil && wsServer.config.TxMemPool.HaveTransaction(txHash) {
+		cResult <- RpcSubResult{Result: jsonresult.TransactionStatusResult{TxID: txHash.String(), Status: txStatusPending}}
+	}
+
+	for {
+		select {
+		case msg, ok := <-subChan:
+			if !ok {
+				cResult <- RpcSubResult{Error: NewRPCError(ErrSubcribe, errors.New("subscription is too slow to receive all events"))}
+				return
+			}
+			switch msg.Topic {
+			case pubsub.TransactionStatusTopic:
+				{
+					event, ok := msg.Value.(*mempool.TxStatusEvent)
+					if !ok {
+						Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *mempool.TxStatusEvent, have %+v", reflect.TypeOf(msg.Value))
+						continue
+					}
+					if !event.TxHash.IsEqual(txHash) || included != nil {
+						continue
+					}
+					res := jsonresult.TransactionStatusResult{
+						TxID:         txHash.String(),
+						Status:       event.Status,
+						ErrorCode:    event.ErrorCode,
+						ErrorMessage: event.ErrorMessage,
+					}
+					if event.ReplacedBy != nil {
+						res.ReplacedBy = event.ReplacedBy.String()
+					}
+					cResult <- RpcSubResult{Result: res}
+					if event.Status != mempool.TxStatusAccepted && event.Status != mempool.TxStatusReturned {
+						return
+					}
+				}
+			case pubsub.NewShardblockTopic:
+				{
+					shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+					if !ok {
+						Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+						continue
+					}
+					if included != nil {
+						continue
+					}
+					for _, tx := range shardBlock.Body.Transactions {
+						if tx.Hash().IsEqual(txHash) {
+							included = newTxIncludedResult(txHash, shardBlock)
+							cResult <- RpcSubResult{Result: *included}
+							break
+						}
+					}
+				}
+			case pubsub.NewBeaconBlockTopic:
+				{
+					// beacon block may only be in beacon pool, use best state of beacon chain
+					if included != nil && isFinalized() {
+						cResult <- RpcSubResult{Result: newTxFinalizedResult(included)}
+						return
+					}
+				}
+			case pubsub.ShardBlockDisconnectedTopic:
+				{
+					shardBlock, ok := msg.Value.(*blockchain.ShardBlock)
+					if !ok {
+						Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ShardBlock, have %+v", reflect.TypeOf(msg.Value))
+						continue
+					}
+					if included == nil || shardBlock.Hash().String() != included.BlockHash {
+						continue
+					}
+					res := *included
+					res.Status = txStatusDisconnected
+					included = nil
+					cResult <- RpcSubResult{Result: res}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Transaction Status " + txHashTemp}}
+				return
+			}
+		}
+	}
+}
+
+func newTxIncludedResult(txHash *common.Hash, shardBlock *blockchain.ShardBlock) *jsonresult.TransactionStatusResult {
+	return &jsonresult.TransactionStatusResult{
+		TxID:        txHash.String(),
+		Status:      txStatusIncluded,
+		BlockHash:   shardBlock.Hash().String(),
+		BlockHeight: shardBlock.Header.Height,
+		ShardID:     shardBlock.Header.ShardID,
+	}
+}
+
+func newTxFinalizedResult(included *jsonresult.TransactionStatusResult) jsonresult.TransactionStatusResult {
+	res := *included
+	res.Status = txStatusFinalized
+	return res
+}
@@ -0,0 +1,114 @@
+package rpcserver
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/mempool"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestShardBlock(height uint64, txs ...metadata.Transaction) *blockchain.ShardBlock {
+	shardBlock := &blockchain.ShardBlock{}
+	shardBlock.Header.Height = height
+	shardBlock.Header.Version = blockchain.SHARD_BLOCK_VERSION
+	shardBlock.Header.Round = 1
+	shardBlock.Header.Epoch = 1
+	shardBlock.Header.BeaconHeight = 1
+	shardBlock.Header.TotalTxsFee = make(map[common.Hash]uint64)
+	if height > 1 {
+		shardBlock.Header.ProducerAddress = new(incognitokey.KeySet).GenerateKey([]byte{1}).PaymentAddress
+		shardBlock.Header.PreviousBlockHash = common.HashH([]byte("previous block"))
+		shardBlock.Header.CommitteeRoot = common.HashH([]byte("committee"))
+	}
+	shardBlock.Body.Transactions = txs
+	return shardBlock
+}
+
+// nextTxStatus returns the next status pushed by subscription, or "closed" when subscription ends
+func nextTxStatus(t *testing.T, cResult chan RpcSubResult) (string, string) {
+	select {
+	case res, ok := <-cResult:
+		if !ok {
+			return "closed", ""
+		}
+		if !assert.Nil(t, res.Error) {
+			return "", ""
+		}
+		status := res.Result.(jsonresult.TransactionStatusResult)
+		return status.Status, status.BlockHash
+	case <-time.After(5 * time.Second):
+		t.Fatal("no tx status")
+	}
+	return "", ""
+}
+
+func TestHandleSubscribeTransactionStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txstatus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Open("leveldb", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	pubSubManager := pubsub.NewPubSubManager()
+	wsServer := &WsServer{config: RpcServerConfig{
+		BlockChain:    blockchain.NewBlockChain(&blockchain.Config{DataBase: db}, false),
+		PubSubManager: pubSubManager,
+	}}
+
+	// tx is already in a stored block, so subscription starts with included status
+	tx := &transaction.Tx{Type: common.TxNormalType, Version: 1, Info: []byte("tx")}
+	block1 := newTestShardBlock(2, tx)
+	block2 := newTestShardBlock(3, tx)
+	assert.Nil(t, db.StoreShardBlock(block1, *block1.Hash(), 0))
+	assert.Nil(t, db.StoreTransactionIndex(*tx.Hash(), *block1.Hash(), 0))
+
+	cResult := make(chan RpcSubResult)
+	closeChan := make(chan struct{})
+	go wsServer.handleSubscribeTransactionStatus([]interface{}{tx.Hash().String()}, "", cResult, closeChan)
+	status, blockHash := nextTxStatus(t, cResult)
+	assert.Equal(t, txStatusIncluded, status)
+	assert.Equal(t, block1.Hash().String(), blockHash)
+
+	// events of different topics published one after another are pushed in the same order
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBlockDisconnectedTopic, block1))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionStatusTopic, &mempool.TxStatusEvent{TxHash: *tx.Hash(), Status: mempool.TxStatusReturned}))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, block2))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBlockDisconnectedTopic, block2))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionStatusTopic, &mempool.TxStatusEvent{TxHash: *tx.Hash(), Status: mempool.TxStatusReturned}))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionStatusTopic, &mempool.TxStatusEvent{TxHash: common.HashH([]byte("other tx")), Status: mempool.TxStatusEvicted}))
+	pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.TransactionStatusTopic, &mempool.TxStatusEvent{TxHash: *tx.Hash(), Status: mempool.TxStatusEvicted}))
+
+	expected := []struct {
+		status    string
+		blockHash string
+	}{
+		{txStatusDisconnected, block1.Hash().String()},
+		{mempool.TxStatusReturned, ""},
+		{txStatusIncluded, block2.Hash().String()},
+		{txStatusDisconnected, block2.Hash().String()},
+		{mempool.TxStatusReturned, ""},
+		{mempool.TxStatusEvicted, ""},
+		{"closed", ""},
+	}
+	for _, item := range expected {
+		status, blockHash := nextTxStatus(t, cResult)
+		assert.Equal(t, item.status, status)
+		assert.Equal(t, item.blockHash, blockHash)
+	}
+}
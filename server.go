@@ -32,6 +32,8 @@ import (
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/transaction"
 
+	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/instantseal"
 	"github.com/incognitochain/incognito-chain/consensus/mubft"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/databasemp"
@@ -69,7 +71,7 @@ type Server struct {
 	addrManager       *addrmanager.AddrManager
 	userKeySet        *incognitokey.KeySet
 	wallet            *wallet.Wallet
-	consensusEngine   *mubft.Engine
+	consensusEngine   consensus.ConsensusEngine
 	blockgen          *blockchain.BlockGenerator
 	pusubManager      *pubsub.PubSubManager
 	// The fee estimator keeps track of how long transactions are left in
@@ -84,53 +86,21 @@ type Server struct {
 // with the RPC server depending on the configuration settings for listen
 // addresses and TLS.
 func (serverObj *Server) setupRPCListeners() ([]net.Listener, error) {
-	// Setup TLS if not disabled.
-	listenFunc := net.Listen
-	if !cfg.DisableTLS {
-		Logger.log.Debug("Disable TLS for RPC is false")
-		// Generate the TLS cert and key file if both don't already
-		// exist.
-		if !fileExists(cfg.RPCKey) && !fileExists(cfg.RPCCert) {
-			err := rpcserver.GenCertPair(cfg.RPCCert, cfg.RPCKey)
-			if err != nil {
-				return nil, err
-			}
-		}
-		keyPair, err := tls.LoadX509KeyPair(cfg.RPCCert, cfg.RPCKey)
-		if err != nil {
-			return nil, err
-		}
-
-		tlsConfig := tls.Config{
-			Certificates: []tls.Certificate{keyPair},
-			MinVersion:   tls.VersionTLS12,
-		}
-
-		// Change the standard net.Listen function to the tls one.
-		listenFunc = func(net string, laddr string) (net.Listener, error) {
-			return tls.Listen(net, laddr, &tlsConfig)
-		}
-	} else {
-		Logger.log.Debug("Disable TLS for RPC is true")
-	}
-
-	netAddrs, err := common.ParseListeners(cfg.RPCListeners, "tcp")
-	if err != nil {
-		return nil, err
-	}
-
-	listeners := make([]net.Listener, 0, len(netAddrs))
-	for _, addr := range netAddrs {
-		listener, err := listenFunc(addr.Network(), addr.String())
-		if err != nil {
-			log.Printf("Can't listen on %s: %v", addr, err)
-			continue
-		}
-		listeners = append(listeners, listener)
-	}
-	return listeners, nil
+	return serverObj.setupListeners(cfg.RPCListeners)
 }
 func (serverObj *Server) setupRPCWsListeners() ([]net.Listener, error) {
+	return serverObj.setupListeners(cfg.RPCWSListeners)
+}
+
+// setupRPCGrpcListeners returns listeners of gRPC server, TLS connections
+// negotiate HTTP/2 as gRPC requires it
+func (serverObj *Server) setupRPCGrpcListeners() ([]net.Listener, error) {
+	return serverObj.setupListeners(cfg.RPCGrpcListeners, "h2")
+}
+
+// setupListeners listens on addresses, with TLS of RPC server unless it is
+// disabled, nextProtos are the application protocols negotiated by TLS
+func (serverObj *Server) setupListeners(listenAddrs []string, nextProtos ...string) ([]net.Listener, error) {
 	// Setup TLS if not disabled.
 	listenFunc := net.Listen
 	if !cfg.DisableTLS {
@@ -151,6 +121,7 @@ func (serverObj *Server) setupRPCWsListeners() ([]net.Listener, error) {
 		tlsConfig := tls.Config{
 			Certificates: []tls.Certificate{keyPair},
 			MinVersion:   tls.VersionTLS12,
+			NextProtos:   nextProtos,
 		}
 
 		// Change the standard net.Listen function to the tls one.
@@ -161,7 +132,7 @@ func (serverObj *Server) setupRPCWsListeners() ([]net.Listener, error) {
 		Logger.log.Debug("Disable TLS for RPC is true")
 	}
 
-	netAddrs, err := common.ParseListeners(cfg.RPCWSListeners, "tcp")
+	netAddrs, err := common.ParseListeners(listenAddrs, "tcp")
 	if err != nil {
 		return nil, err
 	}
@@ -214,6 +185,20 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 	serverObj.blockChain = &blockchain.BlockChain{}
 	serverObj.isEnableMining = cfg.EnableMining
 
+	// bootstrap an empty database from a trusted checkpoint
+	if cfg.ImportSnapshot != common.EmptyString {
+		checkpoint, err := common.Hash{}.NewHashFromStr(cfg.Checkpoint)
+		if err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+		err = blockchain.ImportStateSnapshot(serverObj.dataBase, cfg.ImportSnapshot, *checkpoint)
+		if err != nil {
+			Logger.log.Error(err)
+			return err
+		}
+	}
+
 	relayShards := []byte{}
 	if cfg.RelayShards == "all" {
 		for index := 0; index < common.MAX_SHARD_NUMBER; index++ {
@@ -256,6 +241,8 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		FeeEstimator:      make(map[byte]blockchain.FeeEstimator),
 		PubSubManager:     pubsubManager,
 		RandomClient:      randomClient,
+		SnapshotDir:       cfg.SnapshotDir,
+		PruneBlocks:       cfg.PruneBlocks,
 	})
 	serverObj.blockChain.InitChannelBlockchain(cRemovedTxs)
 	if err != nil {
@@ -321,6 +308,8 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 	for shardID, feeEstimator := range serverObj.feeEstimator {
 		serverObj.blockChain.SetFeeEstimator(feeEstimator, shardID)
 	}
+	// cache of txs verified by itself, shared between mempool and temp mempool
+	verificationCache := mempool.NewVerificationCache(cfg.TxVerificationCacheSize)
 	// create mempool tx
 	serverObj.memPool = &mempool.TxPool{}
 	serverObj.memPool.Init(&mempool.Config{
@@ -336,6 +325,8 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		RelayShards:       relayShards,
 		UserKeyset:        serverObj.userKeySet,
 		PubSubManager:     serverObj.pusubManager,
+		VerificationCache: verificationCache,
+		DataDir:           cfg.DataDir,
 	})
 	serverObj.memPool.AnnouncePersisDatabaseMempool()
 	//add tx pool
@@ -344,12 +335,13 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 	//==============Temp mem pool only used for validation
 	serverObj.tempMemPool = &mempool.TxPool{}
 	serverObj.tempMemPool.Init(&mempool.Config{
-		BlockChain:    serverObj.blockChain,
-		DataBase:      serverObj.dataBase,
-		ChainParams:   chainParams,
-		FeeEstimator:  serverObj.feeEstimator,
-		MaxTx:         cfg.TxPoolMaxTx,
-		PubSubManager: pubsubManager,
+		BlockChain:        serverObj.blockChain,
+		DataBase:          serverObj.dataBase,
+		ChainParams:       chainParams,
+		FeeEstimator:      serverObj.feeEstimator,
+		MaxTx:             cfg.TxPoolMaxTx,
+		PubSubManager:     pubsubManager,
+		VerificationCache: verificationCache,
 	})
 	serverObj.blockChain.AddTempTxPool(serverObj.tempMemPool)
 	//===============
@@ -361,17 +353,31 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 	}
 
 	// Init consensus engine
-	serverObj.consensusEngine, err = mubft.Engine{}.Init(&mubft.EngineConfig{
-		CrossShardPool:    serverObj.crossShardPool,
-		ShardToBeaconPool: serverObj.shardToBeaconPool,
-		ChainParams:       serverObj.chainParams,
-		BlockChain:        serverObj.blockChain,
-		Server:            serverObj,
-		BlockGen:          serverObj.blockgen,
-		NodeMode:          cfg.NodeMode,
-		UserKeySet:        serverObj.userKeySet,
-		PubSubManager:     serverObj.pusubManager,
-	})
+	switch cfg.Consensus {
+	case consensus.InstantSeal:
+		serverObj.consensusEngine, err = instantseal.NewEngine(&instantseal.EngineConfig{
+			CrossShardPool:    serverObj.crossShardPool,
+			ShardToBeaconPool: serverObj.shardToBeaconPool,
+			BlockChain:        serverObj.blockChain,
+			Server:            serverObj,
+			BlockGen:          serverObj.blockgen,
+			UserKeySet:        serverObj.userKeySet,
+			PubSubManager:     serverObj.pusubManager,
+			SealInterval:      time.Duration(cfg.SealInterval) * time.Second,
+		})
+	default:
+		serverObj.consensusEngine, err = mubft.Engine{}.Init(&mubft.EngineConfig{
+			CrossShardPool:    serverObj.crossShardPool,
+			ShardToBeaconPool: serverObj.shardToBeaconPool,
+			ChainParams:       serverObj.chainParams,
+			BlockChain:        serverObj.blockChain,
+			Server:            serverObj,
+			BlockGen:          serverObj.blockgen,
+			NodeMode:          cfg.NodeMode,
+			UserKeySet:        serverObj.userKeySet,
+			PubSubManager:     serverObj.pusubManager,
+		})
+	}
 	if err != nil {
 		return err
 	}
@@ -411,12 +417,14 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		cfg.MaxPeersBeacon = 9999
 	}
 	connManager := connmanager.New(&connmanager.Config{
-		OnInboundAccept:      serverObj.InboundPeerConnected,
-		OnOutboundConnection: serverObj.OutboundPeerConnected,
-		ListenerPeer:         listenPeer,
-		DiscoverPeers:        cfg.DiscoverPeers,
-		DiscoverPeersAddress: cfg.DiscoverPeersAddress,
-		ExternalAddress:      cfg.ExternalAddress,
+		OnInboundAccept:         serverObj.InboundPeerConnected,
+		OnOutboundConnection:    serverObj.OutboundPeerConnected,
+		OnOutboundDisconnection: serverObj.PeerDisconnected,
+		OnInboundDisconnection:  serverObj.PeerDisconnected,
+		ListenerPeer:            listenPeer,
+		DiscoverPeers:           cfg.DiscoverPeers,
+		DiscoverPeersAddress:    cfg.DiscoverPeersAddress,
+		ExternalAddress:         cfg.ExternalAddress,
 		// config for connection of shard
 		MaxPeersSameShard:  cfg.MaxPeersSameShard,
 		MaxPeersOtherShard: cfg.MaxPeersOtherShard,
@@ -445,7 +453,11 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		if err != nil {
 			return err
 		}
-		if len(httpListeners) == 0 && len(wsListeners) == 0 {
+		grpcListeners, err := serverObj.setupRPCGrpcListeners()
+		if err != nil {
+			return err
+		}
+		if len(httpListeners) == 0 && len(wsListeners) == 0 && len(grpcListeners) == 0 {
 			return errors.New("RPCS: No valid listen address")
 		}
 
@@ -453,9 +465,14 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		if serverObj.userKeySet != nil {
 			miningPubkeyB58 = serverObj.userKeySet.GetPublicKeyInBase58CheckEncode()
 		}
+		apiKeys, err := rpcserver.NewAPIKeyManager(cfg.RPCAPIKeyFile)
+		if err != nil {
+			return err
+		}
 		rpcConfig := rpcserver.RpcServerConfig{
 			HttpListenters:  httpListeners,
 			WsListenters:    wsListeners,
+			GrpcListenters:  grpcListeners,
 			RPCQuirks:       cfg.RPCQuirks,
 			RPCMaxClients:   cfg.RPCMaxClients,
 			RPCMaxWSClients: cfg.RPCMaxWSClients,
@@ -471,6 +488,7 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 			RPCLimitUser:    cfg.RPCLimitUser,
 			RPCLimitPass:    cfg.RPCLimitPass,
 			DisableAuth:     cfg.RPCDisableAuth,
+			APIKeys:         apiKeys,
 			NodeMode:        cfg.NodeMode,
 			FeeEstimator:    serverObj.feeEstimator,
 			ProtocolVersion: serverObj.protocolVersion,
@@ -478,6 +496,7 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 			MiningPubKeyB58: miningPubkeyB58,
 			NetSync:         serverObj.netSync,
 			PubSubManager:   pubsubManager,
+			ConsensusEngine: serverObj.consensusEngine,
 		}
 		serverObj.rpcServer = &rpcserver.RpcServer{}
 		serverObj.rpcServer.Init(&rpcConfig)
@@ -524,6 +543,14 @@ func (serverObj *Server) OutboundPeerConnected(peerConn *peer.PeerConn) {
 	}
 }
 
+/*
+// PeerDisconnected is invoked by the connection manager when a peer is disconnected
+*/
+func (serverObj *Server) PeerDisconnected(peerConn *peer.PeerConn) {
+	Logger.log.Debug("PEER disconnected with PEER Id - " + peerConn.GetRemotePeerID().Pretty())
+	serverObj.blockChain.OnPeerDisconnected(peerConn.GetRemotePeerID())
+}
+
 /*
 // WaitForShutdown blocks until the main listener and peer handlers are stopped.
 */
@@ -1024,6 +1051,7 @@ func (serverObj *Server) OnVersion(peerConn *peer.PeerConn, msg *wire.MessageVer
 	remotePeer.SetPeerID(msg.LocalPeerId)
 	remotePeer.SetRawAddress(msg.RawLocalAddress)
 	peerConn.GetRemotePeer().SetPublicKey(pbk)
+	serverObj.blockChain.OnPeerVersionReceived(msg.LocalPeerId, msg.PruneBlocks)
 
 	serverObj.cNewPeers <- remotePeer
 	valid := false
@@ -1393,6 +1421,7 @@ func (serverObj *Server) PushVersionMessage(peerConn *peer.PeerConn) error {
 	msg.(*wire.MessageVersion).RawRemoteAddress = peerConn.GetListenerPeer().GetRawAddress()
 	msg.(*wire.MessageVersion).RemotePeerId = peerConn.GetListenerPeer().GetPeerID()
 	msg.(*wire.MessageVersion).ProtocolVersion = serverObj.protocolVersion
+	msg.(*wire.MessageVersion).PruneBlocks = cfg.PruneBlocks
 
 	// ValidateTransaction Public Key from ProducerPrvKey
 	if peerConn.GetListenerPeer().GetConfig().UserKeySet != nil {
@@ -1710,3 +1739,29 @@ func (serverObj *Server) GetChainMiningStatus(chain int) string {
 	}
 	return offline
 }
+
+// SetLogLevel changes logging level of a subsystem on running node,
+// all subsystems are changed when subsystemID is empty
+func (serverObj *Server) SetLogLevel(subsystemID string, logLevel string) error {
+	if !validLogLevel(logLevel) {
+		return fmt.Errorf("the specified log level [%v] is invalid", logLevel)
+	}
+	if subsystemID == common.EmptyString {
+		setLogLevels(logLevel)
+		return nil
+	}
+	if _, ok := subsystemLoggers[subsystemID]; !ok {
+		return fmt.Errorf("the specified subsystem [%v] is invalid -- supported subsytems %v", subsystemID, supportedSubsystems())
+	}
+	setLogLevel(subsystemID, logLevel)
+	return nil
+}
+
+// GetLogLevels returns current logging level of all subsystems
+func (serverObj *Server) GetLogLevels() map[string]string {
+	result := make(map[string]string)
+	for subsystemID, logger := range subsystemLoggers {
+		result[subsystemID] = logger.Level().String()
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+)
+
+// VerifyProofsInBatch - verify aggregated range proofs and one out of many proofs of all txs (with privacy) at once,
+// these proofs are skipped later when each tx is validated by itself
+func VerifyProofsInBatch(txs []metadata.Transaction, db database.DatabaseInterface) error {
+	items := make([]zkp.PaymentProofBatchItem, 0)
+	itemTxs := make([]metadata.Transaction, 0)
+	prvCoinID := &common.Hash{}
+	prvCoinID.SetBytes(common.PRVCoinID[:])
+	addItem := func(tx metadata.Transaction, normalTx *Tx, tokenID *common.Hash) {
+		if normalTx == nil || !normalTx.IsPrivacy() {
+			return
+		}
+		items = append(items, zkp.PaymentProofBatchItem{
+			Proof:   normalTx.Proof,
+			ShardID: common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte()),
+			TokenID: tokenID,
+		})
+		itemTxs = append(itemTxs, tx)
+	}
+	for _, tx := range txs {
+		switch tx.GetType() {
+		case common.TxNormalType:
+			if normalTx, ok := tx.(*Tx); ok {
+				addItem(tx, normalTx, prvCoinID)
+			}
+		case common.TxCustomTokenType:
+			if customTokenTx, ok := tx.(*TxCustomToken); ok && customTokenTx.TxTokenData.Type != CustomTokenCrossShard {
+				addItem(tx, &customTokenTx.Tx, prvCoinID)
+			}
+		case common.TxCustomTokenPrivacyType:
+			// tx init token is not validated by itself
+			if customTokenPrivacyTx, ok := tx.(*TxCustomTokenPrivacy); ok && customTokenPrivacyTx.TxTokenPrivacyData.Type != CustomTokenInit {
+				addItem(tx, &customTokenPrivacyTx.Tx, prvCoinID)
+				tokenID := customTokenPrivacyTx.TxTokenPrivacyData.PropertyID
+				addItem(tx, &customTokenPrivacyTx.TxTokenPrivacyData.TxNormal, &tokenID)
+			}
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	index, err := zkp.VerifyBatch(items, db)
+	if err != nil {
+		if index >= 0 {
+			return NewTransactionErr(TxProofVerifyFailError, fmt.Errorf("tx %+v: %+v", itemTxs[index].Hash().String(), err))
+		}
+		return NewTransactionErr(TxProofVerifyFailError, err)
+	}
+	return nil
+}
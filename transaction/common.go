@@ -14,6 +14,11 @@ import (
 	"math/rand"
 )
 
+// IsMemoSupported returns true if info of output coins of a tx with version is a memo encrypted for the receiver
+func IsMemoSupported(version int8) bool {
+	return version >= txVersionMemo
+}
+
 // ConvertOutputCoinToInputCoin - convert output coin from old tx to input coin for new tx
 func ConvertOutputCoinToInputCoin(usableOutputsOfOld []*privacy.OutputCoin) []*privacy.InputCoin {
 	var inputCoins []*privacy.InputCoin
@@ -51,7 +56,7 @@ func NewRandomCommitmentsProcessParam(usableInputCoins []*privacy.InputCoin, ran
 // result contains
 // commitmentIndexs = [{1,2,3,4,myindex1,6,7,8}{9,10,11,12,13,myindex2,15,16}...]
 // myCommitmentIndexs = [4, 13, ...]
-func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentIndexs []uint64, myCommitmentIndexs []uint64, commitments [][]byte) {
+func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentIndexs []uint64, myCommitmentIndexs []uint64, commitments [][]byte, err error) {
 	commitmentIndexs = []uint64{} // : list commitment indexes which: random from full db commitments + commitments of usableInputCoins
 	commitments = [][]byte{}
 	myCommitmentIndexs = []uint64{} // : list indexes of commitments(usableInputCoins) in {commitmentIndexs}
@@ -69,7 +74,7 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 		index, err := param.db.GetCommitmentIndex(*param.tokenID, usableCommitment, param.shardID)
 		if err != nil {
 			Logger.log.Error(err)
-			return
+			return nil, nil, nil, err
 		}
 		mapIndexCommitmentsInUsableTx[base58.Base58Check{}.Encode(usableCommitment, common.ZeroByte)] = index
 	}
@@ -77,24 +82,32 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 	// loop to random commitmentIndexs
 	cpRandNum := (len(listUsableCommitments) * param.randNum) - len(listUsableCommitments)
 	fmt.Printf("cpRandNum: %d\n", cpRandNum)
-	lenCommitment, err1 := param.db.GetCommitmentLength(*param.tokenID, param.shardID)
-	if err1 != nil {
-		Logger.log.Error(err1)
-		return
+	lenCommitment, err := param.db.GetCommitmentLength(*param.tokenID, param.shardID)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, nil, nil, err
 	}
 	if lenCommitment == nil {
-		Logger.log.Error(errors.New("Commitments is empty"))
-		return
+		err = errors.New("Commitments is empty")
+		Logger.log.Error(err)
+		return nil, nil, nil, err
 	}
 	if lenCommitment.Uint64() == 1 {
-		commitmentIndexs = []uint64{0, 0, 0, 0, 0, 0, 0}
+		commitmentIndexs = make([]uint64, cpRandNum)
 		temp := param.usableInputCoins[0].CoinDetails.GetCoinCommitment().Compress()
-		commitments = [][]byte{temp, temp, temp, temp, temp, temp, temp}
+		commitments = make([][]byte, cpRandNum)
+		for i := range commitments {
+			commitments[i] = temp
+		}
 	} else {
 		for i := 0; i < cpRandNum; i++ {
 			for {
 				lenCommitment, _ = param.db.GetCommitmentLength(*param.tokenID, param.shardID)
-				index, _ := common.RandBigIntMaxRange(lenCommitment)
+				index, err := randomDecoyIndex(lenCommitment)
+				if err != nil {
+					Logger.log.Error(err)
+					return nil, nil, nil, err
+				}
 				ok, err := param.db.HasCommitmentIndex(*param.tokenID, index.Uint64(), param.shardID)
 				if ok && err == nil {
 					temp, _ := param.db.GetCommitmentByIndex(*param.tokenID, index.Uint64(), param.shardID)
@@ -122,7 +135,27 @@ func RandomCommitmentsProcess(param *RandomCommitmentsProcessParam) (commitmentI
 		myCommitmentIndexs = append(myCommitmentIndexs, uint64(i)) // create myCommitmentIndexs
 		j++
 	}
-	return commitmentIndexs, myCommitmentIndexs, commitments
+	return commitmentIndexs, myCommitmentIndexs, commitments, nil
+}
+
+// randomDecoyIndex picks a commitment index in [0, length) with probability decreasing with its age,
+// age is length * u_1 * ... * u_k with k = decoyRecencyWeight uniform random numbers u_i in [0, 1)
+// and the newest commitment has index length - 1
+func randomDecoyIndex(length *big.Int) (*big.Int, error) {
+	age, err := common.RandBigIntMaxRange(length)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < decoyRecencyWeight; i++ {
+		u, err := common.RandBigIntMaxRange(length)
+		if err != nil {
+			return nil, err
+		}
+		age.Mul(age, u)
+		age.Div(age, length)
+	}
+	index := new(big.Int).Sub(length, big.NewInt(1))
+	return index.Sub(index, age), nil
 }
 
 // CheckSNDerivatorExistence return true if snd exists in snDerivators list
@@ -142,6 +175,7 @@ type EstimateTxSizeParam struct {
 	customTokenParams        *CustomTokenParamTx
 	privacyCustomTokenParams *CustomTokenPrivacyParamTx
 	limitFee                 uint64
+	ringSize                 int
 }
 
 func NewEstimateTxSizeParam(inputCoins []*privacy.OutputCoin, payments []*privacy.PaymentInfo,
@@ -157,10 +191,17 @@ func NewEstimateTxSizeParam(inputCoins []*privacy.OutputCoin, payments []*privac
 		metadata:                 metadata,
 		payments:                 payments,
 		privacyCustomTokenParams: privacyCustomTokenParams,
+		ringSize:                 privacy.CommitmentRingSize,
 	}
 	return estimateTxSizeParam
 }
 
+// SetRingSize sets ring size of one out of many proofs of the estimated tx
+func (param *EstimateTxSizeParam) SetRingSize(ringSize int) *EstimateTxSizeParam {
+	param.ringSize = ringSize
+	return param
+}
+
 // EstimateTxSize returns the estimated size of the tx in kilobyte
 func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 
@@ -179,10 +220,16 @@ func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 
 	sizeProof := uint64(0)
 	if len(estimateTxSizeParam.inputCoins) != 0 || len(estimateTxSizeParam.payments) != 0 {
-		sizeProof = utils.EstimateProofSize(len(estimateTxSizeParam.inputCoins), len(estimateTxSizeParam.payments), estimateTxSizeParam.hasPrivacy)
+		sizeProof = utils.EstimateProofSize(len(estimateTxSizeParam.inputCoins), len(estimateTxSizeParam.payments), estimateTxSizeParam.hasPrivacy, estimateTxSizeParam.ringSize)
 	} else {
 		if estimateTxSizeParam.limitFee > 0 {
-			sizeProof = utils.EstimateProofSize(1, 1, estimateTxSizeParam.hasPrivacy)
+			sizeProof = utils.EstimateProofSize(1, 1, estimateTxSizeParam.hasPrivacy, estimateTxSizeParam.ringSize)
+		}
+	}
+	// encrypted memos are kept in info of output coins
+	for _, payment := range estimateTxSizeParam.payments {
+		if len(payment.Memo) > 0 {
+			sizeProof += uint64(privacy.GetEncryptedMemoSize(len(payment.Memo)))
 		}
 	}
 
@@ -242,7 +289,7 @@ func EstimateTxSize(estimateTxSizeParam *EstimateTxSizeParam) uint64 {
 		customTokenDataSize += uint64(common.SigPrivacySize) // sig
 
 		// Proof
-		customTokenDataSize += utils.EstimateProofSize(len(estimateTxSizeParam.privacyCustomTokenParams.TokenInput), len(estimateTxSizeParam.privacyCustomTokenParams.Receiver), true)
+		customTokenDataSize += utils.EstimateProofSize(len(estimateTxSizeParam.privacyCustomTokenParams.TokenInput), len(estimateTxSizeParam.privacyCustomTokenParams.Receiver), true, estimateTxSizeParam.ringSize)
 
 		customTokenDataSize += uint64(1) //PubKeyLastByte
 
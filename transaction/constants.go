@@ -2,15 +2,45 @@ package transaction
 
 const (
 	// txVersion is the current latest supported transaction version.
-	txVersion = 1
+	txVersion = 7
+
+	// txVersionOneTimeKey is the first version in which outputs of privacy tx are sent to one-time public keys
+	txVersionOneTimeKey = 2
+
+	// txVersionExpiry is the first version in which tx can carry an expiry beacon height
+	txVersionExpiry = 3
+
+	// txVersionMultiSig is the first version in which tx can spend coins of a multisig account
+	txVersionMultiSig = 4
+
+	// txVersionTokenInfo is the first version in which tx init token can register decimals, description,
+	// issuer and supply policy of the token
+	txVersionTokenInfo = 5
+
+	// txVersionRingSize is the first version in which ring size of one out of many proofs must be at least
+	// the minimum ring size of the network, older txs keep the default ring size privacy.CommitmentRingSize
+	txVersionRingSize = 6
+
+	// txVersionMemo is the first version in which info of output coins carries a memo encrypted for the receiver
+	txVersionMemo = 7
 )
 
+// decoyRecencyWeight is the number of uniform random numbers multiplied together to get age of a decoy commitment.
+// Spent coins are mostly recent ones, decoys are skewed towards recent commitments the same way
+// so the newest commitment of a ring does not stand out as the spent one
+const decoyRecencyWeight = 2
+
 const (
 	CustomTokenInit = iota
 	CustomTokenTransfer
 	CustomTokenCrossShard
 )
 
+const (
+	MaxTokenDecimals        = 18
+	MaxTokenDescriptionSize = 512
+)
+
 const (
 	NormalCoinType = iota
 	CustomTokenType
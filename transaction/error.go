@@ -42,6 +42,11 @@ const (
 	TxProofVerifyFailError
 	VerifyMinerCreatedTxBeforeGettingInBlockError
 	CommitOutputCoinError
+	GenerateOneTimePublicKeyError
+	EncryptMemoError
+	TxExpiredError
+	MultiSigAccountError
+	UnsignedTxError
 
 	NormalTokenPRVJsonError
 	NormalTokenJsonError
@@ -51,6 +56,8 @@ const (
 	PrivacyTokenPRVJsonError
 	PrivacyTokenJsonError
 	PrivacyTokenTxTypeNotHandleError
+	PrivacyTokenInfoError
+	PrivacyTokenSupplyError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -88,6 +95,11 @@ var ErrCodeMessage = map[int]struct {
 	CommitOutputCoinError:                         {-1027, "Commit all output error"},
 	TokenIDExistedError:                           {-1028, "This token is existed in network"},
 	TokenIDExistedByCrossShardError:               {-1029, "This token is existed in network by cross shard"},
+	GenerateOneTimePublicKeyError:                 {-1030, "Can not generate one-time public key for payment address %+v"},
+	EncryptMemoError:                              {-1031, "Can not encrypt memo for payment address %+v"},
+	TxExpiredError:                                {-1032, "Tx is expired at beacon height %+v"},
+	MultiSigAccountError:                          {-1033, "Invalid multisig account of tx"},
+	UnsignedTxError:                               {-1034, "Invalid unsigned tx"},
 
 	// for PRV
 	InvalidSanityDataPRVError:  {-2000, "Invalid sanity data for PRV"},
@@ -101,6 +113,8 @@ var ErrCodeMessage = map[int]struct {
 	PrivacyTokenInitPRVError:            {-3004, "Init tx for PRV error"},
 	PrivacyTokenTxTypeNotHandleError:    {-3005, "Can not handle this tx type for privacy token"},
 	PrivacyTokenInitTokenDataError:      {-3006, "Can not init data for privacy token tx"},
+	PrivacyTokenInfoError:               {-3007, "Invalid info of privacy token"},
+	PrivacyTokenSupplyError:             {-3008, "Invalid mint or burn request of privacy token"},
 
 	// for normal token
 	NormalTokenPRVJsonError: {-4000, "Json data error"},
@@ -135,7 +135,6 @@ func (customTokenTx TxCustomToken) ValidateTxWithBlockChain(
 	if customTokenTx.GetType() == common.TxReturnStakingType {
 		return NewTransactionErr(UnexpectedError, errors.New("Wrong return staking tx"))
 	}
-
 	if customTokenTx.Metadata != nil {
 		isContinued, err := customTokenTx.Metadata.ValidateTxWithBlockChain(&customTokenTx, bcr, shardID, db)
 		if err != nil {
@@ -426,6 +425,8 @@ type NormalTokenInitParam struct {
 	metaData       metadata.Metadata
 	hasPrivacyCoin bool
 	shardID        byte
+
+	expiryHeight uint64 // 0 means tx never expires
 }
 
 func NewTxNormalTokenInitParam(
@@ -452,6 +453,12 @@ func NewTxNormalTokenInitParam(
 	return params
 }
 
+// SetExpiryHeight sets the last beacon height at which tx can be included in a block
+func (params *NormalTokenInitParam) SetExpiryHeight(expiryHeight uint64) *NormalTokenInitParam {
+	params.expiryHeight = expiryHeight
+	return params
+}
+
 // CreateTxCustomToken ...
 func (txCustomToken *TxCustomToken) Init(params *NormalTokenInitParam) error {
 	var err error
@@ -464,7 +471,7 @@ func (txCustomToken *TxCustomToken) Init(params *NormalTokenInitParam) error {
 		params.hasPrivacyCoin,
 		params.db,
 		nil,
-		params.metaData))
+		params.metaData).SetExpiryHeight(params.expiryHeight))
 	if err != nil {
 		return NewTransactionErr(UnexpectedError, err)
 	}
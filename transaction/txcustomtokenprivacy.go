@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"sort"
 
@@ -101,6 +102,9 @@ func (txCustomTokenPrivacy TxCustomTokenPrivacy) GetTxActualSize() uint64 {
 	tokenDataSize += uint64(len(txCustomTokenPrivacy.TxTokenPrivacyData.PropertyID))
 	tokenDataSize += 4 // for TxTokenPrivacyData.Type
 	tokenDataSize += 8 // for TxTokenPrivacyData.Amount
+	tokenDataSize += uint64(len(txCustomTokenPrivacy.TxTokenPrivacyData.Description))
+	tokenDataSize += uint64(len(txCustomTokenPrivacy.TxTokenPrivacyData.IssuerKey))
+	tokenDataSize += 2 // for TxTokenPrivacyData.Decimals and TxTokenPrivacyData.SupplyPolicy
 
 	meta := txCustomTokenPrivacy.Metadata
 	if meta != nil {
@@ -118,6 +122,9 @@ func (tx TxCustomTokenPrivacy) GetTxPrivacyTokenActualSize() uint64 {
 	tokenDataSize += uint64(len(tx.TxTokenPrivacyData.PropertyID))
 	tokenDataSize += 4 // for TxTokenPrivacyData.Type
 	tokenDataSize += 8 // for TxTokenPrivacyData.Amount
+	tokenDataSize += uint64(len(tx.TxTokenPrivacyData.Description))
+	tokenDataSize += uint64(len(tx.TxTokenPrivacyData.IssuerKey))
+	tokenDataSize += 2 // for TxTokenPrivacyData.Decimals and TxTokenPrivacyData.SupplyPolicy
 
 	meta := tx.TxTokenPrivacyData.TxNormal.Metadata
 	if meta != nil {
@@ -165,6 +172,12 @@ type TxPrivacyTokenInitParams struct {
 	hasPrivacyCoin  bool
 	hasPrivacyToken bool
 	shardID         byte
+
+	expiryHeight uint64 // 0 means tx never expires
+
+	multiSigAccount *privacy.MultiSigAccount // spends PRV and token coins of a multisig account instead of coins of senderKey
+
+	ringSize int // number of commitments in ring of each PRV and token input coin
 }
 
 func NewTxPrivacyTokenInitParams(senderKey *privacy.PrivateKey,
@@ -188,13 +201,36 @@ func NewTxPrivacyTokenInitParams(senderKey *privacy.PrivateKey,
 		inputCoin:       inputCoin,
 		senderKey:       senderKey,
 		tokenParams:     tokenParams,
+		ringSize:        privacy.CommitmentRingSize,
 	}
 	return params
 }
 
+// SetExpiryHeight sets the last beacon height at which tx can be included in a block
+func (params *TxPrivacyTokenInitParams) SetExpiryHeight(expiryHeight uint64) *TxPrivacyTokenInitParams {
+	params.expiryHeight = expiryHeight
+	return params
+}
+
+// SetMultiSigAccount makes tx spend PRV and token coins of a multisig account,
+// both parts of tx are left unsigned until cosigners of the account combine their signatures
+func (params *TxPrivacyTokenInitParams) SetMultiSigAccount(account *privacy.MultiSigAccount) *TxPrivacyTokenInitParams {
+	params.multiSigAccount = account
+	return params
+}
+
+// SetRingSize sets ring size of one out of many proofs of PRV and token, it must be at least the minimum ring size of the network
+func (params *TxPrivacyTokenInitParams) SetRingSize(ringSize int) *TxPrivacyTokenInitParams {
+	params.ringSize = ringSize
+	return params
+}
+
 // Init -  build normal tx component and privacy custom token data
 func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenInitParams) error {
 	var err error
+	if params.multiSigAccount != nil && params.tokenParams.TokenTxType != CustomTokenTransfer {
+		return NewTransactionErr(MultiSigAccountError, errors.New("multisig account can only transfer privacy token"))
+	}
 	// init data for tx PRV for fee
 	normalTx := Tx{}
 	err = normalTx.Init(NewTxPrivacyInitParams(
@@ -205,7 +241,7 @@ func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenIni
 		params.hasPrivacyCoin,
 		params.db,
 		nil,
-		params.metaData))
+		params.metaData).SetExpiryHeight(params.expiryHeight).SetMultiSigAccount(params.multiSigAccount).SetRingSize(params.ringSize))
 	if err != nil {
 		return NewTransactionErr(PrivacyTokenInitPRVError, err)
 	}
@@ -226,6 +262,10 @@ func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenIni
 				PropertyName:   params.tokenParams.PropertyName,
 				PropertySymbol: params.tokenParams.PropertySymbol,
 				Amount:         params.tokenParams.Amount,
+				Decimals:       params.tokenParams.Decimals,
+				Description:    params.tokenParams.Description,
+				IssuerKey:      params.tokenParams.IssuerKey,
+				SupplyPolicy:   params.tokenParams.SupplyPolicy,
 			}
 
 			// issue token with data of privacy
@@ -294,6 +334,9 @@ func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenIni
 				txCustomTokenPrivacy.TxTokenPrivacyData.PropertyID = newHashInitToken
 				Logger.log.Debugf("A new token privacy wil be issued with ID: %+v", txCustomTokenPrivacy.TxTokenPrivacyData.PropertyID.String())
 			}
+			if err := txCustomTokenPrivacy.TxTokenPrivacyData.validateTokenInfo(params.shardID); err != nil {
+				return NewTransactionErr(PrivacyTokenInfoError, err)
+			}
 		}
 	case CustomTokenTransfer:
 		{
@@ -322,7 +365,7 @@ func (txCustomTokenPrivacy *TxCustomTokenPrivacy) Init(params *TxPrivacyTokenIni
 				params.hasPrivacyToken,
 				params.db,
 				propertyID,
-				nil))
+				nil).SetMultiSigAccount(params.multiSigAccount).SetRingSize(params.ringSize))
 			if err != nil {
 				return NewTransactionErr(PrivacyTokenInitTokenDataError, err)
 			}
@@ -402,25 +445,105 @@ func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateTxWithBlockChain(
 	if err != nil {
 		return NewTransactionErr(InvalidDoubleSpendPrivacyTokenError, err)
 	}
+	if txCustomTokenPrivacy.isTokenSupplyRequest() {
+		_, err = txCustomTokenPrivacy.Metadata.ValidateTxWithBlockChain(&txCustomTokenPrivacy, bcr, shardID, db)
+		if err != nil {
+			return NewTransactionErr(PrivacyTokenSupplyError, err)
+		}
+	}
 	return nil
 }
 
 // ValidateSanityData - validate sanity data of PRV and pToken
 func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateSanityData(bcr metadata.BlockchainRetriever) (bool, error) {
 	// validate sanity data for PRV
-	result, err := txCustomTokenPrivacy.Tx.validateNormalTxSanityData()
+	result, err := txCustomTokenPrivacy.Tx.validateNormalTxSanityData(bcr)
 	if err != nil {
 		return result, NewTransactionErr(InvalidSanityDataPRVError, err)
 	}
 	// validate sanity for pToken
 
-	result, err = txCustomTokenPrivacy.TxTokenPrivacyData.TxNormal.validateNormalTxSanityData()
+	result, err = txCustomTokenPrivacy.TxTokenPrivacyData.TxNormal.validateNormalTxSanityData(bcr)
 	if err != nil {
 		return result, NewTransactionErr(InvalidSanityDataPrivacyTokenError, err)
 	}
+	// validate registry info of token, it is supported from tx version txVersionTokenInfo
+	tokenData := txCustomTokenPrivacy.TxTokenPrivacyData
+	if tokenData.hasTokenInfo() {
+		if tokenData.Type != CustomTokenInit {
+			return false, NewTransactionErr(PrivacyTokenInfoError, errors.New("only tx init token can have token info"))
+		}
+		if txCustomTokenPrivacy.Tx.Version < txVersionTokenInfo {
+			return false, NewTransactionErr(PrivacyTokenInfoError, fmt.Errorf("token info is not supported in tx version %d", txCustomTokenPrivacy.Tx.Version))
+		}
+		err = tokenData.validateTokenInfo(common.GetShardIDFromLastByte(txCustomTokenPrivacy.Tx.PubKeyLastByteSender))
+		if err != nil {
+			return false, NewTransactionErr(PrivacyTokenInfoError, err)
+		}
+	}
+	// validate mint and burn requests of token, mint request comes with a tx init token of existed token id
+	// and burn request comes with a public tx transfer token
+	if txCustomTokenPrivacy.isTokenSupplyRequest() {
+		switch txCustomTokenPrivacy.Metadata.GetType() {
+		case metadata.TokenMintRequestMeta:
+			if tokenData.Type != CustomTokenInit || !tokenData.Mintable {
+				return false, NewTransactionErr(PrivacyTokenSupplyError, errors.New("mint request must come with tx init mintable token"))
+			}
+		case metadata.TokenBurnRequestMeta:
+			if tokenData.Type != CustomTokenTransfer || tokenData.Mintable || tokenData.TxNormal.IsPrivacy() {
+				return false, NewTransactionErr(PrivacyTokenSupplyError, errors.New("burn request must come with non-privacy tx transfer token"))
+			}
+		}
+		_, ok, err := txCustomTokenPrivacy.Metadata.ValidateSanityData(bcr, &txCustomTokenPrivacy)
+		if err != nil || !ok {
+			return false, NewTransactionErr(PrivacyTokenSupplyError, err)
+		}
+	}
 	return result, nil
 }
 
+// isTokenSupplyRequest - check tx carries a mint or burn request of privacy custom token
+func (txCustomTokenPrivacy TxCustomTokenPrivacy) isTokenSupplyRequest() bool {
+	metaType := txCustomTokenPrivacy.GetMetadataType()
+	return metaType == metadata.TokenMintRequestMeta || metaType == metadata.TokenBurnRequestMeta
+}
+
+// validateMintedCoin - check coin minted by issuer, it is not proved like coins of tx init token
+// so its commitment must open to the amount of mint request with the public key of receiver
+func (txCustomTokenPrivacy TxCustomTokenPrivacy) validateMintedCoin() error {
+	mintReq, ok := txCustomTokenPrivacy.Metadata.(*metadata.TokenMintRequest)
+	if !ok {
+		return NewTransactionErr(PrivacyTokenSupplyError, errors.New("metadata is not mint request"))
+	}
+	tokenData := txCustomTokenPrivacy.TxTokenPrivacyData
+	proof := tokenData.TxNormal.Proof
+	if proof == nil || len(proof.GetInputCoins()) != 0 || len(proof.GetOutputCoins()) != 1 || proof.GetOutputCoins()[0].CoinDetails == nil {
+		return NewTransactionErr(PrivacyTokenSupplyError, errors.New("tx minting token must have only one output coin"))
+	}
+	coin := proof.GetOutputCoins()[0].CoinDetails
+	if coin.GetPublicKey() == nil || coin.GetRandomness() == nil || coin.GetSNDerivator() == nil || coin.GetCoinCommitment() == nil {
+		return NewTransactionErr(PrivacyTokenSupplyError, errors.New("minted coin is not opened"))
+	}
+	if coin.GetValue() != mintReq.Amount || tokenData.Amount != mintReq.Amount {
+		return NewTransactionErr(PrivacyTokenSupplyError, fmt.Errorf("minted coin has value %d, mint request has amount %d", coin.GetValue(), mintReq.Amount))
+	}
+	if !bytes.Equal(coin.GetPublicKey().Compress(), mintReq.Receiver.Pk) {
+		return NewTransactionErr(PrivacyTokenSupplyError, errors.New("minted coin is not of receiver of mint request"))
+	}
+	expectedCoin := new(privacy.Coin).Init()
+	expectedCoin.SetPublicKey(coin.GetPublicKey())
+	expectedCoin.SetValue(coin.GetValue())
+	expectedCoin.SetRandomness(coin.GetRandomness())
+	expectedCoin.SetSNDerivator(coin.GetSNDerivator())
+	if err := expectedCoin.CommitAll(); err != nil {
+		return NewTransactionErr(CommitOutputCoinError, err)
+	}
+	if !expectedCoin.GetCoinCommitment().IsEqual(coin.GetCoinCommitment()) {
+		return NewTransactionErr(PrivacyTokenSupplyError, errors.New("commitment of minted coin does not match its value"))
+	}
+	return nil
+}
+
 // ValidateTxByItself - validate tx by itself, check signature, proof,... and metadata
 func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateTxByItself(
 	hasPrivacyCoin bool,
@@ -428,8 +551,20 @@ func (txCustomTokenPrivacy TxCustomTokenPrivacy) ValidateTxByItself(
 	bcr metadata.BlockchainRetriever,
 	shardID byte,
 ) (bool, error) {
-	// no need to check for tx init token
+	// no need to check for tx init token, except PRV part of tx minting token which is signed by issuer
 	if txCustomTokenPrivacy.TxTokenPrivacyData.Type == CustomTokenInit {
+		if txCustomTokenPrivacy.GetMetadataType() != metadata.TokenMintRequestMeta {
+			return true, nil
+		}
+		if ok, err := txCustomTokenPrivacy.Tx.ValidateTransaction(false, db, shardID, nil); !ok {
+			return false, err
+		}
+		if !txCustomTokenPrivacy.Metadata.ValidateMetadataByItself() {
+			return false, NewTransactionErr(UnexpectedError, errors.New("Metadata is invalid"))
+		}
+		if err := txCustomTokenPrivacy.validateMintedCoin(); err != nil {
+			return false, err
+		}
 		return true, nil
 	}
 	// check for proof, signature ...
@@ -487,6 +622,10 @@ func (txCustomTokenPrivacy TxCustomTokenPrivacy) VerifyMinerCreatedTxBeforeGetti
 		Logger.log.Error("Mintable custom token must contain metadata")
 		return false, nil
 	}
+	// token minted by issuer is validated with its mint request
+	if meta.GetType() == metadata.TokenMintRequestMeta {
+		return true, nil
+	}
 	if !meta.IsMinerCreatedMetaType() {
 		return false, nil
 	}
@@ -0,0 +1,52 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMintTx(t *testing.T, coinValue uint64, mintAmount uint64) (*TxCustomTokenPrivacy, *privacy.Coin) {
+	receiver := privacy.GeneratePaymentAddress(privacy.GeneratePrivateKey([]byte{1}))
+	coin := new(privacy.Coin).Init()
+	coin.SetPublicKey(new(privacy.EllipticPoint))
+	assert.Nil(t, coin.GetPublicKey().Decompress(receiver.Pk))
+	coin.SetValue(coinValue)
+	coin.SetRandomness(privacy.RandScalar())
+	coin.SetSNDerivator(privacy.RandScalar())
+	assert.Nil(t, coin.CommitAll())
+	proof := new(zkp.PaymentProof)
+	proof.SetOutputCoins([]*privacy.OutputCoin{{CoinDetails: coin}})
+
+	mintReq, err := metadata.NewTokenMintRequest(common.HashH([]byte("token")), mintAmount, receiver, metadata.TokenMintRequestMeta)
+	assert.Nil(t, err)
+	tx := &TxCustomTokenPrivacy{}
+	tx.Metadata = mintReq
+	tx.TxTokenPrivacyData.Type = CustomTokenInit
+	tx.TxTokenPrivacyData.Amount = mintAmount
+	tx.TxTokenPrivacyData.TxNormal = Tx{Proof: proof}
+	return tx, coin
+}
+
+func TestValidateMintedCoin(t *testing.T) {
+	tx, coin := newTestMintTx(t, 100, 100)
+	assert.Nil(t, tx.validateMintedCoin())
+
+	// value of coin is changed but its commitment is still of the old value
+	coin.SetValue(1000)
+	assert.NotNil(t, tx.validateMintedCoin())
+
+	// value of coin is not the minted amount
+	tx, _ = newTestMintTx(t, 1000, 100)
+	assert.NotNil(t, tx.validateMintedCoin())
+
+	// coin is not of receiver
+	tx, coin = newTestMintTx(t, 100, 100)
+	coin.SetPublicKey(privacy.PedCom.G[0].ScalarMult(privacy.RandScalar()))
+	assert.Nil(t, coin.CommitAll())
+	assert.NotNil(t, tx.validateMintedCoin())
+}
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"strconv"
@@ -20,12 +21,63 @@ type TxTokenPrivacyData struct {
 	Type     int    // action type
 	Mintable bool   // default false
 	Amount   uint64 // init amount
+
+	// registry info of a new token, only set by tx init token
+	Decimals     uint8  `json:",omitempty"`
+	Description  string `json:",omitempty"`
+	IssuerKey    []byte `json:",omitempty"` // public key of issuer, optional for common.TokenSupplyFixed
+	SupplyPolicy byte   `json:",omitempty"` // common.TokenSupplyFixed or common.TokenSupplyIssuerMintable
+}
+
+// hasTokenInfo returns true if registry info of token is set
+func (txTokenPrivacyData TxTokenPrivacyData) hasTokenInfo() bool {
+	return txTokenPrivacyData.Decimals > 0 || txTokenPrivacyData.Description != "" ||
+		len(txTokenPrivacyData.IssuerKey) > 0 || txTokenPrivacyData.SupplyPolicy != common.TokenSupplyFixed
+}
+
+// validateTokenInfo checks registry info of token, issuer must be in shard of tx init token
+func (txTokenPrivacyData TxTokenPrivacyData) validateTokenInfo(shardID byte) error {
+	if txTokenPrivacyData.Decimals > MaxTokenDecimals {
+		return fmt.Errorf("token decimals must not be greater than %d", MaxTokenDecimals)
+	}
+	if len(txTokenPrivacyData.Description) > MaxTokenDescriptionSize {
+		return fmt.Errorf("token description must not be longer than %d bytes", MaxTokenDescriptionSize)
+	}
+	switch txTokenPrivacyData.SupplyPolicy {
+	case common.TokenSupplyFixed:
+	case common.TokenSupplyIssuerMintable:
+		if len(txTokenPrivacyData.IssuerKey) == 0 {
+			return errors.New("issuer-mintable token must have issuer key")
+		}
+		if txTokenPrivacyData.Mintable {
+			return errors.New("bridge token can not be issuer-mintable")
+		}
+	default:
+		return fmt.Errorf("token supply policy %d is invalid", txTokenPrivacyData.SupplyPolicy)
+	}
+	if len(txTokenPrivacyData.IssuerKey) > 0 {
+		issuerKey := txTokenPrivacyData.IssuerKey
+		if len(issuerKey) != privacy.CompressedEllipticPointSize || new(privacy.EllipticPoint).Decompress(issuerKey) != nil {
+			return errors.New("issuer key is invalid")
+		}
+		if common.GetShardIDFromLastByte(issuerKey[len(issuerKey)-1]) != shardID {
+			return errors.New("issuer must be in shard of tx init token")
+		}
+	}
+	return nil
 }
 
 func (txTokenPrivacyData TxTokenPrivacyData) String() string {
 	record := txTokenPrivacyData.PropertyName
 	record += txTokenPrivacyData.PropertySymbol
 	record += fmt.Sprintf("%d", txTokenPrivacyData.Amount)
+	// token info is hashed only if set, so ids of tokens without it are unchanged
+	if txTokenPrivacyData.hasTokenInfo() {
+		record += fmt.Sprintf("%d", txTokenPrivacyData.Decimals)
+		record += txTokenPrivacyData.Description
+		record += string(txTokenPrivacyData.IssuerKey)
+		record += fmt.Sprintf("%d", txTokenPrivacyData.SupplyPolicy)
+	}
 	if txTokenPrivacyData.TxNormal.Proof != nil {
 		for _, out := range txTokenPrivacyData.TxNormal.Proof.GetOutputCoins() {
 			record += string(out.CoinDetails.GetPublicKey().Compress())
@@ -69,6 +121,12 @@ type CustomTokenPrivacyParamTx struct {
 	TokenInput     []*privacy.InputCoin   `json:"TokenInput"`
 	Mintable       bool                   `json:"TokenMintable"`
 	Fee            uint64                 `json:"TokenFee"`
+
+	// registry info of a new token, used when TokenTxType is CustomTokenInit
+	Decimals     uint8  `json:"TokenDecimals"`
+	Description  string `json:"TokenDescription"`
+	IssuerKey    []byte `json:"TokenIssuerKey"`
+	SupplyPolicy byte   `json:"TokenSupplyPolicy"`
 }
 
 // CreateCustomTokenReceiverArray - parse data frm rpc request to create a list vout for preparing to create a custom token tx
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/privacy"
 	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,43 @@ func TestCreateCustomTokenPrivacyReceiverArray(t *testing.T) {
 	assert.Equal(t, uint64(30), uint64(voutsAmount))
 	assert.Equal(t, 2, len(result))
 }
+
+func TestTxTokenPrivacyDataTokenInfo(t *testing.T) {
+	data := TxTokenPrivacyData{
+		PropertyName:   "token",
+		PropertySymbol: "TKN",
+		Amount:         1000,
+	}
+	assert.Equal(t, false, data.hasTokenInfo())
+	assert.Equal(t, "tokenTKN1000", data.String())
+	hash, _ := data.Hash()
+
+	issuerKey := privacy.GeneratePublicKey(privacy.GeneratePrivateKey([]byte{1}))
+	shardID := common.GetShardIDFromLastByte(issuerKey[len(issuerKey)-1])
+	data.Decimals = 9
+	data.IssuerKey = issuerKey
+	data.SupplyPolicy = common.TokenSupplyIssuerMintable
+	assert.Equal(t, true, data.hasTokenInfo())
+	assert.Equal(t, nil, data.validateTokenInfo(shardID))
+	hashWithInfo, _ := data.Hash()
+	assert.NotEqual(t, *hash, *hashWithInfo)
+
+	// issuer must be in shard of tx init token
+	assert.NotEqual(t, nil, data.validateTokenInfo(shardID+1))
+
+	invalid := data
+	invalid.Decimals = MaxTokenDecimals + 1
+	assert.NotEqual(t, nil, invalid.validateTokenInfo(shardID))
+
+	invalid = data
+	invalid.IssuerKey = nil
+	assert.NotEqual(t, nil, invalid.validateTokenInfo(shardID))
+
+	invalid = data
+	invalid.Mintable = true
+	assert.NotEqual(t, nil, invalid.validateTokenInfo(shardID))
+
+	invalid = data
+	invalid.SupplyPolicy = 2
+	assert.NotEqual(t, nil, invalid.validateTokenInfo(shardID))
+}
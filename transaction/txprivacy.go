@@ -29,6 +29,13 @@ type Tx struct {
 	Fee      uint64 `json:"Fee"` // Fee applies: always consant
 	Info     []byte // 512 bytes
 
+	// ExpiryHeight is the last beacon height at which tx can be included in a block, 0 means tx never expires
+	ExpiryHeight uint64 `json:"ExpiryHeight,omitempty"`
+
+	// MultiSigAccount is the multisig account whose coins are spent by tx (see privacy.MultiSigAccount),
+	// SigPubKey is then public key of the account and Sig is combined from signatures of its cosigners
+	MultiSigAccount []byte `json:"MultiSigAccount,omitempty"`
+
 	// Sign and Privacy proof, required
 	SigPubKey            []byte `json:"SigPubKey, omitempty"` // 33 bytes
 	Sig                  []byte `json:"Sig, omitempty"`       //
@@ -76,6 +83,12 @@ type TxPrivacyInitParams struct {
 	tokenID     *common.Hash // default is nil -> use for prv coin
 	metaData    metadata.Metadata
 	info        []byte //512
+
+	expiryHeight uint64 // 0 means tx never expires
+
+	multiSigAccount *privacy.MultiSigAccount // spends coins of a multisig account instead of coins of senderSK
+
+	ringSize int // number of commitments in ring of each input coin
 }
 
 func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
@@ -95,10 +108,30 @@ func NewTxPrivacyInitParams(senderSK *privacy.PrivateKey,
 		metaData:    metaData,
 		paymentInfo: paymentInfo,
 		senderSK:    senderSK,
+		ringSize:    privacy.CommitmentRingSize,
 	}
 	return params
 }
 
+// SetRingSize sets ring size of one out of many proofs, it must be at least the minimum ring size of the network
+func (params *TxPrivacyInitParams) SetRingSize(ringSize int) *TxPrivacyInitParams {
+	params.ringSize = ringSize
+	return params
+}
+
+// SetExpiryHeight sets the last beacon height at which tx can be included in a block
+func (params *TxPrivacyInitParams) SetExpiryHeight(expiryHeight uint64) *TxPrivacyInitParams {
+	params.expiryHeight = expiryHeight
+	return params
+}
+
+// SetMultiSigAccount makes tx spend coins of a multisig account, senderSK is not used
+// and tx is left unsigned until cosigners of the account combine their signatures
+func (params *TxPrivacyInitParams) SetMultiSigAccount(account *privacy.MultiSigAccount) *TxPrivacyInitParams {
+	params.multiSigAccount = account
+	return params
+}
+
 // Init - init value for tx from inputcoin(old output coin from old tx)
 // create new outputcoin and build privacy proof
 // if not want to create a privacy tx proof, set hashPrivacy = false
@@ -132,16 +165,28 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	if tx.LockTime == 0 {
 		tx.LockTime = time.Now().Unix()
 	}
+	tx.ExpiryHeight = params.expiryHeight
 
-	// create sender's key set from sender's spending key
-	senderFullKey := incognitokey.KeySet{}
-	err = senderFullKey.InitFromPrivateKey(params.senderSK)
-	if err != nil {
-		Logger.log.Error(errors.New(fmt.Sprintf("Can not import Private key for sender keyset from %+v", params.senderSK)))
-		return NewTransactionErr(PrivateKeySenderInvalidError, err)
+	var senderPaymentAddress privacy.PaymentAddress
+	if params.multiSigAccount != nil {
+		// serial numbers of coins of multisig account are public, so the spending tx can not have privacy
+		if params.hasPrivacy {
+			return NewTransactionErr(MultiSigAccountError, errors.New("tx spending coins of multisig account can not have privacy"))
+		}
+		tx.MultiSigAccount = params.multiSigAccount.Bytes()
+		senderPaymentAddress = params.multiSigAccount.GetPaymentAddress()
+	} else {
+		// create sender's key set from sender's spending key
+		senderFullKey := incognitokey.KeySet{}
+		err = senderFullKey.InitFromPrivateKey(params.senderSK)
+		if err != nil {
+			Logger.log.Error(errors.New(fmt.Sprintf("Can not import Private key for sender keyset from %+v", params.senderSK)))
+			return NewTransactionErr(PrivateKeySenderInvalidError, err)
+		}
+		senderPaymentAddress = senderFullKey.PaymentAddress
 	}
 	// get public key last byte of sender
-	pkLastByteSender := senderFullKey.PaymentAddress.Pk[len(senderFullKey.PaymentAddress.Pk)-1]
+	pkLastByteSender := senderPaymentAddress.Pk[len(senderPaymentAddress.Pk)-1]
 
 	// init info of tx
 	tx.Info = []byte{}
@@ -159,8 +204,12 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	if len(params.inputCoins) == 0 && params.fee == 0 && !params.hasPrivacy {
 		Logger.log.Debugf("len(inputCoins) == 0 && fee == 0 && !hasPrivacy\n")
 		tx.Fee = params.fee
-		tx.sigPrivKey = *params.senderSK
 		tx.PubKeyLastByteSender = pkLastByteSender
+		if params.multiSigAccount != nil {
+			tx.SigPubKey = senderPaymentAddress.Pk
+			return nil
+		}
+		tx.sigPrivKey = *params.senderSK
 		err := tx.signTx()
 		if err != nil {
 			Logger.log.Error(errors.New(fmt.Sprintf("cannot sign tx", err)))
@@ -170,21 +219,10 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	}
 
 	shardID := common.GetShardIDFromLastByte(pkLastByteSender)
-	var commitmentIndexs []uint64   // array index random of commitments in db
-	var myCommitmentIndexs []uint64 // index in array index random of commitment in db
-
-	if params.hasPrivacy {
-		randomParams := NewRandomCommitmentsProcessParam(params.inputCoins, privacy.CommitmentRingSize, params.db, shardID, params.tokenID)
-		commitmentIndexs, myCommitmentIndexs, _ = RandomCommitmentsProcess(randomParams)
-
-		// Check number of list of random commitments, list of random commitment indices
-		if len(commitmentIndexs) != len(params.inputCoins)*privacy.CommitmentRingSize {
-			return NewTransactionErr(RandomCommitmentError, nil)
-		}
-
-		if len(myCommitmentIndexs) != len(params.inputCoins) {
-			return NewTransactionErr(RandomCommitmentError, errors.New("number of list my commitment indices must be equal to number of input coins"))
-		}
+	// array index random of commitments in db and index in array index random of commitment in db
+	commitmentIndexs, myCommitmentIndexs, err := getRingCommitmentIndices(params.inputCoins, params.hasPrivacy, params.ringSize, params.db, shardID, params.tokenID)
+	if err != nil {
+		return err
 	}
 
 	// Calculate execution time for creating payment proof
@@ -215,23 +253,70 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	if overBalance > 0 {
 		changePaymentInfo := new(privacy.PaymentInfo)
 		changePaymentInfo.Amount = uint64(overBalance)
-		changePaymentInfo.PaymentAddress = senderFullKey.PaymentAddress
+		changePaymentInfo.PaymentAddress = senderPaymentAddress
 		params.paymentInfo = append(params.paymentInfo, changePaymentInfo)
 	}
 
-	// create new output coins
-	outputCoins := make([]*privacy.OutputCoin, len(params.paymentInfo))
-
 	// create SNDs for output coins
+	sndOuts := randomOutputSNDs(len(params.paymentInfo), params.tokenID, shardID, params.db)
+
+	// get list of commitments for proving one-out-of-many from commitmentIndexs
+	commitmentProving, err := getCommitmentsByIndices(commitmentIndexs, params.db, params.tokenID, shardID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.proveAndSign(params, senderPaymentAddress, commitmentIndexs, myCommitmentIndexs, commitmentProving, sndOuts)
+	if err != nil {
+		return err
+	}
+
+	elapsedPrivacy := time.Since(startPrivacy)
+	elapsed := time.Since(start)
+	Logger.log.Debugf("Creating payment proof time %s", elapsedPrivacy)
+	Logger.log.Debugf("Successfully Creating normal tx %+v in %s time", *tx.Hash(), elapsed)
+	return nil
+}
+
+// getRingCommitmentIndices - random indices of commitments in db to hide input coins in rings of ringSize commitments,
+// returns indices of ring members and indices of input coins among them, tx without privacy has no ring
+func getRingCommitmentIndices(inputCoins []*privacy.InputCoin, hasPrivacy bool, ringSize int, db database.DatabaseInterface, shardID byte, tokenID *common.Hash) ([]uint64, []uint64, error) {
+	var commitmentIndexs []uint64   // array index random of commitments in db
+	var myCommitmentIndexs []uint64 // index in array index random of commitment in db
+	if !hasPrivacy {
+		return commitmentIndexs, myCommitmentIndexs, nil
+	}
+	if _, ok := privacy.GetCommitmentRingSizeExp(ringSize); !ok {
+		return nil, nil, NewTransactionErr(RandomCommitmentError, fmt.Errorf("ring size %d is not supported", ringSize))
+	}
+	randomParams := NewRandomCommitmentsProcessParam(inputCoins, ringSize, db, shardID, tokenID)
+	commitmentIndexs, myCommitmentIndexs, _, err := RandomCommitmentsProcess(randomParams)
+	if err != nil {
+		return nil, nil, NewTransactionErr(RandomCommitmentError, err)
+	}
+
+	// Check number of list of random commitments, list of random commitment indices
+	if len(commitmentIndexs) != len(inputCoins)*ringSize {
+		return nil, nil, NewTransactionErr(RandomCommitmentError, nil)
+	}
+
+	if len(myCommitmentIndexs) != len(inputCoins) {
+		return nil, nil, NewTransactionErr(RandomCommitmentError, errors.New("number of list my commitment indices must be equal to number of input coins"))
+	}
+	return commitmentIndexs, myCommitmentIndexs, nil
+}
+
+// randomOutputSNDs - random distinct SNDs for output coins, which are not existed in db
+func randomOutputSNDs(numOutputs int, tokenID *common.Hash, shardID byte, db database.DatabaseInterface) []*big.Int {
 	ok := true
 	sndOuts := make([]*big.Int, 0)
 	for ok {
 		var sndOut *big.Int
-		for i := 0; i < len(params.paymentInfo); i++ {
+		for i := 0; i < numOutputs; i++ {
 			sndOut = privacy.RandScalar()
 			for {
 
-				ok1, err := CheckSNDerivatorExistence(params.tokenID, sndOut, shardID, params.db)
+				ok1, err := CheckSNDerivatorExistence(tokenID, sndOut, shardID, db)
 				if err != nil {
 					Logger.log.Error(err)
 				}
@@ -251,19 +336,79 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 			sndOuts = make([]*big.Int, 0)
 		}
 	}
+	return sndOuts
+}
+
+// getCommitmentsByIndices - get commitments in db of ring members for proving one-out-of-many
+func getCommitmentsByIndices(commitmentIndexs []uint64, db database.DatabaseInterface, tokenID *common.Hash, shardID byte) ([]*privacy.EllipticPoint, error) {
+	commitmentProving := make([]*privacy.EllipticPoint, len(commitmentIndexs))
+	for i, cmIndex := range commitmentIndexs {
+		commitmentProving[i] = new(privacy.EllipticPoint)
+		temp, err := db.GetCommitmentByIndex(*tokenID, cmIndex, shardID)
+		if err != nil {
+			Logger.log.Error(errors.New(fmt.Sprintf("can not get commitment from index=%d shardID=%+v", cmIndex, shardID)))
+			return nil, NewTransactionErr(CanNotGetCommitmentFromIndexError, err, cmIndex, shardID)
+		}
+		err = commitmentProving[i].Decompress(temp)
+		if err != nil {
+			Logger.log.Error(errors.New(fmt.Sprintf("can not get commitment from index=%d shardID=%+v value=%+v", cmIndex, shardID, temp)))
+			return nil, NewTransactionErr(CanNotDecompressCommitmentFromIndexError, err, cmIndex, shardID, temp)
+		}
+	}
+	return commitmentProving, nil
+}
+
+// proveAndSign - create output coins, payment proof and signature of tx from input coins, ring members and SNDs of output coins,
+// payment infos of params already contain change of sender. It does not query database, so it can run on a device without chain data
+func (tx *Tx) proveAndSign(
+	params *TxPrivacyInitParams,
+	senderPaymentAddress privacy.PaymentAddress,
+	commitmentIndexs []uint64,
+	myCommitmentIndexs []uint64,
+	commitmentProving []*privacy.EllipticPoint,
+	sndOuts []*big.Int,
+) error {
+	var err error
+	pkLastByteSender := senderPaymentAddress.Pk[len(senderPaymentAddress.Pk)-1]
+
+	// create new output coins
+	outputCoins := make([]*privacy.OutputCoin, len(params.paymentInfo))
+
+	// outputs of privacy tx (without metadata) are sent to one-time public keys of receivers,
+	// so coins received by the same payment address can not be linked
+	useOneTimeKey := params.hasPrivacy && params.metaData == nil && tx.Version >= txVersionOneTimeKey
 
 	// create new output coins with info: Pk, value, last byte of pk, snd
 	for i, pInfo := range params.paymentInfo {
 		outputCoins[i] = new(privacy.OutputCoin)
 		outputCoins[i].CoinDetails = new(privacy.Coin)
 		outputCoins[i].CoinDetails.SetValue(pInfo.Amount)
-		outputCoins[i].CoinDetails.SetPublicKey(new(privacy.EllipticPoint))
-		err := outputCoins[i].CoinDetails.GetPublicKey().Decompress(pInfo.PaymentAddress.Pk)
-		if err != nil {
-			Logger.log.Error(errors.New(fmt.Sprintf("can not decompress public key from %+v", pInfo.PaymentAddress)))
-			return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+		if useOneTimeKey {
+			oneTimePublicKey, txRandom, err := privacy.GenerateOneTimePublicKey(pInfo.PaymentAddress)
+			if err != nil {
+				Logger.log.Error(errors.New(fmt.Sprintf("can not generate one-time public key for %+v", pInfo.PaymentAddress)))
+				return NewTransactionErr(GenerateOneTimePublicKeyError, err, pInfo.PaymentAddress)
+			}
+			outputCoins[i].CoinDetails.SetPublicKey(oneTimePublicKey)
+			outputCoins[i].CoinDetails.SetTxRandom(txRandom)
+		} else {
+			outputCoins[i].CoinDetails.SetPublicKey(new(privacy.EllipticPoint))
+			err := outputCoins[i].CoinDetails.GetPublicKey().Decompress(pInfo.PaymentAddress.Pk)
+			if err != nil {
+				Logger.log.Error(errors.New(fmt.Sprintf("can not decompress public key from %+v", pInfo.PaymentAddress)))
+				return NewTransactionErr(DecompressPaymentAddressError, err, pInfo.PaymentAddress)
+			}
 		}
 		outputCoins[i].CoinDetails.SetSNDerivator(sndOuts[i])
+
+		// memo is readable only by the receiver, it is supported from tx version txVersionMemo
+		if len(pInfo.Memo) > 0 && tx.Version >= txVersionMemo {
+			err := outputCoins[i].CoinDetails.EncryptMemo(pInfo.Memo, pInfo.PaymentAddress.Tk)
+			if err != nil {
+				Logger.log.Error(errors.New(fmt.Sprintf("can not encrypt memo for %+v", pInfo.PaymentAddress)))
+				return NewTransactionErr(EncryptMemoError, err, pInfo.PaymentAddress)
+			}
+		}
 	}
 
 	// assign fee tx
@@ -272,27 +417,21 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 	// create zero knowledge proof of payment
 	tx.Proof = &zkp.PaymentProof{}
 
-	// get list of commitments for proving one-out-of-many from commitmentIndexs
-	commitmentProving := make([]*privacy.EllipticPoint, len(commitmentIndexs))
-	for i, cmIndex := range commitmentIndexs {
-		commitmentProving[i] = new(privacy.EllipticPoint)
-		temp, err := params.db.GetCommitmentByIndex(*params.tokenID, cmIndex, shardID)
-		if err != nil {
-			Logger.log.Error(errors.New(fmt.Sprintf("can not get commitment from index=%d shardID=%+v", cmIndex, shardID)))
-			return NewTransactionErr(CanNotGetCommitmentFromIndexError, err, cmIndex, shardID)
-		}
-		err = commitmentProving[i].Decompress(temp)
-		if err != nil {
-			Logger.log.Error(errors.New(fmt.Sprintf("can not get commitment from index=%d shardID=%+v value=%+v", cmIndex, shardID, temp)))
-			return NewTransactionErr(CanNotDecompressCommitmentFromIndexError, err, cmIndex, shardID, temp)
+	// serial numbers of coins of multisig account are hashed from their public key and snd
+	privateKey := big.NewInt(0)
+	if params.multiSigAccount != nil {
+		for _, coin := range params.inputCoins {
+			coin.CoinDetails.SetSerialNumber(privacy.MultiSigSerialNumber(coin.CoinDetails.GetPublicKey(), coin.CoinDetails.GetSNDerivator()))
 		}
+	} else {
+		privateKey.SetBytes(*params.senderSK)
 	}
 
 	// prepare witness for proving
 	witness := new(zkp.PaymentWitness)
 	paymentWitnessParam := zkp.PaymentWitnessParam{
 		HasPrivacy:              params.hasPrivacy,
-		PrivateKey:              new(big.Int).SetBytes(*params.senderSK),
+		PrivateKey:              privateKey,
 		InputCoins:              params.inputCoins,
 		OutputCoins:             outputCoins,
 		PublicKeyLastByteSender: pkLastByteSender,
@@ -300,6 +439,7 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 		CommitmentIndices:       commitmentIndexs,
 		MyCommitmentIndices:     myCommitmentIndexs,
 		Fee:                     params.fee,
+		MultiSig:                params.multiSigAccount != nil,
 	}
 	err = witness.Init(paymentWitnessParam)
 	if err.(*privacy.PrivacyError) != nil {
@@ -342,26 +482,28 @@ func (tx *Tx) Init(params *TxPrivacyInitParams) error {
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetSNDerivator(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetPublicKey(nil)
 			tx.Proof.GetInputCoins()[i].CoinDetails.SetRandomness(nil)
+			// memo would link the input coin to the output it was received in
+			tx.Proof.GetInputCoins()[i].CoinDetails.SetInfo(nil)
 		}
 
-	} else {
+	} else if params.multiSigAccount == nil {
 		tx.sigPrivKey = []byte{}
 		randSK := big.NewInt(0)
 		tx.sigPrivKey = append(*params.senderSK, randSK.Bytes()...)
 	}
 
-	// sign tx
+	// sign tx, tx spending coins of multisig account is signed later by cosigners of the account
 	tx.PubKeyLastByteSender = pkLastByteSender
-	err = tx.signTx()
-	if err != nil {
-		Logger.log.Error(err)
-		return NewTransactionErr(SignTxError, err)
+	if params.multiSigAccount != nil {
+		tx.SigPubKey = senderPaymentAddress.Pk
+	} else {
+		err = tx.signTx()
+		if err != nil {
+			Logger.log.Error(err)
+			return NewTransactionErr(SignTxError, err)
+		}
 	}
 
-	elapsedPrivacy := time.Since(startPrivacy)
-	elapsed := time.Since(start)
-	Logger.log.Debugf("Creating payment proof time %s", elapsedPrivacy)
-	Logger.log.Debugf("Successfully Creating normal tx %+v in %s time", *tx.Hash(), elapsed)
 	return nil
 }
 
@@ -405,6 +547,10 @@ func (tx *Tx) verifySigTx() (bool, error) {
 		return false, NewTransactionErr(UnexpectedError, errors.New("input transaction must be an signed one"))
 	}
 
+	if tx.IsMultiSig() {
+		return tx.verifyMultiSigTx()
+	}
+
 	var err error
 	res := false
 
@@ -438,6 +584,20 @@ func (tx *Tx) verifySigTx() (bool, error) {
 	return res, nil
 }
 
+// verifyMultiSigTx verifies signature of tx spending coins of a multisig account,
+// which is combined from signatures of at least M cosigners of the account
+func (tx *Tx) verifyMultiSigTx() (bool, error) {
+	account, err := tx.GetMultiSigAccount()
+	if err != nil {
+		Logger.log.Error(err)
+		return false, NewTransactionErr(MultiSigAccountError, err)
+	}
+	if !bytes.Equal(account.GetPublicKey().Compress(), tx.SigPubKey) {
+		return false, NewTransactionErr(MultiSigAccountError, errors.New("sig public key is not public key of multisig account"))
+	}
+	return account.VerifySig(tx.Hash()[:], tx.Sig), nil
+}
+
 // ValidateTransaction returns true if transaction is valid:
 // - Verify tx signature
 // - Verify the payment proof
@@ -510,7 +670,11 @@ func (tx *Tx) ValidateTransaction(hasPrivacy bool, db database.DatabaseInterface
 		}
 
 		// Verify the payment proof
-		valid, err = tx.Proof.Verify(hasPrivacy, tx.SigPubKey, tx.Fee, db, shardID, tokenID)
+		if tx.IsMultiSig() {
+			valid, err = tx.Proof.VerifyMultiSigSpending(tx.SigPubKey, tx.Fee)
+		} else {
+			valid, err = tx.Proof.Verify(hasPrivacy, tx.SigPubKey, tx.Fee, db, shardID, tokenID)
+		}
 		if !valid {
 			if err != nil {
 				Logger.log.Error(err)
@@ -533,6 +697,10 @@ func (tx Tx) String() string {
 	//fmt.
 	record += strconv.FormatInt(tx.LockTime, 10)
 	record += strconv.FormatUint(tx.Fee, 10)
+	// expiry height is only hashed when it is set, so hash of tx without expiry is unchanged
+	if tx.ExpiryHeight > 0 {
+		record += strconv.FormatUint(tx.ExpiryHeight, 10)
+	}
 	if tx.Proof != nil {
 		tmp := base58.Base58Check{}.Encode(tx.Proof.Bytes()[:], 0x00)
 		record += tmp
@@ -586,6 +754,7 @@ func (tx Tx) GetTxActualSize() uint64 {
 	sizeTx += sigPubKey
 	sig := uint64(len(tx.Sig))
 	sizeTx += sig
+	sizeTx += uint64(len(tx.MultiSigAccount))
 	if tx.Proof != nil {
 		proof := uint64(len(tx.Proof.Bytes()))
 		sizeTx += proof
@@ -794,7 +963,16 @@ func (tx Tx) ValidateTxWithBlockChain(
 	return tx.ValidateDoubleSpendWithBlockchain(bcr, shardID, db, nil)
 }
 
-func (tx Tx) validateNormalTxSanityData() (bool, error) {
+// getMinCommitmentRingSize returns the smallest ring size accepted in proof of tx with version,
+// the minimum ring size of the network applies from tx version txVersionRingSize
+func getMinCommitmentRingSize(version int8, bcr metadata.BlockchainRetriever) int {
+	if version < txVersionRingSize || bcr == nil {
+		return privacy.CommitmentRingSize
+	}
+	return bcr.GetMinCommitmentRingSize()
+}
+
+func (tx Tx) validateNormalTxSanityData(bcr metadata.BlockchainRetriever) (bool, error) {
 	//check version
 	if tx.Version > txVersion {
 		return false, errors.New(fmt.Sprintf("tx version is %d. Wrong version tx. Only support for version >= %d", tx.Version, txVersion))
@@ -803,6 +981,22 @@ func (tx Tx) validateNormalTxSanityData() (bool, error) {
 	if int64(tx.LockTime) > time.Now().Unix() {
 		return false, errors.New("wrong tx locktime")
 	}
+	// expiry height is supported from tx version txVersionExpiry
+	if tx.ExpiryHeight > 0 && tx.Version < txVersionExpiry {
+		return false, errors.New(fmt.Sprintf("expiry height is not supported in tx version %d", tx.Version))
+	}
+	// spending coins of multisig account is supported from tx version txVersionMultiSig, without privacy
+	if tx.IsMultiSig() {
+		if tx.Version < txVersionMultiSig {
+			return false, errors.New(fmt.Sprintf("multisig account is not supported in tx version %d", tx.Version))
+		}
+		if tx.IsPrivacy() {
+			return false, errors.New("tx spending coins of multisig account can not have privacy")
+		}
+		if _, err := tx.GetMultiSigAccount(); err != nil {
+			return false, err
+		}
+	}
 
 	// check tx size
 	if tx.GetTxActualSize() > common.MaxTxSize {
@@ -810,7 +1004,7 @@ func (tx Tx) validateNormalTxSanityData() (bool, error) {
 	}
 
 	// check sanity of Proof
-	validateSanityOfProof, err := tx.validateSanityDataOfProof()
+	validateSanityOfProof, err := tx.validateSanityDataOfProof(getMinCommitmentRingSize(tx.Version, bcr))
 	if err != nil || !validateSanityOfProof {
 		return false, err
 	}
@@ -837,9 +1031,23 @@ func (tx Tx) validateNormalTxSanityData() (bool, error) {
 	return true, nil
 }
 
-func (txN Tx) validateSanityDataOfProof() (bool, error) {
+func (txN Tx) validateSanityDataOfProof(minRingSize int) (bool, error) {
 	if txN.Proof != nil {
 
+		// one-time public keys of output coins are supported from tx version txVersionOneTimeKey
+		for i := 0; i < len(txN.Proof.GetOutputCoins()); i++ {
+			txRandom := txN.Proof.GetOutputCoins()[i].CoinDetails.GetTxRandom()
+			if txRandom == nil {
+				continue
+			}
+			if txN.Version < txVersionOneTimeKey {
+				return false, errors.New(fmt.Sprintf("output coins with one-time public key are not supported in tx version %d", txN.Version))
+			}
+			if !txRandom.IsSafe() {
+				return false, errors.New("validate sanity Tx random of output coin failed")
+			}
+		}
+
 		if len(txN.Proof.GetInputCoins()) > 255 {
 			return false, errors.New("Input coins in tx are very large:" + strconv.Itoa(len(txN.Proof.GetInputCoins())))
 		}
@@ -928,10 +1136,13 @@ func (txN Tx) validateSanityDataOfProof() (bool, error) {
 					return false, errors.New("validate sanity ComOutputValue of proof failed")
 				}
 			}
-			if len(txN.Proof.GetCommitmentIndices()) != len(txN.Proof.GetInputCoins())*privacy.CommitmentRingSize {
+			if len(txN.Proof.GetCommitmentIndices()) != len(txN.Proof.GetInputCoins())*txN.Proof.GetRingSize() {
 				return false, errors.New("validate sanity CommitmentIndices of proof failed")
 
 			}
+			if len(txN.Proof.GetInputCoins()) > 0 && txN.Proof.GetRingSize() < minRingSize {
+				return false, errors.New("validate sanity ring size of proof failed")
+			}
 		}
 
 		if !isPrivacy {
@@ -991,7 +1202,7 @@ func (tx Tx) ValidateSanityData(bcr metadata.BlockchainRetriever) (bool, error)
 		}
 	}
 	Logger.log.Debugf("\n\n\n END sanity data of metadata%+v\n\n\n")
-	return tx.validateNormalTxSanityData()
+	return tx.validateNormalTxSanityData(bcr)
 }
 
 func (tx Tx) ValidateTxByItself(
@@ -1050,6 +1261,30 @@ func (tx Tx) GetLockTime() int64 {
 	return tx.LockTime
 }
 
+func (tx Tx) GetExpiryHeight() uint64 {
+	return tx.ExpiryHeight
+}
+
+// IsExpired returns true if tx can not be included in a block at beacon height beaconHeight
+func (tx Tx) IsExpired(beaconHeight uint64) bool {
+	return tx.ExpiryHeight > 0 && beaconHeight > tx.ExpiryHeight
+}
+
+// IsMultiSig returns true if tx spends coins of a multisig account
+func (tx Tx) IsMultiSig() bool {
+	return len(tx.MultiSigAccount) > 0
+}
+
+// GetMultiSigAccount returns the multisig account whose coins are spent by tx
+func (tx Tx) GetMultiSigAccount() (*privacy.MultiSigAccount, error) {
+	account := new(privacy.MultiSigAccount)
+	err := account.SetBytes(tx.MultiSigAccount)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
 func (tx Tx) GetSigPubKey() []byte {
 	return tx.SigPubKey
 }
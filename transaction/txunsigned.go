@@ -0,0 +1,237 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// UnsignedTx is a template of tx sending PRV, built by a node which does not have private key of sender:
+// input coins of sender, ring members hiding them in tx with privacy and outputs with fresh SNDs.
+// Payment proof and signature of tx are created from the template on an offline device holding the key (see InitFromUnsignedTx)
+type UnsignedTx struct {
+	Version      int8
+	LockTime     int64
+	Fee          uint64
+	Info         []byte
+	ExpiryHeight uint64 `json:",omitempty"`
+	HasPrivacy   bool
+
+	SenderAddress privacy.PaymentAddress
+	InputCoins    []*privacy.InputCoin
+
+	// ring members of input coins, empty in tx without privacy
+	CommitmentIndices   []uint64 // indices in db of commitments of ring members
+	MyCommitmentIndices []uint64 // positions of input coins in CommitmentIndices
+	Commitments         [][]byte // compressed commitments of ring members
+
+	// outputs of tx, the last one is change of sender if input coins are over payment amount and fee
+	PaymentInfos []*privacy.PaymentInfo
+	OutputSNDs   [][]byte
+}
+
+// NewUnsignedTx - build template of tx sending PRV to receivers from input coins of sender (decrypted by readonly key of sender),
+// fee is paid by input coins and the rest is changed to sender, input coins are hidden in rings of ringSize commitments
+func NewUnsignedTx(
+	senderAddress privacy.PaymentAddress,
+	paymentInfos []*privacy.PaymentInfo,
+	inputCoins []*privacy.InputCoin,
+	fee uint64,
+	hasPrivacy bool,
+	ringSize int,
+	db database.DatabaseInterface,
+) (*UnsignedTx, error) {
+	if len(inputCoins) == 0 {
+		return nil, NewTransactionErr(UnsignedTxError, errors.New("unsigned tx must spend input coins"))
+	}
+	if len(inputCoins) > 255 {
+		return nil, NewTransactionErr(InputCoinIsVeryLargeError, nil, strconv.Itoa(len(inputCoins)))
+	}
+	if len(paymentInfos) > 254 {
+		return nil, NewTransactionErr(PaymentInfoIsVeryLargeError, nil, strconv.Itoa(len(paymentInfos)))
+	}
+	if len(senderAddress.Pk) != privacy.CompressedEllipticPointSize {
+		return nil, NewTransactionErr(UnsignedTxError, errors.New("invalid payment address of sender"))
+	}
+	tokenID := &common.Hash{}
+	tokenID.SetBytes(common.PRVCoinID[:])
+	shardID := common.GetShardIDFromLastByte(senderAddress.Pk[len(senderAddress.Pk)-1])
+
+	// input coins decrypted by readonly key have no serial number, it is computed later from private key
+	sumInputValue := uint64(0)
+	for _, coin := range inputCoins {
+		if coin.CoinDetails == nil || coin.CoinDetails.GetSNDerivator() == nil || coin.CoinDetails.GetRandomness() == nil {
+			return nil, NewTransactionErr(UnsignedTxError, errors.New("details of input coin are not decrypted"))
+		}
+		coin.CoinDetails.SetSerialNumber(nil)
+		sumInputValue += coin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, p := range paymentInfos {
+		sumOutputValue += p.Amount
+	}
+	if sumInputValue < sumOutputValue+fee {
+		return nil, NewTransactionErr(WrongInputError, errors.New(fmt.Sprintf("input value less than output value. sumInputValue=%d sumOutputValue=%d fee=%d", sumInputValue, sumOutputValue, fee)))
+	}
+	if overBalance := sumInputValue - sumOutputValue - fee; overBalance > 0 {
+		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
+			PaymentAddress: senderAddress,
+			Amount:         overBalance,
+		})
+	}
+
+	unsignedTx := &UnsignedTx{
+		Version:       txVersion,
+		LockTime:      time.Now().Unix(),
+		Fee:           fee,
+		Info:          []byte{},
+		HasPrivacy:    hasPrivacy,
+		SenderAddress: senderAddress,
+		InputCoins:    inputCoins,
+		PaymentInfos:  paymentInfos,
+	}
+	var err error
+	unsignedTx.CommitmentIndices, unsignedTx.MyCommitmentIndices, err = getRingCommitmentIndices(inputCoins, hasPrivacy, ringSize, db, shardID, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := getCommitmentsByIndices(unsignedTx.CommitmentIndices, db, tokenID, shardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, commitment := range commitments {
+		unsignedTx.Commitments = append(unsignedTx.Commitments, commitment.Compress())
+	}
+	for _, sndOut := range randomOutputSNDs(len(paymentInfos), tokenID, shardID, db) {
+		unsignedTx.OutputSNDs = append(unsignedTx.OutputSNDs, common.AddPaddingBigInt(sndOut, common.BigIntSize))
+	}
+	return unsignedTx, nil
+}
+
+// validate - check template is consistent before proving, values of outputs and fee must be equal to values of input coins
+func (unsignedTx UnsignedTx) validate() error {
+	if unsignedTx.Version < 1 || unsignedTx.Version > txVersion {
+		return fmt.Errorf("tx version %d is not supported", unsignedTx.Version)
+	}
+	if len(unsignedTx.InputCoins) == 0 || len(unsignedTx.InputCoins) > 255 {
+		return fmt.Errorf("number of input coins %d is invalid", len(unsignedTx.InputCoins))
+	}
+	if len(unsignedTx.PaymentInfos) > 255 || len(unsignedTx.OutputSNDs) != len(unsignedTx.PaymentInfos) {
+		return errors.New("number of outputs or output SNDs is invalid")
+	}
+	if len(unsignedTx.SenderAddress.Pk) != privacy.CompressedEllipticPointSize {
+		return errors.New("invalid payment address of sender")
+	}
+
+	sumInputValue := uint64(0)
+	// commitments are recomputed from details of input coins, commitments sent with the coins are not trusted
+	inputCommitments := make([][]byte, len(unsignedTx.InputCoins))
+	for i, coin := range unsignedTx.InputCoins {
+		if coin == nil || coin.CoinDetails == nil || coin.CoinDetails.GetPublicKey() == nil || coin.CoinDetails.GetSNDerivator() == nil || coin.CoinDetails.GetRandomness() == nil {
+			return errors.New("details of input coin are missing")
+		}
+		coinDetails := *coin.CoinDetails
+		if err := coinDetails.CommitAll(); err != nil {
+			return err
+		}
+		inputCommitments[i] = coinDetails.GetCoinCommitment().Compress()
+		if cm := coin.CoinDetails.GetCoinCommitment(); cm != nil && !bytes.Equal(cm.Compress(), inputCommitments[i]) {
+			return errors.New("commitment of input coin does not match its details")
+		}
+		sumInputValue += coin.CoinDetails.GetValue()
+	}
+	sumOutputValue := uint64(0)
+	for _, p := range unsignedTx.PaymentInfos {
+		if p == nil || len(p.PaymentAddress.Pk) != privacy.CompressedEllipticPointSize {
+			return errors.New("invalid payment address of receiver")
+		}
+		if sumOutputValue+p.Amount < sumOutputValue {
+			return errors.New("output value overflows")
+		}
+		sumOutputValue += p.Amount
+	}
+	if sumOutputValue+unsignedTx.Fee < sumOutputValue || sumInputValue != sumOutputValue+unsignedTx.Fee {
+		return fmt.Errorf("input value %d is not equal to output value %d and fee %d", sumInputValue, sumOutputValue, unsignedTx.Fee)
+	}
+
+	if !unsignedTx.HasPrivacy {
+		if len(unsignedTx.CommitmentIndices) > 0 || len(unsignedTx.Commitments) > 0 {
+			return errors.New("tx without privacy has no ring members")
+		}
+		return nil
+	}
+	if len(unsignedTx.Commitments) != len(unsignedTx.CommitmentIndices) || len(unsignedTx.Commitments)%len(unsignedTx.InputCoins) != 0 {
+		return errors.New("number of ring members is invalid")
+	}
+	if len(unsignedTx.MyCommitmentIndices) != len(unsignedTx.InputCoins) {
+		return errors.New("number of list my commitment indices must be equal to number of input coins")
+	}
+	for i, myIndex := range unsignedTx.MyCommitmentIndices {
+		if myIndex >= uint64(len(unsignedTx.Commitments)) {
+			return errors.New("my commitment index is out of range")
+		}
+		if !bytes.Equal(inputCommitments[i], unsignedTx.Commitments[myIndex]) {
+			return errors.New("input coin is not in its ring")
+		}
+	}
+	return nil
+}
+
+// InitFromUnsignedTx - create payment proof and signature of tx from its template with private key of sender.
+// It does not query database, so it can run on an offline device, tx is then sent to a node as a normal signed tx
+func (tx *Tx) InitFromUnsignedTx(unsignedTx *UnsignedTx, senderSK *privacy.PrivateKey) error {
+	if err := unsignedTx.validate(); err != nil {
+		return NewTransactionErr(UnsignedTxError, err)
+	}
+	senderFullKey := incognitokey.KeySet{}
+	err := senderFullKey.InitFromPrivateKey(senderSK)
+	if err != nil {
+		return NewTransactionErr(PrivateKeySenderInvalidError, err)
+	}
+	if !bytes.Equal(senderFullKey.PaymentAddress.Pk, unsignedTx.SenderAddress.Pk) {
+		return NewTransactionErr(UnsignedTxError, errors.New("private key is not of sender"))
+	}
+
+	// serial numbers of input coins can only be computed from private key
+	privateKey := new(big.Int).SetBytes(*senderSK)
+	for _, coin := range unsignedTx.InputCoins {
+		coin.CoinDetails.SetSerialNumber(privacy.PedCom.G[privacy.PedersenPrivateKeyIndex].Derive(privateKey, coin.CoinDetails.GetSNDerivator()))
+	}
+	commitmentProving := make([]*privacy.EllipticPoint, len(unsignedTx.Commitments))
+	for i, commitment := range unsignedTx.Commitments {
+		commitmentProving[i] = new(privacy.EllipticPoint)
+		if err := commitmentProving[i].Decompress(commitment); err != nil {
+			return NewTransactionErr(UnsignedTxError, err)
+		}
+	}
+	sndOuts := make([]*big.Int, len(unsignedTx.OutputSNDs))
+	for i, snd := range unsignedTx.OutputSNDs {
+		sndOuts[i] = new(big.Int).SetBytes(snd)
+	}
+	if common.CheckDuplicateBigIntArray(sndOuts) {
+		return NewTransactionErr(DuplicatedOutputSndError, errors.New("output SNDs of unsigned tx are duplicated"))
+	}
+
+	tx.Version = unsignedTx.Version
+	tx.Type = common.TxNormalType
+	tx.LockTime = unsignedTx.LockTime
+	tx.Info = []byte{}
+	if len(unsignedTx.Info) > 0 {
+		tx.Info = unsignedTx.Info
+	}
+	tx.ExpiryHeight = unsignedTx.ExpiryHeight
+	tx.Metadata = nil
+
+	paymentInfos := make([]*privacy.PaymentInfo, len(unsignedTx.PaymentInfos))
+	copy(paymentInfos, unsignedTx.PaymentInfos)
+	params := NewTxPrivacyInitParams(senderSK, paymentInfos, unsignedTx.InputCoins, unsignedTx.Fee, unsignedTx.HasPrivacy, nil, nil, nil)
+	return tx.proveAndSign(params, senderFullKey.PaymentAddress, unsignedTx.CommitmentIndices, unsignedTx.MyCommitmentIndices, commitmentProving, sndOuts)
+}
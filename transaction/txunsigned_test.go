@@ -0,0 +1,74 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitFromUnsignedTx(t *testing.T) {
+	key, err := wallet.Base58CheckDeserialize("112t8rnXCqbbNYBquntyd6EvDT4WiDDQw84ZSRDKmazkqrzi6w8rWyCVt7QEZgAiYAV4vhJiX7V9MCfuj4hGLoDN7wdU1LoWGEFpLs59X7K3")
+	assert.Equal(t, nil, err)
+	err = key.KeySet.InitFromPrivateKey(&key.KeySet.PrivateKey)
+	assert.Equal(t, nil, err)
+	paymentAddress := key.KeySet.PaymentAddress
+	coinBaseTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 1000, &key.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+	assert.Equal(t, nil, err)
+	outCoin := coinBaseTx.(*Tx).Proof.GetOutputCoins()[0]
+	db.StoreCommitments(common.PRVCoinID, paymentAddress.Pk, [][]byte{outCoin.CoinDetails.GetCoinCommitment().Compress()}, 6)
+	// decoys of ring
+	for i := 0; i < 4; i++ {
+		decoyTx, err := BuildCoinBaseTxByCoinID(NewBuildCoinBaseTxByCoinIDParams(&paymentAddress, 10, &key.KeySet.PrivateKey, db, nil, common.Hash{}, NormalCoinType, "PRV", 0))
+		assert.Equal(t, nil, err)
+		db.StoreCommitments(common.PRVCoinID, paymentAddress.Pk, [][]byte{decoyTx.(*Tx).Proof.GetOutputCoins()[0].CoinDetails.GetCoinCommitment().Compress()}, 6)
+	}
+
+	receiver, _ := wallet.Base58CheckDeserialize("1Uv3BkYiWy9Mjt1yBa4dXBYKo3az22TeCVEpeXN93ieJ8qhrTDuUZBzsPZWjjP2AeRQnjw1y18iFPHTRuAqqufwVC1vNUAWs4wHFbbWC2")
+	paymentInfos := []*privacy.PaymentInfo{{PaymentAddress: receiver.KeySet.PaymentAddress, Amount: 5}}
+	unsignedTx, err := NewUnsignedTx(paymentAddress, paymentInfos, ConvertOutputCoinToInputCoin([]*privacy.OutputCoin{outCoin}), 1, true, privacy.CommitmentRingSize, db)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(unsignedTx.PaymentInfos))
+	assert.Equal(t, uint64(994), unsignedTx.PaymentInfos[1].Amount)
+
+	// template is carried to offline device as json
+	unsignedTxBytes, err := json.Marshal(unsignedTx)
+	assert.Equal(t, nil, err)
+	offlineUnsignedTx := new(UnsignedTx)
+	err = json.Unmarshal(unsignedTxBytes, offlineUnsignedTx)
+	assert.Equal(t, nil, err)
+
+	otherKey, _ := wallet.Base58CheckDeserialize("112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL")
+	tx := Tx{}
+	err = tx.InitFromUnsignedTx(offlineUnsignedTx, &otherKey.KeySet.PrivateKey)
+	assert.NotEqual(t, nil, err)
+
+	err = tx.InitFromUnsignedTx(offlineUnsignedTx, &key.KeySet.PrivateKey)
+	assert.Equal(t, nil, err)
+	valid, err := tx.ValidateSanityData(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, valid)
+	verified, err := tx.ValidateTransaction(true, db, 6, &common.PRVCoinID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, verified)
+	assert.Equal(t, uint64(1), tx.Fee)
+
+	// values of outputs and fee must be equal to values of input coins
+	offlineUnsignedTx.Fee = 2
+	err = tx.InitFromUnsignedTx(offlineUnsignedTx, &key.KeySet.PrivateKey)
+	assert.NotEqual(t, nil, err)
+
+	// input coin must open its ring member even without commitment in its details
+	tamperedUnsignedTx := new(UnsignedTx)
+	err = json.Unmarshal(unsignedTxBytes, tamperedUnsignedTx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, tamperedUnsignedTx.validate())
+	tamperedUnsignedTx.InputCoins[0].CoinDetails.SetCoinCommitment(nil)
+	assert.Equal(t, nil, tamperedUnsignedTx.validate())
+	tamperedUnsignedTx.InputCoins[0].CoinDetails.SetValue(outCoin.CoinDetails.GetValue() + 1)
+	tamperedUnsignedTx.PaymentInfos[1].Amount++
+	assert.NotEqual(t, nil, tamperedUnsignedTx.validate())
+}
@@ -20,5 +20,6 @@ const (
 	PriKeyType         = byte(0x0) // Serialize wallet account key into string with only PRIVATE KEY of account keyset
 	PaymentAddressType = byte(0x1) // Serialize wallet account key into string with only PAYMENT ADDRESS of account keyset
 	ReadonlyKeyType    = byte(0x2) // Serialize wallet account key into string with only READONLY KEY of account keyset
+	MultiSigKeyType    = byte(0x3) // Serialize multisig account key into string with MULTISIG ACCOUNT and its RECEIVING KEY
 
 )
@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
 )
 
 // KeyWallet represents with bip32 standard
@@ -16,6 +17,9 @@ type KeyWallet struct {
 	ChildNumber []byte // 4 bytes
 	ChainCode   []byte // 32 bytes
 	KeySet      incognitokey.KeySet
+
+	// MultiSigAccount is only set for key of a multisig account, whose KeySet has no private key
+	MultiSigAccount *privacy.MultiSigAccount `json:"-"`
 }
 
 // NewMasterKey creates a new master extended PubKey from a Seed
@@ -116,6 +120,15 @@ func (key *KeyWallet) Serialize(keyType byte) ([]byte, error) {
 		keyBytes = append(keyBytes, byte(len(key.KeySet.ReadonlyKey.Rk))) // set length Skenc
 		keyBytes = append(keyBytes, key.KeySet.ReadonlyKey.Rk[:]...)      // set Pkenc
 		buffer.Write(keyBytes)
+	} else if keyType == MultiSigKeyType {
+		if key.MultiSigAccount == nil {
+			return []byte{}, NewWalletError(InvalidMultiSigKeyErr, nil)
+		}
+		keyBytes := make([]byte, 0)
+		keyBytes = append(keyBytes, key.MultiSigAccount.Bytes()...)       // set multisig account, its length is encoded inside
+		keyBytes = append(keyBytes, byte(len(key.KeySet.ReadonlyKey.Rk))) // set length Skenc
+		keyBytes = append(keyBytes, key.KeySet.ReadonlyKey.Rk[:]...)      // set Skenc
+		buffer.Write(keyBytes)
 	} else {
 		return []byte{}, NewWalletError(InvalidKeyTypeErr, nil)
 	}
@@ -174,6 +187,28 @@ func deserialize(data []byte) (*KeyWallet, error) {
 		key.KeySet.ReadonlyKey.Rk = make([]byte, skencKeyLength)
 		copy(key.KeySet.ReadonlyKey.Pk[:], data[2:2+apkKeyLength])
 		copy(key.KeySet.ReadonlyKey.Rk[:], data[3+apkKeyLength:3+apkKeyLength+skencKeyLength])
+	} else if keyType == MultiSigKeyType {
+		// m || n || n public keys || transmission key
+		if len(data) < 3 {
+			return nil, NewWalletError(InvalidMultiSigKeyErr, nil)
+		}
+		accountLength := 2 + (int(data[2])+1)*privacy.CompressedEllipticPointSize
+		if len(data) < 1+accountLength+1+4 {
+			return nil, NewWalletError(InvalidMultiSigKeyErr, nil)
+		}
+		skencKeyLength := int(data[1+accountLength])
+		if len(data) != 1+accountLength+1+skencKeyLength+4 {
+			return nil, NewWalletError(InvalidMultiSigKeyErr, nil)
+		}
+		key.MultiSigAccount = new(privacy.MultiSigAccount)
+		err := key.MultiSigAccount.SetBytes(data[1 : 1+accountLength])
+		if err != nil {
+			return nil, NewWalletError(InvalidMultiSigKeyErr, err)
+		}
+		key.KeySet.PaymentAddress = key.MultiSigAccount.GetPaymentAddress()
+		key.KeySet.ReadonlyKey.Pk = key.KeySet.PaymentAddress.Pk
+		key.KeySet.ReadonlyKey.Rk = make([]byte, skencKeyLength)
+		copy(key.KeySet.ReadonlyKey.Rk[:], data[2+accountLength:2+accountLength+skencKeyLength])
 	}
 
 	// validate checksum
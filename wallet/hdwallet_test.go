@@ -140,7 +140,7 @@ func TestHDWalletSerializeWithInvalidKeyType(t *testing.T) {
 	data := []struct {
 		keyType byte
 	}{
-		{byte(3)},
+		{byte(4)},
 		{byte(10)},
 		{byte(123)},
 		{byte(234)},
@@ -179,7 +179,7 @@ func TestHDWalletBase58CheckSerializeWithInvalidKeyType(t *testing.T) {
 	data := []struct {
 		keyType byte
 	}{
-		{byte(3)},
+		{byte(4)},
 		{byte(10)},
 		{byte(123)},
 		{byte(234)},
@@ -0,0 +1,25 @@
+package wallet
+
+import (
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// NewMultiSigKey creates key of an m-of-n multisig account from public keys of its n cosigners.
+// A random receiving key is generated for the account, it is shared by cosigners to find coins of the account
+// and it is serialized with the account in MultiSigKeyType, which can be imported by any cosigner.
+// Coins of the account can only be spent by txs signed by at least m cosigners
+func NewMultiSigKey(m int, publicKeys []privacy.PublicKey) (*KeyWallet, error) {
+	receivingKey := privacy.GenerateReceivingKey(privacy.RandBytes(32))
+	account, err := privacy.NewMultiSigAccount(m, publicKeys, privacy.GenerateTransmissionKey(receivingKey))
+	if err != nil {
+		return nil, NewWalletError(InvalidMultiSigKeyErr, err)
+	}
+
+	key := &KeyWallet{
+		MultiSigAccount: account,
+	}
+	key.KeySet.PaymentAddress = account.GetPaymentAddress()
+	key.KeySet.ReadonlyKey.Pk = key.KeySet.PaymentAddress.Pk
+	key.KeySet.ReadonlyKey.Rk = receivingKey
+	return key, nil
+}
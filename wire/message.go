@@ -32,12 +32,13 @@ const (
 	CmdPing               = "ping"
 
 	// POS Cmd
-	CmdBFTPropose = "bftpropose"
-	CmdBFTAgree   = "bftagree"
-	CmdBFTCommit  = "bftcommit"
-	CmdBFTReady   = "bftready"
-	CmdBFTReq     = "bftreq"
-	CmdPeerState  = "peerstate"
+	CmdBFTPropose    = "bftpropose"
+	CmdBFTAgree      = "bftagree"
+	CmdBFTCommit     = "bftcommit"
+	CmdBFTReady      = "bftready"
+	CmdBFTReq        = "bftreq"
+	CmdBFTViewChange = "bftviewchange"
+	CmdPeerState     = "peerstate"
 
 	// heavy message check cmd
 	CmdMsgCheck     = "msgcheck"
@@ -135,6 +136,11 @@ func MakeEmptyMessage(messageType string) (Message, error) {
 			Timestamp: time.Now().Unix(),
 		}
 		break
+	case CmdBFTViewChange:
+		msg = &MessageBFTViewChange{
+			Timestamp: time.Now().Unix(),
+		}
+		break
 	case CmdPeerState:
 		msg = &MessagePeerState{
 			Timestamp:         time.Now().Unix(),
@@ -216,6 +222,8 @@ func GetCmdType(msgType reflect.Type) (string, error) {
 		return CmdBFTReady, nil
 	case reflect.TypeOf(&MessageBFTReq{}):
 		return CmdBFTReq, nil
+	case reflect.TypeOf(&MessageBFTViewChange{}):
+		return CmdBFTViewChange, nil
 	case reflect.TypeOf(&MessagePeerState{}):
 		return CmdPeerState, nil
 	case reflect.TypeOf(&MessageMsgCheck{}):
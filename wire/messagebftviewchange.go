@@ -0,0 +1,100 @@
+package wire
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	MaxBFTViewChangePayload = 2000000 // 2000 Kb ~= 2 MB, it may carry a prepared block
+)
+
+// MessageBFTViewChange is sent by a committee member when a round fails,
+// it asks the committee to move on to NextRound and carries the highest
+// block this member prepared (collected enough agree msg) at current height
+type MessageBFTViewChange struct {
+	Layer         string
+	ShardID       byte
+	BestStateHash common.Hash
+	Round         int
+	NextRound     int
+	PreparedRound int
+	PreparedBlock json.RawMessage
+	Pubkey        string
+	ContentSig    string
+	Timestamp     int64
+}
+
+func (msg *MessageBFTViewChange) Hash() string {
+	rawBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return ""
+	}
+	return common.HashH(rawBytes).String()
+}
+
+func (msg *MessageBFTViewChange) MessageType() string {
+	return CmdBFTViewChange
+}
+
+func (msg *MessageBFTViewChange) MaxPayloadLength(pver int) int {
+	return MaxBFTViewChangePayload
+}
+
+func (msg *MessageBFTViewChange) JsonSerialize() ([]byte, error) {
+	jsonBytes, err := json.Marshal(msg)
+	return jsonBytes, err
+}
+
+func (msg *MessageBFTViewChange) JsonDeserialize(jsonStr string) error {
+	err := json.Unmarshal([]byte(jsonStr), msg)
+	return err
+}
+
+func (msg *MessageBFTViewChange) SetSenderID(senderID peer.ID) error {
+	return nil
+}
+
+// dataToSign encodes numbers with fixed width and variable length fields with their length,
+// so two different msgs never give the same bytes to sign
+func (msg *MessageBFTViewChange) dataToSign() []byte {
+	dataBytes := []byte{}
+	dataBytes = appendUint64(dataBytes, uint64(len(msg.Layer)))
+	dataBytes = append(dataBytes, []byte(msg.Layer)...)
+	dataBytes = append(dataBytes, msg.ShardID)
+	dataBytes = append(dataBytes, msg.BestStateHash.GetBytes()...)
+	dataBytes = appendUint64(dataBytes, uint64(msg.Round))
+	dataBytes = appendUint64(dataBytes, uint64(msg.NextRound))
+	dataBytes = appendUint64(dataBytes, uint64(msg.PreparedRound))
+	dataBytes = appendUint64(dataBytes, uint64(len(msg.PreparedBlock)))
+	dataBytes = append(dataBytes, msg.PreparedBlock...)
+	dataBytes = appendUint64(dataBytes, uint64(len(msg.Pubkey)))
+	dataBytes = append(dataBytes, []byte(msg.Pubkey)...)
+	dataBytes = appendUint64(dataBytes, uint64(msg.Timestamp))
+	return dataBytes
+}
+
+func appendUint64(dataBytes []byte, value uint64) []byte {
+	valueBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(valueBytes, value)
+	return append(dataBytes, valueBytes...)
+}
+
+func (msg *MessageBFTViewChange) SignMsg(keySet *incognitokey.KeySet) error {
+	var err error
+	msg.ContentSig, err = keySet.SignDataInBase58CheckEncode(msg.dataToSign())
+	return err
+}
+
+func (msg *MessageBFTViewChange) VerifyMsgSanity() error {
+	if msg.NextRound <= msg.Round {
+		return fmt.Errorf("next round %+v must be greater than round %+v", msg.NextRound, msg.Round)
+	}
+	err := incognitokey.ValidateDataB58(msg.Pubkey, msg.ContentSig, msg.dataToSign())
+	return err
+}
@@ -24,6 +24,9 @@ type MessageVersion struct {
 	LocalPeerId      peer.ID
 	PublicKey        string
 	SignDataB58      string
+	// PruneBlocks is retention window of block bodies of a pruning node,
+	// peer can't serve blocks older than it; 0 means an archival node
+	PruneBlocks uint64
 }
 
 func (msg *MessageVersion) Hash() string {